@@ -26,11 +26,15 @@ func main() {
 	var testPdf string
 	var testJson string
 	var iterations int
+	var ruleSet string
+	var promptVersion string
 
 	flag.StringVar(&envFile, "env", ".env", "env file path")
 	flag.StringVar(&testPdf, "pdf", "", "test PDF file path")
 	flag.StringVar(&testJson, "json", "", "test JSON file path")
 	flag.IntVar(&iterations, "iterations", 1, "number of iterations to run")
+	flag.StringVar(&ruleSet, "rule-set", "", "validation rule set to apply")
+	flag.StringVar(&promptVersion, "prompt-version", "", "prompt version to parse with (default: PROMPT_VERSION)")
 	flag.Parse()
 
 	// Load environment variables from .env file
@@ -48,6 +52,12 @@ func main() {
 	// Create server config
 	cfg := config.NewConfig()
 
+	// Evaluation runs are not operational results and are kept out of reports
+	cfg.PersistResults = false
+
+	// Evaluations parse with the selected prompt version rather than a randomly assigned experiment variant
+	cfg.ExperimentsFile = ""
+
 	// Initialize datastore
 	ds, err := datastore.NewPgEntDatastore(cfg, logger)
 	if err != nil {
@@ -82,7 +92,7 @@ func main() {
 
 	// Run the parser
 	for i := 0; i < iterations; i++ {
-		jobId, err := parserInstance.ParseImage(context.Background(), fileName, bytes.NewReader(inputPdf))
+		jobId, err := parserInstance.ParseImage(context.Background(), fileName, bytes.NewReader(inputPdf), models.ParseOptions{RuleSet: ruleSet, PromptVersion: promptVersion})
 		if err != nil {
 			logger.Fatal("Failed to parse PDF", zap.Error(err))
 		}
@@ -124,7 +134,7 @@ func main() {
 			continue
 		}
 
-		fmt.Printf("Filename: %s - Job ID: %s\nScore: %.2f%% - (%.2f / %d)\nFeedback:\n%s\n\n", fileName, jobId, score.OverallScorePercentage, score.TotalAwardedPoints, int(score.TotalPossiblePoints), score.SummaryCritique)
+		fmt.Printf("Filename: %s - Job ID: %s - Prompt version: %s\nScore: %.2f%% - (%.2f / %d)\nFeedback:\n%s\n\n", fileName, jobId, jobStatus.Attributes[jobs.AttributePromptVersion], score.OverallScorePercentage, score.TotalAwardedPoints, int(score.TotalPossiblePoints), score.SummaryCritique)
 	}
 }
 
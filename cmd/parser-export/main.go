@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/csvexport"
+	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/handlerutils"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+)
+
+func main() {
+	var envFile string
+	var fromDate string
+	var toDate string
+	var outFile string
+
+	flag.StringVar(&envFile, "env", ".env", "env file path")
+	flag.StringVar(&fromDate, "from", "", "first completion date to export (YYYY-MM-DD, UTC)")
+	flag.StringVar(&toDate, "to", "", "last completion date to export (YYYY-MM-DD, UTC), defaults to -from")
+	flag.StringVar(&outFile, "out", "", "output CSV file path, defaults to stdout")
+	flag.Parse()
+
+	// Load environment variables from .env file
+	if err := godotenv.Load(envFile); err != nil {
+		log.Printf("Warning: Error loading .env file: %v", err)
+	}
+
+	// Initialize logger
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	from, to, err := handlerutils.ParseDateRange(fromDate, toDate)
+	if err != nil {
+		logger.Fatal("Invalid date range", zap.Error(err))
+	}
+
+	count, err := run(logger, from, to, outFile)
+	if err != nil {
+		logger.Fatal("Failed to export parse results", zap.Error(err))
+	}
+
+	logger.Info("Exported parse results", zap.Int("results", count), zap.String("from", fromDate), zap.String("to", toDate))
+}
+
+// run writes the parse results completed in [from, to) as CSV to outFile, or to stdout if it is
+// empty, and returns the number of results written. The output file is closed before returning,
+// so an export that fails to write cannot be mistaken for a complete one.
+func run(logger *zap.Logger, from, to time.Time, outFile string) (count int, err error) {
+	// Create server config
+	cfg := config.NewConfig()
+
+	// Initialize datastore
+	ds, err := datastore.NewPgEntDatastore(cfg, logger)
+	if err != nil {
+		return 0, fmt.Errorf("failed to initialize datastore: %w", err)
+	}
+
+	results, err := ds.ListParseResults(context.Background(), from, to)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load parse results: %w", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if outFile != "" {
+		f, err := os.Create(outFile)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			if closeErr := f.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", closeErr)
+			}
+		}()
+		out = f
+	}
+
+	writer, err := csvexport.NewWriter(out)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, result := range results {
+		if err := writer.Write(result); err != nil {
+			return 0, fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return 0, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return len(results), nil
+}
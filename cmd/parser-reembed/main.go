@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/layout"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/parser"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+)
+
+func main() {
+	var envFile string
+	var dryRun bool
+	var prune bool
+	var layoutOnly bool
+
+	flag.StringVar(&envFile, "env", ".env", "env file path")
+	flag.BoolVar(&dryRun, "dry-run", false, "list the samples that would be re-embedded without changing them")
+	flag.BoolVar(&prune, "prune", false, "delete embeddings from other models once every sample has a current one")
+	flag.BoolVar(&layoutOnly, "layout", false, "compute missing layout fingerprints from stored sample documents instead of content embeddings")
+	flag.Parse()
+
+	// Load environment variables from .env file
+	if err := godotenv.Load(envFile); err != nil {
+		log.Printf("Warning: Error loading .env file: %v", err)
+	}
+
+	// Initialize logger
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	// Create server config
+	cfg := config.NewConfig()
+
+	// Initialize datastore
+	ds, err := datastore.NewPgEntDatastore(cfg, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize datastore", zap.Error(err))
+	}
+
+	ctx := context.Background()
+
+	// Layout fingerprints are computed locally from the stored documents; content embeddings
+	// come from the backend whose embedding model samples are re-embedded with.
+	model := layout.Model
+	embed := func(sample models.Sample) (models.Embedding, error) {
+		document, err := ds.GetSampleDocument(ctx, sample.ID)
+		if err != nil {
+			return models.Embedding{}, err
+		}
+		return layout.Fingerprint(document)
+	}
+	if !layoutOnly {
+		parserInstance, err := parser.NewParser(cfg, ds, logger)
+		if err != nil {
+			logger.Fatal("Failed to initialize parser", zap.Error(err))
+		}
+		model = parserInstance.EmbeddingModel()
+		embed = func(sample models.Sample) (models.Embedding, error) {
+			return parserInstance.GetEmbedding(ctx, sample.Prescription)
+		}
+	}
+
+	samples, err := ds.ListSamplesWithoutEmbedding(ctx, model)
+	if err != nil {
+		logger.Fatal("Failed to list samples", zap.Error(err))
+	}
+
+	logger.Info("Samples to re-embed", zap.String("model", model.String()), zap.Int("samples", len(samples)))
+
+	failed, skipped := 0, 0
+	for _, sample := range samples {
+		if dryRun {
+			logger.Info("Would re-embed sample", zap.String("sample_id", sample.ID), zap.String("file_id", sample.FileID))
+			continue
+		}
+
+		embedding, err := embed(sample)
+		if errors.Is(err, datastore.ErrNotFound) || errors.Is(err, layout.ErrNoImage) {
+			// Samples without a stored scan cannot be fingerprinted and stay content-only.
+			logger.Info("Skipped sample without a scanned document", zap.String("sample_id", sample.ID), zap.Error(err))
+			skipped++
+			continue
+		}
+		if err != nil {
+			logger.Error("Failed to generate embedding", zap.String("sample_id", sample.ID), zap.Error(err))
+			failed++
+			continue
+		}
+
+		if err := ds.SaveSampleEmbedding(ctx, sample.ID, embedding); err != nil {
+			logger.Error("Failed to save embedding", zap.String("sample_id", sample.ID), zap.Error(err))
+			failed++
+			continue
+		}
+
+		logger.Info("Re-embedded sample", zap.String("sample_id", sample.ID))
+	}
+
+	if dryRun {
+		return
+	}
+	if failed > 0 {
+		logger.Fatal("Some samples were not re-embedded; run again to retry", zap.Int("failed", failed), zap.Int("samples", len(samples)))
+	}
+
+	if prune {
+		deleted, err := ds.DeleteOtherEmbeddings(ctx, model)
+		if err != nil {
+			logger.Fatal("Failed to delete embeddings from other models", zap.Error(err))
+		}
+		logger.Info("Deleted embeddings from other models", zap.Int("embeddings", deleted))
+	}
+
+	logger.Info("Re-embedded samples", zap.String("model", model.String()), zap.Int("samples", len(samples)-skipped), zap.Int("skipped", skipped))
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/csotherden/prescription-parser/pkg/config"
 	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/layout"
 	"github.com/csotherden/prescription-parser/pkg/parser"
 	"github.com/csotherden/prescription-parser/pkg/server"
 	"go.uber.org/zap"
@@ -51,6 +52,23 @@ func main() {
 		logger.Fatal("Failed to initialize parser", zap.Error(err))
 	}
 
+	// Samples are only retrieved by embeddings from the current model
+	model := parserInstance.EmbeddingModel()
+	if stale, err := ds.ListSamplesWithoutEmbedding(context.Background(), model); err != nil {
+		logger.Warn("Failed to check sample embeddings", zap.Error(err))
+	} else if len(stale) > 0 {
+		logger.Warn("Some samples have no embedding from the current model and will not be retrieved; run parser-reembed", zap.String("model", model.String()), zap.Int("samples", len(stale)))
+	}
+
+	// Layout retrieval only finds samples with a layout fingerprint
+	if cfg.SampleRetrieval == parser.RetrievalLayout {
+		if missing, err := ds.ListSamplesWithoutEmbedding(context.Background(), layout.Model); err != nil {
+			logger.Warn("Failed to check sample layout fingerprints", zap.Error(err))
+		} else if len(missing) > 0 {
+			logger.Warn("Some samples have no layout fingerprint and are only retrieved by content; run parser-reembed -layout", zap.Int("samples", len(missing)))
+		}
+	}
+
 	// Create and configure server
 	srv, err := server.NewServer(cfg, ds, parserInstance, logger)
 	if err != nil {
@@ -17,7 +17,9 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/csotherden/prescription-parser/ent/embedding"
+	"github.com/csotherden/prescription-parser/ent/parseresult"
 	"github.com/csotherden/prescription-parser/ent/prescription"
+	"github.com/csotherden/prescription-parser/ent/promptoverlay"
 )
 
 // Client is the client that holds all ent builders.
@@ -27,8 +29,12 @@ type Client struct {
 	Schema *migrate.Schema
 	// Embedding is the client for interacting with the Embedding builders.
 	Embedding *EmbeddingClient
+	// ParseResult is the client for interacting with the ParseResult builders.
+	ParseResult *ParseResultClient
 	// Prescription is the client for interacting with the Prescription builders.
 	Prescription *PrescriptionClient
+	// PromptOverlay is the client for interacting with the PromptOverlay builders.
+	PromptOverlay *PromptOverlayClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -41,7 +47,9 @@ func NewClient(opts ...Option) *Client {
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
 	c.Embedding = NewEmbeddingClient(c.config)
+	c.ParseResult = NewParseResultClient(c.config)
 	c.Prescription = NewPrescriptionClient(c.config)
+	c.PromptOverlay = NewPromptOverlayClient(c.config)
 }
 
 type (
@@ -132,10 +140,12 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:          ctx,
-		config:       cfg,
-		Embedding:    NewEmbeddingClient(cfg),
-		Prescription: NewPrescriptionClient(cfg),
+		ctx:           ctx,
+		config:        cfg,
+		Embedding:     NewEmbeddingClient(cfg),
+		ParseResult:   NewParseResultClient(cfg),
+		Prescription:  NewPrescriptionClient(cfg),
+		PromptOverlay: NewPromptOverlayClient(cfg),
 	}, nil
 }
 
@@ -153,10 +163,12 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:          ctx,
-		config:       cfg,
-		Embedding:    NewEmbeddingClient(cfg),
-		Prescription: NewPrescriptionClient(cfg),
+		ctx:           ctx,
+		config:        cfg,
+		Embedding:     NewEmbeddingClient(cfg),
+		ParseResult:   NewParseResultClient(cfg),
+		Prescription:  NewPrescriptionClient(cfg),
+		PromptOverlay: NewPromptOverlayClient(cfg),
 	}, nil
 }
 
@@ -186,14 +198,18 @@ func (c *Client) Close() error {
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
 	c.Embedding.Use(hooks...)
+	c.ParseResult.Use(hooks...)
 	c.Prescription.Use(hooks...)
+	c.PromptOverlay.Use(hooks...)
 }
 
 // Intercept adds the query interceptors to all the entity clients.
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	c.Embedding.Intercept(interceptors...)
+	c.ParseResult.Intercept(interceptors...)
 	c.Prescription.Intercept(interceptors...)
+	c.PromptOverlay.Intercept(interceptors...)
 }
 
 // Mutate implements the ent.Mutator interface.
@@ -201,8 +217,12 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
 	case *EmbeddingMutation:
 		return c.Embedding.mutate(ctx, m)
+	case *ParseResultMutation:
+		return c.ParseResult.mutate(ctx, m)
 	case *PrescriptionMutation:
 		return c.Prescription.mutate(ctx, m)
+	case *PromptOverlayMutation:
+		return c.PromptOverlay.mutate(ctx, m)
 	default:
 		return nil, fmt.Errorf("ent: unknown mutation type %T", m)
 	}
@@ -357,6 +377,139 @@ func (c *EmbeddingClient) mutate(ctx context.Context, m *EmbeddingMutation) (Val
 	}
 }
 
+// ParseResultClient is a client for the ParseResult schema.
+type ParseResultClient struct {
+	config
+}
+
+// NewParseResultClient returns a client for the ParseResult from the given config.
+func NewParseResultClient(c config) *ParseResultClient {
+	return &ParseResultClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `parseresult.Hooks(f(g(h())))`.
+func (c *ParseResultClient) Use(hooks ...Hook) {
+	c.hooks.ParseResult = append(c.hooks.ParseResult, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `parseresult.Intercept(f(g(h())))`.
+func (c *ParseResultClient) Intercept(interceptors ...Interceptor) {
+	c.inters.ParseResult = append(c.inters.ParseResult, interceptors...)
+}
+
+// Create returns a builder for creating a ParseResult entity.
+func (c *ParseResultClient) Create() *ParseResultCreate {
+	mutation := newParseResultMutation(c.config, OpCreate)
+	return &ParseResultCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of ParseResult entities.
+func (c *ParseResultClient) CreateBulk(builders ...*ParseResultCreate) *ParseResultCreateBulk {
+	return &ParseResultCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *ParseResultClient) MapCreateBulk(slice any, setFunc func(*ParseResultCreate, int)) *ParseResultCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &ParseResultCreateBulk{err: fmt.Errorf("calling to ParseResultClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*ParseResultCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &ParseResultCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for ParseResult.
+func (c *ParseResultClient) Update() *ParseResultUpdate {
+	mutation := newParseResultMutation(c.config, OpUpdate)
+	return &ParseResultUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *ParseResultClient) UpdateOne(pr *ParseResult) *ParseResultUpdateOne {
+	mutation := newParseResultMutation(c.config, OpUpdateOne, withParseResult(pr))
+	return &ParseResultUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *ParseResultClient) UpdateOneID(id uuid.UUID) *ParseResultUpdateOne {
+	mutation := newParseResultMutation(c.config, OpUpdateOne, withParseResultID(id))
+	return &ParseResultUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for ParseResult.
+func (c *ParseResultClient) Delete() *ParseResultDelete {
+	mutation := newParseResultMutation(c.config, OpDelete)
+	return &ParseResultDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *ParseResultClient) DeleteOne(pr *ParseResult) *ParseResultDeleteOne {
+	return c.DeleteOneID(pr.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *ParseResultClient) DeleteOneID(id uuid.UUID) *ParseResultDeleteOne {
+	builder := c.Delete().Where(parseresult.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &ParseResultDeleteOne{builder}
+}
+
+// Query returns a query builder for ParseResult.
+func (c *ParseResultClient) Query() *ParseResultQuery {
+	return &ParseResultQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeParseResult},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a ParseResult entity by its id.
+func (c *ParseResultClient) Get(ctx context.Context, id uuid.UUID) (*ParseResult, error) {
+	return c.Query().Where(parseresult.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *ParseResultClient) GetX(ctx context.Context, id uuid.UUID) *ParseResult {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *ParseResultClient) Hooks() []Hook {
+	return c.hooks.ParseResult
+}
+
+// Interceptors returns the client interceptors.
+func (c *ParseResultClient) Interceptors() []Interceptor {
+	return c.inters.ParseResult
+}
+
+func (c *ParseResultClient) mutate(ctx context.Context, m *ParseResultMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&ParseResultCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&ParseResultUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&ParseResultUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&ParseResultDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown ParseResult mutation op: %q", m.Op())
+	}
+}
+
 // PrescriptionClient is a client for the Prescription schema.
 type PrescriptionClient struct {
 	config
@@ -465,6 +618,22 @@ func (c *PrescriptionClient) GetX(ctx context.Context, id uuid.UUID) *Prescripti
 	return obj
 }
 
+// QueryEmbeddings queries the embeddings edge of a Prescription.
+func (c *PrescriptionClient) QueryEmbeddings(pr *Prescription) *EmbeddingQuery {
+	query := (&EmbeddingClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := pr.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(prescription.Table, prescription.FieldID, id),
+			sqlgraph.To(embedding.Table, embedding.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, prescription.EmbeddingsTable, prescription.EmbeddingsColumn),
+		)
+		fromV = sqlgraph.Neighbors(pr.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
 // Hooks returns the client hooks.
 func (c *PrescriptionClient) Hooks() []Hook {
 	return c.hooks.Prescription
@@ -490,12 +659,145 @@ func (c *PrescriptionClient) mutate(ctx context.Context, m *PrescriptionMutation
 	}
 }
 
+// PromptOverlayClient is a client for the PromptOverlay schema.
+type PromptOverlayClient struct {
+	config
+}
+
+// NewPromptOverlayClient returns a client for the PromptOverlay from the given config.
+func NewPromptOverlayClient(c config) *PromptOverlayClient {
+	return &PromptOverlayClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `promptoverlay.Hooks(f(g(h())))`.
+func (c *PromptOverlayClient) Use(hooks ...Hook) {
+	c.hooks.PromptOverlay = append(c.hooks.PromptOverlay, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `promptoverlay.Intercept(f(g(h())))`.
+func (c *PromptOverlayClient) Intercept(interceptors ...Interceptor) {
+	c.inters.PromptOverlay = append(c.inters.PromptOverlay, interceptors...)
+}
+
+// Create returns a builder for creating a PromptOverlay entity.
+func (c *PromptOverlayClient) Create() *PromptOverlayCreate {
+	mutation := newPromptOverlayMutation(c.config, OpCreate)
+	return &PromptOverlayCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of PromptOverlay entities.
+func (c *PromptOverlayClient) CreateBulk(builders ...*PromptOverlayCreate) *PromptOverlayCreateBulk {
+	return &PromptOverlayCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *PromptOverlayClient) MapCreateBulk(slice any, setFunc func(*PromptOverlayCreate, int)) *PromptOverlayCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &PromptOverlayCreateBulk{err: fmt.Errorf("calling to PromptOverlayClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*PromptOverlayCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &PromptOverlayCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for PromptOverlay.
+func (c *PromptOverlayClient) Update() *PromptOverlayUpdate {
+	mutation := newPromptOverlayMutation(c.config, OpUpdate)
+	return &PromptOverlayUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *PromptOverlayClient) UpdateOne(po *PromptOverlay) *PromptOverlayUpdateOne {
+	mutation := newPromptOverlayMutation(c.config, OpUpdateOne, withPromptOverlay(po))
+	return &PromptOverlayUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *PromptOverlayClient) UpdateOneID(id uuid.UUID) *PromptOverlayUpdateOne {
+	mutation := newPromptOverlayMutation(c.config, OpUpdateOne, withPromptOverlayID(id))
+	return &PromptOverlayUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for PromptOverlay.
+func (c *PromptOverlayClient) Delete() *PromptOverlayDelete {
+	mutation := newPromptOverlayMutation(c.config, OpDelete)
+	return &PromptOverlayDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *PromptOverlayClient) DeleteOne(po *PromptOverlay) *PromptOverlayDeleteOne {
+	return c.DeleteOneID(po.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *PromptOverlayClient) DeleteOneID(id uuid.UUID) *PromptOverlayDeleteOne {
+	builder := c.Delete().Where(promptoverlay.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &PromptOverlayDeleteOne{builder}
+}
+
+// Query returns a query builder for PromptOverlay.
+func (c *PromptOverlayClient) Query() *PromptOverlayQuery {
+	return &PromptOverlayQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypePromptOverlay},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a PromptOverlay entity by its id.
+func (c *PromptOverlayClient) Get(ctx context.Context, id uuid.UUID) (*PromptOverlay, error) {
+	return c.Query().Where(promptoverlay.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *PromptOverlayClient) GetX(ctx context.Context, id uuid.UUID) *PromptOverlay {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *PromptOverlayClient) Hooks() []Hook {
+	return c.hooks.PromptOverlay
+}
+
+// Interceptors returns the client interceptors.
+func (c *PromptOverlayClient) Interceptors() []Interceptor {
+	return c.inters.PromptOverlay
+}
+
+func (c *PromptOverlayClient) mutate(ctx context.Context, m *PromptOverlayMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&PromptOverlayCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&PromptOverlayUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&PromptOverlayUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&PromptOverlayDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown PromptOverlay mutation op: %q", m.Op())
+	}
+}
+
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		Embedding, Prescription []ent.Hook
+		Embedding, ParseResult, Prescription, PromptOverlay []ent.Hook
 	}
 	inters struct {
-		Embedding, Prescription []ent.Interceptor
+		Embedding, ParseResult, Prescription, PromptOverlay []ent.Interceptor
 	}
 )
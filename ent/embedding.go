@@ -21,6 +21,14 @@ type Embedding struct {
 	ID uuid.UUID `json:"id,omitempty"`
 	// Embedding holds the value of the "embedding" field.
 	Embedding pgvector.Vector `json:"embedding,omitempty"`
+	// Model holds the value of the "model" field.
+	Model string `json:"model,omitempty"`
+	// Version holds the value of the "version" field.
+	Version string `json:"version,omitempty"`
+	// Dimensions holds the value of the "dimensions" field.
+	Dimensions int `json:"dimensions,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind string `json:"kind,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the EmbeddingQuery when eager-loading is set.
 	Edges                  EmbeddingEdges `json:"edges"`
@@ -55,6 +63,10 @@ func (*Embedding) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case embedding.FieldEmbedding:
 			values[i] = new(pgvector.Vector)
+		case embedding.FieldDimensions:
+			values[i] = new(sql.NullInt64)
+		case embedding.FieldModel, embedding.FieldVersion, embedding.FieldKind:
+			values[i] = new(sql.NullString)
 		case embedding.FieldID:
 			values[i] = new(uuid.UUID)
 		case embedding.ForeignKeys[0]: // embedding_prescription
@@ -86,6 +98,30 @@ func (e *Embedding) assignValues(columns []string, values []any) error {
 			} else if value != nil {
 				e.Embedding = *value
 			}
+		case embedding.FieldModel:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field model", values[i])
+			} else if value.Valid {
+				e.Model = value.String
+			}
+		case embedding.FieldVersion:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				e.Version = value.String
+			}
+		case embedding.FieldDimensions:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field dimensions", values[i])
+			} else if value.Valid {
+				e.Dimensions = int(value.Int64)
+			}
+		case embedding.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				e.Kind = value.String
+			}
 		case embedding.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullScanner); !ok {
 				return fmt.Errorf("unexpected type %T for field embedding_prescription", values[i])
@@ -136,6 +172,18 @@ func (e *Embedding) String() string {
 	builder.WriteString(fmt.Sprintf("id=%v, ", e.ID))
 	builder.WriteString("embedding=")
 	builder.WriteString(fmt.Sprintf("%v", e.Embedding))
+	builder.WriteString(", ")
+	builder.WriteString("model=")
+	builder.WriteString(e.Model)
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(e.Version)
+	builder.WriteString(", ")
+	builder.WriteString("dimensions=")
+	builder.WriteString(fmt.Sprintf("%v", e.Dimensions))
+	builder.WriteString(", ")
+	builder.WriteString("kind=")
+	builder.WriteString(e.Kind)
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -15,6 +15,14 @@ const (
 	FieldID = "id"
 	// FieldEmbedding holds the string denoting the embedding field in the database.
 	FieldEmbedding = "embedding"
+	// FieldModel holds the string denoting the model field in the database.
+	FieldModel = "model"
+	// FieldVersion holds the string denoting the version field in the database.
+	FieldVersion = "version"
+	// FieldDimensions holds the string denoting the dimensions field in the database.
+	FieldDimensions = "dimensions"
+	// FieldKind holds the string denoting the kind field in the database.
+	FieldKind = "kind"
 	// EdgePrescription holds the string denoting the prescription edge name in mutations.
 	EdgePrescription = "prescription"
 	// Table holds the table name of the embedding in the database.
@@ -32,6 +40,10 @@ const (
 var Columns = []string{
 	FieldID,
 	FieldEmbedding,
+	FieldModel,
+	FieldVersion,
+	FieldDimensions,
+	FieldKind,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "embeddings"
@@ -56,6 +68,14 @@ func ValidColumn(column string) bool {
 }
 
 var (
+	// DefaultModel holds the default value on creation for the "model" field.
+	DefaultModel string
+	// DefaultVersion holds the default value on creation for the "version" field.
+	DefaultVersion string
+	// DefaultDimensions holds the default value on creation for the "dimensions" field.
+	DefaultDimensions int
+	// DefaultKind holds the default value on creation for the "kind" field.
+	DefaultKind string
 	// DefaultID holds the default value on creation for the "id" field.
 	DefaultID func() uuid.UUID
 )
@@ -73,6 +93,26 @@ func ByEmbedding(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldEmbedding, opts...).ToFunc()
 }
 
+// ByModel orders the results by the model field.
+func ByModel(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldModel, opts...).ToFunc()
+}
+
+// ByVersion orders the results by the version field.
+func ByVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVersion, opts...).ToFunc()
+}
+
+// ByDimensions orders the results by the dimensions field.
+func ByDimensions(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDimensions, opts...).ToFunc()
+}
+
+// ByKind orders the results by the kind field.
+func ByKind(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldKind, opts...).ToFunc()
+}
+
 // ByPrescriptionField orders the results by prescription field.
 func ByPrescriptionField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
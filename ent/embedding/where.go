@@ -60,6 +60,26 @@ func Embedding(v pgvector.Vector) predicate.Embedding {
 	return predicate.Embedding(sql.FieldEQ(FieldEmbedding, v))
 }
 
+// Model applies equality check predicate on the "model" field. It's identical to ModelEQ.
+func Model(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEQ(FieldModel, v))
+}
+
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEQ(FieldVersion, v))
+}
+
+// Dimensions applies equality check predicate on the "dimensions" field. It's identical to DimensionsEQ.
+func Dimensions(v int) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEQ(FieldDimensions, v))
+}
+
+// Kind applies equality check predicate on the "kind" field. It's identical to KindEQ.
+func Kind(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEQ(FieldKind, v))
+}
+
 // EmbeddingEQ applies the EQ predicate on the "embedding" field.
 func EmbeddingEQ(v pgvector.Vector) predicate.Embedding {
 	return predicate.Embedding(sql.FieldEQ(FieldEmbedding, v))
@@ -100,6 +120,241 @@ func EmbeddingLTE(v pgvector.Vector) predicate.Embedding {
 	return predicate.Embedding(sql.FieldLTE(FieldEmbedding, v))
 }
 
+// ModelEQ applies the EQ predicate on the "model" field.
+func ModelEQ(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEQ(FieldModel, v))
+}
+
+// ModelNEQ applies the NEQ predicate on the "model" field.
+func ModelNEQ(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldNEQ(FieldModel, v))
+}
+
+// ModelIn applies the In predicate on the "model" field.
+func ModelIn(vs ...string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldIn(FieldModel, vs...))
+}
+
+// ModelNotIn applies the NotIn predicate on the "model" field.
+func ModelNotIn(vs ...string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldNotIn(FieldModel, vs...))
+}
+
+// ModelGT applies the GT predicate on the "model" field.
+func ModelGT(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldGT(FieldModel, v))
+}
+
+// ModelGTE applies the GTE predicate on the "model" field.
+func ModelGTE(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldGTE(FieldModel, v))
+}
+
+// ModelLT applies the LT predicate on the "model" field.
+func ModelLT(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldLT(FieldModel, v))
+}
+
+// ModelLTE applies the LTE predicate on the "model" field.
+func ModelLTE(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldLTE(FieldModel, v))
+}
+
+// ModelContains applies the Contains predicate on the "model" field.
+func ModelContains(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldContains(FieldModel, v))
+}
+
+// ModelHasPrefix applies the HasPrefix predicate on the "model" field.
+func ModelHasPrefix(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldHasPrefix(FieldModel, v))
+}
+
+// ModelHasSuffix applies the HasSuffix predicate on the "model" field.
+func ModelHasSuffix(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldHasSuffix(FieldModel, v))
+}
+
+// ModelEqualFold applies the EqualFold predicate on the "model" field.
+func ModelEqualFold(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEqualFold(FieldModel, v))
+}
+
+// ModelContainsFold applies the ContainsFold predicate on the "model" field.
+func ModelContainsFold(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldContainsFold(FieldModel, v))
+}
+
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldLTE(FieldVersion, v))
+}
+
+// VersionContains applies the Contains predicate on the "version" field.
+func VersionContains(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldContains(FieldVersion, v))
+}
+
+// VersionHasPrefix applies the HasPrefix predicate on the "version" field.
+func VersionHasPrefix(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldHasPrefix(FieldVersion, v))
+}
+
+// VersionHasSuffix applies the HasSuffix predicate on the "version" field.
+func VersionHasSuffix(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldHasSuffix(FieldVersion, v))
+}
+
+// VersionEqualFold applies the EqualFold predicate on the "version" field.
+func VersionEqualFold(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEqualFold(FieldVersion, v))
+}
+
+// VersionContainsFold applies the ContainsFold predicate on the "version" field.
+func VersionContainsFold(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldContainsFold(FieldVersion, v))
+}
+
+// DimensionsEQ applies the EQ predicate on the "dimensions" field.
+func DimensionsEQ(v int) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEQ(FieldDimensions, v))
+}
+
+// DimensionsNEQ applies the NEQ predicate on the "dimensions" field.
+func DimensionsNEQ(v int) predicate.Embedding {
+	return predicate.Embedding(sql.FieldNEQ(FieldDimensions, v))
+}
+
+// DimensionsIn applies the In predicate on the "dimensions" field.
+func DimensionsIn(vs ...int) predicate.Embedding {
+	return predicate.Embedding(sql.FieldIn(FieldDimensions, vs...))
+}
+
+// DimensionsNotIn applies the NotIn predicate on the "dimensions" field.
+func DimensionsNotIn(vs ...int) predicate.Embedding {
+	return predicate.Embedding(sql.FieldNotIn(FieldDimensions, vs...))
+}
+
+// DimensionsGT applies the GT predicate on the "dimensions" field.
+func DimensionsGT(v int) predicate.Embedding {
+	return predicate.Embedding(sql.FieldGT(FieldDimensions, v))
+}
+
+// DimensionsGTE applies the GTE predicate on the "dimensions" field.
+func DimensionsGTE(v int) predicate.Embedding {
+	return predicate.Embedding(sql.FieldGTE(FieldDimensions, v))
+}
+
+// DimensionsLT applies the LT predicate on the "dimensions" field.
+func DimensionsLT(v int) predicate.Embedding {
+	return predicate.Embedding(sql.FieldLT(FieldDimensions, v))
+}
+
+// DimensionsLTE applies the LTE predicate on the "dimensions" field.
+func DimensionsLTE(v int) predicate.Embedding {
+	return predicate.Embedding(sql.FieldLTE(FieldDimensions, v))
+}
+
+// KindEQ applies the EQ predicate on the "kind" field.
+func KindEQ(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEQ(FieldKind, v))
+}
+
+// KindNEQ applies the NEQ predicate on the "kind" field.
+func KindNEQ(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldNEQ(FieldKind, v))
+}
+
+// KindIn applies the In predicate on the "kind" field.
+func KindIn(vs ...string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldIn(FieldKind, vs...))
+}
+
+// KindNotIn applies the NotIn predicate on the "kind" field.
+func KindNotIn(vs ...string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldNotIn(FieldKind, vs...))
+}
+
+// KindGT applies the GT predicate on the "kind" field.
+func KindGT(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldGT(FieldKind, v))
+}
+
+// KindGTE applies the GTE predicate on the "kind" field.
+func KindGTE(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldGTE(FieldKind, v))
+}
+
+// KindLT applies the LT predicate on the "kind" field.
+func KindLT(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldLT(FieldKind, v))
+}
+
+// KindLTE applies the LTE predicate on the "kind" field.
+func KindLTE(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldLTE(FieldKind, v))
+}
+
+// KindContains applies the Contains predicate on the "kind" field.
+func KindContains(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldContains(FieldKind, v))
+}
+
+// KindHasPrefix applies the HasPrefix predicate on the "kind" field.
+func KindHasPrefix(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldHasPrefix(FieldKind, v))
+}
+
+// KindHasSuffix applies the HasSuffix predicate on the "kind" field.
+func KindHasSuffix(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldHasSuffix(FieldKind, v))
+}
+
+// KindEqualFold applies the EqualFold predicate on the "kind" field.
+func KindEqualFold(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldEqualFold(FieldKind, v))
+}
+
+// KindContainsFold applies the ContainsFold predicate on the "kind" field.
+func KindContainsFold(v string) predicate.Embedding {
+	return predicate.Embedding(sql.FieldContainsFold(FieldKind, v))
+}
+
 // HasPrescription applies the HasEdge predicate on the "prescription" edge.
 func HasPrescription() predicate.Embedding {
 	return predicate.Embedding(func(s *sql.Selector) {
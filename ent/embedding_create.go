@@ -28,6 +28,62 @@ func (ec *EmbeddingCreate) SetEmbedding(pg pgvector.Vector) *EmbeddingCreate {
 	return ec
 }
 
+// SetModel sets the "model" field.
+func (ec *EmbeddingCreate) SetModel(s string) *EmbeddingCreate {
+	ec.mutation.SetModel(s)
+	return ec
+}
+
+// SetNillableModel sets the "model" field if the given value is not nil.
+func (ec *EmbeddingCreate) SetNillableModel(s *string) *EmbeddingCreate {
+	if s != nil {
+		ec.SetModel(*s)
+	}
+	return ec
+}
+
+// SetVersion sets the "version" field.
+func (ec *EmbeddingCreate) SetVersion(s string) *EmbeddingCreate {
+	ec.mutation.SetVersion(s)
+	return ec
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (ec *EmbeddingCreate) SetNillableVersion(s *string) *EmbeddingCreate {
+	if s != nil {
+		ec.SetVersion(*s)
+	}
+	return ec
+}
+
+// SetDimensions sets the "dimensions" field.
+func (ec *EmbeddingCreate) SetDimensions(i int) *EmbeddingCreate {
+	ec.mutation.SetDimensions(i)
+	return ec
+}
+
+// SetNillableDimensions sets the "dimensions" field if the given value is not nil.
+func (ec *EmbeddingCreate) SetNillableDimensions(i *int) *EmbeddingCreate {
+	if i != nil {
+		ec.SetDimensions(*i)
+	}
+	return ec
+}
+
+// SetKind sets the "kind" field.
+func (ec *EmbeddingCreate) SetKind(s string) *EmbeddingCreate {
+	ec.mutation.SetKind(s)
+	return ec
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (ec *EmbeddingCreate) SetNillableKind(s *string) *EmbeddingCreate {
+	if s != nil {
+		ec.SetKind(*s)
+	}
+	return ec
+}
+
 // SetID sets the "id" field.
 func (ec *EmbeddingCreate) SetID(u uuid.UUID) *EmbeddingCreate {
 	ec.mutation.SetID(u)
@@ -88,6 +144,22 @@ func (ec *EmbeddingCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (ec *EmbeddingCreate) defaults() {
+	if _, ok := ec.mutation.Model(); !ok {
+		v := embedding.DefaultModel
+		ec.mutation.SetModel(v)
+	}
+	if _, ok := ec.mutation.Version(); !ok {
+		v := embedding.DefaultVersion
+		ec.mutation.SetVersion(v)
+	}
+	if _, ok := ec.mutation.Dimensions(); !ok {
+		v := embedding.DefaultDimensions
+		ec.mutation.SetDimensions(v)
+	}
+	if _, ok := ec.mutation.Kind(); !ok {
+		v := embedding.DefaultKind
+		ec.mutation.SetKind(v)
+	}
 	if _, ok := ec.mutation.ID(); !ok {
 		v := embedding.DefaultID()
 		ec.mutation.SetID(v)
@@ -99,6 +171,18 @@ func (ec *EmbeddingCreate) check() error {
 	if _, ok := ec.mutation.Embedding(); !ok {
 		return &ValidationError{Name: "embedding", err: errors.New(`ent: missing required field "Embedding.embedding"`)}
 	}
+	if _, ok := ec.mutation.Model(); !ok {
+		return &ValidationError{Name: "model", err: errors.New(`ent: missing required field "Embedding.model"`)}
+	}
+	if _, ok := ec.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "Embedding.version"`)}
+	}
+	if _, ok := ec.mutation.Dimensions(); !ok {
+		return &ValidationError{Name: "dimensions", err: errors.New(`ent: missing required field "Embedding.dimensions"`)}
+	}
+	if _, ok := ec.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "Embedding.kind"`)}
+	}
 	if len(ec.mutation.PrescriptionIDs()) == 0 {
 		return &ValidationError{Name: "prescription", err: errors.New(`ent: missing required edge "Embedding.prescription"`)}
 	}
@@ -141,6 +225,22 @@ func (ec *EmbeddingCreate) createSpec() (*Embedding, *sqlgraph.CreateSpec) {
 		_spec.SetField(embedding.FieldEmbedding, field.TypeOther, value)
 		_node.Embedding = value
 	}
+	if value, ok := ec.mutation.Model(); ok {
+		_spec.SetField(embedding.FieldModel, field.TypeString, value)
+		_node.Model = value
+	}
+	if value, ok := ec.mutation.Version(); ok {
+		_spec.SetField(embedding.FieldVersion, field.TypeString, value)
+		_node.Version = value
+	}
+	if value, ok := ec.mutation.Dimensions(); ok {
+		_spec.SetField(embedding.FieldDimensions, field.TypeInt, value)
+		_node.Dimensions = value
+	}
+	if value, ok := ec.mutation.Kind(); ok {
+		_spec.SetField(embedding.FieldKind, field.TypeString, value)
+		_node.Kind = value
+	}
 	if nodes := ec.mutation.PrescriptionIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
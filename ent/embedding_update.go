@@ -44,6 +44,69 @@ func (eu *EmbeddingUpdate) SetNillableEmbedding(pg *pgvector.Vector) *EmbeddingU
 	return eu
 }
 
+// SetModel sets the "model" field.
+func (eu *EmbeddingUpdate) SetModel(s string) *EmbeddingUpdate {
+	eu.mutation.SetModel(s)
+	return eu
+}
+
+// SetNillableModel sets the "model" field if the given value is not nil.
+func (eu *EmbeddingUpdate) SetNillableModel(s *string) *EmbeddingUpdate {
+	if s != nil {
+		eu.SetModel(*s)
+	}
+	return eu
+}
+
+// SetVersion sets the "version" field.
+func (eu *EmbeddingUpdate) SetVersion(s string) *EmbeddingUpdate {
+	eu.mutation.SetVersion(s)
+	return eu
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (eu *EmbeddingUpdate) SetNillableVersion(s *string) *EmbeddingUpdate {
+	if s != nil {
+		eu.SetVersion(*s)
+	}
+	return eu
+}
+
+// SetDimensions sets the "dimensions" field.
+func (eu *EmbeddingUpdate) SetDimensions(i int) *EmbeddingUpdate {
+	eu.mutation.ResetDimensions()
+	eu.mutation.SetDimensions(i)
+	return eu
+}
+
+// SetNillableDimensions sets the "dimensions" field if the given value is not nil.
+func (eu *EmbeddingUpdate) SetNillableDimensions(i *int) *EmbeddingUpdate {
+	if i != nil {
+		eu.SetDimensions(*i)
+	}
+	return eu
+}
+
+// AddDimensions adds i to the "dimensions" field.
+func (eu *EmbeddingUpdate) AddDimensions(i int) *EmbeddingUpdate {
+	eu.mutation.AddDimensions(i)
+	return eu
+}
+
+// SetKind sets the "kind" field.
+func (eu *EmbeddingUpdate) SetKind(s string) *EmbeddingUpdate {
+	eu.mutation.SetKind(s)
+	return eu
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (eu *EmbeddingUpdate) SetNillableKind(s *string) *EmbeddingUpdate {
+	if s != nil {
+		eu.SetKind(*s)
+	}
+	return eu
+}
+
 // SetPrescriptionID sets the "prescription" edge to the Prescription entity by ID.
 func (eu *EmbeddingUpdate) SetPrescriptionID(id uuid.UUID) *EmbeddingUpdate {
 	eu.mutation.SetPrescriptionID(id)
@@ -116,6 +179,21 @@ func (eu *EmbeddingUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := eu.mutation.Embedding(); ok {
 		_spec.SetField(embedding.FieldEmbedding, field.TypeOther, value)
 	}
+	if value, ok := eu.mutation.Model(); ok {
+		_spec.SetField(embedding.FieldModel, field.TypeString, value)
+	}
+	if value, ok := eu.mutation.Version(); ok {
+		_spec.SetField(embedding.FieldVersion, field.TypeString, value)
+	}
+	if value, ok := eu.mutation.Dimensions(); ok {
+		_spec.SetField(embedding.FieldDimensions, field.TypeInt, value)
+	}
+	if value, ok := eu.mutation.AddedDimensions(); ok {
+		_spec.AddField(embedding.FieldDimensions, field.TypeInt, value)
+	}
+	if value, ok := eu.mutation.Kind(); ok {
+		_spec.SetField(embedding.FieldKind, field.TypeString, value)
+	}
 	if eu.mutation.PrescriptionCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -179,6 +257,69 @@ func (euo *EmbeddingUpdateOne) SetNillableEmbedding(pg *pgvector.Vector) *Embedd
 	return euo
 }
 
+// SetModel sets the "model" field.
+func (euo *EmbeddingUpdateOne) SetModel(s string) *EmbeddingUpdateOne {
+	euo.mutation.SetModel(s)
+	return euo
+}
+
+// SetNillableModel sets the "model" field if the given value is not nil.
+func (euo *EmbeddingUpdateOne) SetNillableModel(s *string) *EmbeddingUpdateOne {
+	if s != nil {
+		euo.SetModel(*s)
+	}
+	return euo
+}
+
+// SetVersion sets the "version" field.
+func (euo *EmbeddingUpdateOne) SetVersion(s string) *EmbeddingUpdateOne {
+	euo.mutation.SetVersion(s)
+	return euo
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (euo *EmbeddingUpdateOne) SetNillableVersion(s *string) *EmbeddingUpdateOne {
+	if s != nil {
+		euo.SetVersion(*s)
+	}
+	return euo
+}
+
+// SetDimensions sets the "dimensions" field.
+func (euo *EmbeddingUpdateOne) SetDimensions(i int) *EmbeddingUpdateOne {
+	euo.mutation.ResetDimensions()
+	euo.mutation.SetDimensions(i)
+	return euo
+}
+
+// SetNillableDimensions sets the "dimensions" field if the given value is not nil.
+func (euo *EmbeddingUpdateOne) SetNillableDimensions(i *int) *EmbeddingUpdateOne {
+	if i != nil {
+		euo.SetDimensions(*i)
+	}
+	return euo
+}
+
+// AddDimensions adds i to the "dimensions" field.
+func (euo *EmbeddingUpdateOne) AddDimensions(i int) *EmbeddingUpdateOne {
+	euo.mutation.AddDimensions(i)
+	return euo
+}
+
+// SetKind sets the "kind" field.
+func (euo *EmbeddingUpdateOne) SetKind(s string) *EmbeddingUpdateOne {
+	euo.mutation.SetKind(s)
+	return euo
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (euo *EmbeddingUpdateOne) SetNillableKind(s *string) *EmbeddingUpdateOne {
+	if s != nil {
+		euo.SetKind(*s)
+	}
+	return euo
+}
+
 // SetPrescriptionID sets the "prescription" edge to the Prescription entity by ID.
 func (euo *EmbeddingUpdateOne) SetPrescriptionID(id uuid.UUID) *EmbeddingUpdateOne {
 	euo.mutation.SetPrescriptionID(id)
@@ -281,6 +422,21 @@ func (euo *EmbeddingUpdateOne) sqlSave(ctx context.Context) (_node *Embedding, e
 	if value, ok := euo.mutation.Embedding(); ok {
 		_spec.SetField(embedding.FieldEmbedding, field.TypeOther, value)
 	}
+	if value, ok := euo.mutation.Model(); ok {
+		_spec.SetField(embedding.FieldModel, field.TypeString, value)
+	}
+	if value, ok := euo.mutation.Version(); ok {
+		_spec.SetField(embedding.FieldVersion, field.TypeString, value)
+	}
+	if value, ok := euo.mutation.Dimensions(); ok {
+		_spec.SetField(embedding.FieldDimensions, field.TypeInt, value)
+	}
+	if value, ok := euo.mutation.AddedDimensions(); ok {
+		_spec.AddField(embedding.FieldDimensions, field.TypeInt, value)
+	}
+	if value, ok := euo.mutation.Kind(); ok {
+		_spec.SetField(embedding.FieldKind, field.TypeString, value)
+	}
 	if euo.mutation.PrescriptionCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
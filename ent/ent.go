@@ -13,7 +13,9 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/csotherden/prescription-parser/ent/embedding"
+	"github.com/csotherden/prescription-parser/ent/parseresult"
 	"github.com/csotherden/prescription-parser/ent/prescription"
+	"github.com/csotherden/prescription-parser/ent/promptoverlay"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -74,8 +76,10 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			embedding.Table:    embedding.ValidColumn,
-			prescription.Table: prescription.ValidColumn,
+			embedding.Table:     embedding.ValidColumn,
+			parseresult.Table:   parseresult.ValidColumn,
+			prescription.Table:  prescription.ValidColumn,
+			promptoverlay.Table: promptoverlay.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
@@ -21,6 +21,18 @@ func (f EmbeddingFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, e
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.EmbeddingMutation", m)
 }
 
+// The ParseResultFunc type is an adapter to allow the use of ordinary
+// function as ParseResult mutator.
+type ParseResultFunc func(context.Context, *ent.ParseResultMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f ParseResultFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.ParseResultMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.ParseResultMutation", m)
+}
+
 // The PrescriptionFunc type is an adapter to allow the use of ordinary
 // function as Prescription mutator.
 type PrescriptionFunc func(context.Context, *ent.PrescriptionMutation) (ent.Value, error)
@@ -33,6 +45,18 @@ func (f PrescriptionFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.PrescriptionMutation", m)
 }
 
+// The PromptOverlayFunc type is an adapter to allow the use of ordinary
+// function as PromptOverlay mutator.
+type PromptOverlayFunc func(context.Context, *ent.PromptOverlayMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f PromptOverlayFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.PromptOverlayMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.PromptOverlayMutation", m)
+}
+
 // Condition is a hook condition function.
 type Condition func(context.Context, ent.Mutation) bool
 
@@ -3,7 +3,6 @@
 package migrate
 
 import (
-	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/schema/field"
 )
@@ -12,7 +11,11 @@ var (
 	// EmbeddingsColumns holds the columns for the "embeddings" table.
 	EmbeddingsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
-		{Name: "embedding", Type: field.TypeOther, SchemaType: map[string]string{"postgres": "vector(1536)"}},
+		{Name: "embedding", Type: field.TypeOther, SchemaType: map[string]string{"postgres": "vector"}},
+		{Name: "model", Type: field.TypeString, Default: ""},
+		{Name: "version", Type: field.TypeString, Default: ""},
+		{Name: "dimensions", Type: field.TypeInt, Default: 1536},
+		{Name: "kind", Type: field.TypeString, Default: "content"},
 		{Name: "embedding_prescription", Type: field.TypeUUID},
 	}
 	// EmbeddingsTable holds the schema information for the "embeddings" table.
@@ -23,20 +26,45 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "embeddings_prescriptions_prescription",
-				Columns:    []*schema.Column{EmbeddingsColumns[2]},
+				Columns:    []*schema.Column{EmbeddingsColumns[6]},
 				RefColumns: []*schema.Column{PrescriptionsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 		},
 		Indexes: []*schema.Index{
 			{
-				Name:    "embedding_embedding",
+				Name:    "embedding_model_version_embedding_prescription",
+				Unique:  true,
+				Columns: []*schema.Column{EmbeddingsColumns[2], EmbeddingsColumns[3], EmbeddingsColumns[6]},
+			},
+		},
+	}
+	// ParseResultsColumns holds the columns for the "parse_results" table.
+	ParseResultsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "status", Type: field.TypeString},
+		{Name: "content", Type: field.TypeJSON},
+		{Name: "validation", Type: field.TypeJSON, Nullable: true},
+		{Name: "blocked", Type: field.TypeBool, Default: false},
+		{Name: "verification", Type: field.TypeJSON, Nullable: true},
+		{Name: "origins", Type: field.TypeJSON, Nullable: true},
+		{Name: "prescriptions", Type: field.TypeJSON, Nullable: true},
+		{Name: "attributes", Type: field.TypeJSON, Nullable: true},
+		{Name: "started_at", Type: field.TypeTime},
+		{Name: "completed_at", Type: field.TypeTime},
+		{Name: "review", Type: field.TypeJSON, Nullable: true},
+	}
+	// ParseResultsTable holds the schema information for the "parse_results" table.
+	ParseResultsTable = &schema.Table{
+		Name:       "parse_results",
+		Columns:    ParseResultsColumns,
+		PrimaryKey: []*schema.Column{ParseResultsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "parseresult_completed_at",
 				Unique:  false,
-				Columns: []*schema.Column{EmbeddingsColumns[1]},
-				Annotation: &entsql.IndexAnnotation{
-					OpClass: "vector_l2_ops",
-					Type:    "hnsw",
-				},
+				Columns: []*schema.Column{ParseResultsColumns[11]},
 			},
 		},
 	}
@@ -47,6 +75,10 @@ var (
 		{Name: "file_id", Type: field.TypeString},
 		{Name: "mime_type", Type: field.TypeString},
 		{Name: "content", Type: field.TypeJSON},
+		{Name: "document_hash", Type: field.TypeString, Nullable: true},
+		{Name: "template", Type: field.TypeString, Nullable: true},
+		{Name: "tenant", Type: field.TypeString, Nullable: true},
+		{Name: "drug_class", Type: field.TypeString, Nullable: true},
 	}
 	// PrescriptionsTable holds the schema information for the "prescriptions" table.
 	PrescriptionsTable = &schema.Table{
@@ -54,10 +86,34 @@ var (
 		Columns:    PrescriptionsColumns,
 		PrimaryKey: []*schema.Column{PrescriptionsColumns[0]},
 	}
+	// PromptOverlaysColumns holds the columns for the "prompt_overlays" table.
+	PromptOverlaysColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "template", Type: field.TypeString},
+		{Name: "version", Type: field.TypeInt},
+		{Name: "instructions", Type: field.TypeString, Size: 2147483647},
+		{Name: "author", Type: field.TypeString, Nullable: true},
+	}
+	// PromptOverlaysTable holds the schema information for the "prompt_overlays" table.
+	PromptOverlaysTable = &schema.Table{
+		Name:       "prompt_overlays",
+		Columns:    PromptOverlaysColumns,
+		PrimaryKey: []*schema.Column{PromptOverlaysColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "promptoverlay_template_version",
+				Unique:  true,
+				Columns: []*schema.Column{PromptOverlaysColumns[2], PromptOverlaysColumns[3]},
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		EmbeddingsTable,
+		ParseResultsTable,
 		PrescriptionsTable,
+		PromptOverlaysTable,
 	}
 )
 
@@ -12,8 +12,10 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"github.com/csotherden/prescription-parser/ent/embedding"
+	"github.com/csotherden/prescription-parser/ent/parseresult"
 	"github.com/csotherden/prescription-parser/ent/predicate"
 	"github.com/csotherden/prescription-parser/ent/prescription"
+	"github.com/csotherden/prescription-parser/ent/promptoverlay"
 	"github.com/csotherden/prescription-parser/pkg/models"
 	"github.com/google/uuid"
 	pgvector "github.com/pgvector/pgvector-go"
@@ -28,8 +30,10 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeEmbedding    = "Embedding"
-	TypePrescription = "Prescription"
+	TypeEmbedding     = "Embedding"
+	TypeParseResult   = "ParseResult"
+	TypePrescription  = "Prescription"
+	TypePromptOverlay = "PromptOverlay"
 )
 
 // EmbeddingMutation represents an operation that mutates the Embedding nodes in the graph.
@@ -39,6 +43,11 @@ type EmbeddingMutation struct {
 	typ                 string
 	id                  *uuid.UUID
 	embedding           *pgvector.Vector
+	model               *string
+	version             *string
+	dimensions          *int
+	adddimensions       *int
+	kind                *string
 	clearedFields       map[string]struct{}
 	prescription        *uuid.UUID
 	clearedprescription bool
@@ -187,6 +196,170 @@ func (m *EmbeddingMutation) ResetEmbedding() {
 	m.embedding = nil
 }
 
+// SetModel sets the "model" field.
+func (m *EmbeddingMutation) SetModel(s string) {
+	m.model = &s
+}
+
+// Model returns the value of the "model" field in the mutation.
+func (m *EmbeddingMutation) Model() (r string, exists bool) {
+	v := m.model
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldModel returns the old "model" field's value of the Embedding entity.
+// If the Embedding object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmbeddingMutation) OldModel(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldModel is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldModel requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldModel: %w", err)
+	}
+	return oldValue.Model, nil
+}
+
+// ResetModel resets all changes to the "model" field.
+func (m *EmbeddingMutation) ResetModel() {
+	m.model = nil
+}
+
+// SetVersion sets the "version" field.
+func (m *EmbeddingMutation) SetVersion(s string) {
+	m.version = &s
+}
+
+// Version returns the value of the "version" field in the mutation.
+func (m *EmbeddingMutation) Version() (r string, exists bool) {
+	v := m.version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVersion returns the old "version" field's value of the Embedding entity.
+// If the Embedding object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmbeddingMutation) OldVersion(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVersion: %w", err)
+	}
+	return oldValue.Version, nil
+}
+
+// ResetVersion resets all changes to the "version" field.
+func (m *EmbeddingMutation) ResetVersion() {
+	m.version = nil
+}
+
+// SetDimensions sets the "dimensions" field.
+func (m *EmbeddingMutation) SetDimensions(i int) {
+	m.dimensions = &i
+	m.adddimensions = nil
+}
+
+// Dimensions returns the value of the "dimensions" field in the mutation.
+func (m *EmbeddingMutation) Dimensions() (r int, exists bool) {
+	v := m.dimensions
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDimensions returns the old "dimensions" field's value of the Embedding entity.
+// If the Embedding object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmbeddingMutation) OldDimensions(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDimensions is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDimensions requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDimensions: %w", err)
+	}
+	return oldValue.Dimensions, nil
+}
+
+// AddDimensions adds i to the "dimensions" field.
+func (m *EmbeddingMutation) AddDimensions(i int) {
+	if m.adddimensions != nil {
+		*m.adddimensions += i
+	} else {
+		m.adddimensions = &i
+	}
+}
+
+// AddedDimensions returns the value that was added to the "dimensions" field in this mutation.
+func (m *EmbeddingMutation) AddedDimensions() (r int, exists bool) {
+	v := m.adddimensions
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetDimensions resets all changes to the "dimensions" field.
+func (m *EmbeddingMutation) ResetDimensions() {
+	m.dimensions = nil
+	m.adddimensions = nil
+}
+
+// SetKind sets the "kind" field.
+func (m *EmbeddingMutation) SetKind(s string) {
+	m.kind = &s
+}
+
+// Kind returns the value of the "kind" field in the mutation.
+func (m *EmbeddingMutation) Kind() (r string, exists bool) {
+	v := m.kind
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldKind returns the old "kind" field's value of the Embedding entity.
+// If the Embedding object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmbeddingMutation) OldKind(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldKind is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldKind requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldKind: %w", err)
+	}
+	return oldValue.Kind, nil
+}
+
+// ResetKind resets all changes to the "kind" field.
+func (m *EmbeddingMutation) ResetKind() {
+	m.kind = nil
+}
+
 // SetPrescriptionID sets the "prescription" edge to the Prescription entity by id.
 func (m *EmbeddingMutation) SetPrescriptionID(id uuid.UUID) {
 	m.prescription = &id
@@ -260,10 +433,22 @@ func (m *EmbeddingMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *EmbeddingMutation) Fields() []string {
-	fields := make([]string, 0, 1)
+	fields := make([]string, 0, 5)
 	if m.embedding != nil {
 		fields = append(fields, embedding.FieldEmbedding)
 	}
+	if m.model != nil {
+		fields = append(fields, embedding.FieldModel)
+	}
+	if m.version != nil {
+		fields = append(fields, embedding.FieldVersion)
+	}
+	if m.dimensions != nil {
+		fields = append(fields, embedding.FieldDimensions)
+	}
+	if m.kind != nil {
+		fields = append(fields, embedding.FieldKind)
+	}
 	return fields
 }
 
@@ -274,6 +459,14 @@ func (m *EmbeddingMutation) Field(name string) (ent.Value, bool) {
 	switch name {
 	case embedding.FieldEmbedding:
 		return m.Embedding()
+	case embedding.FieldModel:
+		return m.Model()
+	case embedding.FieldVersion:
+		return m.Version()
+	case embedding.FieldDimensions:
+		return m.Dimensions()
+	case embedding.FieldKind:
+		return m.Kind()
 	}
 	return nil, false
 }
@@ -285,6 +478,14 @@ func (m *EmbeddingMutation) OldField(ctx context.Context, name string) (ent.Valu
 	switch name {
 	case embedding.FieldEmbedding:
 		return m.OldEmbedding(ctx)
+	case embedding.FieldModel:
+		return m.OldModel(ctx)
+	case embedding.FieldVersion:
+		return m.OldVersion(ctx)
+	case embedding.FieldDimensions:
+		return m.OldDimensions(ctx)
+	case embedding.FieldKind:
+		return m.OldKind(ctx)
 	}
 	return nil, fmt.Errorf("unknown Embedding field %s", name)
 }
@@ -301,6 +502,34 @@ func (m *EmbeddingMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetEmbedding(v)
 		return nil
+	case embedding.FieldModel:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetModel(v)
+		return nil
+	case embedding.FieldVersion:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVersion(v)
+		return nil
+	case embedding.FieldDimensions:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDimensions(v)
+		return nil
+	case embedding.FieldKind:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetKind(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Embedding field %s", name)
 }
@@ -308,13 +537,21 @@ func (m *EmbeddingMutation) SetField(name string, value ent.Value) error {
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
 func (m *EmbeddingMutation) AddedFields() []string {
-	return nil
+	var fields []string
+	if m.adddimensions != nil {
+		fields = append(fields, embedding.FieldDimensions)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
 func (m *EmbeddingMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case embedding.FieldDimensions:
+		return m.AddedDimensions()
+	}
 	return nil, false
 }
 
@@ -323,6 +560,13 @@ func (m *EmbeddingMutation) AddedField(name string) (ent.Value, bool) {
 // type.
 func (m *EmbeddingMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case embedding.FieldDimensions:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddDimensions(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Embedding numeric field %s", name)
 }
@@ -353,6 +597,18 @@ func (m *EmbeddingMutation) ResetField(name string) error {
 	case embedding.FieldEmbedding:
 		m.ResetEmbedding()
 		return nil
+	case embedding.FieldModel:
+		m.ResetModel()
+		return nil
+	case embedding.FieldVersion:
+		m.ResetVersion()
+		return nil
+	case embedding.FieldDimensions:
+		m.ResetDimensions()
+		return nil
+	case embedding.FieldKind:
+		m.ResetKind()
+		return nil
 	}
 	return fmt.Errorf("unknown Embedding field %s", name)
 }
@@ -431,33 +687,44 @@ func (m *EmbeddingMutation) ResetEdge(name string) error {
 	return fmt.Errorf("unknown Embedding edge %s", name)
 }
 
-// PrescriptionMutation represents an operation that mutates the Prescription nodes in the graph.
-type PrescriptionMutation struct {
+// ParseResultMutation represents an operation that mutates the ParseResult nodes in the graph.
+type ParseResultMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *uuid.UUID
-	created_at    *time.Time
-	file_id       *string
-	mime_type     *string
-	content       *models.Prescription
-	clearedFields map[string]struct{}
-	done          bool
-	oldValue      func(context.Context) (*Prescription, error)
-	predicates    []predicate.Prescription
+	op                  Op
+	typ                 string
+	id                  *uuid.UUID
+	created_at          *time.Time
+	status              *string
+	content             *models.Prescription
+	validation          *[]models.ValidationIssue
+	appendvalidation    []models.ValidationIssue
+	blocked             *bool
+	verification        *[]models.FieldChange
+	appendverification  []models.FieldChange
+	origins             *map[string]string
+	prescriptions       *[]models.DocumentPrescription
+	appendprescriptions []models.DocumentPrescription
+	attributes          *map[string]string
+	started_at          *time.Time
+	completed_at        *time.Time
+	review              **models.Review
+	clearedFields       map[string]struct{}
+	done                bool
+	oldValue            func(context.Context) (*ParseResult, error)
+	predicates          []predicate.ParseResult
 }
 
-var _ ent.Mutation = (*PrescriptionMutation)(nil)
+var _ ent.Mutation = (*ParseResultMutation)(nil)
 
-// prescriptionOption allows management of the mutation configuration using functional options.
-type prescriptionOption func(*PrescriptionMutation)
+// parseresultOption allows management of the mutation configuration using functional options.
+type parseresultOption func(*ParseResultMutation)
 
-// newPrescriptionMutation creates new mutation for the Prescription entity.
-func newPrescriptionMutation(c config, op Op, opts ...prescriptionOption) *PrescriptionMutation {
-	m := &PrescriptionMutation{
+// newParseResultMutation creates new mutation for the ParseResult entity.
+func newParseResultMutation(c config, op Op, opts ...parseresultOption) *ParseResultMutation {
+	m := &ParseResultMutation{
 		config:        c,
 		op:            op,
-		typ:           TypePrescription,
+		typ:           TypeParseResult,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -466,20 +733,20 @@ func newPrescriptionMutation(c config, op Op, opts ...prescriptionOption) *Presc
 	return m
 }
 
-// withPrescriptionID sets the ID field of the mutation.
-func withPrescriptionID(id uuid.UUID) prescriptionOption {
-	return func(m *PrescriptionMutation) {
+// withParseResultID sets the ID field of the mutation.
+func withParseResultID(id uuid.UUID) parseresultOption {
+	return func(m *ParseResultMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Prescription
+			value *ParseResult
 		)
-		m.oldValue = func(ctx context.Context) (*Prescription, error) {
+		m.oldValue = func(ctx context.Context) (*ParseResult, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Prescription.Get(ctx, id)
+					value, err = m.Client().ParseResult.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -488,10 +755,10 @@ func withPrescriptionID(id uuid.UUID) prescriptionOption {
 	}
 }
 
-// withPrescription sets the old Prescription of the mutation.
-func withPrescription(node *Prescription) prescriptionOption {
-	return func(m *PrescriptionMutation) {
-		m.oldValue = func(context.Context) (*Prescription, error) {
+// withParseResult sets the old ParseResult of the mutation.
+func withParseResult(node *ParseResult) parseresultOption {
+	return func(m *ParseResultMutation) {
+		m.oldValue = func(context.Context) (*ParseResult, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -500,7 +767,7 @@ func withPrescription(node *Prescription) prescriptionOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m PrescriptionMutation) Client() *Client {
+func (m ParseResultMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -508,7 +775,7 @@ func (m PrescriptionMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m PrescriptionMutation) Tx() (*Tx, error) {
+func (m ParseResultMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -518,14 +785,14 @@ func (m PrescriptionMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Prescription entities.
-func (m *PrescriptionMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of ParseResult entities.
+func (m *ParseResultMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *PrescriptionMutation) ID() (id uuid.UUID, exists bool) {
+func (m *ParseResultMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -536,7 +803,7 @@ func (m *PrescriptionMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *PrescriptionMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *ParseResultMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -545,19 +812,19 @@ func (m *PrescriptionMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Prescription.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().ParseResult.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *PrescriptionMutation) SetCreatedAt(t time.Time) {
+func (m *ParseResultMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *PrescriptionMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *ParseResultMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -565,10 +832,10 @@ func (m *PrescriptionMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Prescription entity.
-// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PrescriptionMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *ParseResultMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -583,164 +850,2203 @@ func (m *PrescriptionMutation) OldCreatedAt(ctx context.Context) (v time.Time, e
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *PrescriptionMutation) ResetCreatedAt() {
+func (m *ParseResultMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// SetFileID sets the "file_id" field.
-func (m *PrescriptionMutation) SetFileID(s string) {
-	m.file_id = &s
+// SetStatus sets the "status" field.
+func (m *ParseResultMutation) SetStatus(s string) {
+	m.status = &s
 }
 
-// FileID returns the value of the "file_id" field in the mutation.
-func (m *PrescriptionMutation) FileID() (r string, exists bool) {
-	v := m.file_id
+// Status returns the value of the "status" field in the mutation.
+func (m *ParseResultMutation) Status() (r string, exists bool) {
+	v := m.status
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldFileID returns the old "file_id" field's value of the Prescription entity.
-// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// OldStatus returns the old "status" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PrescriptionMutation) OldFileID(ctx context.Context) (v string, err error) {
+func (m *ParseResultMutation) OldStatus(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldFileID is only allowed on UpdateOne operations")
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldFileID requires an ID field in the mutation")
+		return v, errors.New("OldStatus requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldFileID: %w", err)
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
 	}
-	return oldValue.FileID, nil
+	return oldValue.Status, nil
 }
 
-// ResetFileID resets all changes to the "file_id" field.
-func (m *PrescriptionMutation) ResetFileID() {
-	m.file_id = nil
+// ResetStatus resets all changes to the "status" field.
+func (m *ParseResultMutation) ResetStatus() {
+	m.status = nil
 }
 
-// SetMimeType sets the "mime_type" field.
-func (m *PrescriptionMutation) SetMimeType(s string) {
-	m.mime_type = &s
+// SetContent sets the "content" field.
+func (m *ParseResultMutation) SetContent(value models.Prescription) {
+	m.content = &value
 }
 
-// MimeType returns the value of the "mime_type" field in the mutation.
-func (m *PrescriptionMutation) MimeType() (r string, exists bool) {
-	v := m.mime_type
+// Content returns the value of the "content" field in the mutation.
+func (m *ParseResultMutation) Content() (r models.Prescription, exists bool) {
+	v := m.content
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldMimeType returns the old "mime_type" field's value of the Prescription entity.
-// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// OldContent returns the old "content" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PrescriptionMutation) OldMimeType(ctx context.Context) (v string, err error) {
+func (m *ParseResultMutation) OldContent(ctx context.Context) (v models.Prescription, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldMimeType is only allowed on UpdateOne operations")
+		return v, errors.New("OldContent is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldMimeType requires an ID field in the mutation")
+		return v, errors.New("OldContent requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldMimeType: %w", err)
+		return v, fmt.Errorf("querying old value for OldContent: %w", err)
 	}
-	return oldValue.MimeType, nil
+	return oldValue.Content, nil
 }
 
-// ResetMimeType resets all changes to the "mime_type" field.
-func (m *PrescriptionMutation) ResetMimeType() {
-	m.mime_type = nil
+// ResetContent resets all changes to the "content" field.
+func (m *ParseResultMutation) ResetContent() {
+	m.content = nil
 }
 
-// SetContent sets the "content" field.
-func (m *PrescriptionMutation) SetContent(value models.Prescription) {
-	m.content = &value
+// SetValidation sets the "validation" field.
+func (m *ParseResultMutation) SetValidation(mi []models.ValidationIssue) {
+	m.validation = &mi
+	m.appendvalidation = nil
 }
 
-// Content returns the value of the "content" field in the mutation.
-func (m *PrescriptionMutation) Content() (r models.Prescription, exists bool) {
-	v := m.content
+// Validation returns the value of the "validation" field in the mutation.
+func (m *ParseResultMutation) Validation() (r []models.ValidationIssue, exists bool) {
+	v := m.validation
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldContent returns the old "content" field's value of the Prescription entity.
-// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// OldValidation returns the old "validation" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PrescriptionMutation) OldContent(ctx context.Context) (v models.Prescription, err error) {
+func (m *ParseResultMutation) OldValidation(ctx context.Context) (v []models.ValidationIssue, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldContent is only allowed on UpdateOne operations")
+		return v, errors.New("OldValidation is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldContent requires an ID field in the mutation")
+		return v, errors.New("OldValidation requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldContent: %w", err)
+		return v, fmt.Errorf("querying old value for OldValidation: %w", err)
 	}
-	return oldValue.Content, nil
+	return oldValue.Validation, nil
 }
 
-// ResetContent resets all changes to the "content" field.
-func (m *PrescriptionMutation) ResetContent() {
-	m.content = nil
+// AppendValidation adds mi to the "validation" field.
+func (m *ParseResultMutation) AppendValidation(mi []models.ValidationIssue) {
+	m.appendvalidation = append(m.appendvalidation, mi...)
 }
 
-// Where appends a list predicates to the PrescriptionMutation builder.
-func (m *PrescriptionMutation) Where(ps ...predicate.Prescription) {
-	m.predicates = append(m.predicates, ps...)
+// AppendedValidation returns the list of values that were appended to the "validation" field in this mutation.
+func (m *ParseResultMutation) AppendedValidation() ([]models.ValidationIssue, bool) {
+	if len(m.appendvalidation) == 0 {
+		return nil, false
+	}
+	return m.appendvalidation, true
 }
 
-// WhereP appends storage-level predicates to the PrescriptionMutation builder. Using this method,
-// users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *PrescriptionMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Prescription, len(ps))
-	for i := range ps {
-		p[i] = ps[i]
-	}
-	m.Where(p...)
+// ClearValidation clears the value of the "validation" field.
+func (m *ParseResultMutation) ClearValidation() {
+	m.validation = nil
+	m.appendvalidation = nil
+	m.clearedFields[parseresult.FieldValidation] = struct{}{}
 }
 
-// Op returns the operation name.
-func (m *PrescriptionMutation) Op() Op {
-	return m.op
+// ValidationCleared returns if the "validation" field was cleared in this mutation.
+func (m *ParseResultMutation) ValidationCleared() bool {
+	_, ok := m.clearedFields[parseresult.FieldValidation]
+	return ok
 }
 
-// SetOp allows setting the mutation operation.
-func (m *PrescriptionMutation) SetOp(op Op) {
-	m.op = op
+// ResetValidation resets all changes to the "validation" field.
+func (m *ParseResultMutation) ResetValidation() {
+	m.validation = nil
+	m.appendvalidation = nil
+	delete(m.clearedFields, parseresult.FieldValidation)
 }
 
-// Type returns the node type of this mutation (Prescription).
-func (m *PrescriptionMutation) Type() string {
-	return m.typ
+// SetBlocked sets the "blocked" field.
+func (m *ParseResultMutation) SetBlocked(b bool) {
+	m.blocked = &b
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *PrescriptionMutation) Fields() []string {
-	fields := make([]string, 0, 4)
+// Blocked returns the value of the "blocked" field in the mutation.
+func (m *ParseResultMutation) Blocked() (r bool, exists bool) {
+	v := m.blocked
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBlocked returns the old "blocked" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ParseResultMutation) OldBlocked(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBlocked is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBlocked requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBlocked: %w", err)
+	}
+	return oldValue.Blocked, nil
+}
+
+// ResetBlocked resets all changes to the "blocked" field.
+func (m *ParseResultMutation) ResetBlocked() {
+	m.blocked = nil
+}
+
+// SetVerification sets the "verification" field.
+func (m *ParseResultMutation) SetVerification(mc []models.FieldChange) {
+	m.verification = &mc
+	m.appendverification = nil
+}
+
+// Verification returns the value of the "verification" field in the mutation.
+func (m *ParseResultMutation) Verification() (r []models.FieldChange, exists bool) {
+	v := m.verification
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVerification returns the old "verification" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ParseResultMutation) OldVerification(ctx context.Context) (v []models.FieldChange, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVerification is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVerification requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVerification: %w", err)
+	}
+	return oldValue.Verification, nil
+}
+
+// AppendVerification adds mc to the "verification" field.
+func (m *ParseResultMutation) AppendVerification(mc []models.FieldChange) {
+	m.appendverification = append(m.appendverification, mc...)
+}
+
+// AppendedVerification returns the list of values that were appended to the "verification" field in this mutation.
+func (m *ParseResultMutation) AppendedVerification() ([]models.FieldChange, bool) {
+	if len(m.appendverification) == 0 {
+		return nil, false
+	}
+	return m.appendverification, true
+}
+
+// ClearVerification clears the value of the "verification" field.
+func (m *ParseResultMutation) ClearVerification() {
+	m.verification = nil
+	m.appendverification = nil
+	m.clearedFields[parseresult.FieldVerification] = struct{}{}
+}
+
+// VerificationCleared returns if the "verification" field was cleared in this mutation.
+func (m *ParseResultMutation) VerificationCleared() bool {
+	_, ok := m.clearedFields[parseresult.FieldVerification]
+	return ok
+}
+
+// ResetVerification resets all changes to the "verification" field.
+func (m *ParseResultMutation) ResetVerification() {
+	m.verification = nil
+	m.appendverification = nil
+	delete(m.clearedFields, parseresult.FieldVerification)
+}
+
+// SetOrigins sets the "origins" field.
+func (m *ParseResultMutation) SetOrigins(value map[string]string) {
+	m.origins = &value
+}
+
+// Origins returns the value of the "origins" field in the mutation.
+func (m *ParseResultMutation) Origins() (r map[string]string, exists bool) {
+	v := m.origins
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOrigins returns the old "origins" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ParseResultMutation) OldOrigins(ctx context.Context) (v map[string]string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOrigins is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOrigins requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOrigins: %w", err)
+	}
+	return oldValue.Origins, nil
+}
+
+// ClearOrigins clears the value of the "origins" field.
+func (m *ParseResultMutation) ClearOrigins() {
+	m.origins = nil
+	m.clearedFields[parseresult.FieldOrigins] = struct{}{}
+}
+
+// OriginsCleared returns if the "origins" field was cleared in this mutation.
+func (m *ParseResultMutation) OriginsCleared() bool {
+	_, ok := m.clearedFields[parseresult.FieldOrigins]
+	return ok
+}
+
+// ResetOrigins resets all changes to the "origins" field.
+func (m *ParseResultMutation) ResetOrigins() {
+	m.origins = nil
+	delete(m.clearedFields, parseresult.FieldOrigins)
+}
+
+// SetPrescriptions sets the "prescriptions" field.
+func (m *ParseResultMutation) SetPrescriptions(mp []models.DocumentPrescription) {
+	m.prescriptions = &mp
+	m.appendprescriptions = nil
+}
+
+// Prescriptions returns the value of the "prescriptions" field in the mutation.
+func (m *ParseResultMutation) Prescriptions() (r []models.DocumentPrescription, exists bool) {
+	v := m.prescriptions
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPrescriptions returns the old "prescriptions" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ParseResultMutation) OldPrescriptions(ctx context.Context) (v []models.DocumentPrescription, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPrescriptions is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPrescriptions requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPrescriptions: %w", err)
+	}
+	return oldValue.Prescriptions, nil
+}
+
+// AppendPrescriptions adds mp to the "prescriptions" field.
+func (m *ParseResultMutation) AppendPrescriptions(mp []models.DocumentPrescription) {
+	m.appendprescriptions = append(m.appendprescriptions, mp...)
+}
+
+// AppendedPrescriptions returns the list of values that were appended to the "prescriptions" field in this mutation.
+func (m *ParseResultMutation) AppendedPrescriptions() ([]models.DocumentPrescription, bool) {
+	if len(m.appendprescriptions) == 0 {
+		return nil, false
+	}
+	return m.appendprescriptions, true
+}
+
+// ClearPrescriptions clears the value of the "prescriptions" field.
+func (m *ParseResultMutation) ClearPrescriptions() {
+	m.prescriptions = nil
+	m.appendprescriptions = nil
+	m.clearedFields[parseresult.FieldPrescriptions] = struct{}{}
+}
+
+// PrescriptionsCleared returns if the "prescriptions" field was cleared in this mutation.
+func (m *ParseResultMutation) PrescriptionsCleared() bool {
+	_, ok := m.clearedFields[parseresult.FieldPrescriptions]
+	return ok
+}
+
+// ResetPrescriptions resets all changes to the "prescriptions" field.
+func (m *ParseResultMutation) ResetPrescriptions() {
+	m.prescriptions = nil
+	m.appendprescriptions = nil
+	delete(m.clearedFields, parseresult.FieldPrescriptions)
+}
+
+// SetAttributes sets the "attributes" field.
+func (m *ParseResultMutation) SetAttributes(value map[string]string) {
+	m.attributes = &value
+}
+
+// Attributes returns the value of the "attributes" field in the mutation.
+func (m *ParseResultMutation) Attributes() (r map[string]string, exists bool) {
+	v := m.attributes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAttributes returns the old "attributes" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ParseResultMutation) OldAttributes(ctx context.Context) (v map[string]string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAttributes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAttributes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAttributes: %w", err)
+	}
+	return oldValue.Attributes, nil
+}
+
+// ClearAttributes clears the value of the "attributes" field.
+func (m *ParseResultMutation) ClearAttributes() {
+	m.attributes = nil
+	m.clearedFields[parseresult.FieldAttributes] = struct{}{}
+}
+
+// AttributesCleared returns if the "attributes" field was cleared in this mutation.
+func (m *ParseResultMutation) AttributesCleared() bool {
+	_, ok := m.clearedFields[parseresult.FieldAttributes]
+	return ok
+}
+
+// ResetAttributes resets all changes to the "attributes" field.
+func (m *ParseResultMutation) ResetAttributes() {
+	m.attributes = nil
+	delete(m.clearedFields, parseresult.FieldAttributes)
+}
+
+// SetStartedAt sets the "started_at" field.
+func (m *ParseResultMutation) SetStartedAt(t time.Time) {
+	m.started_at = &t
+}
+
+// StartedAt returns the value of the "started_at" field in the mutation.
+func (m *ParseResultMutation) StartedAt() (r time.Time, exists bool) {
+	v := m.started_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStartedAt returns the old "started_at" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ParseResultMutation) OldStartedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStartedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStartedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStartedAt: %w", err)
+	}
+	return oldValue.StartedAt, nil
+}
+
+// ResetStartedAt resets all changes to the "started_at" field.
+func (m *ParseResultMutation) ResetStartedAt() {
+	m.started_at = nil
+}
+
+// SetCompletedAt sets the "completed_at" field.
+func (m *ParseResultMutation) SetCompletedAt(t time.Time) {
+	m.completed_at = &t
+}
+
+// CompletedAt returns the value of the "completed_at" field in the mutation.
+func (m *ParseResultMutation) CompletedAt() (r time.Time, exists bool) {
+	v := m.completed_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCompletedAt returns the old "completed_at" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ParseResultMutation) OldCompletedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCompletedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCompletedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCompletedAt: %w", err)
+	}
+	return oldValue.CompletedAt, nil
+}
+
+// ResetCompletedAt resets all changes to the "completed_at" field.
+func (m *ParseResultMutation) ResetCompletedAt() {
+	m.completed_at = nil
+}
+
+// SetReview sets the "review" field.
+func (m *ParseResultMutation) SetReview(value *models.Review) {
+	m.review = &value
+}
+
+// Review returns the value of the "review" field in the mutation.
+func (m *ParseResultMutation) Review() (r *models.Review, exists bool) {
+	v := m.review
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldReview returns the old "review" field's value of the ParseResult entity.
+// If the ParseResult object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ParseResultMutation) OldReview(ctx context.Context) (v *models.Review, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldReview is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldReview requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldReview: %w", err)
+	}
+	return oldValue.Review, nil
+}
+
+// ClearReview clears the value of the "review" field.
+func (m *ParseResultMutation) ClearReview() {
+	m.review = nil
+	m.clearedFields[parseresult.FieldReview] = struct{}{}
+}
+
+// ReviewCleared returns if the "review" field was cleared in this mutation.
+func (m *ParseResultMutation) ReviewCleared() bool {
+	_, ok := m.clearedFields[parseresult.FieldReview]
+	return ok
+}
+
+// ResetReview resets all changes to the "review" field.
+func (m *ParseResultMutation) ResetReview() {
+	m.review = nil
+	delete(m.clearedFields, parseresult.FieldReview)
+}
+
+// Where appends a list predicates to the ParseResultMutation builder.
+func (m *ParseResultMutation) Where(ps ...predicate.ParseResult) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the ParseResultMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ParseResultMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.ParseResult, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *ParseResultMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *ParseResultMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (ParseResult).
+func (m *ParseResultMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ParseResultMutation) Fields() []string {
+	fields := make([]string, 0, 12)
+	if m.created_at != nil {
+		fields = append(fields, parseresult.FieldCreatedAt)
+	}
+	if m.status != nil {
+		fields = append(fields, parseresult.FieldStatus)
+	}
+	if m.content != nil {
+		fields = append(fields, parseresult.FieldContent)
+	}
+	if m.validation != nil {
+		fields = append(fields, parseresult.FieldValidation)
+	}
+	if m.blocked != nil {
+		fields = append(fields, parseresult.FieldBlocked)
+	}
+	if m.verification != nil {
+		fields = append(fields, parseresult.FieldVerification)
+	}
+	if m.origins != nil {
+		fields = append(fields, parseresult.FieldOrigins)
+	}
+	if m.prescriptions != nil {
+		fields = append(fields, parseresult.FieldPrescriptions)
+	}
+	if m.attributes != nil {
+		fields = append(fields, parseresult.FieldAttributes)
+	}
+	if m.started_at != nil {
+		fields = append(fields, parseresult.FieldStartedAt)
+	}
+	if m.completed_at != nil {
+		fields = append(fields, parseresult.FieldCompletedAt)
+	}
+	if m.review != nil {
+		fields = append(fields, parseresult.FieldReview)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ParseResultMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case parseresult.FieldCreatedAt:
+		return m.CreatedAt()
+	case parseresult.FieldStatus:
+		return m.Status()
+	case parseresult.FieldContent:
+		return m.Content()
+	case parseresult.FieldValidation:
+		return m.Validation()
+	case parseresult.FieldBlocked:
+		return m.Blocked()
+	case parseresult.FieldVerification:
+		return m.Verification()
+	case parseresult.FieldOrigins:
+		return m.Origins()
+	case parseresult.FieldPrescriptions:
+		return m.Prescriptions()
+	case parseresult.FieldAttributes:
+		return m.Attributes()
+	case parseresult.FieldStartedAt:
+		return m.StartedAt()
+	case parseresult.FieldCompletedAt:
+		return m.CompletedAt()
+	case parseresult.FieldReview:
+		return m.Review()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ParseResultMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case parseresult.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case parseresult.FieldStatus:
+		return m.OldStatus(ctx)
+	case parseresult.FieldContent:
+		return m.OldContent(ctx)
+	case parseresult.FieldValidation:
+		return m.OldValidation(ctx)
+	case parseresult.FieldBlocked:
+		return m.OldBlocked(ctx)
+	case parseresult.FieldVerification:
+		return m.OldVerification(ctx)
+	case parseresult.FieldOrigins:
+		return m.OldOrigins(ctx)
+	case parseresult.FieldPrescriptions:
+		return m.OldPrescriptions(ctx)
+	case parseresult.FieldAttributes:
+		return m.OldAttributes(ctx)
+	case parseresult.FieldStartedAt:
+		return m.OldStartedAt(ctx)
+	case parseresult.FieldCompletedAt:
+		return m.OldCompletedAt(ctx)
+	case parseresult.FieldReview:
+		return m.OldReview(ctx)
+	}
+	return nil, fmt.Errorf("unknown ParseResult field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ParseResultMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case parseresult.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case parseresult.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case parseresult.FieldContent:
+		v, ok := value.(models.Prescription)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetContent(v)
+		return nil
+	case parseresult.FieldValidation:
+		v, ok := value.([]models.ValidationIssue)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetValidation(v)
+		return nil
+	case parseresult.FieldBlocked:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBlocked(v)
+		return nil
+	case parseresult.FieldVerification:
+		v, ok := value.([]models.FieldChange)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVerification(v)
+		return nil
+	case parseresult.FieldOrigins:
+		v, ok := value.(map[string]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOrigins(v)
+		return nil
+	case parseresult.FieldPrescriptions:
+		v, ok := value.([]models.DocumentPrescription)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPrescriptions(v)
+		return nil
+	case parseresult.FieldAttributes:
+		v, ok := value.(map[string]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAttributes(v)
+		return nil
+	case parseresult.FieldStartedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStartedAt(v)
+		return nil
+	case parseresult.FieldCompletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCompletedAt(v)
+		return nil
+	case parseresult.FieldReview:
+		v, ok := value.(*models.Review)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetReview(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ParseResult field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ParseResultMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ParseResultMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ParseResultMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown ParseResult numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ParseResultMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(parseresult.FieldValidation) {
+		fields = append(fields, parseresult.FieldValidation)
+	}
+	if m.FieldCleared(parseresult.FieldVerification) {
+		fields = append(fields, parseresult.FieldVerification)
+	}
+	if m.FieldCleared(parseresult.FieldOrigins) {
+		fields = append(fields, parseresult.FieldOrigins)
+	}
+	if m.FieldCleared(parseresult.FieldPrescriptions) {
+		fields = append(fields, parseresult.FieldPrescriptions)
+	}
+	if m.FieldCleared(parseresult.FieldAttributes) {
+		fields = append(fields, parseresult.FieldAttributes)
+	}
+	if m.FieldCleared(parseresult.FieldReview) {
+		fields = append(fields, parseresult.FieldReview)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ParseResultMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ParseResultMutation) ClearField(name string) error {
+	switch name {
+	case parseresult.FieldValidation:
+		m.ClearValidation()
+		return nil
+	case parseresult.FieldVerification:
+		m.ClearVerification()
+		return nil
+	case parseresult.FieldOrigins:
+		m.ClearOrigins()
+		return nil
+	case parseresult.FieldPrescriptions:
+		m.ClearPrescriptions()
+		return nil
+	case parseresult.FieldAttributes:
+		m.ClearAttributes()
+		return nil
+	case parseresult.FieldReview:
+		m.ClearReview()
+		return nil
+	}
+	return fmt.Errorf("unknown ParseResult nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ParseResultMutation) ResetField(name string) error {
+	switch name {
+	case parseresult.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case parseresult.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case parseresult.FieldContent:
+		m.ResetContent()
+		return nil
+	case parseresult.FieldValidation:
+		m.ResetValidation()
+		return nil
+	case parseresult.FieldBlocked:
+		m.ResetBlocked()
+		return nil
+	case parseresult.FieldVerification:
+		m.ResetVerification()
+		return nil
+	case parseresult.FieldOrigins:
+		m.ResetOrigins()
+		return nil
+	case parseresult.FieldPrescriptions:
+		m.ResetPrescriptions()
+		return nil
+	case parseresult.FieldAttributes:
+		m.ResetAttributes()
+		return nil
+	case parseresult.FieldStartedAt:
+		m.ResetStartedAt()
+		return nil
+	case parseresult.FieldCompletedAt:
+		m.ResetCompletedAt()
+		return nil
+	case parseresult.FieldReview:
+		m.ResetReview()
+		return nil
+	}
+	return fmt.Errorf("unknown ParseResult field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ParseResultMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ParseResultMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ParseResultMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ParseResultMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ParseResultMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ParseResultMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ParseResultMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown ParseResult unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ParseResultMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown ParseResult edge %s", name)
+}
+
+// PrescriptionMutation represents an operation that mutates the Prescription nodes in the graph.
+type PrescriptionMutation struct {
+	config
+	op                Op
+	typ               string
+	id                *uuid.UUID
+	created_at        *time.Time
+	file_id           *string
+	mime_type         *string
+	content           *models.Prescription
+	document_hash     *string
+	template          *string
+	tenant            *string
+	drug_class        *string
+	clearedFields     map[string]struct{}
+	embeddings        map[uuid.UUID]struct{}
+	removedembeddings map[uuid.UUID]struct{}
+	clearedembeddings bool
+	done              bool
+	oldValue          func(context.Context) (*Prescription, error)
+	predicates        []predicate.Prescription
+}
+
+var _ ent.Mutation = (*PrescriptionMutation)(nil)
+
+// prescriptionOption allows management of the mutation configuration using functional options.
+type prescriptionOption func(*PrescriptionMutation)
+
+// newPrescriptionMutation creates new mutation for the Prescription entity.
+func newPrescriptionMutation(c config, op Op, opts ...prescriptionOption) *PrescriptionMutation {
+	m := &PrescriptionMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePrescription,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPrescriptionID sets the ID field of the mutation.
+func withPrescriptionID(id uuid.UUID) prescriptionOption {
+	return func(m *PrescriptionMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Prescription
+		)
+		m.oldValue = func(ctx context.Context) (*Prescription, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Prescription.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPrescription sets the old Prescription of the mutation.
+func withPrescription(node *Prescription) prescriptionOption {
+	return func(m *PrescriptionMutation) {
+		m.oldValue = func(context.Context) (*Prescription, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PrescriptionMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PrescriptionMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Prescription entities.
+func (m *PrescriptionMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PrescriptionMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PrescriptionMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Prescription.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *PrescriptionMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *PrescriptionMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Prescription entity.
+// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PrescriptionMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *PrescriptionMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetFileID sets the "file_id" field.
+func (m *PrescriptionMutation) SetFileID(s string) {
+	m.file_id = &s
+}
+
+// FileID returns the value of the "file_id" field in the mutation.
+func (m *PrescriptionMutation) FileID() (r string, exists bool) {
+	v := m.file_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFileID returns the old "file_id" field's value of the Prescription entity.
+// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PrescriptionMutation) OldFileID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFileID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFileID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFileID: %w", err)
+	}
+	return oldValue.FileID, nil
+}
+
+// ResetFileID resets all changes to the "file_id" field.
+func (m *PrescriptionMutation) ResetFileID() {
+	m.file_id = nil
+}
+
+// SetMimeType sets the "mime_type" field.
+func (m *PrescriptionMutation) SetMimeType(s string) {
+	m.mime_type = &s
+}
+
+// MimeType returns the value of the "mime_type" field in the mutation.
+func (m *PrescriptionMutation) MimeType() (r string, exists bool) {
+	v := m.mime_type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMimeType returns the old "mime_type" field's value of the Prescription entity.
+// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PrescriptionMutation) OldMimeType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMimeType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMimeType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMimeType: %w", err)
+	}
+	return oldValue.MimeType, nil
+}
+
+// ResetMimeType resets all changes to the "mime_type" field.
+func (m *PrescriptionMutation) ResetMimeType() {
+	m.mime_type = nil
+}
+
+// SetContent sets the "content" field.
+func (m *PrescriptionMutation) SetContent(value models.Prescription) {
+	m.content = &value
+}
+
+// Content returns the value of the "content" field in the mutation.
+func (m *PrescriptionMutation) Content() (r models.Prescription, exists bool) {
+	v := m.content
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldContent returns the old "content" field's value of the Prescription entity.
+// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PrescriptionMutation) OldContent(ctx context.Context) (v models.Prescription, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldContent is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldContent requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldContent: %w", err)
+	}
+	return oldValue.Content, nil
+}
+
+// ResetContent resets all changes to the "content" field.
+func (m *PrescriptionMutation) ResetContent() {
+	m.content = nil
+}
+
+// SetDocumentHash sets the "document_hash" field.
+func (m *PrescriptionMutation) SetDocumentHash(s string) {
+	m.document_hash = &s
+}
+
+// DocumentHash returns the value of the "document_hash" field in the mutation.
+func (m *PrescriptionMutation) DocumentHash() (r string, exists bool) {
+	v := m.document_hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDocumentHash returns the old "document_hash" field's value of the Prescription entity.
+// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PrescriptionMutation) OldDocumentHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDocumentHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDocumentHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDocumentHash: %w", err)
+	}
+	return oldValue.DocumentHash, nil
+}
+
+// ClearDocumentHash clears the value of the "document_hash" field.
+func (m *PrescriptionMutation) ClearDocumentHash() {
+	m.document_hash = nil
+	m.clearedFields[prescription.FieldDocumentHash] = struct{}{}
+}
+
+// DocumentHashCleared returns if the "document_hash" field was cleared in this mutation.
+func (m *PrescriptionMutation) DocumentHashCleared() bool {
+	_, ok := m.clearedFields[prescription.FieldDocumentHash]
+	return ok
+}
+
+// ResetDocumentHash resets all changes to the "document_hash" field.
+func (m *PrescriptionMutation) ResetDocumentHash() {
+	m.document_hash = nil
+	delete(m.clearedFields, prescription.FieldDocumentHash)
+}
+
+// SetTemplate sets the "template" field.
+func (m *PrescriptionMutation) SetTemplate(s string) {
+	m.template = &s
+}
+
+// Template returns the value of the "template" field in the mutation.
+func (m *PrescriptionMutation) Template() (r string, exists bool) {
+	v := m.template
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTemplate returns the old "template" field's value of the Prescription entity.
+// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PrescriptionMutation) OldTemplate(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTemplate is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTemplate requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTemplate: %w", err)
+	}
+	return oldValue.Template, nil
+}
+
+// ClearTemplate clears the value of the "template" field.
+func (m *PrescriptionMutation) ClearTemplate() {
+	m.template = nil
+	m.clearedFields[prescription.FieldTemplate] = struct{}{}
+}
+
+// TemplateCleared returns if the "template" field was cleared in this mutation.
+func (m *PrescriptionMutation) TemplateCleared() bool {
+	_, ok := m.clearedFields[prescription.FieldTemplate]
+	return ok
+}
+
+// ResetTemplate resets all changes to the "template" field.
+func (m *PrescriptionMutation) ResetTemplate() {
+	m.template = nil
+	delete(m.clearedFields, prescription.FieldTemplate)
+}
+
+// SetTenant sets the "tenant" field.
+func (m *PrescriptionMutation) SetTenant(s string) {
+	m.tenant = &s
+}
+
+// Tenant returns the value of the "tenant" field in the mutation.
+func (m *PrescriptionMutation) Tenant() (r string, exists bool) {
+	v := m.tenant
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTenant returns the old "tenant" field's value of the Prescription entity.
+// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PrescriptionMutation) OldTenant(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTenant is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTenant requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTenant: %w", err)
+	}
+	return oldValue.Tenant, nil
+}
+
+// ClearTenant clears the value of the "tenant" field.
+func (m *PrescriptionMutation) ClearTenant() {
+	m.tenant = nil
+	m.clearedFields[prescription.FieldTenant] = struct{}{}
+}
+
+// TenantCleared returns if the "tenant" field was cleared in this mutation.
+func (m *PrescriptionMutation) TenantCleared() bool {
+	_, ok := m.clearedFields[prescription.FieldTenant]
+	return ok
+}
+
+// ResetTenant resets all changes to the "tenant" field.
+func (m *PrescriptionMutation) ResetTenant() {
+	m.tenant = nil
+	delete(m.clearedFields, prescription.FieldTenant)
+}
+
+// SetDrugClass sets the "drug_class" field.
+func (m *PrescriptionMutation) SetDrugClass(s string) {
+	m.drug_class = &s
+}
+
+// DrugClass returns the value of the "drug_class" field in the mutation.
+func (m *PrescriptionMutation) DrugClass() (r string, exists bool) {
+	v := m.drug_class
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDrugClass returns the old "drug_class" field's value of the Prescription entity.
+// If the Prescription object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PrescriptionMutation) OldDrugClass(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDrugClass is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDrugClass requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDrugClass: %w", err)
+	}
+	return oldValue.DrugClass, nil
+}
+
+// ClearDrugClass clears the value of the "drug_class" field.
+func (m *PrescriptionMutation) ClearDrugClass() {
+	m.drug_class = nil
+	m.clearedFields[prescription.FieldDrugClass] = struct{}{}
+}
+
+// DrugClassCleared returns if the "drug_class" field was cleared in this mutation.
+func (m *PrescriptionMutation) DrugClassCleared() bool {
+	_, ok := m.clearedFields[prescription.FieldDrugClass]
+	return ok
+}
+
+// ResetDrugClass resets all changes to the "drug_class" field.
+func (m *PrescriptionMutation) ResetDrugClass() {
+	m.drug_class = nil
+	delete(m.clearedFields, prescription.FieldDrugClass)
+}
+
+// AddEmbeddingIDs adds the "embeddings" edge to the Embedding entity by ids.
+func (m *PrescriptionMutation) AddEmbeddingIDs(ids ...uuid.UUID) {
+	if m.embeddings == nil {
+		m.embeddings = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.embeddings[ids[i]] = struct{}{}
+	}
+}
+
+// ClearEmbeddings clears the "embeddings" edge to the Embedding entity.
+func (m *PrescriptionMutation) ClearEmbeddings() {
+	m.clearedembeddings = true
+}
+
+// EmbeddingsCleared reports if the "embeddings" edge to the Embedding entity was cleared.
+func (m *PrescriptionMutation) EmbeddingsCleared() bool {
+	return m.clearedembeddings
+}
+
+// RemoveEmbeddingIDs removes the "embeddings" edge to the Embedding entity by IDs.
+func (m *PrescriptionMutation) RemoveEmbeddingIDs(ids ...uuid.UUID) {
+	if m.removedembeddings == nil {
+		m.removedembeddings = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.embeddings, ids[i])
+		m.removedembeddings[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedEmbeddings returns the removed IDs of the "embeddings" edge to the Embedding entity.
+func (m *PrescriptionMutation) RemovedEmbeddingsIDs() (ids []uuid.UUID) {
+	for id := range m.removedembeddings {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// EmbeddingsIDs returns the "embeddings" edge IDs in the mutation.
+func (m *PrescriptionMutation) EmbeddingsIDs() (ids []uuid.UUID) {
+	for id := range m.embeddings {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetEmbeddings resets all changes to the "embeddings" edge.
+func (m *PrescriptionMutation) ResetEmbeddings() {
+	m.embeddings = nil
+	m.clearedembeddings = false
+	m.removedembeddings = nil
+}
+
+// Where appends a list predicates to the PrescriptionMutation builder.
+func (m *PrescriptionMutation) Where(ps ...predicate.Prescription) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PrescriptionMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PrescriptionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Prescription, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PrescriptionMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PrescriptionMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Prescription).
+func (m *PrescriptionMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PrescriptionMutation) Fields() []string {
+	fields := make([]string, 0, 8)
+	if m.created_at != nil {
+		fields = append(fields, prescription.FieldCreatedAt)
+	}
+	if m.file_id != nil {
+		fields = append(fields, prescription.FieldFileID)
+	}
+	if m.mime_type != nil {
+		fields = append(fields, prescription.FieldMimeType)
+	}
+	if m.content != nil {
+		fields = append(fields, prescription.FieldContent)
+	}
+	if m.document_hash != nil {
+		fields = append(fields, prescription.FieldDocumentHash)
+	}
+	if m.template != nil {
+		fields = append(fields, prescription.FieldTemplate)
+	}
+	if m.tenant != nil {
+		fields = append(fields, prescription.FieldTenant)
+	}
+	if m.drug_class != nil {
+		fields = append(fields, prescription.FieldDrugClass)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PrescriptionMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case prescription.FieldCreatedAt:
+		return m.CreatedAt()
+	case prescription.FieldFileID:
+		return m.FileID()
+	case prescription.FieldMimeType:
+		return m.MimeType()
+	case prescription.FieldContent:
+		return m.Content()
+	case prescription.FieldDocumentHash:
+		return m.DocumentHash()
+	case prescription.FieldTemplate:
+		return m.Template()
+	case prescription.FieldTenant:
+		return m.Tenant()
+	case prescription.FieldDrugClass:
+		return m.DrugClass()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PrescriptionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case prescription.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case prescription.FieldFileID:
+		return m.OldFileID(ctx)
+	case prescription.FieldMimeType:
+		return m.OldMimeType(ctx)
+	case prescription.FieldContent:
+		return m.OldContent(ctx)
+	case prescription.FieldDocumentHash:
+		return m.OldDocumentHash(ctx)
+	case prescription.FieldTemplate:
+		return m.OldTemplate(ctx)
+	case prescription.FieldTenant:
+		return m.OldTenant(ctx)
+	case prescription.FieldDrugClass:
+		return m.OldDrugClass(ctx)
+	}
+	return nil, fmt.Errorf("unknown Prescription field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PrescriptionMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case prescription.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case prescription.FieldFileID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFileID(v)
+		return nil
+	case prescription.FieldMimeType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMimeType(v)
+		return nil
+	case prescription.FieldContent:
+		v, ok := value.(models.Prescription)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetContent(v)
+		return nil
+	case prescription.FieldDocumentHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDocumentHash(v)
+		return nil
+	case prescription.FieldTemplate:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTemplate(v)
+		return nil
+	case prescription.FieldTenant:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTenant(v)
+		return nil
+	case prescription.FieldDrugClass:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDrugClass(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Prescription field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PrescriptionMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PrescriptionMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PrescriptionMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Prescription numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PrescriptionMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(prescription.FieldDocumentHash) {
+		fields = append(fields, prescription.FieldDocumentHash)
+	}
+	if m.FieldCleared(prescription.FieldTemplate) {
+		fields = append(fields, prescription.FieldTemplate)
+	}
+	if m.FieldCleared(prescription.FieldTenant) {
+		fields = append(fields, prescription.FieldTenant)
+	}
+	if m.FieldCleared(prescription.FieldDrugClass) {
+		fields = append(fields, prescription.FieldDrugClass)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PrescriptionMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PrescriptionMutation) ClearField(name string) error {
+	switch name {
+	case prescription.FieldDocumentHash:
+		m.ClearDocumentHash()
+		return nil
+	case prescription.FieldTemplate:
+		m.ClearTemplate()
+		return nil
+	case prescription.FieldTenant:
+		m.ClearTenant()
+		return nil
+	case prescription.FieldDrugClass:
+		m.ClearDrugClass()
+		return nil
+	}
+	return fmt.Errorf("unknown Prescription nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PrescriptionMutation) ResetField(name string) error {
+	switch name {
+	case prescription.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case prescription.FieldFileID:
+		m.ResetFileID()
+		return nil
+	case prescription.FieldMimeType:
+		m.ResetMimeType()
+		return nil
+	case prescription.FieldContent:
+		m.ResetContent()
+		return nil
+	case prescription.FieldDocumentHash:
+		m.ResetDocumentHash()
+		return nil
+	case prescription.FieldTemplate:
+		m.ResetTemplate()
+		return nil
+	case prescription.FieldTenant:
+		m.ResetTenant()
+		return nil
+	case prescription.FieldDrugClass:
+		m.ResetDrugClass()
+		return nil
+	}
+	return fmt.Errorf("unknown Prescription field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PrescriptionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.embeddings != nil {
+		edges = append(edges, prescription.EdgeEmbeddings)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PrescriptionMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case prescription.EdgeEmbeddings:
+		ids := make([]ent.Value, 0, len(m.embeddings))
+		for id := range m.embeddings {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PrescriptionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.removedembeddings != nil {
+		edges = append(edges, prescription.EdgeEmbeddings)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PrescriptionMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case prescription.EdgeEmbeddings:
+		ids := make([]ent.Value, 0, len(m.removedembeddings))
+		for id := range m.removedembeddings {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PrescriptionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedembeddings {
+		edges = append(edges, prescription.EdgeEmbeddings)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PrescriptionMutation) EdgeCleared(name string) bool {
+	switch name {
+	case prescription.EdgeEmbeddings:
+		return m.clearedembeddings
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PrescriptionMutation) ClearEdge(name string) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Prescription unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PrescriptionMutation) ResetEdge(name string) error {
+	switch name {
+	case prescription.EdgeEmbeddings:
+		m.ResetEmbeddings()
+		return nil
+	}
+	return fmt.Errorf("unknown Prescription edge %s", name)
+}
+
+// PromptOverlayMutation represents an operation that mutates the PromptOverlay nodes in the graph.
+type PromptOverlayMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *uuid.UUID
+	created_at    *time.Time
+	template      *string
+	version       *int
+	addversion    *int
+	instructions  *string
+	author        *string
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*PromptOverlay, error)
+	predicates    []predicate.PromptOverlay
+}
+
+var _ ent.Mutation = (*PromptOverlayMutation)(nil)
+
+// promptoverlayOption allows management of the mutation configuration using functional options.
+type promptoverlayOption func(*PromptOverlayMutation)
+
+// newPromptOverlayMutation creates new mutation for the PromptOverlay entity.
+func newPromptOverlayMutation(c config, op Op, opts ...promptoverlayOption) *PromptOverlayMutation {
+	m := &PromptOverlayMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePromptOverlay,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPromptOverlayID sets the ID field of the mutation.
+func withPromptOverlayID(id uuid.UUID) promptoverlayOption {
+	return func(m *PromptOverlayMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *PromptOverlay
+		)
+		m.oldValue = func(ctx context.Context) (*PromptOverlay, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().PromptOverlay.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPromptOverlay sets the old PromptOverlay of the mutation.
+func withPromptOverlay(node *PromptOverlay) promptoverlayOption {
+	return func(m *PromptOverlayMutation) {
+		m.oldValue = func(context.Context) (*PromptOverlay, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PromptOverlayMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PromptOverlayMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of PromptOverlay entities.
+func (m *PromptOverlayMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PromptOverlayMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PromptOverlayMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().PromptOverlay.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *PromptOverlayMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *PromptOverlayMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the PromptOverlay entity.
+// If the PromptOverlay object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromptOverlayMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *PromptOverlayMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetTemplate sets the "template" field.
+func (m *PromptOverlayMutation) SetTemplate(s string) {
+	m.template = &s
+}
+
+// Template returns the value of the "template" field in the mutation.
+func (m *PromptOverlayMutation) Template() (r string, exists bool) {
+	v := m.template
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTemplate returns the old "template" field's value of the PromptOverlay entity.
+// If the PromptOverlay object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromptOverlayMutation) OldTemplate(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTemplate is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTemplate requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTemplate: %w", err)
+	}
+	return oldValue.Template, nil
+}
+
+// ResetTemplate resets all changes to the "template" field.
+func (m *PromptOverlayMutation) ResetTemplate() {
+	m.template = nil
+}
+
+// SetVersion sets the "version" field.
+func (m *PromptOverlayMutation) SetVersion(i int) {
+	m.version = &i
+	m.addversion = nil
+}
+
+// Version returns the value of the "version" field in the mutation.
+func (m *PromptOverlayMutation) Version() (r int, exists bool) {
+	v := m.version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVersion returns the old "version" field's value of the PromptOverlay entity.
+// If the PromptOverlay object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromptOverlayMutation) OldVersion(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVersion: %w", err)
+	}
+	return oldValue.Version, nil
+}
+
+// AddVersion adds i to the "version" field.
+func (m *PromptOverlayMutation) AddVersion(i int) {
+	if m.addversion != nil {
+		*m.addversion += i
+	} else {
+		m.addversion = &i
+	}
+}
+
+// AddedVersion returns the value that was added to the "version" field in this mutation.
+func (m *PromptOverlayMutation) AddedVersion() (r int, exists bool) {
+	v := m.addversion
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetVersion resets all changes to the "version" field.
+func (m *PromptOverlayMutation) ResetVersion() {
+	m.version = nil
+	m.addversion = nil
+}
+
+// SetInstructions sets the "instructions" field.
+func (m *PromptOverlayMutation) SetInstructions(s string) {
+	m.instructions = &s
+}
+
+// Instructions returns the value of the "instructions" field in the mutation.
+func (m *PromptOverlayMutation) Instructions() (r string, exists bool) {
+	v := m.instructions
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldInstructions returns the old "instructions" field's value of the PromptOverlay entity.
+// If the PromptOverlay object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromptOverlayMutation) OldInstructions(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldInstructions is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldInstructions requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldInstructions: %w", err)
+	}
+	return oldValue.Instructions, nil
+}
+
+// ResetInstructions resets all changes to the "instructions" field.
+func (m *PromptOverlayMutation) ResetInstructions() {
+	m.instructions = nil
+}
+
+// SetAuthor sets the "author" field.
+func (m *PromptOverlayMutation) SetAuthor(s string) {
+	m.author = &s
+}
+
+// Author returns the value of the "author" field in the mutation.
+func (m *PromptOverlayMutation) Author() (r string, exists bool) {
+	v := m.author
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAuthor returns the old "author" field's value of the PromptOverlay entity.
+// If the PromptOverlay object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PromptOverlayMutation) OldAuthor(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAuthor is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAuthor requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAuthor: %w", err)
+	}
+	return oldValue.Author, nil
+}
+
+// ClearAuthor clears the value of the "author" field.
+func (m *PromptOverlayMutation) ClearAuthor() {
+	m.author = nil
+	m.clearedFields[promptoverlay.FieldAuthor] = struct{}{}
+}
+
+// AuthorCleared returns if the "author" field was cleared in this mutation.
+func (m *PromptOverlayMutation) AuthorCleared() bool {
+	_, ok := m.clearedFields[promptoverlay.FieldAuthor]
+	return ok
+}
+
+// ResetAuthor resets all changes to the "author" field.
+func (m *PromptOverlayMutation) ResetAuthor() {
+	m.author = nil
+	delete(m.clearedFields, promptoverlay.FieldAuthor)
+}
+
+// Where appends a list predicates to the PromptOverlayMutation builder.
+func (m *PromptOverlayMutation) Where(ps ...predicate.PromptOverlay) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PromptOverlayMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PromptOverlayMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.PromptOverlay, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PromptOverlayMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PromptOverlayMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (PromptOverlay).
+func (m *PromptOverlayMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PromptOverlayMutation) Fields() []string {
+	fields := make([]string, 0, 5)
 	if m.created_at != nil {
-		fields = append(fields, prescription.FieldCreatedAt)
+		fields = append(fields, promptoverlay.FieldCreatedAt)
 	}
-	if m.file_id != nil {
-		fields = append(fields, prescription.FieldFileID)
+	if m.template != nil {
+		fields = append(fields, promptoverlay.FieldTemplate)
 	}
-	if m.mime_type != nil {
-		fields = append(fields, prescription.FieldMimeType)
+	if m.version != nil {
+		fields = append(fields, promptoverlay.FieldVersion)
 	}
-	if m.content != nil {
-		fields = append(fields, prescription.FieldContent)
+	if m.instructions != nil {
+		fields = append(fields, promptoverlay.FieldInstructions)
+	}
+	if m.author != nil {
+		fields = append(fields, promptoverlay.FieldAuthor)
 	}
 	return fields
 }
@@ -748,16 +3054,18 @@ func (m *PrescriptionMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *PrescriptionMutation) Field(name string) (ent.Value, bool) {
+func (m *PromptOverlayMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case prescription.FieldCreatedAt:
+	case promptoverlay.FieldCreatedAt:
 		return m.CreatedAt()
-	case prescription.FieldFileID:
-		return m.FileID()
-	case prescription.FieldMimeType:
-		return m.MimeType()
-	case prescription.FieldContent:
-		return m.Content()
+	case promptoverlay.FieldTemplate:
+		return m.Template()
+	case promptoverlay.FieldVersion:
+		return m.Version()
+	case promptoverlay.FieldInstructions:
+		return m.Instructions()
+	case promptoverlay.FieldAuthor:
+		return m.Author()
 	}
 	return nil, false
 }
@@ -765,162 +3073,198 @@ func (m *PrescriptionMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *PrescriptionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *PromptOverlayMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case prescription.FieldCreatedAt:
+	case promptoverlay.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
-	case prescription.FieldFileID:
-		return m.OldFileID(ctx)
-	case prescription.FieldMimeType:
-		return m.OldMimeType(ctx)
-	case prescription.FieldContent:
-		return m.OldContent(ctx)
+	case promptoverlay.FieldTemplate:
+		return m.OldTemplate(ctx)
+	case promptoverlay.FieldVersion:
+		return m.OldVersion(ctx)
+	case promptoverlay.FieldInstructions:
+		return m.OldInstructions(ctx)
+	case promptoverlay.FieldAuthor:
+		return m.OldAuthor(ctx)
 	}
-	return nil, fmt.Errorf("unknown Prescription field %s", name)
+	return nil, fmt.Errorf("unknown PromptOverlay field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PrescriptionMutation) SetField(name string, value ent.Value) error {
+func (m *PromptOverlayMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case prescription.FieldCreatedAt:
+	case promptoverlay.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCreatedAt(v)
 		return nil
-	case prescription.FieldFileID:
+	case promptoverlay.FieldTemplate:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetFileID(v)
+		m.SetTemplate(v)
 		return nil
-	case prescription.FieldMimeType:
+	case promptoverlay.FieldVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVersion(v)
+		return nil
+	case promptoverlay.FieldInstructions:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetMimeType(v)
+		m.SetInstructions(v)
 		return nil
-	case prescription.FieldContent:
-		v, ok := value.(models.Prescription)
+	case promptoverlay.FieldAuthor:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetContent(v)
+		m.SetAuthor(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Prescription field %s", name)
+	return fmt.Errorf("unknown PromptOverlay field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *PrescriptionMutation) AddedFields() []string {
-	return nil
+func (m *PromptOverlayMutation) AddedFields() []string {
+	var fields []string
+	if m.addversion != nil {
+		fields = append(fields, promptoverlay.FieldVersion)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *PrescriptionMutation) AddedField(name string) (ent.Value, bool) {
+func (m *PromptOverlayMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case promptoverlay.FieldVersion:
+		return m.AddedVersion()
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PrescriptionMutation) AddField(name string, value ent.Value) error {
+func (m *PromptOverlayMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case promptoverlay.FieldVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddVersion(v)
+		return nil
 	}
-	return fmt.Errorf("unknown Prescription numeric field %s", name)
+	return fmt.Errorf("unknown PromptOverlay numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *PrescriptionMutation) ClearedFields() []string {
-	return nil
+func (m *PromptOverlayMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(promptoverlay.FieldAuthor) {
+		fields = append(fields, promptoverlay.FieldAuthor)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *PrescriptionMutation) FieldCleared(name string) bool {
+func (m *PromptOverlayMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *PrescriptionMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Prescription nullable field %s", name)
+func (m *PromptOverlayMutation) ClearField(name string) error {
+	switch name {
+	case promptoverlay.FieldAuthor:
+		m.ClearAuthor()
+		return nil
+	}
+	return fmt.Errorf("unknown PromptOverlay nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *PrescriptionMutation) ResetField(name string) error {
+func (m *PromptOverlayMutation) ResetField(name string) error {
 	switch name {
-	case prescription.FieldCreatedAt:
+	case promptoverlay.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
-	case prescription.FieldFileID:
-		m.ResetFileID()
+	case promptoverlay.FieldTemplate:
+		m.ResetTemplate()
 		return nil
-	case prescription.FieldMimeType:
-		m.ResetMimeType()
+	case promptoverlay.FieldVersion:
+		m.ResetVersion()
 		return nil
-	case prescription.FieldContent:
-		m.ResetContent()
+	case promptoverlay.FieldInstructions:
+		m.ResetInstructions()
+		return nil
+	case promptoverlay.FieldAuthor:
+		m.ResetAuthor()
 		return nil
 	}
-	return fmt.Errorf("unknown Prescription field %s", name)
+	return fmt.Errorf("unknown PromptOverlay field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *PrescriptionMutation) AddedEdges() []string {
+func (m *PromptOverlayMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *PrescriptionMutation) AddedIDs(name string) []ent.Value {
+func (m *PromptOverlayMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *PrescriptionMutation) RemovedEdges() []string {
+func (m *PromptOverlayMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *PrescriptionMutation) RemovedIDs(name string) []ent.Value {
+func (m *PromptOverlayMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *PrescriptionMutation) ClearedEdges() []string {
+func (m *PromptOverlayMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *PrescriptionMutation) EdgeCleared(name string) bool {
+func (m *PromptOverlayMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *PrescriptionMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown Prescription unique edge %s", name)
+func (m *PromptOverlayMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown PromptOverlay unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *PrescriptionMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown Prescription edge %s", name)
+func (m *PromptOverlayMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown PromptOverlay edge %s", name)
 }
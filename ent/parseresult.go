@@ -0,0 +1,248 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/csotherden/prescription-parser/ent/parseresult"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/google/uuid"
+)
+
+// ParseResult is the model entity for the ParseResult schema.
+type ParseResult struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Status holds the value of the "status" field.
+	Status string `json:"status,omitempty"`
+	// Content holds the value of the "content" field.
+	Content models.Prescription `json:"content,omitempty"`
+	// Validation holds the value of the "validation" field.
+	Validation []models.ValidationIssue `json:"validation,omitempty"`
+	// Blocked holds the value of the "blocked" field.
+	Blocked bool `json:"blocked,omitempty"`
+	// Verification holds the value of the "verification" field.
+	Verification []models.FieldChange `json:"verification,omitempty"`
+	// Origins holds the value of the "origins" field.
+	Origins map[string]string `json:"origins,omitempty"`
+	// Prescriptions holds the value of the "prescriptions" field.
+	Prescriptions []models.DocumentPrescription `json:"prescriptions,omitempty"`
+	// Attributes holds the value of the "attributes" field.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// StartedAt holds the value of the "started_at" field.
+	StartedAt time.Time `json:"started_at,omitempty"`
+	// CompletedAt holds the value of the "completed_at" field.
+	CompletedAt time.Time `json:"completed_at,omitempty"`
+	// Review holds the value of the "review" field.
+	Review       *models.Review `json:"review,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ParseResult) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case parseresult.FieldContent, parseresult.FieldValidation, parseresult.FieldVerification, parseresult.FieldOrigins, parseresult.FieldPrescriptions, parseresult.FieldAttributes, parseresult.FieldReview:
+			values[i] = new([]byte)
+		case parseresult.FieldBlocked:
+			values[i] = new(sql.NullBool)
+		case parseresult.FieldStatus:
+			values[i] = new(sql.NullString)
+		case parseresult.FieldCreatedAt, parseresult.FieldStartedAt, parseresult.FieldCompletedAt:
+			values[i] = new(sql.NullTime)
+		case parseresult.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ParseResult fields.
+func (pr *ParseResult) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case parseresult.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				pr.ID = *value
+			}
+		case parseresult.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				pr.CreatedAt = value.Time
+			}
+		case parseresult.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				pr.Status = value.String
+			}
+		case parseresult.FieldContent:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field content", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pr.Content); err != nil {
+					return fmt.Errorf("unmarshal field content: %w", err)
+				}
+			}
+		case parseresult.FieldValidation:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field validation", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pr.Validation); err != nil {
+					return fmt.Errorf("unmarshal field validation: %w", err)
+				}
+			}
+		case parseresult.FieldBlocked:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field blocked", values[i])
+			} else if value.Valid {
+				pr.Blocked = value.Bool
+			}
+		case parseresult.FieldVerification:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field verification", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pr.Verification); err != nil {
+					return fmt.Errorf("unmarshal field verification: %w", err)
+				}
+			}
+		case parseresult.FieldOrigins:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field origins", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pr.Origins); err != nil {
+					return fmt.Errorf("unmarshal field origins: %w", err)
+				}
+			}
+		case parseresult.FieldPrescriptions:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field prescriptions", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pr.Prescriptions); err != nil {
+					return fmt.Errorf("unmarshal field prescriptions: %w", err)
+				}
+			}
+		case parseresult.FieldAttributes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field attributes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pr.Attributes); err != nil {
+					return fmt.Errorf("unmarshal field attributes: %w", err)
+				}
+			}
+		case parseresult.FieldStartedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field started_at", values[i])
+			} else if value.Valid {
+				pr.StartedAt = value.Time
+			}
+		case parseresult.FieldCompletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field completed_at", values[i])
+			} else if value.Valid {
+				pr.CompletedAt = value.Time
+			}
+		case parseresult.FieldReview:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field review", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pr.Review); err != nil {
+					return fmt.Errorf("unmarshal field review: %w", err)
+				}
+			}
+		default:
+			pr.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ParseResult.
+// This includes values selected through modifiers, order, etc.
+func (pr *ParseResult) Value(name string) (ent.Value, error) {
+	return pr.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this ParseResult.
+// Note that you need to call ParseResult.Unwrap() before calling this method if this ParseResult
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pr *ParseResult) Update() *ParseResultUpdateOne {
+	return NewParseResultClient(pr.config).UpdateOne(pr)
+}
+
+// Unwrap unwraps the ParseResult entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pr *ParseResult) Unwrap() *ParseResult {
+	_tx, ok := pr.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ParseResult is not a transactional entity")
+	}
+	pr.config.driver = _tx.drv
+	return pr
+}
+
+// String implements the fmt.Stringer.
+func (pr *ParseResult) String() string {
+	var builder strings.Builder
+	builder.WriteString("ParseResult(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pr.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(pr.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(pr.Status)
+	builder.WriteString(", ")
+	builder.WriteString("content=")
+	builder.WriteString(fmt.Sprintf("%v", pr.Content))
+	builder.WriteString(", ")
+	builder.WriteString("validation=")
+	builder.WriteString(fmt.Sprintf("%v", pr.Validation))
+	builder.WriteString(", ")
+	builder.WriteString("blocked=")
+	builder.WriteString(fmt.Sprintf("%v", pr.Blocked))
+	builder.WriteString(", ")
+	builder.WriteString("verification=")
+	builder.WriteString(fmt.Sprintf("%v", pr.Verification))
+	builder.WriteString(", ")
+	builder.WriteString("origins=")
+	builder.WriteString(fmt.Sprintf("%v", pr.Origins))
+	builder.WriteString(", ")
+	builder.WriteString("prescriptions=")
+	builder.WriteString(fmt.Sprintf("%v", pr.Prescriptions))
+	builder.WriteString(", ")
+	builder.WriteString("attributes=")
+	builder.WriteString(fmt.Sprintf("%v", pr.Attributes))
+	builder.WriteString(", ")
+	builder.WriteString("started_at=")
+	builder.WriteString(pr.StartedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("completed_at=")
+	builder.WriteString(pr.CompletedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("review=")
+	builder.WriteString(fmt.Sprintf("%v", pr.Review))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ParseResults is a parsable slice of ParseResult.
+type ParseResults []*ParseResult
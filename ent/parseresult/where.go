@@ -0,0 +1,351 @@
+// Code generated by ent, DO NOT EDIT.
+
+package parseresult
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldLTE(FieldID, id))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// Status applies equality check predicate on the "status" field. It's identical to StatusEQ.
+func Status(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldStatus, v))
+}
+
+// Blocked applies equality check predicate on the "blocked" field. It's identical to BlockedEQ.
+func Blocked(v bool) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldBlocked, v))
+}
+
+// StartedAt applies equality check predicate on the "started_at" field. It's identical to StartedAtEQ.
+func StartedAt(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldStartedAt, v))
+}
+
+// CompletedAt applies equality check predicate on the "completed_at" field. It's identical to CompletedAtEQ.
+func CompletedAt(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldCompletedAt, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// StatusGT applies the GT predicate on the "status" field.
+func StatusGT(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldGT(FieldStatus, v))
+}
+
+// StatusGTE applies the GTE predicate on the "status" field.
+func StatusGTE(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldGTE(FieldStatus, v))
+}
+
+// StatusLT applies the LT predicate on the "status" field.
+func StatusLT(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldLT(FieldStatus, v))
+}
+
+// StatusLTE applies the LTE predicate on the "status" field.
+func StatusLTE(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldLTE(FieldStatus, v))
+}
+
+// StatusContains applies the Contains predicate on the "status" field.
+func StatusContains(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldContains(FieldStatus, v))
+}
+
+// StatusHasPrefix applies the HasPrefix predicate on the "status" field.
+func StatusHasPrefix(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldHasPrefix(FieldStatus, v))
+}
+
+// StatusHasSuffix applies the HasSuffix predicate on the "status" field.
+func StatusHasSuffix(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldHasSuffix(FieldStatus, v))
+}
+
+// StatusEqualFold applies the EqualFold predicate on the "status" field.
+func StatusEqualFold(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEqualFold(FieldStatus, v))
+}
+
+// StatusContainsFold applies the ContainsFold predicate on the "status" field.
+func StatusContainsFold(v string) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldContainsFold(FieldStatus, v))
+}
+
+// ValidationIsNil applies the IsNil predicate on the "validation" field.
+func ValidationIsNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIsNull(FieldValidation))
+}
+
+// ValidationNotNil applies the NotNil predicate on the "validation" field.
+func ValidationNotNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotNull(FieldValidation))
+}
+
+// BlockedEQ applies the EQ predicate on the "blocked" field.
+func BlockedEQ(v bool) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldBlocked, v))
+}
+
+// BlockedNEQ applies the NEQ predicate on the "blocked" field.
+func BlockedNEQ(v bool) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNEQ(FieldBlocked, v))
+}
+
+// VerificationIsNil applies the IsNil predicate on the "verification" field.
+func VerificationIsNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIsNull(FieldVerification))
+}
+
+// VerificationNotNil applies the NotNil predicate on the "verification" field.
+func VerificationNotNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotNull(FieldVerification))
+}
+
+// OriginsIsNil applies the IsNil predicate on the "origins" field.
+func OriginsIsNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIsNull(FieldOrigins))
+}
+
+// OriginsNotNil applies the NotNil predicate on the "origins" field.
+func OriginsNotNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotNull(FieldOrigins))
+}
+
+// PrescriptionsIsNil applies the IsNil predicate on the "prescriptions" field.
+func PrescriptionsIsNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIsNull(FieldPrescriptions))
+}
+
+// PrescriptionsNotNil applies the NotNil predicate on the "prescriptions" field.
+func PrescriptionsNotNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotNull(FieldPrescriptions))
+}
+
+// AttributesIsNil applies the IsNil predicate on the "attributes" field.
+func AttributesIsNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIsNull(FieldAttributes))
+}
+
+// AttributesNotNil applies the NotNil predicate on the "attributes" field.
+func AttributesNotNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotNull(FieldAttributes))
+}
+
+// StartedAtEQ applies the EQ predicate on the "started_at" field.
+func StartedAtEQ(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldStartedAt, v))
+}
+
+// StartedAtNEQ applies the NEQ predicate on the "started_at" field.
+func StartedAtNEQ(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNEQ(FieldStartedAt, v))
+}
+
+// StartedAtIn applies the In predicate on the "started_at" field.
+func StartedAtIn(vs ...time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIn(FieldStartedAt, vs...))
+}
+
+// StartedAtNotIn applies the NotIn predicate on the "started_at" field.
+func StartedAtNotIn(vs ...time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotIn(FieldStartedAt, vs...))
+}
+
+// StartedAtGT applies the GT predicate on the "started_at" field.
+func StartedAtGT(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldGT(FieldStartedAt, v))
+}
+
+// StartedAtGTE applies the GTE predicate on the "started_at" field.
+func StartedAtGTE(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldGTE(FieldStartedAt, v))
+}
+
+// StartedAtLT applies the LT predicate on the "started_at" field.
+func StartedAtLT(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldLT(FieldStartedAt, v))
+}
+
+// StartedAtLTE applies the LTE predicate on the "started_at" field.
+func StartedAtLTE(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldLTE(FieldStartedAt, v))
+}
+
+// CompletedAtEQ applies the EQ predicate on the "completed_at" field.
+func CompletedAtEQ(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldEQ(FieldCompletedAt, v))
+}
+
+// CompletedAtNEQ applies the NEQ predicate on the "completed_at" field.
+func CompletedAtNEQ(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNEQ(FieldCompletedAt, v))
+}
+
+// CompletedAtIn applies the In predicate on the "completed_at" field.
+func CompletedAtIn(vs ...time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIn(FieldCompletedAt, vs...))
+}
+
+// CompletedAtNotIn applies the NotIn predicate on the "completed_at" field.
+func CompletedAtNotIn(vs ...time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotIn(FieldCompletedAt, vs...))
+}
+
+// CompletedAtGT applies the GT predicate on the "completed_at" field.
+func CompletedAtGT(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldGT(FieldCompletedAt, v))
+}
+
+// CompletedAtGTE applies the GTE predicate on the "completed_at" field.
+func CompletedAtGTE(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldGTE(FieldCompletedAt, v))
+}
+
+// CompletedAtLT applies the LT predicate on the "completed_at" field.
+func CompletedAtLT(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldLT(FieldCompletedAt, v))
+}
+
+// CompletedAtLTE applies the LTE predicate on the "completed_at" field.
+func CompletedAtLTE(v time.Time) predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldLTE(FieldCompletedAt, v))
+}
+
+// ReviewIsNil applies the IsNil predicate on the "review" field.
+func ReviewIsNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldIsNull(FieldReview))
+}
+
+// ReviewNotNil applies the NotNil predicate on the "review" field.
+func ReviewNotNil() predicate.ParseResult {
+	return predicate.ParseResult(sql.FieldNotNull(FieldReview))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ParseResult) predicate.ParseResult {
+	return predicate.ParseResult(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ParseResult) predicate.ParseResult {
+	return predicate.ParseResult(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ParseResult) predicate.ParseResult {
+	return predicate.ParseResult(sql.NotPredicates(p))
+}
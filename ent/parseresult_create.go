@@ -0,0 +1,352 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/parseresult"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/google/uuid"
+)
+
+// ParseResultCreate is the builder for creating a ParseResult entity.
+type ParseResultCreate struct {
+	config
+	mutation *ParseResultMutation
+	hooks    []Hook
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (prc *ParseResultCreate) SetCreatedAt(t time.Time) *ParseResultCreate {
+	prc.mutation.SetCreatedAt(t)
+	return prc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (prc *ParseResultCreate) SetNillableCreatedAt(t *time.Time) *ParseResultCreate {
+	if t != nil {
+		prc.SetCreatedAt(*t)
+	}
+	return prc
+}
+
+// SetStatus sets the "status" field.
+func (prc *ParseResultCreate) SetStatus(s string) *ParseResultCreate {
+	prc.mutation.SetStatus(s)
+	return prc
+}
+
+// SetContent sets the "content" field.
+func (prc *ParseResultCreate) SetContent(m models.Prescription) *ParseResultCreate {
+	prc.mutation.SetContent(m)
+	return prc
+}
+
+// SetValidation sets the "validation" field.
+func (prc *ParseResultCreate) SetValidation(mi []models.ValidationIssue) *ParseResultCreate {
+	prc.mutation.SetValidation(mi)
+	return prc
+}
+
+// SetBlocked sets the "blocked" field.
+func (prc *ParseResultCreate) SetBlocked(b bool) *ParseResultCreate {
+	prc.mutation.SetBlocked(b)
+	return prc
+}
+
+// SetNillableBlocked sets the "blocked" field if the given value is not nil.
+func (prc *ParseResultCreate) SetNillableBlocked(b *bool) *ParseResultCreate {
+	if b != nil {
+		prc.SetBlocked(*b)
+	}
+	return prc
+}
+
+// SetVerification sets the "verification" field.
+func (prc *ParseResultCreate) SetVerification(mc []models.FieldChange) *ParseResultCreate {
+	prc.mutation.SetVerification(mc)
+	return prc
+}
+
+// SetOrigins sets the "origins" field.
+func (prc *ParseResultCreate) SetOrigins(m map[string]string) *ParseResultCreate {
+	prc.mutation.SetOrigins(m)
+	return prc
+}
+
+// SetPrescriptions sets the "prescriptions" field.
+func (prc *ParseResultCreate) SetPrescriptions(mp []models.DocumentPrescription) *ParseResultCreate {
+	prc.mutation.SetPrescriptions(mp)
+	return prc
+}
+
+// SetAttributes sets the "attributes" field.
+func (prc *ParseResultCreate) SetAttributes(m map[string]string) *ParseResultCreate {
+	prc.mutation.SetAttributes(m)
+	return prc
+}
+
+// SetStartedAt sets the "started_at" field.
+func (prc *ParseResultCreate) SetStartedAt(t time.Time) *ParseResultCreate {
+	prc.mutation.SetStartedAt(t)
+	return prc
+}
+
+// SetCompletedAt sets the "completed_at" field.
+func (prc *ParseResultCreate) SetCompletedAt(t time.Time) *ParseResultCreate {
+	prc.mutation.SetCompletedAt(t)
+	return prc
+}
+
+// SetReview sets the "review" field.
+func (prc *ParseResultCreate) SetReview(m *models.Review) *ParseResultCreate {
+	prc.mutation.SetReview(m)
+	return prc
+}
+
+// SetID sets the "id" field.
+func (prc *ParseResultCreate) SetID(u uuid.UUID) *ParseResultCreate {
+	prc.mutation.SetID(u)
+	return prc
+}
+
+// Mutation returns the ParseResultMutation object of the builder.
+func (prc *ParseResultCreate) Mutation() *ParseResultMutation {
+	return prc.mutation
+}
+
+// Save creates the ParseResult in the database.
+func (prc *ParseResultCreate) Save(ctx context.Context) (*ParseResult, error) {
+	prc.defaults()
+	return withHooks(ctx, prc.sqlSave, prc.mutation, prc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (prc *ParseResultCreate) SaveX(ctx context.Context) *ParseResult {
+	v, err := prc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (prc *ParseResultCreate) Exec(ctx context.Context) error {
+	_, err := prc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (prc *ParseResultCreate) ExecX(ctx context.Context) {
+	if err := prc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (prc *ParseResultCreate) defaults() {
+	if _, ok := prc.mutation.CreatedAt(); !ok {
+		v := parseresult.DefaultCreatedAt()
+		prc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := prc.mutation.Blocked(); !ok {
+		v := parseresult.DefaultBlocked
+		prc.mutation.SetBlocked(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (prc *ParseResultCreate) check() error {
+	if _, ok := prc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ParseResult.created_at"`)}
+	}
+	if _, ok := prc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "ParseResult.status"`)}
+	}
+	if _, ok := prc.mutation.Content(); !ok {
+		return &ValidationError{Name: "content", err: errors.New(`ent: missing required field "ParseResult.content"`)}
+	}
+	if _, ok := prc.mutation.Blocked(); !ok {
+		return &ValidationError{Name: "blocked", err: errors.New(`ent: missing required field "ParseResult.blocked"`)}
+	}
+	if _, ok := prc.mutation.StartedAt(); !ok {
+		return &ValidationError{Name: "started_at", err: errors.New(`ent: missing required field "ParseResult.started_at"`)}
+	}
+	if _, ok := prc.mutation.CompletedAt(); !ok {
+		return &ValidationError{Name: "completed_at", err: errors.New(`ent: missing required field "ParseResult.completed_at"`)}
+	}
+	return nil
+}
+
+func (prc *ParseResultCreate) sqlSave(ctx context.Context) (*ParseResult, error) {
+	if err := prc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := prc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, prc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	prc.mutation.id = &_node.ID
+	prc.mutation.done = true
+	return _node, nil
+}
+
+func (prc *ParseResultCreate) createSpec() (*ParseResult, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ParseResult{config: prc.config}
+		_spec = sqlgraph.NewCreateSpec(parseresult.Table, sqlgraph.NewFieldSpec(parseresult.FieldID, field.TypeUUID))
+	)
+	if id, ok := prc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := prc.mutation.CreatedAt(); ok {
+		_spec.SetField(parseresult.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := prc.mutation.Status(); ok {
+		_spec.SetField(parseresult.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
+	if value, ok := prc.mutation.Content(); ok {
+		_spec.SetField(parseresult.FieldContent, field.TypeJSON, value)
+		_node.Content = value
+	}
+	if value, ok := prc.mutation.Validation(); ok {
+		_spec.SetField(parseresult.FieldValidation, field.TypeJSON, value)
+		_node.Validation = value
+	}
+	if value, ok := prc.mutation.Blocked(); ok {
+		_spec.SetField(parseresult.FieldBlocked, field.TypeBool, value)
+		_node.Blocked = value
+	}
+	if value, ok := prc.mutation.Verification(); ok {
+		_spec.SetField(parseresult.FieldVerification, field.TypeJSON, value)
+		_node.Verification = value
+	}
+	if value, ok := prc.mutation.Origins(); ok {
+		_spec.SetField(parseresult.FieldOrigins, field.TypeJSON, value)
+		_node.Origins = value
+	}
+	if value, ok := prc.mutation.Prescriptions(); ok {
+		_spec.SetField(parseresult.FieldPrescriptions, field.TypeJSON, value)
+		_node.Prescriptions = value
+	}
+	if value, ok := prc.mutation.Attributes(); ok {
+		_spec.SetField(parseresult.FieldAttributes, field.TypeJSON, value)
+		_node.Attributes = value
+	}
+	if value, ok := prc.mutation.StartedAt(); ok {
+		_spec.SetField(parseresult.FieldStartedAt, field.TypeTime, value)
+		_node.StartedAt = value
+	}
+	if value, ok := prc.mutation.CompletedAt(); ok {
+		_spec.SetField(parseresult.FieldCompletedAt, field.TypeTime, value)
+		_node.CompletedAt = value
+	}
+	if value, ok := prc.mutation.Review(); ok {
+		_spec.SetField(parseresult.FieldReview, field.TypeJSON, value)
+		_node.Review = value
+	}
+	return _node, _spec
+}
+
+// ParseResultCreateBulk is the builder for creating many ParseResult entities in bulk.
+type ParseResultCreateBulk struct {
+	config
+	err      error
+	builders []*ParseResultCreate
+}
+
+// Save creates the ParseResult entities in the database.
+func (prcb *ParseResultCreateBulk) Save(ctx context.Context) ([]*ParseResult, error) {
+	if prcb.err != nil {
+		return nil, prcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(prcb.builders))
+	nodes := make([]*ParseResult, len(prcb.builders))
+	mutators := make([]Mutator, len(prcb.builders))
+	for i := range prcb.builders {
+		func(i int, root context.Context) {
+			builder := prcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ParseResultMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, prcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, prcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, prcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (prcb *ParseResultCreateBulk) SaveX(ctx context.Context) []*ParseResult {
+	v, err := prcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (prcb *ParseResultCreateBulk) Exec(ctx context.Context) error {
+	_, err := prcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (prcb *ParseResultCreateBulk) ExecX(ctx context.Context) {
+	if err := prcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
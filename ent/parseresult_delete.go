@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/parseresult"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+)
+
+// ParseResultDelete is the builder for deleting a ParseResult entity.
+type ParseResultDelete struct {
+	config
+	hooks    []Hook
+	mutation *ParseResultMutation
+}
+
+// Where appends a list predicates to the ParseResultDelete builder.
+func (prd *ParseResultDelete) Where(ps ...predicate.ParseResult) *ParseResultDelete {
+	prd.mutation.Where(ps...)
+	return prd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (prd *ParseResultDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, prd.sqlExec, prd.mutation, prd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (prd *ParseResultDelete) ExecX(ctx context.Context) int {
+	n, err := prd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (prd *ParseResultDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(parseresult.Table, sqlgraph.NewFieldSpec(parseresult.FieldID, field.TypeUUID))
+	if ps := prd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, prd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	prd.mutation.done = true
+	return affected, err
+}
+
+// ParseResultDeleteOne is the builder for deleting a single ParseResult entity.
+type ParseResultDeleteOne struct {
+	prd *ParseResultDelete
+}
+
+// Where appends a list predicates to the ParseResultDelete builder.
+func (prdo *ParseResultDeleteOne) Where(ps ...predicate.ParseResult) *ParseResultDeleteOne {
+	prdo.prd.mutation.Where(ps...)
+	return prdo
+}
+
+// Exec executes the deletion query.
+func (prdo *ParseResultDeleteOne) Exec(ctx context.Context) error {
+	n, err := prdo.prd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{parseresult.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (prdo *ParseResultDeleteOne) ExecX(ctx context.Context) {
+	if err := prdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
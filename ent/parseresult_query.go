@@ -0,0 +1,528 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/parseresult"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+	"github.com/google/uuid"
+)
+
+// ParseResultQuery is the builder for querying ParseResult entities.
+type ParseResultQuery struct {
+	config
+	ctx        *QueryContext
+	order      []parseresult.OrderOption
+	inters     []Interceptor
+	predicates []predicate.ParseResult
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ParseResultQuery builder.
+func (prq *ParseResultQuery) Where(ps ...predicate.ParseResult) *ParseResultQuery {
+	prq.predicates = append(prq.predicates, ps...)
+	return prq
+}
+
+// Limit the number of records to be returned by this query.
+func (prq *ParseResultQuery) Limit(limit int) *ParseResultQuery {
+	prq.ctx.Limit = &limit
+	return prq
+}
+
+// Offset to start from.
+func (prq *ParseResultQuery) Offset(offset int) *ParseResultQuery {
+	prq.ctx.Offset = &offset
+	return prq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (prq *ParseResultQuery) Unique(unique bool) *ParseResultQuery {
+	prq.ctx.Unique = &unique
+	return prq
+}
+
+// Order specifies how the records should be ordered.
+func (prq *ParseResultQuery) Order(o ...parseresult.OrderOption) *ParseResultQuery {
+	prq.order = append(prq.order, o...)
+	return prq
+}
+
+// First returns the first ParseResult entity from the query.
+// Returns a *NotFoundError when no ParseResult was found.
+func (prq *ParseResultQuery) First(ctx context.Context) (*ParseResult, error) {
+	nodes, err := prq.Limit(1).All(setContextOp(ctx, prq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{parseresult.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (prq *ParseResultQuery) FirstX(ctx context.Context) *ParseResult {
+	node, err := prq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ParseResult ID from the query.
+// Returns a *NotFoundError when no ParseResult ID was found.
+func (prq *ParseResultQuery) FirstID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
+	if ids, err = prq.Limit(1).IDs(setContextOp(ctx, prq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{parseresult.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (prq *ParseResultQuery) FirstIDX(ctx context.Context) uuid.UUID {
+	id, err := prq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ParseResult entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ParseResult entity is found.
+// Returns a *NotFoundError when no ParseResult entities are found.
+func (prq *ParseResultQuery) Only(ctx context.Context) (*ParseResult, error) {
+	nodes, err := prq.Limit(2).All(setContextOp(ctx, prq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{parseresult.Label}
+	default:
+		return nil, &NotSingularError{parseresult.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (prq *ParseResultQuery) OnlyX(ctx context.Context) *ParseResult {
+	node, err := prq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ParseResult ID in the query.
+// Returns a *NotSingularError when more than one ParseResult ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (prq *ParseResultQuery) OnlyID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
+	if ids, err = prq.Limit(2).IDs(setContextOp(ctx, prq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{parseresult.Label}
+	default:
+		err = &NotSingularError{parseresult.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (prq *ParseResultQuery) OnlyIDX(ctx context.Context) uuid.UUID {
+	id, err := prq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ParseResults.
+func (prq *ParseResultQuery) All(ctx context.Context) ([]*ParseResult, error) {
+	ctx = setContextOp(ctx, prq.ctx, ent.OpQueryAll)
+	if err := prq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ParseResult, *ParseResultQuery]()
+	return withInterceptors[[]*ParseResult](ctx, prq, qr, prq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (prq *ParseResultQuery) AllX(ctx context.Context) []*ParseResult {
+	nodes, err := prq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ParseResult IDs.
+func (prq *ParseResultQuery) IDs(ctx context.Context) (ids []uuid.UUID, err error) {
+	if prq.ctx.Unique == nil && prq.path != nil {
+		prq.Unique(true)
+	}
+	ctx = setContextOp(ctx, prq.ctx, ent.OpQueryIDs)
+	if err = prq.Select(parseresult.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (prq *ParseResultQuery) IDsX(ctx context.Context) []uuid.UUID {
+	ids, err := prq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (prq *ParseResultQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, prq.ctx, ent.OpQueryCount)
+	if err := prq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, prq, querierCount[*ParseResultQuery](), prq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (prq *ParseResultQuery) CountX(ctx context.Context) int {
+	count, err := prq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (prq *ParseResultQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, prq.ctx, ent.OpQueryExist)
+	switch _, err := prq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (prq *ParseResultQuery) ExistX(ctx context.Context) bool {
+	exist, err := prq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ParseResultQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (prq *ParseResultQuery) Clone() *ParseResultQuery {
+	if prq == nil {
+		return nil
+	}
+	return &ParseResultQuery{
+		config:     prq.config,
+		ctx:        prq.ctx.Clone(),
+		order:      append([]parseresult.OrderOption{}, prq.order...),
+		inters:     append([]Interceptor{}, prq.inters...),
+		predicates: append([]predicate.ParseResult{}, prq.predicates...),
+		// clone intermediate query.
+		sql:  prq.sql.Clone(),
+		path: prq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		CreatedAt time.Time `json:"created_at,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ParseResult.Query().
+//		GroupBy(parseresult.FieldCreatedAt).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (prq *ParseResultQuery) GroupBy(field string, fields ...string) *ParseResultGroupBy {
+	prq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ParseResultGroupBy{build: prq}
+	grbuild.flds = &prq.ctx.Fields
+	grbuild.label = parseresult.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		CreatedAt time.Time `json:"created_at,omitempty"`
+//	}
+//
+//	client.ParseResult.Query().
+//		Select(parseresult.FieldCreatedAt).
+//		Scan(ctx, &v)
+func (prq *ParseResultQuery) Select(fields ...string) *ParseResultSelect {
+	prq.ctx.Fields = append(prq.ctx.Fields, fields...)
+	sbuild := &ParseResultSelect{ParseResultQuery: prq}
+	sbuild.label = parseresult.Label
+	sbuild.flds, sbuild.scan = &prq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ParseResultSelect configured with the given aggregations.
+func (prq *ParseResultQuery) Aggregate(fns ...AggregateFunc) *ParseResultSelect {
+	return prq.Select().Aggregate(fns...)
+}
+
+func (prq *ParseResultQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range prq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, prq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range prq.ctx.Fields {
+		if !parseresult.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if prq.path != nil {
+		prev, err := prq.path(ctx)
+		if err != nil {
+			return err
+		}
+		prq.sql = prev
+	}
+	return nil
+}
+
+func (prq *ParseResultQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ParseResult, error) {
+	var (
+		nodes = []*ParseResult{}
+		_spec = prq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ParseResult).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ParseResult{config: prq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, prq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (prq *ParseResultQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := prq.querySpec()
+	_spec.Node.Columns = prq.ctx.Fields
+	if len(prq.ctx.Fields) > 0 {
+		_spec.Unique = prq.ctx.Unique != nil && *prq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, prq.driver, _spec)
+}
+
+func (prq *ParseResultQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(parseresult.Table, parseresult.Columns, sqlgraph.NewFieldSpec(parseresult.FieldID, field.TypeUUID))
+	_spec.From = prq.sql
+	if unique := prq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if prq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := prq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, parseresult.FieldID)
+		for i := range fields {
+			if fields[i] != parseresult.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := prq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := prq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := prq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := prq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (prq *ParseResultQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(prq.driver.Dialect())
+	t1 := builder.Table(parseresult.Table)
+	columns := prq.ctx.Fields
+	if len(columns) == 0 {
+		columns = parseresult.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if prq.sql != nil {
+		selector = prq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if prq.ctx.Unique != nil && *prq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range prq.predicates {
+		p(selector)
+	}
+	for _, p := range prq.order {
+		p(selector)
+	}
+	if offset := prq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := prq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ParseResultGroupBy is the group-by builder for ParseResult entities.
+type ParseResultGroupBy struct {
+	selector
+	build *ParseResultQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (prgb *ParseResultGroupBy) Aggregate(fns ...AggregateFunc) *ParseResultGroupBy {
+	prgb.fns = append(prgb.fns, fns...)
+	return prgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (prgb *ParseResultGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, prgb.build.ctx, ent.OpQueryGroupBy)
+	if err := prgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ParseResultQuery, *ParseResultGroupBy](ctx, prgb.build, prgb, prgb.build.inters, v)
+}
+
+func (prgb *ParseResultGroupBy) sqlScan(ctx context.Context, root *ParseResultQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(prgb.fns))
+	for _, fn := range prgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*prgb.flds)+len(prgb.fns))
+		for _, f := range *prgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*prgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := prgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ParseResultSelect is the builder for selecting fields of ParseResult entities.
+type ParseResultSelect struct {
+	*ParseResultQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (prs *ParseResultSelect) Aggregate(fns ...AggregateFunc) *ParseResultSelect {
+	prs.fns = append(prs.fns, fns...)
+	return prs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (prs *ParseResultSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, prs.ctx, ent.OpQuerySelect)
+	if err := prs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ParseResultQuery, *ParseResultSelect](ctx, prs.ParseResultQuery, prs, prs.inters, v)
+}
+
+func (prs *ParseResultSelect) sqlScan(ctx context.Context, root *ParseResultQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(prs.fns))
+	for _, fn := range prs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*prs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := prs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,630 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/parseresult"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// ParseResultUpdate is the builder for updating ParseResult entities.
+type ParseResultUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ParseResultMutation
+}
+
+// Where appends a list predicates to the ParseResultUpdate builder.
+func (pru *ParseResultUpdate) Where(ps ...predicate.ParseResult) *ParseResultUpdate {
+	pru.mutation.Where(ps...)
+	return pru
+}
+
+// SetStatus sets the "status" field.
+func (pru *ParseResultUpdate) SetStatus(s string) *ParseResultUpdate {
+	pru.mutation.SetStatus(s)
+	return pru
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (pru *ParseResultUpdate) SetNillableStatus(s *string) *ParseResultUpdate {
+	if s != nil {
+		pru.SetStatus(*s)
+	}
+	return pru
+}
+
+// SetContent sets the "content" field.
+func (pru *ParseResultUpdate) SetContent(m models.Prescription) *ParseResultUpdate {
+	pru.mutation.SetContent(m)
+	return pru
+}
+
+// SetNillableContent sets the "content" field if the given value is not nil.
+func (pru *ParseResultUpdate) SetNillableContent(m *models.Prescription) *ParseResultUpdate {
+	if m != nil {
+		pru.SetContent(*m)
+	}
+	return pru
+}
+
+// SetValidation sets the "validation" field.
+func (pru *ParseResultUpdate) SetValidation(mi []models.ValidationIssue) *ParseResultUpdate {
+	pru.mutation.SetValidation(mi)
+	return pru
+}
+
+// AppendValidation appends mi to the "validation" field.
+func (pru *ParseResultUpdate) AppendValidation(mi []models.ValidationIssue) *ParseResultUpdate {
+	pru.mutation.AppendValidation(mi)
+	return pru
+}
+
+// ClearValidation clears the value of the "validation" field.
+func (pru *ParseResultUpdate) ClearValidation() *ParseResultUpdate {
+	pru.mutation.ClearValidation()
+	return pru
+}
+
+// SetBlocked sets the "blocked" field.
+func (pru *ParseResultUpdate) SetBlocked(b bool) *ParseResultUpdate {
+	pru.mutation.SetBlocked(b)
+	return pru
+}
+
+// SetNillableBlocked sets the "blocked" field if the given value is not nil.
+func (pru *ParseResultUpdate) SetNillableBlocked(b *bool) *ParseResultUpdate {
+	if b != nil {
+		pru.SetBlocked(*b)
+	}
+	return pru
+}
+
+// SetVerification sets the "verification" field.
+func (pru *ParseResultUpdate) SetVerification(mc []models.FieldChange) *ParseResultUpdate {
+	pru.mutation.SetVerification(mc)
+	return pru
+}
+
+// AppendVerification appends mc to the "verification" field.
+func (pru *ParseResultUpdate) AppendVerification(mc []models.FieldChange) *ParseResultUpdate {
+	pru.mutation.AppendVerification(mc)
+	return pru
+}
+
+// ClearVerification clears the value of the "verification" field.
+func (pru *ParseResultUpdate) ClearVerification() *ParseResultUpdate {
+	pru.mutation.ClearVerification()
+	return pru
+}
+
+// SetOrigins sets the "origins" field.
+func (pru *ParseResultUpdate) SetOrigins(m map[string]string) *ParseResultUpdate {
+	pru.mutation.SetOrigins(m)
+	return pru
+}
+
+// ClearOrigins clears the value of the "origins" field.
+func (pru *ParseResultUpdate) ClearOrigins() *ParseResultUpdate {
+	pru.mutation.ClearOrigins()
+	return pru
+}
+
+// SetPrescriptions sets the "prescriptions" field.
+func (pru *ParseResultUpdate) SetPrescriptions(mp []models.DocumentPrescription) *ParseResultUpdate {
+	pru.mutation.SetPrescriptions(mp)
+	return pru
+}
+
+// AppendPrescriptions appends mp to the "prescriptions" field.
+func (pru *ParseResultUpdate) AppendPrescriptions(mp []models.DocumentPrescription) *ParseResultUpdate {
+	pru.mutation.AppendPrescriptions(mp)
+	return pru
+}
+
+// ClearPrescriptions clears the value of the "prescriptions" field.
+func (pru *ParseResultUpdate) ClearPrescriptions() *ParseResultUpdate {
+	pru.mutation.ClearPrescriptions()
+	return pru
+}
+
+// SetAttributes sets the "attributes" field.
+func (pru *ParseResultUpdate) SetAttributes(m map[string]string) *ParseResultUpdate {
+	pru.mutation.SetAttributes(m)
+	return pru
+}
+
+// ClearAttributes clears the value of the "attributes" field.
+func (pru *ParseResultUpdate) ClearAttributes() *ParseResultUpdate {
+	pru.mutation.ClearAttributes()
+	return pru
+}
+
+// SetStartedAt sets the "started_at" field.
+func (pru *ParseResultUpdate) SetStartedAt(t time.Time) *ParseResultUpdate {
+	pru.mutation.SetStartedAt(t)
+	return pru
+}
+
+// SetNillableStartedAt sets the "started_at" field if the given value is not nil.
+func (pru *ParseResultUpdate) SetNillableStartedAt(t *time.Time) *ParseResultUpdate {
+	if t != nil {
+		pru.SetStartedAt(*t)
+	}
+	return pru
+}
+
+// SetCompletedAt sets the "completed_at" field.
+func (pru *ParseResultUpdate) SetCompletedAt(t time.Time) *ParseResultUpdate {
+	pru.mutation.SetCompletedAt(t)
+	return pru
+}
+
+// SetNillableCompletedAt sets the "completed_at" field if the given value is not nil.
+func (pru *ParseResultUpdate) SetNillableCompletedAt(t *time.Time) *ParseResultUpdate {
+	if t != nil {
+		pru.SetCompletedAt(*t)
+	}
+	return pru
+}
+
+// SetReview sets the "review" field.
+func (pru *ParseResultUpdate) SetReview(m *models.Review) *ParseResultUpdate {
+	pru.mutation.SetReview(m)
+	return pru
+}
+
+// ClearReview clears the value of the "review" field.
+func (pru *ParseResultUpdate) ClearReview() *ParseResultUpdate {
+	pru.mutation.ClearReview()
+	return pru
+}
+
+// Mutation returns the ParseResultMutation object of the builder.
+func (pru *ParseResultUpdate) Mutation() *ParseResultMutation {
+	return pru.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (pru *ParseResultUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, pru.sqlSave, pru.mutation, pru.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pru *ParseResultUpdate) SaveX(ctx context.Context) int {
+	affected, err := pru.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (pru *ParseResultUpdate) Exec(ctx context.Context) error {
+	_, err := pru.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pru *ParseResultUpdate) ExecX(ctx context.Context) {
+	if err := pru.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (pru *ParseResultUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(parseresult.Table, parseresult.Columns, sqlgraph.NewFieldSpec(parseresult.FieldID, field.TypeUUID))
+	if ps := pru.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := pru.mutation.Status(); ok {
+		_spec.SetField(parseresult.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := pru.mutation.Content(); ok {
+		_spec.SetField(parseresult.FieldContent, field.TypeJSON, value)
+	}
+	if value, ok := pru.mutation.Validation(); ok {
+		_spec.SetField(parseresult.FieldValidation, field.TypeJSON, value)
+	}
+	if value, ok := pru.mutation.AppendedValidation(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, parseresult.FieldValidation, value)
+		})
+	}
+	if pru.mutation.ValidationCleared() {
+		_spec.ClearField(parseresult.FieldValidation, field.TypeJSON)
+	}
+	if value, ok := pru.mutation.Blocked(); ok {
+		_spec.SetField(parseresult.FieldBlocked, field.TypeBool, value)
+	}
+	if value, ok := pru.mutation.Verification(); ok {
+		_spec.SetField(parseresult.FieldVerification, field.TypeJSON, value)
+	}
+	if value, ok := pru.mutation.AppendedVerification(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, parseresult.FieldVerification, value)
+		})
+	}
+	if pru.mutation.VerificationCleared() {
+		_spec.ClearField(parseresult.FieldVerification, field.TypeJSON)
+	}
+	if value, ok := pru.mutation.Origins(); ok {
+		_spec.SetField(parseresult.FieldOrigins, field.TypeJSON, value)
+	}
+	if pru.mutation.OriginsCleared() {
+		_spec.ClearField(parseresult.FieldOrigins, field.TypeJSON)
+	}
+	if value, ok := pru.mutation.Prescriptions(); ok {
+		_spec.SetField(parseresult.FieldPrescriptions, field.TypeJSON, value)
+	}
+	if value, ok := pru.mutation.AppendedPrescriptions(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, parseresult.FieldPrescriptions, value)
+		})
+	}
+	if pru.mutation.PrescriptionsCleared() {
+		_spec.ClearField(parseresult.FieldPrescriptions, field.TypeJSON)
+	}
+	if value, ok := pru.mutation.Attributes(); ok {
+		_spec.SetField(parseresult.FieldAttributes, field.TypeJSON, value)
+	}
+	if pru.mutation.AttributesCleared() {
+		_spec.ClearField(parseresult.FieldAttributes, field.TypeJSON)
+	}
+	if value, ok := pru.mutation.StartedAt(); ok {
+		_spec.SetField(parseresult.FieldStartedAt, field.TypeTime, value)
+	}
+	if value, ok := pru.mutation.CompletedAt(); ok {
+		_spec.SetField(parseresult.FieldCompletedAt, field.TypeTime, value)
+	}
+	if value, ok := pru.mutation.Review(); ok {
+		_spec.SetField(parseresult.FieldReview, field.TypeJSON, value)
+	}
+	if pru.mutation.ReviewCleared() {
+		_spec.ClearField(parseresult.FieldReview, field.TypeJSON)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, pru.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{parseresult.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	pru.mutation.done = true
+	return n, nil
+}
+
+// ParseResultUpdateOne is the builder for updating a single ParseResult entity.
+type ParseResultUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ParseResultMutation
+}
+
+// SetStatus sets the "status" field.
+func (pruo *ParseResultUpdateOne) SetStatus(s string) *ParseResultUpdateOne {
+	pruo.mutation.SetStatus(s)
+	return pruo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (pruo *ParseResultUpdateOne) SetNillableStatus(s *string) *ParseResultUpdateOne {
+	if s != nil {
+		pruo.SetStatus(*s)
+	}
+	return pruo
+}
+
+// SetContent sets the "content" field.
+func (pruo *ParseResultUpdateOne) SetContent(m models.Prescription) *ParseResultUpdateOne {
+	pruo.mutation.SetContent(m)
+	return pruo
+}
+
+// SetNillableContent sets the "content" field if the given value is not nil.
+func (pruo *ParseResultUpdateOne) SetNillableContent(m *models.Prescription) *ParseResultUpdateOne {
+	if m != nil {
+		pruo.SetContent(*m)
+	}
+	return pruo
+}
+
+// SetValidation sets the "validation" field.
+func (pruo *ParseResultUpdateOne) SetValidation(mi []models.ValidationIssue) *ParseResultUpdateOne {
+	pruo.mutation.SetValidation(mi)
+	return pruo
+}
+
+// AppendValidation appends mi to the "validation" field.
+func (pruo *ParseResultUpdateOne) AppendValidation(mi []models.ValidationIssue) *ParseResultUpdateOne {
+	pruo.mutation.AppendValidation(mi)
+	return pruo
+}
+
+// ClearValidation clears the value of the "validation" field.
+func (pruo *ParseResultUpdateOne) ClearValidation() *ParseResultUpdateOne {
+	pruo.mutation.ClearValidation()
+	return pruo
+}
+
+// SetBlocked sets the "blocked" field.
+func (pruo *ParseResultUpdateOne) SetBlocked(b bool) *ParseResultUpdateOne {
+	pruo.mutation.SetBlocked(b)
+	return pruo
+}
+
+// SetNillableBlocked sets the "blocked" field if the given value is not nil.
+func (pruo *ParseResultUpdateOne) SetNillableBlocked(b *bool) *ParseResultUpdateOne {
+	if b != nil {
+		pruo.SetBlocked(*b)
+	}
+	return pruo
+}
+
+// SetVerification sets the "verification" field.
+func (pruo *ParseResultUpdateOne) SetVerification(mc []models.FieldChange) *ParseResultUpdateOne {
+	pruo.mutation.SetVerification(mc)
+	return pruo
+}
+
+// AppendVerification appends mc to the "verification" field.
+func (pruo *ParseResultUpdateOne) AppendVerification(mc []models.FieldChange) *ParseResultUpdateOne {
+	pruo.mutation.AppendVerification(mc)
+	return pruo
+}
+
+// ClearVerification clears the value of the "verification" field.
+func (pruo *ParseResultUpdateOne) ClearVerification() *ParseResultUpdateOne {
+	pruo.mutation.ClearVerification()
+	return pruo
+}
+
+// SetOrigins sets the "origins" field.
+func (pruo *ParseResultUpdateOne) SetOrigins(m map[string]string) *ParseResultUpdateOne {
+	pruo.mutation.SetOrigins(m)
+	return pruo
+}
+
+// ClearOrigins clears the value of the "origins" field.
+func (pruo *ParseResultUpdateOne) ClearOrigins() *ParseResultUpdateOne {
+	pruo.mutation.ClearOrigins()
+	return pruo
+}
+
+// SetPrescriptions sets the "prescriptions" field.
+func (pruo *ParseResultUpdateOne) SetPrescriptions(mp []models.DocumentPrescription) *ParseResultUpdateOne {
+	pruo.mutation.SetPrescriptions(mp)
+	return pruo
+}
+
+// AppendPrescriptions appends mp to the "prescriptions" field.
+func (pruo *ParseResultUpdateOne) AppendPrescriptions(mp []models.DocumentPrescription) *ParseResultUpdateOne {
+	pruo.mutation.AppendPrescriptions(mp)
+	return pruo
+}
+
+// ClearPrescriptions clears the value of the "prescriptions" field.
+func (pruo *ParseResultUpdateOne) ClearPrescriptions() *ParseResultUpdateOne {
+	pruo.mutation.ClearPrescriptions()
+	return pruo
+}
+
+// SetAttributes sets the "attributes" field.
+func (pruo *ParseResultUpdateOne) SetAttributes(m map[string]string) *ParseResultUpdateOne {
+	pruo.mutation.SetAttributes(m)
+	return pruo
+}
+
+// ClearAttributes clears the value of the "attributes" field.
+func (pruo *ParseResultUpdateOne) ClearAttributes() *ParseResultUpdateOne {
+	pruo.mutation.ClearAttributes()
+	return pruo
+}
+
+// SetStartedAt sets the "started_at" field.
+func (pruo *ParseResultUpdateOne) SetStartedAt(t time.Time) *ParseResultUpdateOne {
+	pruo.mutation.SetStartedAt(t)
+	return pruo
+}
+
+// SetNillableStartedAt sets the "started_at" field if the given value is not nil.
+func (pruo *ParseResultUpdateOne) SetNillableStartedAt(t *time.Time) *ParseResultUpdateOne {
+	if t != nil {
+		pruo.SetStartedAt(*t)
+	}
+	return pruo
+}
+
+// SetCompletedAt sets the "completed_at" field.
+func (pruo *ParseResultUpdateOne) SetCompletedAt(t time.Time) *ParseResultUpdateOne {
+	pruo.mutation.SetCompletedAt(t)
+	return pruo
+}
+
+// SetNillableCompletedAt sets the "completed_at" field if the given value is not nil.
+func (pruo *ParseResultUpdateOne) SetNillableCompletedAt(t *time.Time) *ParseResultUpdateOne {
+	if t != nil {
+		pruo.SetCompletedAt(*t)
+	}
+	return pruo
+}
+
+// SetReview sets the "review" field.
+func (pruo *ParseResultUpdateOne) SetReview(m *models.Review) *ParseResultUpdateOne {
+	pruo.mutation.SetReview(m)
+	return pruo
+}
+
+// ClearReview clears the value of the "review" field.
+func (pruo *ParseResultUpdateOne) ClearReview() *ParseResultUpdateOne {
+	pruo.mutation.ClearReview()
+	return pruo
+}
+
+// Mutation returns the ParseResultMutation object of the builder.
+func (pruo *ParseResultUpdateOne) Mutation() *ParseResultMutation {
+	return pruo.mutation
+}
+
+// Where appends a list predicates to the ParseResultUpdate builder.
+func (pruo *ParseResultUpdateOne) Where(ps ...predicate.ParseResult) *ParseResultUpdateOne {
+	pruo.mutation.Where(ps...)
+	return pruo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (pruo *ParseResultUpdateOne) Select(field string, fields ...string) *ParseResultUpdateOne {
+	pruo.fields = append([]string{field}, fields...)
+	return pruo
+}
+
+// Save executes the query and returns the updated ParseResult entity.
+func (pruo *ParseResultUpdateOne) Save(ctx context.Context) (*ParseResult, error) {
+	return withHooks(ctx, pruo.sqlSave, pruo.mutation, pruo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pruo *ParseResultUpdateOne) SaveX(ctx context.Context) *ParseResult {
+	node, err := pruo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (pruo *ParseResultUpdateOne) Exec(ctx context.Context) error {
+	_, err := pruo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pruo *ParseResultUpdateOne) ExecX(ctx context.Context) {
+	if err := pruo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (pruo *ParseResultUpdateOne) sqlSave(ctx context.Context) (_node *ParseResult, err error) {
+	_spec := sqlgraph.NewUpdateSpec(parseresult.Table, parseresult.Columns, sqlgraph.NewFieldSpec(parseresult.FieldID, field.TypeUUID))
+	id, ok := pruo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ParseResult.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := pruo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, parseresult.FieldID)
+		for _, f := range fields {
+			if !parseresult.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != parseresult.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := pruo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := pruo.mutation.Status(); ok {
+		_spec.SetField(parseresult.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := pruo.mutation.Content(); ok {
+		_spec.SetField(parseresult.FieldContent, field.TypeJSON, value)
+	}
+	if value, ok := pruo.mutation.Validation(); ok {
+		_spec.SetField(parseresult.FieldValidation, field.TypeJSON, value)
+	}
+	if value, ok := pruo.mutation.AppendedValidation(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, parseresult.FieldValidation, value)
+		})
+	}
+	if pruo.mutation.ValidationCleared() {
+		_spec.ClearField(parseresult.FieldValidation, field.TypeJSON)
+	}
+	if value, ok := pruo.mutation.Blocked(); ok {
+		_spec.SetField(parseresult.FieldBlocked, field.TypeBool, value)
+	}
+	if value, ok := pruo.mutation.Verification(); ok {
+		_spec.SetField(parseresult.FieldVerification, field.TypeJSON, value)
+	}
+	if value, ok := pruo.mutation.AppendedVerification(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, parseresult.FieldVerification, value)
+		})
+	}
+	if pruo.mutation.VerificationCleared() {
+		_spec.ClearField(parseresult.FieldVerification, field.TypeJSON)
+	}
+	if value, ok := pruo.mutation.Origins(); ok {
+		_spec.SetField(parseresult.FieldOrigins, field.TypeJSON, value)
+	}
+	if pruo.mutation.OriginsCleared() {
+		_spec.ClearField(parseresult.FieldOrigins, field.TypeJSON)
+	}
+	if value, ok := pruo.mutation.Prescriptions(); ok {
+		_spec.SetField(parseresult.FieldPrescriptions, field.TypeJSON, value)
+	}
+	if value, ok := pruo.mutation.AppendedPrescriptions(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, parseresult.FieldPrescriptions, value)
+		})
+	}
+	if pruo.mutation.PrescriptionsCleared() {
+		_spec.ClearField(parseresult.FieldPrescriptions, field.TypeJSON)
+	}
+	if value, ok := pruo.mutation.Attributes(); ok {
+		_spec.SetField(parseresult.FieldAttributes, field.TypeJSON, value)
+	}
+	if pruo.mutation.AttributesCleared() {
+		_spec.ClearField(parseresult.FieldAttributes, field.TypeJSON)
+	}
+	if value, ok := pruo.mutation.StartedAt(); ok {
+		_spec.SetField(parseresult.FieldStartedAt, field.TypeTime, value)
+	}
+	if value, ok := pruo.mutation.CompletedAt(); ok {
+		_spec.SetField(parseresult.FieldCompletedAt, field.TypeTime, value)
+	}
+	if value, ok := pruo.mutation.Review(); ok {
+		_spec.SetField(parseresult.FieldReview, field.TypeJSON, value)
+	}
+	if pruo.mutation.ReviewCleared() {
+		_spec.ClearField(parseresult.FieldReview, field.TypeJSON)
+	}
+	_node = &ParseResult{config: pruo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, pruo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{parseresult.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	pruo.mutation.done = true
+	return _node, nil
+}
@@ -9,5 +9,11 @@ import (
 // Embedding is the predicate function for embedding builders.
 type Embedding func(*sql.Selector)
 
+// ParseResult is the predicate function for parseresult builders.
+type ParseResult func(*sql.Selector)
+
 // Prescription is the predicate function for prescription builders.
 type Prescription func(*sql.Selector)
+
+// PromptOverlay is the predicate function for promptoverlay builders.
+type PromptOverlay func(*sql.Selector)
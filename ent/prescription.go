@@ -27,10 +27,39 @@ type Prescription struct {
 	// MimeType holds the value of the "mime_type" field.
 	MimeType string `json:"mime_type,omitempty"`
 	// Content holds the value of the "content" field.
-	Content      models.Prescription `json:"content,omitempty"`
+	Content models.Prescription `json:"content,omitempty"`
+	// DocumentHash holds the value of the "document_hash" field.
+	DocumentHash string `json:"document_hash,omitempty"`
+	// Template holds the value of the "template" field.
+	Template string `json:"template,omitempty"`
+	// Tenant holds the value of the "tenant" field.
+	Tenant string `json:"tenant,omitempty"`
+	// DrugClass holds the value of the "drug_class" field.
+	DrugClass string `json:"drug_class,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PrescriptionQuery when eager-loading is set.
+	Edges        PrescriptionEdges `json:"edges"`
 	selectValues sql.SelectValues
 }
 
+// PrescriptionEdges holds the relations/edges for other nodes in the graph.
+type PrescriptionEdges struct {
+	// Embeddings holds the value of the embeddings edge.
+	Embeddings []*Embedding `json:"embeddings,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// EmbeddingsOrErr returns the Embeddings value or an error if the edge
+// was not loaded in eager-loading.
+func (e PrescriptionEdges) EmbeddingsOrErr() ([]*Embedding, error) {
+	if e.loadedTypes[0] {
+		return e.Embeddings, nil
+	}
+	return nil, &NotLoadedError{edge: "embeddings"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Prescription) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -38,7 +67,7 @@ func (*Prescription) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case prescription.FieldContent:
 			values[i] = new([]byte)
-		case prescription.FieldFileID, prescription.FieldMimeType:
+		case prescription.FieldFileID, prescription.FieldMimeType, prescription.FieldDocumentHash, prescription.FieldTemplate, prescription.FieldTenant, prescription.FieldDrugClass:
 			values[i] = new(sql.NullString)
 		case prescription.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
@@ -91,6 +120,30 @@ func (pr *Prescription) assignValues(columns []string, values []any) error {
 					return fmt.Errorf("unmarshal field content: %w", err)
 				}
 			}
+		case prescription.FieldDocumentHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field document_hash", values[i])
+			} else if value.Valid {
+				pr.DocumentHash = value.String
+			}
+		case prescription.FieldTemplate:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field template", values[i])
+			} else if value.Valid {
+				pr.Template = value.String
+			}
+		case prescription.FieldTenant:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field tenant", values[i])
+			} else if value.Valid {
+				pr.Tenant = value.String
+			}
+		case prescription.FieldDrugClass:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field drug_class", values[i])
+			} else if value.Valid {
+				pr.DrugClass = value.String
+			}
 		default:
 			pr.selectValues.Set(columns[i], values[i])
 		}
@@ -104,6 +157,11 @@ func (pr *Prescription) Value(name string) (ent.Value, error) {
 	return pr.selectValues.Get(name)
 }
 
+// QueryEmbeddings queries the "embeddings" edge of the Prescription entity.
+func (pr *Prescription) QueryEmbeddings() *EmbeddingQuery {
+	return NewPrescriptionClient(pr.config).QueryEmbeddings(pr)
+}
+
 // Update returns a builder for updating this Prescription.
 // Note that you need to call Prescription.Unwrap() before calling this method if this Prescription
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -138,6 +196,18 @@ func (pr *Prescription) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("content=")
 	builder.WriteString(fmt.Sprintf("%v", pr.Content))
+	builder.WriteString(", ")
+	builder.WriteString("document_hash=")
+	builder.WriteString(pr.DocumentHash)
+	builder.WriteString(", ")
+	builder.WriteString("template=")
+	builder.WriteString(pr.Template)
+	builder.WriteString(", ")
+	builder.WriteString("tenant=")
+	builder.WriteString(pr.Tenant)
+	builder.WriteString(", ")
+	builder.WriteString("drug_class=")
+	builder.WriteString(pr.DrugClass)
 	builder.WriteByte(')')
 	return builder.String()
 }
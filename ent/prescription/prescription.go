@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/google/uuid"
 )
 
@@ -22,8 +23,25 @@ const (
 	FieldMimeType = "mime_type"
 	// FieldContent holds the string denoting the content field in the database.
 	FieldContent = "content"
+	// FieldDocumentHash holds the string denoting the document_hash field in the database.
+	FieldDocumentHash = "document_hash"
+	// FieldTemplate holds the string denoting the template field in the database.
+	FieldTemplate = "template"
+	// FieldTenant holds the string denoting the tenant field in the database.
+	FieldTenant = "tenant"
+	// FieldDrugClass holds the string denoting the drug_class field in the database.
+	FieldDrugClass = "drug_class"
+	// EdgeEmbeddings holds the string denoting the embeddings edge name in mutations.
+	EdgeEmbeddings = "embeddings"
 	// Table holds the table name of the prescription in the database.
 	Table = "prescriptions"
+	// EmbeddingsTable is the table that holds the embeddings relation/edge.
+	EmbeddingsTable = "embeddings"
+	// EmbeddingsInverseTable is the table name for the Embedding entity.
+	// It exists in this package in order to avoid circular dependency with the "embedding" package.
+	EmbeddingsInverseTable = "embeddings"
+	// EmbeddingsColumn is the table column denoting the embeddings relation/edge.
+	EmbeddingsColumn = "embedding_prescription"
 )
 
 // Columns holds all SQL columns for prescription fields.
@@ -33,6 +51,10 @@ var Columns = []string{
 	FieldFileID,
 	FieldMimeType,
 	FieldContent,
+	FieldDocumentHash,
+	FieldTemplate,
+	FieldTenant,
+	FieldDrugClass,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -74,3 +96,44 @@ func ByFileID(opts ...sql.OrderTermOption) OrderOption {
 func ByMimeType(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldMimeType, opts...).ToFunc()
 }
+
+// ByDocumentHash orders the results by the document_hash field.
+func ByDocumentHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDocumentHash, opts...).ToFunc()
+}
+
+// ByTemplate orders the results by the template field.
+func ByTemplate(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTemplate, opts...).ToFunc()
+}
+
+// ByTenant orders the results by the tenant field.
+func ByTenant(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTenant, opts...).ToFunc()
+}
+
+// ByDrugClass orders the results by the drug_class field.
+func ByDrugClass(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDrugClass, opts...).ToFunc()
+}
+
+// ByEmbeddingsCount orders the results by embeddings count.
+func ByEmbeddingsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newEmbeddingsStep(), opts...)
+	}
+}
+
+// ByEmbeddings orders the results by embeddings terms.
+func ByEmbeddings(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newEmbeddingsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+func newEmbeddingsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(EmbeddingsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, true, EmbeddingsTable, EmbeddingsColumn),
+	)
+}
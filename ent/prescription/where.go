@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/csotherden/prescription-parser/ent/predicate"
 	"github.com/google/uuid"
 )
@@ -70,6 +71,26 @@ func MimeType(v string) predicate.Prescription {
 	return predicate.Prescription(sql.FieldEQ(FieldMimeType, v))
 }
 
+// DocumentHash applies equality check predicate on the "document_hash" field. It's identical to DocumentHashEQ.
+func DocumentHash(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEQ(FieldDocumentHash, v))
+}
+
+// Template applies equality check predicate on the "template" field. It's identical to TemplateEQ.
+func Template(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEQ(FieldTemplate, v))
+}
+
+// Tenant applies equality check predicate on the "tenant" field. It's identical to TenantEQ.
+func Tenant(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEQ(FieldTenant, v))
+}
+
+// DrugClass applies equality check predicate on the "drug_class" field. It's identical to DrugClassEQ.
+func DrugClass(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEQ(FieldDrugClass, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Prescription {
 	return predicate.Prescription(sql.FieldEQ(FieldCreatedAt, v))
@@ -240,6 +261,329 @@ func MimeTypeContainsFold(v string) predicate.Prescription {
 	return predicate.Prescription(sql.FieldContainsFold(FieldMimeType, v))
 }
 
+// DocumentHashEQ applies the EQ predicate on the "document_hash" field.
+func DocumentHashEQ(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEQ(FieldDocumentHash, v))
+}
+
+// DocumentHashNEQ applies the NEQ predicate on the "document_hash" field.
+func DocumentHashNEQ(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldNEQ(FieldDocumentHash, v))
+}
+
+// DocumentHashIn applies the In predicate on the "document_hash" field.
+func DocumentHashIn(vs ...string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldIn(FieldDocumentHash, vs...))
+}
+
+// DocumentHashNotIn applies the NotIn predicate on the "document_hash" field.
+func DocumentHashNotIn(vs ...string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldNotIn(FieldDocumentHash, vs...))
+}
+
+// DocumentHashGT applies the GT predicate on the "document_hash" field.
+func DocumentHashGT(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldGT(FieldDocumentHash, v))
+}
+
+// DocumentHashGTE applies the GTE predicate on the "document_hash" field.
+func DocumentHashGTE(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldGTE(FieldDocumentHash, v))
+}
+
+// DocumentHashLT applies the LT predicate on the "document_hash" field.
+func DocumentHashLT(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldLT(FieldDocumentHash, v))
+}
+
+// DocumentHashLTE applies the LTE predicate on the "document_hash" field.
+func DocumentHashLTE(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldLTE(FieldDocumentHash, v))
+}
+
+// DocumentHashContains applies the Contains predicate on the "document_hash" field.
+func DocumentHashContains(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldContains(FieldDocumentHash, v))
+}
+
+// DocumentHashHasPrefix applies the HasPrefix predicate on the "document_hash" field.
+func DocumentHashHasPrefix(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldHasPrefix(FieldDocumentHash, v))
+}
+
+// DocumentHashHasSuffix applies the HasSuffix predicate on the "document_hash" field.
+func DocumentHashHasSuffix(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldHasSuffix(FieldDocumentHash, v))
+}
+
+// DocumentHashIsNil applies the IsNil predicate on the "document_hash" field.
+func DocumentHashIsNil() predicate.Prescription {
+	return predicate.Prescription(sql.FieldIsNull(FieldDocumentHash))
+}
+
+// DocumentHashNotNil applies the NotNil predicate on the "document_hash" field.
+func DocumentHashNotNil() predicate.Prescription {
+	return predicate.Prescription(sql.FieldNotNull(FieldDocumentHash))
+}
+
+// DocumentHashEqualFold applies the EqualFold predicate on the "document_hash" field.
+func DocumentHashEqualFold(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEqualFold(FieldDocumentHash, v))
+}
+
+// DocumentHashContainsFold applies the ContainsFold predicate on the "document_hash" field.
+func DocumentHashContainsFold(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldContainsFold(FieldDocumentHash, v))
+}
+
+// TemplateEQ applies the EQ predicate on the "template" field.
+func TemplateEQ(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEQ(FieldTemplate, v))
+}
+
+// TemplateNEQ applies the NEQ predicate on the "template" field.
+func TemplateNEQ(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldNEQ(FieldTemplate, v))
+}
+
+// TemplateIn applies the In predicate on the "template" field.
+func TemplateIn(vs ...string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldIn(FieldTemplate, vs...))
+}
+
+// TemplateNotIn applies the NotIn predicate on the "template" field.
+func TemplateNotIn(vs ...string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldNotIn(FieldTemplate, vs...))
+}
+
+// TemplateGT applies the GT predicate on the "template" field.
+func TemplateGT(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldGT(FieldTemplate, v))
+}
+
+// TemplateGTE applies the GTE predicate on the "template" field.
+func TemplateGTE(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldGTE(FieldTemplate, v))
+}
+
+// TemplateLT applies the LT predicate on the "template" field.
+func TemplateLT(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldLT(FieldTemplate, v))
+}
+
+// TemplateLTE applies the LTE predicate on the "template" field.
+func TemplateLTE(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldLTE(FieldTemplate, v))
+}
+
+// TemplateContains applies the Contains predicate on the "template" field.
+func TemplateContains(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldContains(FieldTemplate, v))
+}
+
+// TemplateHasPrefix applies the HasPrefix predicate on the "template" field.
+func TemplateHasPrefix(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldHasPrefix(FieldTemplate, v))
+}
+
+// TemplateHasSuffix applies the HasSuffix predicate on the "template" field.
+func TemplateHasSuffix(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldHasSuffix(FieldTemplate, v))
+}
+
+// TemplateIsNil applies the IsNil predicate on the "template" field.
+func TemplateIsNil() predicate.Prescription {
+	return predicate.Prescription(sql.FieldIsNull(FieldTemplate))
+}
+
+// TemplateNotNil applies the NotNil predicate on the "template" field.
+func TemplateNotNil() predicate.Prescription {
+	return predicate.Prescription(sql.FieldNotNull(FieldTemplate))
+}
+
+// TemplateEqualFold applies the EqualFold predicate on the "template" field.
+func TemplateEqualFold(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEqualFold(FieldTemplate, v))
+}
+
+// TemplateContainsFold applies the ContainsFold predicate on the "template" field.
+func TemplateContainsFold(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldContainsFold(FieldTemplate, v))
+}
+
+// TenantEQ applies the EQ predicate on the "tenant" field.
+func TenantEQ(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEQ(FieldTenant, v))
+}
+
+// TenantNEQ applies the NEQ predicate on the "tenant" field.
+func TenantNEQ(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldNEQ(FieldTenant, v))
+}
+
+// TenantIn applies the In predicate on the "tenant" field.
+func TenantIn(vs ...string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldIn(FieldTenant, vs...))
+}
+
+// TenantNotIn applies the NotIn predicate on the "tenant" field.
+func TenantNotIn(vs ...string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldNotIn(FieldTenant, vs...))
+}
+
+// TenantGT applies the GT predicate on the "tenant" field.
+func TenantGT(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldGT(FieldTenant, v))
+}
+
+// TenantGTE applies the GTE predicate on the "tenant" field.
+func TenantGTE(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldGTE(FieldTenant, v))
+}
+
+// TenantLT applies the LT predicate on the "tenant" field.
+func TenantLT(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldLT(FieldTenant, v))
+}
+
+// TenantLTE applies the LTE predicate on the "tenant" field.
+func TenantLTE(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldLTE(FieldTenant, v))
+}
+
+// TenantContains applies the Contains predicate on the "tenant" field.
+func TenantContains(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldContains(FieldTenant, v))
+}
+
+// TenantHasPrefix applies the HasPrefix predicate on the "tenant" field.
+func TenantHasPrefix(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldHasPrefix(FieldTenant, v))
+}
+
+// TenantHasSuffix applies the HasSuffix predicate on the "tenant" field.
+func TenantHasSuffix(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldHasSuffix(FieldTenant, v))
+}
+
+// TenantIsNil applies the IsNil predicate on the "tenant" field.
+func TenantIsNil() predicate.Prescription {
+	return predicate.Prescription(sql.FieldIsNull(FieldTenant))
+}
+
+// TenantNotNil applies the NotNil predicate on the "tenant" field.
+func TenantNotNil() predicate.Prescription {
+	return predicate.Prescription(sql.FieldNotNull(FieldTenant))
+}
+
+// TenantEqualFold applies the EqualFold predicate on the "tenant" field.
+func TenantEqualFold(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEqualFold(FieldTenant, v))
+}
+
+// TenantContainsFold applies the ContainsFold predicate on the "tenant" field.
+func TenantContainsFold(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldContainsFold(FieldTenant, v))
+}
+
+// DrugClassEQ applies the EQ predicate on the "drug_class" field.
+func DrugClassEQ(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEQ(FieldDrugClass, v))
+}
+
+// DrugClassNEQ applies the NEQ predicate on the "drug_class" field.
+func DrugClassNEQ(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldNEQ(FieldDrugClass, v))
+}
+
+// DrugClassIn applies the In predicate on the "drug_class" field.
+func DrugClassIn(vs ...string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldIn(FieldDrugClass, vs...))
+}
+
+// DrugClassNotIn applies the NotIn predicate on the "drug_class" field.
+func DrugClassNotIn(vs ...string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldNotIn(FieldDrugClass, vs...))
+}
+
+// DrugClassGT applies the GT predicate on the "drug_class" field.
+func DrugClassGT(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldGT(FieldDrugClass, v))
+}
+
+// DrugClassGTE applies the GTE predicate on the "drug_class" field.
+func DrugClassGTE(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldGTE(FieldDrugClass, v))
+}
+
+// DrugClassLT applies the LT predicate on the "drug_class" field.
+func DrugClassLT(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldLT(FieldDrugClass, v))
+}
+
+// DrugClassLTE applies the LTE predicate on the "drug_class" field.
+func DrugClassLTE(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldLTE(FieldDrugClass, v))
+}
+
+// DrugClassContains applies the Contains predicate on the "drug_class" field.
+func DrugClassContains(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldContains(FieldDrugClass, v))
+}
+
+// DrugClassHasPrefix applies the HasPrefix predicate on the "drug_class" field.
+func DrugClassHasPrefix(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldHasPrefix(FieldDrugClass, v))
+}
+
+// DrugClassHasSuffix applies the HasSuffix predicate on the "drug_class" field.
+func DrugClassHasSuffix(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldHasSuffix(FieldDrugClass, v))
+}
+
+// DrugClassIsNil applies the IsNil predicate on the "drug_class" field.
+func DrugClassIsNil() predicate.Prescription {
+	return predicate.Prescription(sql.FieldIsNull(FieldDrugClass))
+}
+
+// DrugClassNotNil applies the NotNil predicate on the "drug_class" field.
+func DrugClassNotNil() predicate.Prescription {
+	return predicate.Prescription(sql.FieldNotNull(FieldDrugClass))
+}
+
+// DrugClassEqualFold applies the EqualFold predicate on the "drug_class" field.
+func DrugClassEqualFold(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldEqualFold(FieldDrugClass, v))
+}
+
+// DrugClassContainsFold applies the ContainsFold predicate on the "drug_class" field.
+func DrugClassContainsFold(v string) predicate.Prescription {
+	return predicate.Prescription(sql.FieldContainsFold(FieldDrugClass, v))
+}
+
+// HasEmbeddings applies the HasEdge predicate on the "embeddings" edge.
+func HasEmbeddings() predicate.Prescription {
+	return predicate.Prescription(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, EmbeddingsTable, EmbeddingsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasEmbeddingsWith applies the HasEdge predicate on the "embeddings" edge with a given conditions (other predicates).
+func HasEmbeddingsWith(preds ...predicate.Embedding) predicate.Prescription {
+	return predicate.Prescription(func(s *sql.Selector) {
+		step := newEmbeddingsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Prescription) predicate.Prescription {
 	return predicate.Prescription(sql.AndPredicates(predicates...))
@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/embedding"
 	"github.com/csotherden/prescription-parser/ent/prescription"
 	"github.com/csotherden/prescription-parser/pkg/models"
 	"github.com/google/uuid"
@@ -54,6 +55,62 @@ func (pc *PrescriptionCreate) SetContent(m models.Prescription) *PrescriptionCre
 	return pc
 }
 
+// SetDocumentHash sets the "document_hash" field.
+func (pc *PrescriptionCreate) SetDocumentHash(s string) *PrescriptionCreate {
+	pc.mutation.SetDocumentHash(s)
+	return pc
+}
+
+// SetNillableDocumentHash sets the "document_hash" field if the given value is not nil.
+func (pc *PrescriptionCreate) SetNillableDocumentHash(s *string) *PrescriptionCreate {
+	if s != nil {
+		pc.SetDocumentHash(*s)
+	}
+	return pc
+}
+
+// SetTemplate sets the "template" field.
+func (pc *PrescriptionCreate) SetTemplate(s string) *PrescriptionCreate {
+	pc.mutation.SetTemplate(s)
+	return pc
+}
+
+// SetNillableTemplate sets the "template" field if the given value is not nil.
+func (pc *PrescriptionCreate) SetNillableTemplate(s *string) *PrescriptionCreate {
+	if s != nil {
+		pc.SetTemplate(*s)
+	}
+	return pc
+}
+
+// SetTenant sets the "tenant" field.
+func (pc *PrescriptionCreate) SetTenant(s string) *PrescriptionCreate {
+	pc.mutation.SetTenant(s)
+	return pc
+}
+
+// SetNillableTenant sets the "tenant" field if the given value is not nil.
+func (pc *PrescriptionCreate) SetNillableTenant(s *string) *PrescriptionCreate {
+	if s != nil {
+		pc.SetTenant(*s)
+	}
+	return pc
+}
+
+// SetDrugClass sets the "drug_class" field.
+func (pc *PrescriptionCreate) SetDrugClass(s string) *PrescriptionCreate {
+	pc.mutation.SetDrugClass(s)
+	return pc
+}
+
+// SetNillableDrugClass sets the "drug_class" field if the given value is not nil.
+func (pc *PrescriptionCreate) SetNillableDrugClass(s *string) *PrescriptionCreate {
+	if s != nil {
+		pc.SetDrugClass(*s)
+	}
+	return pc
+}
+
 // SetID sets the "id" field.
 func (pc *PrescriptionCreate) SetID(u uuid.UUID) *PrescriptionCreate {
 	pc.mutation.SetID(u)
@@ -68,6 +125,21 @@ func (pc *PrescriptionCreate) SetNillableID(u *uuid.UUID) *PrescriptionCreate {
 	return pc
 }
 
+// AddEmbeddingIDs adds the "embeddings" edge to the Embedding entity by IDs.
+func (pc *PrescriptionCreate) AddEmbeddingIDs(ids ...uuid.UUID) *PrescriptionCreate {
+	pc.mutation.AddEmbeddingIDs(ids...)
+	return pc
+}
+
+// AddEmbeddings adds the "embeddings" edges to the Embedding entity.
+func (pc *PrescriptionCreate) AddEmbeddings(e ...*Embedding) *PrescriptionCreate {
+	ids := make([]uuid.UUID, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return pc.AddEmbeddingIDs(ids...)
+}
+
 // Mutation returns the PrescriptionMutation object of the builder.
 func (pc *PrescriptionCreate) Mutation() *PrescriptionMutation {
 	return pc.mutation
@@ -178,6 +250,38 @@ func (pc *PrescriptionCreate) createSpec() (*Prescription, *sqlgraph.CreateSpec)
 		_spec.SetField(prescription.FieldContent, field.TypeJSON, value)
 		_node.Content = value
 	}
+	if value, ok := pc.mutation.DocumentHash(); ok {
+		_spec.SetField(prescription.FieldDocumentHash, field.TypeString, value)
+		_node.DocumentHash = value
+	}
+	if value, ok := pc.mutation.Template(); ok {
+		_spec.SetField(prescription.FieldTemplate, field.TypeString, value)
+		_node.Template = value
+	}
+	if value, ok := pc.mutation.Tenant(); ok {
+		_spec.SetField(prescription.FieldTenant, field.TypeString, value)
+		_node.Tenant = value
+	}
+	if value, ok := pc.mutation.DrugClass(); ok {
+		_spec.SetField(prescription.FieldDrugClass, field.TypeString, value)
+		_node.DrugClass = value
+	}
+	if nodes := pc.mutation.EmbeddingsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   prescription.EmbeddingsTable,
+			Columns: []string{prescription.EmbeddingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(embedding.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
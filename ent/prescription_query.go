@@ -4,6 +4,7 @@ package ent
 
 import (
 	"context"
+	"database/sql/driver"
 	"fmt"
 	"math"
 
@@ -11,6 +12,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/embedding"
 	"github.com/csotherden/prescription-parser/ent/predicate"
 	"github.com/csotherden/prescription-parser/ent/prescription"
 	"github.com/google/uuid"
@@ -19,10 +21,11 @@ import (
 // PrescriptionQuery is the builder for querying Prescription entities.
 type PrescriptionQuery struct {
 	config
-	ctx        *QueryContext
-	order      []prescription.OrderOption
-	inters     []Interceptor
-	predicates []predicate.Prescription
+	ctx            *QueryContext
+	order          []prescription.OrderOption
+	inters         []Interceptor
+	predicates     []predicate.Prescription
+	withEmbeddings *EmbeddingQuery
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -59,6 +62,28 @@ func (pq *PrescriptionQuery) Order(o ...prescription.OrderOption) *PrescriptionQ
 	return pq
 }
 
+// QueryEmbeddings chains the current query on the "embeddings" edge.
+func (pq *PrescriptionQuery) QueryEmbeddings() *EmbeddingQuery {
+	query := (&EmbeddingClient{config: pq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := pq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := pq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(prescription.Table, prescription.FieldID, selector),
+			sqlgraph.To(embedding.Table, embedding.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, prescription.EmbeddingsTable, prescription.EmbeddingsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(pq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Prescription entity from the query.
 // Returns a *NotFoundError when no Prescription was found.
 func (pq *PrescriptionQuery) First(ctx context.Context) (*Prescription, error) {
@@ -246,17 +271,29 @@ func (pq *PrescriptionQuery) Clone() *PrescriptionQuery {
 		return nil
 	}
 	return &PrescriptionQuery{
-		config:     pq.config,
-		ctx:        pq.ctx.Clone(),
-		order:      append([]prescription.OrderOption{}, pq.order...),
-		inters:     append([]Interceptor{}, pq.inters...),
-		predicates: append([]predicate.Prescription{}, pq.predicates...),
+		config:         pq.config,
+		ctx:            pq.ctx.Clone(),
+		order:          append([]prescription.OrderOption{}, pq.order...),
+		inters:         append([]Interceptor{}, pq.inters...),
+		predicates:     append([]predicate.Prescription{}, pq.predicates...),
+		withEmbeddings: pq.withEmbeddings.Clone(),
 		// clone intermediate query.
 		sql:  pq.sql.Clone(),
 		path: pq.path,
 	}
 }
 
+// WithEmbeddings tells the query-builder to eager-load the nodes that are connected to
+// the "embeddings" edge. The optional arguments are used to configure the query builder of the edge.
+func (pq *PrescriptionQuery) WithEmbeddings(opts ...func(*EmbeddingQuery)) *PrescriptionQuery {
+	query := (&EmbeddingClient{config: pq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	pq.withEmbeddings = query
+	return pq
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -333,8 +370,11 @@ func (pq *PrescriptionQuery) prepareQuery(ctx context.Context) error {
 
 func (pq *PrescriptionQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Prescription, error) {
 	var (
-		nodes = []*Prescription{}
-		_spec = pq.querySpec()
+		nodes       = []*Prescription{}
+		_spec       = pq.querySpec()
+		loadedTypes = [1]bool{
+			pq.withEmbeddings != nil,
+		}
 	)
 	_spec.ScanValues = func(columns []string) ([]any, error) {
 		return (*Prescription).scanValues(nil, columns)
@@ -342,6 +382,7 @@ func (pq *PrescriptionQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]
 	_spec.Assign = func(columns []string, values []any) error {
 		node := &Prescription{config: pq.config}
 		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
 		return node.assignValues(columns, values)
 	}
 	for i := range hooks {
@@ -353,9 +394,48 @@ func (pq *PrescriptionQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]
 	if len(nodes) == 0 {
 		return nodes, nil
 	}
+	if query := pq.withEmbeddings; query != nil {
+		if err := pq.loadEmbeddings(ctx, query, nodes,
+			func(n *Prescription) { n.Edges.Embeddings = []*Embedding{} },
+			func(n *Prescription, e *Embedding) { n.Edges.Embeddings = append(n.Edges.Embeddings, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
+func (pq *PrescriptionQuery) loadEmbeddings(ctx context.Context, query *EmbeddingQuery, nodes []*Prescription, init func(*Prescription), assign func(*Prescription, *Embedding)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uuid.UUID]*Prescription)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Embedding(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(prescription.EmbeddingsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.embedding_prescription
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "embedding_prescription" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "embedding_prescription" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+
 func (pq *PrescriptionQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := pq.querySpec()
 	_spec.Node.Columns = pq.ctx.Fields
@@ -10,9 +10,11 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/embedding"
 	"github.com/csotherden/prescription-parser/ent/predicate"
 	"github.com/csotherden/prescription-parser/ent/prescription"
 	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/google/uuid"
 )
 
 // PrescriptionUpdate is the builder for updating Prescription entities.
@@ -70,11 +72,127 @@ func (pu *PrescriptionUpdate) SetNillableContent(m *models.Prescription) *Prescr
 	return pu
 }
 
+// SetDocumentHash sets the "document_hash" field.
+func (pu *PrescriptionUpdate) SetDocumentHash(s string) *PrescriptionUpdate {
+	pu.mutation.SetDocumentHash(s)
+	return pu
+}
+
+// SetNillableDocumentHash sets the "document_hash" field if the given value is not nil.
+func (pu *PrescriptionUpdate) SetNillableDocumentHash(s *string) *PrescriptionUpdate {
+	if s != nil {
+		pu.SetDocumentHash(*s)
+	}
+	return pu
+}
+
+// ClearDocumentHash clears the value of the "document_hash" field.
+func (pu *PrescriptionUpdate) ClearDocumentHash() *PrescriptionUpdate {
+	pu.mutation.ClearDocumentHash()
+	return pu
+}
+
+// SetTemplate sets the "template" field.
+func (pu *PrescriptionUpdate) SetTemplate(s string) *PrescriptionUpdate {
+	pu.mutation.SetTemplate(s)
+	return pu
+}
+
+// SetNillableTemplate sets the "template" field if the given value is not nil.
+func (pu *PrescriptionUpdate) SetNillableTemplate(s *string) *PrescriptionUpdate {
+	if s != nil {
+		pu.SetTemplate(*s)
+	}
+	return pu
+}
+
+// ClearTemplate clears the value of the "template" field.
+func (pu *PrescriptionUpdate) ClearTemplate() *PrescriptionUpdate {
+	pu.mutation.ClearTemplate()
+	return pu
+}
+
+// SetTenant sets the "tenant" field.
+func (pu *PrescriptionUpdate) SetTenant(s string) *PrescriptionUpdate {
+	pu.mutation.SetTenant(s)
+	return pu
+}
+
+// SetNillableTenant sets the "tenant" field if the given value is not nil.
+func (pu *PrescriptionUpdate) SetNillableTenant(s *string) *PrescriptionUpdate {
+	if s != nil {
+		pu.SetTenant(*s)
+	}
+	return pu
+}
+
+// ClearTenant clears the value of the "tenant" field.
+func (pu *PrescriptionUpdate) ClearTenant() *PrescriptionUpdate {
+	pu.mutation.ClearTenant()
+	return pu
+}
+
+// SetDrugClass sets the "drug_class" field.
+func (pu *PrescriptionUpdate) SetDrugClass(s string) *PrescriptionUpdate {
+	pu.mutation.SetDrugClass(s)
+	return pu
+}
+
+// SetNillableDrugClass sets the "drug_class" field if the given value is not nil.
+func (pu *PrescriptionUpdate) SetNillableDrugClass(s *string) *PrescriptionUpdate {
+	if s != nil {
+		pu.SetDrugClass(*s)
+	}
+	return pu
+}
+
+// ClearDrugClass clears the value of the "drug_class" field.
+func (pu *PrescriptionUpdate) ClearDrugClass() *PrescriptionUpdate {
+	pu.mutation.ClearDrugClass()
+	return pu
+}
+
+// AddEmbeddingIDs adds the "embeddings" edge to the Embedding entity by IDs.
+func (pu *PrescriptionUpdate) AddEmbeddingIDs(ids ...uuid.UUID) *PrescriptionUpdate {
+	pu.mutation.AddEmbeddingIDs(ids...)
+	return pu
+}
+
+// AddEmbeddings adds the "embeddings" edges to the Embedding entity.
+func (pu *PrescriptionUpdate) AddEmbeddings(e ...*Embedding) *PrescriptionUpdate {
+	ids := make([]uuid.UUID, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return pu.AddEmbeddingIDs(ids...)
+}
+
 // Mutation returns the PrescriptionMutation object of the builder.
 func (pu *PrescriptionUpdate) Mutation() *PrescriptionMutation {
 	return pu.mutation
 }
 
+// ClearEmbeddings clears all "embeddings" edges to the Embedding entity.
+func (pu *PrescriptionUpdate) ClearEmbeddings() *PrescriptionUpdate {
+	pu.mutation.ClearEmbeddings()
+	return pu
+}
+
+// RemoveEmbeddingIDs removes the "embeddings" edge to Embedding entities by IDs.
+func (pu *PrescriptionUpdate) RemoveEmbeddingIDs(ids ...uuid.UUID) *PrescriptionUpdate {
+	pu.mutation.RemoveEmbeddingIDs(ids...)
+	return pu
+}
+
+// RemoveEmbeddings removes "embeddings" edges to Embedding entities.
+func (pu *PrescriptionUpdate) RemoveEmbeddings(e ...*Embedding) *PrescriptionUpdate {
+	ids := make([]uuid.UUID, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return pu.RemoveEmbeddingIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (pu *PrescriptionUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, pu.sqlSave, pu.mutation, pu.hooks)
@@ -120,6 +238,75 @@ func (pu *PrescriptionUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := pu.mutation.Content(); ok {
 		_spec.SetField(prescription.FieldContent, field.TypeJSON, value)
 	}
+	if value, ok := pu.mutation.DocumentHash(); ok {
+		_spec.SetField(prescription.FieldDocumentHash, field.TypeString, value)
+	}
+	if pu.mutation.DocumentHashCleared() {
+		_spec.ClearField(prescription.FieldDocumentHash, field.TypeString)
+	}
+	if value, ok := pu.mutation.Template(); ok {
+		_spec.SetField(prescription.FieldTemplate, field.TypeString, value)
+	}
+	if pu.mutation.TemplateCleared() {
+		_spec.ClearField(prescription.FieldTemplate, field.TypeString)
+	}
+	if value, ok := pu.mutation.Tenant(); ok {
+		_spec.SetField(prescription.FieldTenant, field.TypeString, value)
+	}
+	if pu.mutation.TenantCleared() {
+		_spec.ClearField(prescription.FieldTenant, field.TypeString)
+	}
+	if value, ok := pu.mutation.DrugClass(); ok {
+		_spec.SetField(prescription.FieldDrugClass, field.TypeString, value)
+	}
+	if pu.mutation.DrugClassCleared() {
+		_spec.ClearField(prescription.FieldDrugClass, field.TypeString)
+	}
+	if pu.mutation.EmbeddingsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   prescription.EmbeddingsTable,
+			Columns: []string{prescription.EmbeddingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(embedding.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RemovedEmbeddingsIDs(); len(nodes) > 0 && !pu.mutation.EmbeddingsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   prescription.EmbeddingsTable,
+			Columns: []string{prescription.EmbeddingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(embedding.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.EmbeddingsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   prescription.EmbeddingsTable,
+			Columns: []string{prescription.EmbeddingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(embedding.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{prescription.Label}
@@ -182,11 +369,127 @@ func (puo *PrescriptionUpdateOne) SetNillableContent(m *models.Prescription) *Pr
 	return puo
 }
 
+// SetDocumentHash sets the "document_hash" field.
+func (puo *PrescriptionUpdateOne) SetDocumentHash(s string) *PrescriptionUpdateOne {
+	puo.mutation.SetDocumentHash(s)
+	return puo
+}
+
+// SetNillableDocumentHash sets the "document_hash" field if the given value is not nil.
+func (puo *PrescriptionUpdateOne) SetNillableDocumentHash(s *string) *PrescriptionUpdateOne {
+	if s != nil {
+		puo.SetDocumentHash(*s)
+	}
+	return puo
+}
+
+// ClearDocumentHash clears the value of the "document_hash" field.
+func (puo *PrescriptionUpdateOne) ClearDocumentHash() *PrescriptionUpdateOne {
+	puo.mutation.ClearDocumentHash()
+	return puo
+}
+
+// SetTemplate sets the "template" field.
+func (puo *PrescriptionUpdateOne) SetTemplate(s string) *PrescriptionUpdateOne {
+	puo.mutation.SetTemplate(s)
+	return puo
+}
+
+// SetNillableTemplate sets the "template" field if the given value is not nil.
+func (puo *PrescriptionUpdateOne) SetNillableTemplate(s *string) *PrescriptionUpdateOne {
+	if s != nil {
+		puo.SetTemplate(*s)
+	}
+	return puo
+}
+
+// ClearTemplate clears the value of the "template" field.
+func (puo *PrescriptionUpdateOne) ClearTemplate() *PrescriptionUpdateOne {
+	puo.mutation.ClearTemplate()
+	return puo
+}
+
+// SetTenant sets the "tenant" field.
+func (puo *PrescriptionUpdateOne) SetTenant(s string) *PrescriptionUpdateOne {
+	puo.mutation.SetTenant(s)
+	return puo
+}
+
+// SetNillableTenant sets the "tenant" field if the given value is not nil.
+func (puo *PrescriptionUpdateOne) SetNillableTenant(s *string) *PrescriptionUpdateOne {
+	if s != nil {
+		puo.SetTenant(*s)
+	}
+	return puo
+}
+
+// ClearTenant clears the value of the "tenant" field.
+func (puo *PrescriptionUpdateOne) ClearTenant() *PrescriptionUpdateOne {
+	puo.mutation.ClearTenant()
+	return puo
+}
+
+// SetDrugClass sets the "drug_class" field.
+func (puo *PrescriptionUpdateOne) SetDrugClass(s string) *PrescriptionUpdateOne {
+	puo.mutation.SetDrugClass(s)
+	return puo
+}
+
+// SetNillableDrugClass sets the "drug_class" field if the given value is not nil.
+func (puo *PrescriptionUpdateOne) SetNillableDrugClass(s *string) *PrescriptionUpdateOne {
+	if s != nil {
+		puo.SetDrugClass(*s)
+	}
+	return puo
+}
+
+// ClearDrugClass clears the value of the "drug_class" field.
+func (puo *PrescriptionUpdateOne) ClearDrugClass() *PrescriptionUpdateOne {
+	puo.mutation.ClearDrugClass()
+	return puo
+}
+
+// AddEmbeddingIDs adds the "embeddings" edge to the Embedding entity by IDs.
+func (puo *PrescriptionUpdateOne) AddEmbeddingIDs(ids ...uuid.UUID) *PrescriptionUpdateOne {
+	puo.mutation.AddEmbeddingIDs(ids...)
+	return puo
+}
+
+// AddEmbeddings adds the "embeddings" edges to the Embedding entity.
+func (puo *PrescriptionUpdateOne) AddEmbeddings(e ...*Embedding) *PrescriptionUpdateOne {
+	ids := make([]uuid.UUID, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return puo.AddEmbeddingIDs(ids...)
+}
+
 // Mutation returns the PrescriptionMutation object of the builder.
 func (puo *PrescriptionUpdateOne) Mutation() *PrescriptionMutation {
 	return puo.mutation
 }
 
+// ClearEmbeddings clears all "embeddings" edges to the Embedding entity.
+func (puo *PrescriptionUpdateOne) ClearEmbeddings() *PrescriptionUpdateOne {
+	puo.mutation.ClearEmbeddings()
+	return puo
+}
+
+// RemoveEmbeddingIDs removes the "embeddings" edge to Embedding entities by IDs.
+func (puo *PrescriptionUpdateOne) RemoveEmbeddingIDs(ids ...uuid.UUID) *PrescriptionUpdateOne {
+	puo.mutation.RemoveEmbeddingIDs(ids...)
+	return puo
+}
+
+// RemoveEmbeddings removes "embeddings" edges to Embedding entities.
+func (puo *PrescriptionUpdateOne) RemoveEmbeddings(e ...*Embedding) *PrescriptionUpdateOne {
+	ids := make([]uuid.UUID, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return puo.RemoveEmbeddingIDs(ids...)
+}
+
 // Where appends a list predicates to the PrescriptionUpdate builder.
 func (puo *PrescriptionUpdateOne) Where(ps ...predicate.Prescription) *PrescriptionUpdateOne {
 	puo.mutation.Where(ps...)
@@ -262,6 +565,75 @@ func (puo *PrescriptionUpdateOne) sqlSave(ctx context.Context) (_node *Prescript
 	if value, ok := puo.mutation.Content(); ok {
 		_spec.SetField(prescription.FieldContent, field.TypeJSON, value)
 	}
+	if value, ok := puo.mutation.DocumentHash(); ok {
+		_spec.SetField(prescription.FieldDocumentHash, field.TypeString, value)
+	}
+	if puo.mutation.DocumentHashCleared() {
+		_spec.ClearField(prescription.FieldDocumentHash, field.TypeString)
+	}
+	if value, ok := puo.mutation.Template(); ok {
+		_spec.SetField(prescription.FieldTemplate, field.TypeString, value)
+	}
+	if puo.mutation.TemplateCleared() {
+		_spec.ClearField(prescription.FieldTemplate, field.TypeString)
+	}
+	if value, ok := puo.mutation.Tenant(); ok {
+		_spec.SetField(prescription.FieldTenant, field.TypeString, value)
+	}
+	if puo.mutation.TenantCleared() {
+		_spec.ClearField(prescription.FieldTenant, field.TypeString)
+	}
+	if value, ok := puo.mutation.DrugClass(); ok {
+		_spec.SetField(prescription.FieldDrugClass, field.TypeString, value)
+	}
+	if puo.mutation.DrugClassCleared() {
+		_spec.ClearField(prescription.FieldDrugClass, field.TypeString)
+	}
+	if puo.mutation.EmbeddingsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   prescription.EmbeddingsTable,
+			Columns: []string{prescription.EmbeddingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(embedding.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RemovedEmbeddingsIDs(); len(nodes) > 0 && !puo.mutation.EmbeddingsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   prescription.EmbeddingsTable,
+			Columns: []string{prescription.EmbeddingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(embedding.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.EmbeddingsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   prescription.EmbeddingsTable,
+			Columns: []string{prescription.EmbeddingsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(embedding.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Prescription{config: puo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
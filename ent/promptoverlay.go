@@ -0,0 +1,153 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/csotherden/prescription-parser/ent/promptoverlay"
+	"github.com/google/uuid"
+)
+
+// PromptOverlay is the model entity for the PromptOverlay schema.
+type PromptOverlay struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Template holds the value of the "template" field.
+	Template string `json:"template,omitempty"`
+	// Version holds the value of the "version" field.
+	Version int `json:"version,omitempty"`
+	// Instructions holds the value of the "instructions" field.
+	Instructions string `json:"instructions,omitempty"`
+	// Author holds the value of the "author" field.
+	Author       string `json:"author,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*PromptOverlay) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case promptoverlay.FieldVersion:
+			values[i] = new(sql.NullInt64)
+		case promptoverlay.FieldTemplate, promptoverlay.FieldInstructions, promptoverlay.FieldAuthor:
+			values[i] = new(sql.NullString)
+		case promptoverlay.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case promptoverlay.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the PromptOverlay fields.
+func (po *PromptOverlay) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case promptoverlay.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				po.ID = *value
+			}
+		case promptoverlay.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				po.CreatedAt = value.Time
+			}
+		case promptoverlay.FieldTemplate:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field template", values[i])
+			} else if value.Valid {
+				po.Template = value.String
+			}
+		case promptoverlay.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				po.Version = int(value.Int64)
+			}
+		case promptoverlay.FieldInstructions:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field instructions", values[i])
+			} else if value.Valid {
+				po.Instructions = value.String
+			}
+		case promptoverlay.FieldAuthor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field author", values[i])
+			} else if value.Valid {
+				po.Author = value.String
+			}
+		default:
+			po.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the PromptOverlay.
+// This includes values selected through modifiers, order, etc.
+func (po *PromptOverlay) Value(name string) (ent.Value, error) {
+	return po.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this PromptOverlay.
+// Note that you need to call PromptOverlay.Unwrap() before calling this method if this PromptOverlay
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (po *PromptOverlay) Update() *PromptOverlayUpdateOne {
+	return NewPromptOverlayClient(po.config).UpdateOne(po)
+}
+
+// Unwrap unwraps the PromptOverlay entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (po *PromptOverlay) Unwrap() *PromptOverlay {
+	_tx, ok := po.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: PromptOverlay is not a transactional entity")
+	}
+	po.config.driver = _tx.drv
+	return po
+}
+
+// String implements the fmt.Stringer.
+func (po *PromptOverlay) String() string {
+	var builder strings.Builder
+	builder.WriteString("PromptOverlay(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", po.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(po.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("template=")
+	builder.WriteString(po.Template)
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", po.Version))
+	builder.WriteString(", ")
+	builder.WriteString("instructions=")
+	builder.WriteString(po.Instructions)
+	builder.WriteString(", ")
+	builder.WriteString("author=")
+	builder.WriteString(po.Author)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// PromptOverlays is a parsable slice of PromptOverlay.
+type PromptOverlays []*PromptOverlay
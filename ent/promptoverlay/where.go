@@ -0,0 +1,381 @@
+// Code generated by ent, DO NOT EDIT.
+
+package promptoverlay
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLTE(FieldID, id))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// Template applies equality check predicate on the "template" field. It's identical to TemplateEQ.
+func Template(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldTemplate, v))
+}
+
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v int) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldVersion, v))
+}
+
+// Instructions applies equality check predicate on the "instructions" field. It's identical to InstructionsEQ.
+func Instructions(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldInstructions, v))
+}
+
+// Author applies equality check predicate on the "author" field. It's identical to AuthorEQ.
+func Author(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldAuthor, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// TemplateEQ applies the EQ predicate on the "template" field.
+func TemplateEQ(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldTemplate, v))
+}
+
+// TemplateNEQ applies the NEQ predicate on the "template" field.
+func TemplateNEQ(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNEQ(FieldTemplate, v))
+}
+
+// TemplateIn applies the In predicate on the "template" field.
+func TemplateIn(vs ...string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldIn(FieldTemplate, vs...))
+}
+
+// TemplateNotIn applies the NotIn predicate on the "template" field.
+func TemplateNotIn(vs ...string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNotIn(FieldTemplate, vs...))
+}
+
+// TemplateGT applies the GT predicate on the "template" field.
+func TemplateGT(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGT(FieldTemplate, v))
+}
+
+// TemplateGTE applies the GTE predicate on the "template" field.
+func TemplateGTE(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGTE(FieldTemplate, v))
+}
+
+// TemplateLT applies the LT predicate on the "template" field.
+func TemplateLT(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLT(FieldTemplate, v))
+}
+
+// TemplateLTE applies the LTE predicate on the "template" field.
+func TemplateLTE(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLTE(FieldTemplate, v))
+}
+
+// TemplateContains applies the Contains predicate on the "template" field.
+func TemplateContains(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldContains(FieldTemplate, v))
+}
+
+// TemplateHasPrefix applies the HasPrefix predicate on the "template" field.
+func TemplateHasPrefix(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldHasPrefix(FieldTemplate, v))
+}
+
+// TemplateHasSuffix applies the HasSuffix predicate on the "template" field.
+func TemplateHasSuffix(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldHasSuffix(FieldTemplate, v))
+}
+
+// TemplateEqualFold applies the EqualFold predicate on the "template" field.
+func TemplateEqualFold(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEqualFold(FieldTemplate, v))
+}
+
+// TemplateContainsFold applies the ContainsFold predicate on the "template" field.
+func TemplateContainsFold(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldContainsFold(FieldTemplate, v))
+}
+
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v int) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v int) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...int) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...int) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v int) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v int) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v int) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v int) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLTE(FieldVersion, v))
+}
+
+// InstructionsEQ applies the EQ predicate on the "instructions" field.
+func InstructionsEQ(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldInstructions, v))
+}
+
+// InstructionsNEQ applies the NEQ predicate on the "instructions" field.
+func InstructionsNEQ(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNEQ(FieldInstructions, v))
+}
+
+// InstructionsIn applies the In predicate on the "instructions" field.
+func InstructionsIn(vs ...string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldIn(FieldInstructions, vs...))
+}
+
+// InstructionsNotIn applies the NotIn predicate on the "instructions" field.
+func InstructionsNotIn(vs ...string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNotIn(FieldInstructions, vs...))
+}
+
+// InstructionsGT applies the GT predicate on the "instructions" field.
+func InstructionsGT(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGT(FieldInstructions, v))
+}
+
+// InstructionsGTE applies the GTE predicate on the "instructions" field.
+func InstructionsGTE(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGTE(FieldInstructions, v))
+}
+
+// InstructionsLT applies the LT predicate on the "instructions" field.
+func InstructionsLT(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLT(FieldInstructions, v))
+}
+
+// InstructionsLTE applies the LTE predicate on the "instructions" field.
+func InstructionsLTE(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLTE(FieldInstructions, v))
+}
+
+// InstructionsContains applies the Contains predicate on the "instructions" field.
+func InstructionsContains(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldContains(FieldInstructions, v))
+}
+
+// InstructionsHasPrefix applies the HasPrefix predicate on the "instructions" field.
+func InstructionsHasPrefix(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldHasPrefix(FieldInstructions, v))
+}
+
+// InstructionsHasSuffix applies the HasSuffix predicate on the "instructions" field.
+func InstructionsHasSuffix(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldHasSuffix(FieldInstructions, v))
+}
+
+// InstructionsEqualFold applies the EqualFold predicate on the "instructions" field.
+func InstructionsEqualFold(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEqualFold(FieldInstructions, v))
+}
+
+// InstructionsContainsFold applies the ContainsFold predicate on the "instructions" field.
+func InstructionsContainsFold(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldContainsFold(FieldInstructions, v))
+}
+
+// AuthorEQ applies the EQ predicate on the "author" field.
+func AuthorEQ(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEQ(FieldAuthor, v))
+}
+
+// AuthorNEQ applies the NEQ predicate on the "author" field.
+func AuthorNEQ(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNEQ(FieldAuthor, v))
+}
+
+// AuthorIn applies the In predicate on the "author" field.
+func AuthorIn(vs ...string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldIn(FieldAuthor, vs...))
+}
+
+// AuthorNotIn applies the NotIn predicate on the "author" field.
+func AuthorNotIn(vs ...string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNotIn(FieldAuthor, vs...))
+}
+
+// AuthorGT applies the GT predicate on the "author" field.
+func AuthorGT(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGT(FieldAuthor, v))
+}
+
+// AuthorGTE applies the GTE predicate on the "author" field.
+func AuthorGTE(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldGTE(FieldAuthor, v))
+}
+
+// AuthorLT applies the LT predicate on the "author" field.
+func AuthorLT(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLT(FieldAuthor, v))
+}
+
+// AuthorLTE applies the LTE predicate on the "author" field.
+func AuthorLTE(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldLTE(FieldAuthor, v))
+}
+
+// AuthorContains applies the Contains predicate on the "author" field.
+func AuthorContains(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldContains(FieldAuthor, v))
+}
+
+// AuthorHasPrefix applies the HasPrefix predicate on the "author" field.
+func AuthorHasPrefix(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldHasPrefix(FieldAuthor, v))
+}
+
+// AuthorHasSuffix applies the HasSuffix predicate on the "author" field.
+func AuthorHasSuffix(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldHasSuffix(FieldAuthor, v))
+}
+
+// AuthorIsNil applies the IsNil predicate on the "author" field.
+func AuthorIsNil() predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldIsNull(FieldAuthor))
+}
+
+// AuthorNotNil applies the NotNil predicate on the "author" field.
+func AuthorNotNil() predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldNotNull(FieldAuthor))
+}
+
+// AuthorEqualFold applies the EqualFold predicate on the "author" field.
+func AuthorEqualFold(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldEqualFold(FieldAuthor, v))
+}
+
+// AuthorContainsFold applies the ContainsFold predicate on the "author" field.
+func AuthorContainsFold(v string) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.FieldContainsFold(FieldAuthor, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.PromptOverlay) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.PromptOverlay) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.PromptOverlay) predicate.PromptOverlay {
+	return predicate.PromptOverlay(sql.NotPredicates(p))
+}
@@ -0,0 +1,293 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/promptoverlay"
+	"github.com/google/uuid"
+)
+
+// PromptOverlayCreate is the builder for creating a PromptOverlay entity.
+type PromptOverlayCreate struct {
+	config
+	mutation *PromptOverlayMutation
+	hooks    []Hook
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (poc *PromptOverlayCreate) SetCreatedAt(t time.Time) *PromptOverlayCreate {
+	poc.mutation.SetCreatedAt(t)
+	return poc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (poc *PromptOverlayCreate) SetNillableCreatedAt(t *time.Time) *PromptOverlayCreate {
+	if t != nil {
+		poc.SetCreatedAt(*t)
+	}
+	return poc
+}
+
+// SetTemplate sets the "template" field.
+func (poc *PromptOverlayCreate) SetTemplate(s string) *PromptOverlayCreate {
+	poc.mutation.SetTemplate(s)
+	return poc
+}
+
+// SetVersion sets the "version" field.
+func (poc *PromptOverlayCreate) SetVersion(i int) *PromptOverlayCreate {
+	poc.mutation.SetVersion(i)
+	return poc
+}
+
+// SetInstructions sets the "instructions" field.
+func (poc *PromptOverlayCreate) SetInstructions(s string) *PromptOverlayCreate {
+	poc.mutation.SetInstructions(s)
+	return poc
+}
+
+// SetAuthor sets the "author" field.
+func (poc *PromptOverlayCreate) SetAuthor(s string) *PromptOverlayCreate {
+	poc.mutation.SetAuthor(s)
+	return poc
+}
+
+// SetNillableAuthor sets the "author" field if the given value is not nil.
+func (poc *PromptOverlayCreate) SetNillableAuthor(s *string) *PromptOverlayCreate {
+	if s != nil {
+		poc.SetAuthor(*s)
+	}
+	return poc
+}
+
+// SetID sets the "id" field.
+func (poc *PromptOverlayCreate) SetID(u uuid.UUID) *PromptOverlayCreate {
+	poc.mutation.SetID(u)
+	return poc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (poc *PromptOverlayCreate) SetNillableID(u *uuid.UUID) *PromptOverlayCreate {
+	if u != nil {
+		poc.SetID(*u)
+	}
+	return poc
+}
+
+// Mutation returns the PromptOverlayMutation object of the builder.
+func (poc *PromptOverlayCreate) Mutation() *PromptOverlayMutation {
+	return poc.mutation
+}
+
+// Save creates the PromptOverlay in the database.
+func (poc *PromptOverlayCreate) Save(ctx context.Context) (*PromptOverlay, error) {
+	poc.defaults()
+	return withHooks(ctx, poc.sqlSave, poc.mutation, poc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (poc *PromptOverlayCreate) SaveX(ctx context.Context) *PromptOverlay {
+	v, err := poc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (poc *PromptOverlayCreate) Exec(ctx context.Context) error {
+	_, err := poc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (poc *PromptOverlayCreate) ExecX(ctx context.Context) {
+	if err := poc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (poc *PromptOverlayCreate) defaults() {
+	if _, ok := poc.mutation.CreatedAt(); !ok {
+		v := promptoverlay.DefaultCreatedAt()
+		poc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := poc.mutation.ID(); !ok {
+		v := promptoverlay.DefaultID()
+		poc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (poc *PromptOverlayCreate) check() error {
+	if _, ok := poc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "PromptOverlay.created_at"`)}
+	}
+	if _, ok := poc.mutation.Template(); !ok {
+		return &ValidationError{Name: "template", err: errors.New(`ent: missing required field "PromptOverlay.template"`)}
+	}
+	if v, ok := poc.mutation.Template(); ok {
+		if err := promptoverlay.TemplateValidator(v); err != nil {
+			return &ValidationError{Name: "template", err: fmt.Errorf(`ent: validator failed for field "PromptOverlay.template": %w`, err)}
+		}
+	}
+	if _, ok := poc.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "PromptOverlay.version"`)}
+	}
+	if v, ok := poc.mutation.Version(); ok {
+		if err := promptoverlay.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "PromptOverlay.version": %w`, err)}
+		}
+	}
+	if _, ok := poc.mutation.Instructions(); !ok {
+		return &ValidationError{Name: "instructions", err: errors.New(`ent: missing required field "PromptOverlay.instructions"`)}
+	}
+	return nil
+}
+
+func (poc *PromptOverlayCreate) sqlSave(ctx context.Context) (*PromptOverlay, error) {
+	if err := poc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := poc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, poc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	poc.mutation.id = &_node.ID
+	poc.mutation.done = true
+	return _node, nil
+}
+
+func (poc *PromptOverlayCreate) createSpec() (*PromptOverlay, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PromptOverlay{config: poc.config}
+		_spec = sqlgraph.NewCreateSpec(promptoverlay.Table, sqlgraph.NewFieldSpec(promptoverlay.FieldID, field.TypeUUID))
+	)
+	if id, ok := poc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := poc.mutation.CreatedAt(); ok {
+		_spec.SetField(promptoverlay.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := poc.mutation.Template(); ok {
+		_spec.SetField(promptoverlay.FieldTemplate, field.TypeString, value)
+		_node.Template = value
+	}
+	if value, ok := poc.mutation.Version(); ok {
+		_spec.SetField(promptoverlay.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := poc.mutation.Instructions(); ok {
+		_spec.SetField(promptoverlay.FieldInstructions, field.TypeString, value)
+		_node.Instructions = value
+	}
+	if value, ok := poc.mutation.Author(); ok {
+		_spec.SetField(promptoverlay.FieldAuthor, field.TypeString, value)
+		_node.Author = value
+	}
+	return _node, _spec
+}
+
+// PromptOverlayCreateBulk is the builder for creating many PromptOverlay entities in bulk.
+type PromptOverlayCreateBulk struct {
+	config
+	err      error
+	builders []*PromptOverlayCreate
+}
+
+// Save creates the PromptOverlay entities in the database.
+func (pocb *PromptOverlayCreateBulk) Save(ctx context.Context) ([]*PromptOverlay, error) {
+	if pocb.err != nil {
+		return nil, pocb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(pocb.builders))
+	nodes := make([]*PromptOverlay, len(pocb.builders))
+	mutators := make([]Mutator, len(pocb.builders))
+	for i := range pocb.builders {
+		func(i int, root context.Context) {
+			builder := pocb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PromptOverlayMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, pocb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, pocb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, pocb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pocb *PromptOverlayCreateBulk) SaveX(ctx context.Context) []*PromptOverlay {
+	v, err := pocb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pocb *PromptOverlayCreateBulk) Exec(ctx context.Context) error {
+	_, err := pocb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pocb *PromptOverlayCreateBulk) ExecX(ctx context.Context) {
+	if err := pocb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+	"github.com/csotherden/prescription-parser/ent/promptoverlay"
+)
+
+// PromptOverlayDelete is the builder for deleting a PromptOverlay entity.
+type PromptOverlayDelete struct {
+	config
+	hooks    []Hook
+	mutation *PromptOverlayMutation
+}
+
+// Where appends a list predicates to the PromptOverlayDelete builder.
+func (pod *PromptOverlayDelete) Where(ps ...predicate.PromptOverlay) *PromptOverlayDelete {
+	pod.mutation.Where(ps...)
+	return pod
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (pod *PromptOverlayDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, pod.sqlExec, pod.mutation, pod.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pod *PromptOverlayDelete) ExecX(ctx context.Context) int {
+	n, err := pod.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (pod *PromptOverlayDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(promptoverlay.Table, sqlgraph.NewFieldSpec(promptoverlay.FieldID, field.TypeUUID))
+	if ps := pod.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, pod.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	pod.mutation.done = true
+	return affected, err
+}
+
+// PromptOverlayDeleteOne is the builder for deleting a single PromptOverlay entity.
+type PromptOverlayDeleteOne struct {
+	pod *PromptOverlayDelete
+}
+
+// Where appends a list predicates to the PromptOverlayDelete builder.
+func (podo *PromptOverlayDeleteOne) Where(ps ...predicate.PromptOverlay) *PromptOverlayDeleteOne {
+	podo.pod.mutation.Where(ps...)
+	return podo
+}
+
+// Exec executes the deletion query.
+func (podo *PromptOverlayDeleteOne) Exec(ctx context.Context) error {
+	n, err := podo.pod.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{promptoverlay.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (podo *PromptOverlayDeleteOne) ExecX(ctx context.Context) {
+	if err := podo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,528 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+	"github.com/csotherden/prescription-parser/ent/promptoverlay"
+	"github.com/google/uuid"
+)
+
+// PromptOverlayQuery is the builder for querying PromptOverlay entities.
+type PromptOverlayQuery struct {
+	config
+	ctx        *QueryContext
+	order      []promptoverlay.OrderOption
+	inters     []Interceptor
+	predicates []predicate.PromptOverlay
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the PromptOverlayQuery builder.
+func (poq *PromptOverlayQuery) Where(ps ...predicate.PromptOverlay) *PromptOverlayQuery {
+	poq.predicates = append(poq.predicates, ps...)
+	return poq
+}
+
+// Limit the number of records to be returned by this query.
+func (poq *PromptOverlayQuery) Limit(limit int) *PromptOverlayQuery {
+	poq.ctx.Limit = &limit
+	return poq
+}
+
+// Offset to start from.
+func (poq *PromptOverlayQuery) Offset(offset int) *PromptOverlayQuery {
+	poq.ctx.Offset = &offset
+	return poq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (poq *PromptOverlayQuery) Unique(unique bool) *PromptOverlayQuery {
+	poq.ctx.Unique = &unique
+	return poq
+}
+
+// Order specifies how the records should be ordered.
+func (poq *PromptOverlayQuery) Order(o ...promptoverlay.OrderOption) *PromptOverlayQuery {
+	poq.order = append(poq.order, o...)
+	return poq
+}
+
+// First returns the first PromptOverlay entity from the query.
+// Returns a *NotFoundError when no PromptOverlay was found.
+func (poq *PromptOverlayQuery) First(ctx context.Context) (*PromptOverlay, error) {
+	nodes, err := poq.Limit(1).All(setContextOp(ctx, poq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{promptoverlay.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (poq *PromptOverlayQuery) FirstX(ctx context.Context) *PromptOverlay {
+	node, err := poq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first PromptOverlay ID from the query.
+// Returns a *NotFoundError when no PromptOverlay ID was found.
+func (poq *PromptOverlayQuery) FirstID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
+	if ids, err = poq.Limit(1).IDs(setContextOp(ctx, poq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{promptoverlay.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (poq *PromptOverlayQuery) FirstIDX(ctx context.Context) uuid.UUID {
+	id, err := poq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single PromptOverlay entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one PromptOverlay entity is found.
+// Returns a *NotFoundError when no PromptOverlay entities are found.
+func (poq *PromptOverlayQuery) Only(ctx context.Context) (*PromptOverlay, error) {
+	nodes, err := poq.Limit(2).All(setContextOp(ctx, poq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{promptoverlay.Label}
+	default:
+		return nil, &NotSingularError{promptoverlay.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (poq *PromptOverlayQuery) OnlyX(ctx context.Context) *PromptOverlay {
+	node, err := poq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only PromptOverlay ID in the query.
+// Returns a *NotSingularError when more than one PromptOverlay ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (poq *PromptOverlayQuery) OnlyID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
+	if ids, err = poq.Limit(2).IDs(setContextOp(ctx, poq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{promptoverlay.Label}
+	default:
+		err = &NotSingularError{promptoverlay.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (poq *PromptOverlayQuery) OnlyIDX(ctx context.Context) uuid.UUID {
+	id, err := poq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of PromptOverlays.
+func (poq *PromptOverlayQuery) All(ctx context.Context) ([]*PromptOverlay, error) {
+	ctx = setContextOp(ctx, poq.ctx, ent.OpQueryAll)
+	if err := poq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*PromptOverlay, *PromptOverlayQuery]()
+	return withInterceptors[[]*PromptOverlay](ctx, poq, qr, poq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (poq *PromptOverlayQuery) AllX(ctx context.Context) []*PromptOverlay {
+	nodes, err := poq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of PromptOverlay IDs.
+func (poq *PromptOverlayQuery) IDs(ctx context.Context) (ids []uuid.UUID, err error) {
+	if poq.ctx.Unique == nil && poq.path != nil {
+		poq.Unique(true)
+	}
+	ctx = setContextOp(ctx, poq.ctx, ent.OpQueryIDs)
+	if err = poq.Select(promptoverlay.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (poq *PromptOverlayQuery) IDsX(ctx context.Context) []uuid.UUID {
+	ids, err := poq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (poq *PromptOverlayQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, poq.ctx, ent.OpQueryCount)
+	if err := poq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, poq, querierCount[*PromptOverlayQuery](), poq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (poq *PromptOverlayQuery) CountX(ctx context.Context) int {
+	count, err := poq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (poq *PromptOverlayQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, poq.ctx, ent.OpQueryExist)
+	switch _, err := poq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (poq *PromptOverlayQuery) ExistX(ctx context.Context) bool {
+	exist, err := poq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the PromptOverlayQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (poq *PromptOverlayQuery) Clone() *PromptOverlayQuery {
+	if poq == nil {
+		return nil
+	}
+	return &PromptOverlayQuery{
+		config:     poq.config,
+		ctx:        poq.ctx.Clone(),
+		order:      append([]promptoverlay.OrderOption{}, poq.order...),
+		inters:     append([]Interceptor{}, poq.inters...),
+		predicates: append([]predicate.PromptOverlay{}, poq.predicates...),
+		// clone intermediate query.
+		sql:  poq.sql.Clone(),
+		path: poq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		CreatedAt time.Time `json:"created_at,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.PromptOverlay.Query().
+//		GroupBy(promptoverlay.FieldCreatedAt).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (poq *PromptOverlayQuery) GroupBy(field string, fields ...string) *PromptOverlayGroupBy {
+	poq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &PromptOverlayGroupBy{build: poq}
+	grbuild.flds = &poq.ctx.Fields
+	grbuild.label = promptoverlay.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		CreatedAt time.Time `json:"created_at,omitempty"`
+//	}
+//
+//	client.PromptOverlay.Query().
+//		Select(promptoverlay.FieldCreatedAt).
+//		Scan(ctx, &v)
+func (poq *PromptOverlayQuery) Select(fields ...string) *PromptOverlaySelect {
+	poq.ctx.Fields = append(poq.ctx.Fields, fields...)
+	sbuild := &PromptOverlaySelect{PromptOverlayQuery: poq}
+	sbuild.label = promptoverlay.Label
+	sbuild.flds, sbuild.scan = &poq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a PromptOverlaySelect configured with the given aggregations.
+func (poq *PromptOverlayQuery) Aggregate(fns ...AggregateFunc) *PromptOverlaySelect {
+	return poq.Select().Aggregate(fns...)
+}
+
+func (poq *PromptOverlayQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range poq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, poq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range poq.ctx.Fields {
+		if !promptoverlay.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if poq.path != nil {
+		prev, err := poq.path(ctx)
+		if err != nil {
+			return err
+		}
+		poq.sql = prev
+	}
+	return nil
+}
+
+func (poq *PromptOverlayQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*PromptOverlay, error) {
+	var (
+		nodes = []*PromptOverlay{}
+		_spec = poq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*PromptOverlay).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &PromptOverlay{config: poq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, poq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (poq *PromptOverlayQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := poq.querySpec()
+	_spec.Node.Columns = poq.ctx.Fields
+	if len(poq.ctx.Fields) > 0 {
+		_spec.Unique = poq.ctx.Unique != nil && *poq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, poq.driver, _spec)
+}
+
+func (poq *PromptOverlayQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(promptoverlay.Table, promptoverlay.Columns, sqlgraph.NewFieldSpec(promptoverlay.FieldID, field.TypeUUID))
+	_spec.From = poq.sql
+	if unique := poq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if poq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := poq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, promptoverlay.FieldID)
+		for i := range fields {
+			if fields[i] != promptoverlay.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := poq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := poq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := poq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := poq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (poq *PromptOverlayQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(poq.driver.Dialect())
+	t1 := builder.Table(promptoverlay.Table)
+	columns := poq.ctx.Fields
+	if len(columns) == 0 {
+		columns = promptoverlay.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if poq.sql != nil {
+		selector = poq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if poq.ctx.Unique != nil && *poq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range poq.predicates {
+		p(selector)
+	}
+	for _, p := range poq.order {
+		p(selector)
+	}
+	if offset := poq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := poq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// PromptOverlayGroupBy is the group-by builder for PromptOverlay entities.
+type PromptOverlayGroupBy struct {
+	selector
+	build *PromptOverlayQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (pogb *PromptOverlayGroupBy) Aggregate(fns ...AggregateFunc) *PromptOverlayGroupBy {
+	pogb.fns = append(pogb.fns, fns...)
+	return pogb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (pogb *PromptOverlayGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, pogb.build.ctx, ent.OpQueryGroupBy)
+	if err := pogb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*PromptOverlayQuery, *PromptOverlayGroupBy](ctx, pogb.build, pogb, pogb.build.inters, v)
+}
+
+func (pogb *PromptOverlayGroupBy) sqlScan(ctx context.Context, root *PromptOverlayQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(pogb.fns))
+	for _, fn := range pogb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*pogb.flds)+len(pogb.fns))
+		for _, f := range *pogb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*pogb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := pogb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// PromptOverlaySelect is the builder for selecting fields of PromptOverlay entities.
+type PromptOverlaySelect struct {
+	*PromptOverlayQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (pos *PromptOverlaySelect) Aggregate(fns ...AggregateFunc) *PromptOverlaySelect {
+	pos.fns = append(pos.fns, fns...)
+	return pos
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (pos *PromptOverlaySelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, pos.ctx, ent.OpQuerySelect)
+	if err := pos.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*PromptOverlayQuery, *PromptOverlaySelect](ctx, pos.PromptOverlayQuery, pos, pos.inters, v)
+}
+
+func (pos *PromptOverlaySelect) sqlScan(ctx context.Context, root *PromptOverlayQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(pos.fns))
+	for _, fn := range pos.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*pos.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := pos.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
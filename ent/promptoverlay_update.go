@@ -0,0 +1,181 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+	"github.com/csotherden/prescription-parser/ent/promptoverlay"
+)
+
+// PromptOverlayUpdate is the builder for updating PromptOverlay entities.
+type PromptOverlayUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PromptOverlayMutation
+}
+
+// Where appends a list predicates to the PromptOverlayUpdate builder.
+func (pou *PromptOverlayUpdate) Where(ps ...predicate.PromptOverlay) *PromptOverlayUpdate {
+	pou.mutation.Where(ps...)
+	return pou
+}
+
+// Mutation returns the PromptOverlayMutation object of the builder.
+func (pou *PromptOverlayUpdate) Mutation() *PromptOverlayMutation {
+	return pou.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (pou *PromptOverlayUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, pou.sqlSave, pou.mutation, pou.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pou *PromptOverlayUpdate) SaveX(ctx context.Context) int {
+	affected, err := pou.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (pou *PromptOverlayUpdate) Exec(ctx context.Context) error {
+	_, err := pou.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pou *PromptOverlayUpdate) ExecX(ctx context.Context) {
+	if err := pou.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (pou *PromptOverlayUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(promptoverlay.Table, promptoverlay.Columns, sqlgraph.NewFieldSpec(promptoverlay.FieldID, field.TypeUUID))
+	if ps := pou.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if pou.mutation.AuthorCleared() {
+		_spec.ClearField(promptoverlay.FieldAuthor, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, pou.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{promptoverlay.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	pou.mutation.done = true
+	return n, nil
+}
+
+// PromptOverlayUpdateOne is the builder for updating a single PromptOverlay entity.
+type PromptOverlayUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PromptOverlayMutation
+}
+
+// Mutation returns the PromptOverlayMutation object of the builder.
+func (pouo *PromptOverlayUpdateOne) Mutation() *PromptOverlayMutation {
+	return pouo.mutation
+}
+
+// Where appends a list predicates to the PromptOverlayUpdate builder.
+func (pouo *PromptOverlayUpdateOne) Where(ps ...predicate.PromptOverlay) *PromptOverlayUpdateOne {
+	pouo.mutation.Where(ps...)
+	return pouo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (pouo *PromptOverlayUpdateOne) Select(field string, fields ...string) *PromptOverlayUpdateOne {
+	pouo.fields = append([]string{field}, fields...)
+	return pouo
+}
+
+// Save executes the query and returns the updated PromptOverlay entity.
+func (pouo *PromptOverlayUpdateOne) Save(ctx context.Context) (*PromptOverlay, error) {
+	return withHooks(ctx, pouo.sqlSave, pouo.mutation, pouo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pouo *PromptOverlayUpdateOne) SaveX(ctx context.Context) *PromptOverlay {
+	node, err := pouo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (pouo *PromptOverlayUpdateOne) Exec(ctx context.Context) error {
+	_, err := pouo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pouo *PromptOverlayUpdateOne) ExecX(ctx context.Context) {
+	if err := pouo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (pouo *PromptOverlayUpdateOne) sqlSave(ctx context.Context) (_node *PromptOverlay, err error) {
+	_spec := sqlgraph.NewUpdateSpec(promptoverlay.Table, promptoverlay.Columns, sqlgraph.NewFieldSpec(promptoverlay.FieldID, field.TypeUUID))
+	id, ok := pouo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PromptOverlay.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := pouo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, promptoverlay.FieldID)
+		for _, f := range fields {
+			if !promptoverlay.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != promptoverlay.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := pouo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if pouo.mutation.AuthorCleared() {
+		_spec.ClearField(promptoverlay.FieldAuthor, field.TypeString)
+	}
+	_node = &PromptOverlay{config: pouo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, pouo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{promptoverlay.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	pouo.mutation.done = true
+	return _node, nil
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/csotherden/prescription-parser/ent/embedding"
+	"github.com/csotherden/prescription-parser/ent/parseresult"
 	"github.com/csotherden/prescription-parser/ent/prescription"
+	"github.com/csotherden/prescription-parser/ent/promptoverlay"
 	"github.com/csotherden/prescription-parser/ent/schema"
 	"github.com/google/uuid"
 )
@@ -17,10 +19,39 @@ import (
 func init() {
 	embeddingFields := schema.Embedding{}.Fields()
 	_ = embeddingFields
+	// embeddingDescModel is the schema descriptor for model field.
+	embeddingDescModel := embeddingFields[2].Descriptor()
+	// embedding.DefaultModel holds the default value on creation for the model field.
+	embedding.DefaultModel = embeddingDescModel.Default.(string)
+	// embeddingDescVersion is the schema descriptor for version field.
+	embeddingDescVersion := embeddingFields[3].Descriptor()
+	// embedding.DefaultVersion holds the default value on creation for the version field.
+	embedding.DefaultVersion = embeddingDescVersion.Default.(string)
+	// embeddingDescDimensions is the schema descriptor for dimensions field.
+	embeddingDescDimensions := embeddingFields[4].Descriptor()
+	// embedding.DefaultDimensions holds the default value on creation for the dimensions field.
+	embedding.DefaultDimensions = embeddingDescDimensions.Default.(int)
+	// embeddingDescKind is the schema descriptor for kind field.
+	embeddingDescKind := embeddingFields[5].Descriptor()
+	// embedding.DefaultKind holds the default value on creation for the kind field.
+	embedding.DefaultKind = embeddingDescKind.Default.(string)
 	// embeddingDescID is the schema descriptor for id field.
 	embeddingDescID := embeddingFields[0].Descriptor()
 	// embedding.DefaultID holds the default value on creation for the id field.
 	embedding.DefaultID = embeddingDescID.Default.(func() uuid.UUID)
+	parseresultMixin := schema.ParseResult{}.Mixin()
+	parseresultMixinFields0 := parseresultMixin[0].Fields()
+	_ = parseresultMixinFields0
+	parseresultFields := schema.ParseResult{}.Fields()
+	_ = parseresultFields
+	// parseresultDescCreatedAt is the schema descriptor for created_at field.
+	parseresultDescCreatedAt := parseresultMixinFields0[0].Descriptor()
+	// parseresult.DefaultCreatedAt holds the default value on creation for the created_at field.
+	parseresult.DefaultCreatedAt = parseresultDescCreatedAt.Default.(func() time.Time)
+	// parseresultDescBlocked is the schema descriptor for blocked field.
+	parseresultDescBlocked := parseresultFields[4].Descriptor()
+	// parseresult.DefaultBlocked holds the default value on creation for the blocked field.
+	parseresult.DefaultBlocked = parseresultDescBlocked.Default.(bool)
 	prescriptionMixin := schema.Prescription{}.Mixin()
 	prescriptionMixinFields0 := prescriptionMixin[0].Fields()
 	_ = prescriptionMixinFields0
@@ -34,4 +65,25 @@ func init() {
 	prescriptionDescID := prescriptionFields[0].Descriptor()
 	// prescription.DefaultID holds the default value on creation for the id field.
 	prescription.DefaultID = prescriptionDescID.Default.(func() uuid.UUID)
+	promptoverlayMixin := schema.PromptOverlay{}.Mixin()
+	promptoverlayMixinFields0 := promptoverlayMixin[0].Fields()
+	_ = promptoverlayMixinFields0
+	promptoverlayFields := schema.PromptOverlay{}.Fields()
+	_ = promptoverlayFields
+	// promptoverlayDescCreatedAt is the schema descriptor for created_at field.
+	promptoverlayDescCreatedAt := promptoverlayMixinFields0[0].Descriptor()
+	// promptoverlay.DefaultCreatedAt holds the default value on creation for the created_at field.
+	promptoverlay.DefaultCreatedAt = promptoverlayDescCreatedAt.Default.(func() time.Time)
+	// promptoverlayDescTemplate is the schema descriptor for template field.
+	promptoverlayDescTemplate := promptoverlayFields[1].Descriptor()
+	// promptoverlay.TemplateValidator is a validator for the "template" field. It is called by the builders before save.
+	promptoverlay.TemplateValidator = promptoverlayDescTemplate.Validators[0].(func(string) error)
+	// promptoverlayDescVersion is the schema descriptor for version field.
+	promptoverlayDescVersion := promptoverlayFields[2].Descriptor()
+	// promptoverlay.VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	promptoverlay.VersionValidator = promptoverlayDescVersion.Validators[0].(func(int) error)
+	// promptoverlayDescID is the schema descriptor for id field.
+	promptoverlayDescID := promptoverlayFields[0].Descriptor()
+	// promptoverlay.DefaultID holds the default value on creation for the id field.
+	promptoverlay.DefaultID = promptoverlayDescID.Default.(func() uuid.UUID)
 }
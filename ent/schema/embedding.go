@@ -3,7 +3,6 @@ package schema
 import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
-	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
@@ -22,10 +21,27 @@ func (Embedding) Fields() []ent.Field {
 		field.UUID("id", uuid.UUID{}).
 			Default(uuid.New).
 			Immutable(),
+		// The column has no fixed dimension so that models with different dimensions can share
+		// it. Similarity search uses a partial HNSW index per model, created by the datastore.
 		field.Other("embedding", pgvector.Vector{}).
 			SchemaType(map[string]string{
-				dialect.Postgres: "vector(1536)",
+				dialect.Postgres: "vector",
 			}),
+		// Embedding model that produced the vector. Rows created before models were recorded
+		// have an empty model and are not searched until they are re-embedded.
+		field.String("model").
+			Default(""),
+		// Version of the text embedded for a prescription, see parser.EmbeddingVersion.
+		field.String("version").
+			Default(""),
+		// Number of dimensions of the vector. Rows created before models were recorded were all
+		// 1536-dimensional.
+		field.Int("dimensions").
+			Default(1536),
+		// What is embedded, see models.EmbeddingKindContent. Rows created before kinds were
+		// recorded are all content embeddings.
+		field.String("kind").
+			Default("content"),
 	}
 }
 
@@ -36,12 +52,12 @@ func (Embedding) Edges() []ent.Edge {
 	}
 }
 
+// Indexes of the Embedding.
 func (Embedding) Indexes() []ent.Index {
 	return []ent.Index{
-		index.Fields("embedding").
-			Annotations(
-				entsql.IndexType("hnsw"),
-				entsql.OpClass("vector_l2_ops"),
-			),
+		// A prescription has at most one embedding per model and version.
+		index.Fields("model", "version").
+			Edges("prescription").
+			Unique(),
 	}
 }
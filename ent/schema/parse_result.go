@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/google/uuid"
+)
+
+// ParseResult holds the schema definition for the ParseResult entity.
+// A parse result is the persisted outcome of a completed prescription parsing job.
+type ParseResult struct {
+	ent.Schema
+}
+
+// Fields of the ParseResult.
+func (ParseResult) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Immutable(),
+		field.String("status"),
+		field.JSON("content", models.Prescription{}),
+		field.JSON("validation", []models.ValidationIssue{}).
+			Optional(),
+		field.Bool("blocked").
+			Default(false),
+		field.JSON("verification", []models.FieldChange{}).
+			Optional(),
+		field.JSON("origins", map[string]string{}).
+			Optional(),
+		field.JSON("prescriptions", []models.DocumentPrescription{}).
+			Optional(),
+		field.JSON("attributes", map[string]string{}).
+			Optional(),
+		field.Time("started_at"),
+		field.Time("completed_at"),
+		field.JSON("review", &models.Review{}).
+			Optional(),
+	}
+}
+
+// Edges of the ParseResult.
+func (ParseResult) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the ParseResult.
+func (ParseResult) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("completed_at"),
+	}
+}
+
+// Mixin of the ParseResult
+func (ParseResult) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
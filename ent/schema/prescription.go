@@ -2,6 +2,7 @@ package schema
 
 import (
 	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"github.com/csotherden/prescription-parser/pkg/models"
 	"github.com/google/uuid"
@@ -21,12 +22,25 @@ func (Prescription) Fields() []ent.Field {
 		field.String("file_id"),
 		field.String("mime_type"),
 		field.JSON("content", models.Prescription{}),
+		// SHA-256 of the original document in the blob store, empty for samples saved without one.
+		field.String("document_hash").
+			Optional(),
+		// Sample metadata that retrieval can be limited to, empty if unknown.
+		field.String("template").
+			Optional(),
+		field.String("tenant").
+			Optional(),
+		field.String("drug_class").
+			Optional(),
 	}
 }
 
 // Edges of the Prescription.
 func (Prescription) Edges() []ent.Edge {
-	return nil
+	return []ent.Edge{
+		edge.From("embeddings", Embedding.Type).
+			Ref("prescription"),
+	}
 }
 
 // Mixin of the Prescription
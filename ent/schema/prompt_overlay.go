@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// PromptOverlay holds the schema definition for the PromptOverlay entity.
+// A prompt overlay is a version of the instructions appended to the system prompt for documents
+// identified as a form template. Versions are never changed; the latest one is applied.
+type PromptOverlay struct {
+	ent.Schema
+}
+
+// Fields of the PromptOverlay.
+func (PromptOverlay) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.String("template").
+			NotEmpty().
+			Immutable(),
+		field.Int("version").
+			Positive().
+			Immutable(),
+		// Empty instructions turn the overlay off while keeping its history.
+		field.Text("instructions").
+			Immutable(),
+		field.String("author").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Edges of the PromptOverlay.
+func (PromptOverlay) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the PromptOverlay.
+func (PromptOverlay) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("template", "version").
+			Unique(),
+	}
+}
+
+// Mixin of the PromptOverlay
+func (PromptOverlay) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
@@ -14,8 +14,12 @@ type Tx struct {
 	config
 	// Embedding is the client for interacting with the Embedding builders.
 	Embedding *EmbeddingClient
+	// ParseResult is the client for interacting with the ParseResult builders.
+	ParseResult *ParseResultClient
 	// Prescription is the client for interacting with the Prescription builders.
 	Prescription *PrescriptionClient
+	// PromptOverlay is the client for interacting with the PromptOverlay builders.
+	PromptOverlay *PromptOverlayClient
 
 	// lazily loaded.
 	client     *Client
@@ -148,7 +152,9 @@ func (tx *Tx) Client() *Client {
 
 func (tx *Tx) init() {
 	tx.Embedding = NewEmbeddingClient(tx.config)
+	tx.ParseResult = NewParseResultClient(tx.config)
 	tx.Prescription = NewPrescriptionClient(tx.config)
+	tx.PromptOverlay = NewPromptOverlayClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
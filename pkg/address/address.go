@@ -0,0 +1,109 @@
+// Package address standardizes the postal addresses on parsed prescriptions without calling
+// external services. State names are converted to two-letter codes, ZIP codes are validated
+// and checked against the state using a bundled ZIP prefix table, and street lines are
+// abbreviated following USPS Publication 28.
+package address
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// Standardizer normalizes the patient and prescriber office addresses of a prescription.
+type Standardizer struct{}
+
+// NewStandardizer creates an address standardizer.
+func NewStandardizer() *Standardizer {
+	return &Standardizer{}
+}
+
+// Process standardizes the patient and prescriber office addresses in place and reports
+// values that could not be standardized or are inconsistent.
+func (s *Standardizer) Process(ctx context.Context, rx *models.Prescription) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+	issues = append(issues, Standardize("patient.address", &rx.Patient.Address)...)
+	issues = append(issues, Standardize("prescriber.office.address", &rx.Prescriber.Office.Address)...)
+	return issues
+}
+
+// Standardize normalizes an address in place. Issues are reported against fields under
+// the given path prefix. Empty addresses are left unchanged.
+func Standardize(path string, addr *models.Address) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+
+	addr.Street = StandardizeStreet(addr.Street)
+	addr.City = strings.Join(splitWords(addr.City), " ")
+
+	if addr.State != "" {
+		state, ok := NormalizeState(addr.State)
+		if ok {
+			addr.State = state
+		} else {
+			issues = append(issues, models.ValidationIssue{
+				Field:    path + ".state",
+				Code:     "address_state_unknown",
+				Severity: models.SeverityWarning,
+				Message:  fmt.Sprintf("state %q is not a recognized US state or territory", addr.State),
+			})
+		}
+	}
+
+	if addr.Zip == "" {
+		return issues
+	}
+
+	zip, ok := NormalizeZip(addr.Zip)
+	if !ok {
+		return append(issues, models.ValidationIssue{
+			Field:    path + ".zip",
+			Code:     "address_zip_invalid",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("ZIP code %q is not a valid 5-digit or ZIP+4 code", addr.Zip),
+		})
+	}
+	addr.Zip = zip
+
+	states := ZipStates(zip)
+	switch {
+	case states == nil:
+		issues = append(issues, models.ValidationIssue{
+			Field:    path + ".zip",
+			Code:     "address_zip_invalid",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("ZIP code %q uses an unassigned prefix", zip),
+		})
+	case validStates[addr.State] && !slices.Contains(states, addr.State):
+		issues = append(issues, models.ValidationIssue{
+			Field:    path + ".zip",
+			Code:     "address_zip_state_mismatch",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("ZIP code %s is in %s, not %s", zip, strings.Join(states, "/"), addr.State),
+		})
+	}
+
+	return issues
+}
+
+// NormalizeState returns the two-letter USPS code for a state name, code or common abbreviation.
+// It reports false if the value is not recognized.
+func NormalizeState(state string) (string, bool) {
+	name := strings.Join(splitWords(state), " ")
+	name = strings.TrimPrefix(name, "STATE OF ")
+	name = strings.TrimPrefix(name, "COMMONWEALTH OF ")
+
+	if validStates[name] {
+		return name, true
+	}
+	if code, ok := stateCodes[name]; ok {
+		return code, true
+	}
+	if code, ok := stateAbbreviations[name]; ok {
+		return code, true
+	}
+
+	return state, false
+}
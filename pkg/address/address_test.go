@@ -0,0 +1,163 @@
+package address
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func TestStandardizeStreet(t *testing.T) {
+	tests := []struct {
+		street string
+		want   string
+	}{
+		{street: "123 North Main Street", want: "123 N MAIN ST"},
+		{street: "123 Main St.", want: "123 MAIN ST"},
+		{street: "450 Park Avenue, Suite 200", want: "450 PARK AVE STE 200"},
+		{street: "10 North Street", want: "10 NORTH ST"},
+		{street: "77 Massachusetts Ave NW", want: "77 MASSACHUSETTS AVE NW"},
+		{street: "1600 Pennsylvania Avenue Northwest", want: "1600 PENNSYLVANIA AVE NW"},
+		{street: "55 Elm Boulevard Apartment 4B", want: "55 ELM BLVD APT 4B"},
+		{street: "8 Oak Ln Apt #12", want: "8 OAK LN APT 12"},
+		{street: "8 Oak Lane #12", want: "8 OAK LN # 12"},
+		{street: "200 Medical Center Drive, Building C, Floor 3", want: "200 MEDICAL CENTER DR BLDG C FL 3"},
+		{street: "1 O'Neil Circle", want: "1 ONEIL CIR"},
+		{street: "123 Front Street", want: "123 FRONT ST"},
+		{street: "45 Upper Valley Road", want: "45 UPPER VALLEY RD"},
+		{street: "900 Office Park Drive", want: "900 OFFICE PARK DR"},
+		{street: "12 Lower Main St", want: "12 LOWER MAIN ST"},
+		{street: "12 Main St Rear", want: "12 MAIN ST REAR"},
+		{street: "30 Elm Ave N Upper", want: "30 ELM AVE N UPPR"},
+		{street: "7 Harbor View Lobby", want: "7 HARBOR VW LBBY"},
+		{street: "5 Suite Road", want: "5 SUITE RD"},
+		{street: "5 Oak Road Suite A", want: "5 OAK RD STE A"},
+		{street: "101 Calle Bayamón Apt 3", want: "101 CALLE BAYAMON APT 3"},
+		{street: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.street, func(t *testing.T) {
+			if got := StandardizeStreet(tt.street); got != tt.want {
+				t.Errorf("StandardizeStreet(%q) = %q, want %q", tt.street, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeState(t *testing.T) {
+	tests := []struct {
+		state  string
+		want   string
+		wantOK bool
+	}{
+		{state: "New York", want: "NY", wantOK: true},
+		{state: "ny", want: "NY", wantOK: true},
+		{state: "Calif.", want: "CA", wantOK: true},
+		{state: "Commonwealth of Massachusetts", want: "MA", wantOK: true},
+		{state: "D.C.", want: "DC", wantOK: true},
+		{state: "Puerto Rico", want: "PR", wantOK: true},
+		{state: "Ontario", want: "Ontario", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			got, ok := NormalizeState(tt.state)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizeState(%q) = %q, %v, want %q, %v", tt.state, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNormalizeZip(t *testing.T) {
+	tests := []struct {
+		zip    string
+		want   string
+		wantOK bool
+	}{
+		{zip: "10001", want: "10001", wantOK: true},
+		{zip: "100011234", want: "10001-1234", wantOK: true},
+		{zip: "10001 1234", want: "10001-1234", wantOK: true},
+		{zip: "10001-1234", want: "10001-1234", wantOK: true},
+		{zip: "1000", want: "1000", wantOK: false},
+		{zip: "K1A 0B1", want: "K1A 0B1", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.zip, func(t *testing.T) {
+			got, ok := NormalizeZip(tt.zip)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizeZip(%q) = %q, %v, want %q, %v", tt.zip, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestZipStates(t *testing.T) {
+	tests := []struct {
+		zip  string
+		want string
+	}{
+		{zip: "02138", want: "MA"},
+		{zip: "05501", want: "MA"},
+		{zip: "05401", want: "VT"},
+		{zip: "20500", want: "DC"},
+		{zip: "73301", want: "TX"},
+		{zip: "96950", want: "GU,MP"},
+		{zip: "00100", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.zip, func(t *testing.T) {
+			if got := strings.Join(ZipStates(tt.zip), ","); got != tt.want {
+				t.Errorf("ZipStates(%q) = %q, want %q", tt.zip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandardizerProcess(t *testing.T) {
+	rx := models.Prescription{
+		Patient: models.Patient{
+			Address: models.Address{Street: "12 Maple Avenue, Apt. 3", City: "Brooklyn", State: "New York", Zip: "112011234"},
+		},
+		Prescriber: models.Prescriber{
+			Office: models.PrescriberOffice{
+				Address: models.Address{Street: "1 Hospital Plaza", City: "Boston", State: "Massachusetts", Zip: "10001"},
+			},
+		},
+	}
+
+	issues := NewStandardizer().Process(context.Background(), &rx)
+
+	want := models.Address{Street: "12 MAPLE AVE APT 3", City: "BROOKLYN", State: "NY", Zip: "11201-1234"}
+	if rx.Patient.Address != want {
+		t.Errorf("Expected patient address %+v, got %+v", want, rx.Patient.Address)
+	}
+
+	if len(issues) != 1 || issues[0].Code != "address_zip_state_mismatch" || issues[0].Field != "prescriber.office.address.zip" {
+		t.Errorf("Expected a ZIP/state mismatch on the prescriber office, got %+v", issues)
+	}
+
+	rx = models.Prescription{
+		Patient: models.Patient{Address: models.Address{State: "Narnia", Zip: "ABCDE"}},
+	}
+	var codes []string
+	for _, issue := range NewStandardizer().Process(context.Background(), &rx) {
+		codes = append(codes, issue.Code)
+	}
+	if strings.Join(codes, ",") != "address_state_unknown,address_zip_invalid" {
+		t.Errorf("Unexpected issues %v", codes)
+	}
+
+	rx = models.Prescription{
+		Patient: models.Patient{Address: models.Address{Street: "5 Calle Cataño", City: "Bayamón", State: "Puerto Rico", Zip: "00961"}},
+	}
+	issues = NewStandardizer().Process(context.Background(), &rx)
+	want = models.Address{Street: "5 CALLE CATANO", City: "BAYAMON", State: "PR", Zip: "00961"}
+	if rx.Patient.Address != want || len(issues) != 0 {
+		t.Errorf("Expected Puerto Rico address %+v without issues, got %+v with %+v", want, rx.Patient.Address, issues)
+	}
+}
@@ -0,0 +1,89 @@
+package address
+
+// stateCodes maps upper-case state, district, territory and military mail region names to
+// their two-letter USPS codes.
+var stateCodes = map[string]string{
+	"ALABAMA":                      "AL",
+	"ALASKA":                       "AK",
+	"ARIZONA":                      "AZ",
+	"ARKANSAS":                     "AR",
+	"CALIFORNIA":                   "CA",
+	"COLORADO":                     "CO",
+	"CONNECTICUT":                  "CT",
+	"DELAWARE":                     "DE",
+	"DISTRICT OF COLUMBIA":         "DC",
+	"FLORIDA":                      "FL",
+	"GEORGIA":                      "GA",
+	"HAWAII":                       "HI",
+	"IDAHO":                        "ID",
+	"ILLINOIS":                     "IL",
+	"INDIANA":                      "IN",
+	"IOWA":                         "IA",
+	"KANSAS":                       "KS",
+	"KENTUCKY":                     "KY",
+	"LOUISIANA":                    "LA",
+	"MAINE":                        "ME",
+	"MARYLAND":                     "MD",
+	"MASSACHUSETTS":                "MA",
+	"MICHIGAN":                     "MI",
+	"MINNESOTA":                    "MN",
+	"MISSISSIPPI":                  "MS",
+	"MISSOURI":                     "MO",
+	"MONTANA":                      "MT",
+	"NEBRASKA":                     "NE",
+	"NEVADA":                       "NV",
+	"NEW HAMPSHIRE":                "NH",
+	"NEW JERSEY":                   "NJ",
+	"NEW MEXICO":                   "NM",
+	"NEW YORK":                     "NY",
+	"NORTH CAROLINA":               "NC",
+	"NORTH DAKOTA":                 "ND",
+	"OHIO":                         "OH",
+	"OKLAHOMA":                     "OK",
+	"OREGON":                       "OR",
+	"PENNSYLVANIA":                 "PA",
+	"RHODE ISLAND":                 "RI",
+	"SOUTH CAROLINA":               "SC",
+	"SOUTH DAKOTA":                 "SD",
+	"TENNESSEE":                    "TN",
+	"TEXAS":                        "TX",
+	"UTAH":                         "UT",
+	"VERMONT":                      "VT",
+	"VIRGINIA":                     "VA",
+	"WASHINGTON":                   "WA",
+	"WEST VIRGINIA":                "WV",
+	"WISCONSIN":                    "WI",
+	"WYOMING":                      "WY",
+	"AMERICAN SAMOA":               "AS",
+	"GUAM":                         "GU",
+	"NORTHERN MARIANA ISLANDS":     "MP",
+	"PUERTO RICO":                  "PR",
+	"VIRGIN ISLANDS":               "VI",
+	"US VIRGIN ISLANDS":            "VI",
+	"ARMED FORCES AMERICAS":        "AA",
+	"ARMED FORCES EUROPE":          "AE",
+	"ARMED FORCES PACIFIC":         "AP",
+	"WASHINGTON DC":                "DC",
+	"WASHINGTON D C":               "DC",
+	"D C":                          "DC",
+	"UNITED STATES VIRGIN ISLANDS": "VI",
+}
+
+// stateAbbreviations maps common non-USPS abbreviations to two-letter codes.
+var stateAbbreviations = map[string]string{
+	"ALA": "AL", "ARIZ": "AZ", "ARK": "AR", "CALIF": "CA", "CAL": "CA", "COLO": "CO", "CONN": "CT",
+	"DEL": "DE", "FLA": "FL", "ILL": "IL", "IND": "IN", "KAN": "KS", "KANS": "KS", "MASS": "MA",
+	"MICH": "MI", "MINN": "MN", "MISS": "MS", "MONT": "MT", "NEB": "NE", "NEBR": "NE", "NEV": "NV",
+	"OKLA": "OK", "ORE": "OR", "OREG": "OR", "PENN": "PA", "PENNA": "PA", "TENN": "TN", "TEX": "TX",
+	"VT": "VT", "WASH": "WA", "WIS": "WI", "WISC": "WI", "WYO": "WY", "N MEX": "NM", "N DAK": "ND",
+	"S DAK": "SD", "W VA": "WV",
+}
+
+// validStates is the set of two-letter codes accepted in the state field.
+var validStates = func() map[string]bool {
+	codes := make(map[string]bool, len(stateCodes))
+	for _, code := range stateCodes {
+		codes[code] = true
+	}
+	return codes
+}()
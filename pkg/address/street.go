@@ -0,0 +1,338 @@
+package address
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// streetSuffixes maps street suffix names and their common variants to the standard
+// abbreviations in USPS Publication 28, Appendix C1.
+var streetSuffixes = map[string]string{
+	"ALLEY": "ALY", "ALLEE": "ALY", "ALLY": "ALY", "ALY": "ALY",
+	"ANNEX": "ANX", "ANEX": "ANX", "ANNX": "ANX", "ANX": "ANX",
+	"ARCADE": "ARC", "ARC": "ARC",
+	"AVENUE": "AVE", "AV": "AVE", "AVE": "AVE", "AVEN": "AVE", "AVENU": "AVE", "AVN": "AVE", "AVNUE": "AVE",
+	"BAYOU": "BYU", "BAYOO": "BYU",
+	"BEACH": "BCH", "BCH": "BCH",
+	"BEND": "BND", "BND": "BND",
+	"BLUFF": "BLF", "BLUF": "BLF", "BLF": "BLF",
+	"BOTTOM": "BTM", "BOT": "BTM", "BTM": "BTM", "BOTTM": "BTM",
+	"BOULEVARD": "BLVD", "BLVD": "BLVD", "BOUL": "BLVD", "BOULV": "BLVD",
+	"BRANCH": "BR", "BR": "BR", "BRNCH": "BR",
+	"BRIDGE": "BRG", "BRDGE": "BRG", "BRG": "BRG",
+	"BROOK": "BRK", "BRK": "BRK",
+	"BYPASS": "BYP", "BYP": "BYP", "BYPA": "BYP", "BYPAS": "BYP", "BYPS": "BYP",
+	"CAMP": "CP", "CP": "CP", "CMP": "CP",
+	"CANYON": "CYN", "CANYN": "CYN", "CNYN": "CYN", "CYN": "CYN",
+	"CAPE": "CPE", "CPE": "CPE",
+	"CAUSEWAY": "CSWY", "CAUSWA": "CSWY", "CSWY": "CSWY",
+	"CENTER": "CTR", "CEN": "CTR", "CENT": "CTR", "CENTR": "CTR", "CENTRE": "CTR", "CNTER": "CTR", "CNTR": "CTR", "CTR": "CTR",
+	"CIRCLE": "CIR", "CIR": "CIR", "CIRC": "CIR", "CIRCL": "CIR", "CRCL": "CIR", "CRCLE": "CIR",
+	"CLIFF": "CLF", "CLF": "CLF",
+	"CLUB": "CLB", "CLB": "CLB",
+	"COMMON": "CMN", "CMN": "CMN",
+	"CORNER": "COR", "COR": "COR",
+	"CORNERS": "CORS", "CORS": "CORS",
+	"COURSE": "CRSE", "CRSE": "CRSE",
+	"COURT": "CT", "CT": "CT",
+	"COURTS": "CTS", "CTS": "CTS",
+	"COVE": "CV", "CV": "CV",
+	"CREEK": "CRK", "CRK": "CRK",
+	"CRESCENT": "CRES", "CRES": "CRES", "CRSENT": "CRES", "CRSNT": "CRES",
+	"CROSSING": "XING", "CRSSNG": "XING", "XING": "XING",
+	"CROSSROAD": "XRD", "XRD": "XRD",
+	"CURVE": "CURV", "CURV": "CURV",
+	"DALE": "DL", "DL": "DL",
+	"DAM": "DM", "DM": "DM",
+	"DIVIDE": "DV", "DIV": "DV", "DV": "DV", "DVD": "DV",
+	"DRIVE": "DR", "DR": "DR", "DRIV": "DR", "DRV": "DR",
+	"DRIVES": "DRS", "DRS": "DRS",
+	"ESTATE": "EST", "EST": "EST",
+	"ESTATES": "ESTS", "ESTS": "ESTS",
+	"EXPRESSWAY": "EXPY", "EXP": "EXPY", "EXPR": "EXPY", "EXPRESS": "EXPY", "EXPW": "EXPY", "EXPY": "EXPY",
+	"EXTENSION": "EXT", "EXT": "EXT", "EXTN": "EXT", "EXTNSN": "EXT",
+	"FALLS": "FLS", "FLS": "FLS",
+	"FERRY": "FRY", "FRRY": "FRY", "FRY": "FRY",
+	"FIELD": "FLD", "FLD": "FLD",
+	"FIELDS": "FLDS", "FLDS": "FLDS",
+	"FLAT": "FLT", "FLT": "FLT",
+	"FORD": "FRD", "FRD": "FRD",
+	"FOREST": "FRST", "FORESTS": "FRST", "FRST": "FRST",
+	"FORGE": "FRG", "FORG": "FRG", "FRG": "FRG",
+	"FORK": "FRK", "FRK": "FRK",
+	"FORT": "FT", "FRT": "FT", "FT": "FT",
+	"FREEWAY": "FWY", "FREEWY": "FWY", "FRWAY": "FWY", "FRWY": "FWY", "FWY": "FWY",
+	"GARDEN": "GDN", "GARDN": "GDN", "GRDEN": "GDN", "GRDN": "GDN", "GDN": "GDN",
+	"GARDENS": "GDNS", "GDNS": "GDNS", "GRDNS": "GDNS",
+	"GATEWAY": "GTWY", "GATEWY": "GTWY", "GATWAY": "GTWY", "GTWAY": "GTWY", "GTWY": "GTWY",
+	"GLEN": "GLN", "GLN": "GLN",
+	"GREEN": "GRN", "GRN": "GRN",
+	"GROVE": "GRV", "GROV": "GRV", "GRV": "GRV",
+	"HARBOR": "HBR", "HARB": "HBR", "HARBR": "HBR", "HBR": "HBR", "HRBOR": "HBR",
+	"HAVEN": "HVN", "HVN": "HVN",
+	"HEIGHTS": "HTS", "HT": "HTS", "HTS": "HTS",
+	"HIGHWAY": "HWY", "HIGHWY": "HWY", "HIWAY": "HWY", "HIWY": "HWY", "HWAY": "HWY", "HWY": "HWY",
+	"HILL": "HL", "HL": "HL",
+	"HILLS": "HLS", "HLS": "HLS",
+	"HOLLOW": "HOLW", "HLLW": "HOLW", "HOLLOWS": "HOLW", "HOLW": "HOLW", "HOLWS": "HOLW",
+	"ISLAND": "IS", "IS": "IS", "ISLND": "IS",
+	"JUNCTION": "JCT", "JCT": "JCT", "JCTION": "JCT", "JCTN": "JCT", "JUNCTN": "JCT", "JUNCTON": "JCT",
+	"KNOLL": "KNL", "KNL": "KNL", "KNOL": "KNL",
+	"LAKE": "LK", "LK": "LK",
+	"LAKES": "LKS", "LKS": "LKS",
+	"LANDING": "LNDG", "LNDG": "LNDG", "LNDNG": "LNDG",
+	"LANE": "LN", "LN": "LN",
+	"LOOP": "LOOP", "LOOPS": "LOOP",
+	"MALL":  "MALL",
+	"MANOR": "MNR", "MNR": "MNR",
+	"MEADOW": "MDW", "MDW": "MDW",
+	"MEADOWS": "MDWS", "MDWS": "MDWS", "MEDOWS": "MDWS",
+	"MILL": "ML", "ML": "ML",
+	"MILLS": "MLS", "MLS": "MLS",
+	"MOTORWAY": "MTWY", "MTWY": "MTWY",
+	"MOUNT": "MT", "MNT": "MT", "MT": "MT",
+	"MOUNTAIN": "MTN", "MNTAIN": "MTN", "MNTN": "MTN", "MOUNTIN": "MTN", "MTIN": "MTN", "MTN": "MTN",
+	"ORCHARD": "ORCH", "ORCH": "ORCH", "ORCHRD": "ORCH",
+	"OVAL": "OVAL", "OVL": "OVAL",
+	"OVERPASS": "OPAS", "OPAS": "OPAS",
+	"PARK": "PARK", "PRK": "PARK", "PARKS": "PARK",
+	"PARKWAY": "PKWY", "PARKWY": "PKWY", "PKWAY": "PKWY", "PKWY": "PKWY", "PKY": "PKWY", "PARKWAYS": "PKWY", "PKWYS": "PKWY",
+	"PASS": "PASS",
+	"PATH": "PATH", "PATHS": "PATH",
+	"PIKE": "PIKE", "PIKES": "PIKE",
+	"PINE": "PNE", "PNE": "PNE",
+	"PINES": "PNES", "PNES": "PNES",
+	"PLACE": "PL", "PL": "PL",
+	"PLAIN": "PLN", "PLN": "PLN",
+	"PLAINS": "PLNS", "PLNS": "PLNS",
+	"PLAZA": "PLZ", "PLZ": "PLZ", "PLZA": "PLZ",
+	"POINT": "PT", "PT": "PT",
+	"POINTS": "PTS", "PTS": "PTS",
+	"PORT": "PRT", "PRT": "PRT",
+	"PRAIRIE": "PR", "PR": "PR", "PRR": "PR",
+	"RANCH": "RNCH", "RANCHES": "RNCH", "RNCH": "RNCH", "RNCHS": "RNCH",
+	"RIDGE": "RDG", "RDG": "RDG", "RDGE": "RDG",
+	"RIVER": "RIV", "RIV": "RIV", "RVR": "RIV", "RIVR": "RIV",
+	"ROAD": "RD", "RD": "RD",
+	"ROADS": "RDS", "RDS": "RDS",
+	"ROUTE": "RTE", "RTE": "RTE",
+	"ROW":   "ROW",
+	"RUN":   "RUN",
+	"SHORE": "SHR", "SHOAR": "SHR", "SHR": "SHR",
+	"SHORES": "SHRS", "SHOARS": "SHRS", "SHRS": "SHRS",
+	"SKYWAY": "SKWY", "SKWY": "SKWY",
+	"SPRING": "SPG", "SPG": "SPG", "SPNG": "SPG", "SPRNG": "SPG",
+	"SPRINGS": "SPGS", "SPGS": "SPGS", "SPNGS": "SPGS", "SPRNGS": "SPGS",
+	"SQUARE": "SQ", "SQ": "SQ", "SQR": "SQ", "SQRE": "SQ", "SQU": "SQ",
+	"STATION": "STA", "STA": "STA", "STATN": "STA", "STN": "STA",
+	"STREET": "ST", "ST": "ST", "STR": "ST", "STRT": "ST", "STREETS": "STS", "STS": "STS",
+	"SUMMIT": "SMT", "SMT": "SMT", "SUMIT": "SMT", "SUMITT": "SMT",
+	"TERRACE": "TER", "TER": "TER", "TERR": "TER",
+	"TRACE": "TRCE", "TRACES": "TRCE", "TRCE": "TRCE",
+	"TRAIL": "TRL", "TRAILS": "TRL", "TRL": "TRL", "TRLS": "TRL",
+	"TUNNEL": "TUNL", "TUNEL": "TUNL", "TUNL": "TUNL", "TUNLS": "TUNL", "TUNNELS": "TUNL", "TUNNL": "TUNL",
+	"TURNPIKE": "TPKE", "TPKE": "TPKE", "TRNPK": "TPKE", "TURNPK": "TPKE",
+	"UNION": "UN", "UN": "UN",
+	"VALLEY": "VLY", "VALLY": "VLY", "VLLY": "VLY", "VLY": "VLY",
+	"VIADUCT": "VIA", "VDCT": "VIA", "VIA": "VIA", "VIADCT": "VIA",
+	"VIEW": "VW", "VW": "VW",
+	"VILLAGE": "VLG", "VILL": "VLG", "VILLAG": "VLG", "VILLG": "VLG", "VLG": "VLG",
+	"VISTA": "VIS", "VIS": "VIS", "VIST": "VIS", "VST": "VIS", "VSTA": "VIS",
+	"WALK": "WALK", "WALKS": "WALK",
+	"WAY": "WAY", "WY": "WAY",
+	"WELL": "WL", "WL": "WL",
+	"WELLS": "WLS", "WLS": "WLS",
+}
+
+// unitDesignators maps secondary unit designators to the standard abbreviations in
+// USPS Publication 28, Appendix C2.
+var unitDesignators = map[string]string{
+	"APARTMENT": "APT", "APT": "APT",
+	"BASEMENT": "BSMT", "BSMT": "BSMT",
+	"BUILDING": "BLDG", "BLDG": "BLDG", "BLD": "BLDG",
+	"DEPARTMENT": "DEPT", "DEPT": "DEPT",
+	"FLOOR": "FL", "FL": "FL", "FLR": "FL",
+	"FRONT": "FRNT", "FRNT": "FRNT",
+	"HANGAR": "HNGR", "HNGR": "HNGR",
+	"LOBBY": "LBBY", "LBBY": "LBBY",
+	"LOT":   "LOT",
+	"LOWER": "LOWR", "LOWR": "LOWR",
+	"OFFICE": "OFC", "OFC": "OFC",
+	"PENTHOUSE": "PH", "PH": "PH",
+	"PIER": "PIER",
+	"REAR": "REAR",
+	"ROOM": "RM", "RM": "RM",
+	"SLIP":  "SLIP",
+	"SPACE": "SPC", "SPC": "SPC",
+	"STOP":  "STOP",
+	"SUITE": "STE", "STE": "STE", "SUIT": "STE",
+	"TRAILER": "TRLR", "TRLR": "TRLR",
+	"UNIT":  "UNIT",
+	"UPPER": "UPPR", "UPPR": "UPPR",
+}
+
+// unnumberedDesignators are the unit designators of Publication 28, Appendix C2, that take no
+// unit number. As they are also common street name words, such as "Front Street" or "Upper Valley
+// Road", they are only taken as units after the street suffix.
+var unnumberedDesignators = map[string]bool{
+	"BSMT": true, "FRNT": true, "LBBY": true, "LOWR": true, "OFC": true, "PH": true, "REAR": true, "UPPR": true,
+}
+
+// directionals maps directional words to the standard abbreviations in USPS Publication 28.
+var directionals = map[string]string{
+	"NORTH": "N", "N": "N",
+	"SOUTH": "S", "S": "S",
+	"EAST": "E", "E": "E",
+	"WEST": "W", "W": "W",
+	"NORTHEAST": "NE", "NE": "NE",
+	"NORTHWEST": "NW", "NW": "NW",
+	"SOUTHEAST": "SE", "SE": "SE",
+	"SOUTHWEST": "SW", "SW": "SW",
+}
+
+// StandardizeStreet formats a street address line in the USPS style: upper case without
+// punctuation, with directionals, the street suffix and any secondary unit designator abbreviated.
+// "123 North Main Street, Apartment 4B" becomes "123 N MAIN ST APT 4B". Words are only
+// abbreviated in the position where they act as a suffix or directional, so street names
+// such as "Park Avenue" or "North Street" keep their name words.
+func StandardizeStreet(street string) string {
+	words := splitWords(street)
+	if len(words) == 0 {
+		return ""
+	}
+
+	// Split the primary address from the secondary unit at the first unit designator or "#".
+	// The first word is never treated as a unit so addresses like "Lot 5 Orchard Road" are left intact.
+	primary, secondary := words, []string(nil)
+	for i := 1; i < len(words); i++ {
+		if isUnit(words, i) {
+			primary, secondary = words[:i], words[i:]
+			break
+		}
+	}
+
+	primary = standardizePrimary(primary)
+	secondary = standardizeSecondary(secondary)
+
+	return strings.Join(append(primary, secondary...), " ")
+}
+
+// isUnit reports whether the secondary unit of an address starts at words[i]: a "#", a designator
+// followed by a unit number, such as "Suite 200" or "Building C", or a designator taking no number
+// right after the street suffix and any post-directional, such as "Main St Rear".
+func isUnit(words []string, i int) bool {
+	if strings.HasPrefix(words[i], "#") {
+		return true
+	}
+	abbr, ok := unitDesignators[words[i]]
+	if !ok {
+		return false
+	}
+
+	if !unnumberedDesignators[abbr] {
+		return i+1 < len(words) && isUnitNumber(words[i+1])
+	}
+
+	previous := i - 1
+	if _, ok := directionals[words[previous]]; ok && previous > 1 {
+		previous--
+	}
+	_, ok = streetSuffixes[words[previous]]
+	return ok && previous > 0
+}
+
+// isUnitNumber reports whether a word is a unit number such as "200", "4B", "#12" or "C".
+func isUnitNumber(word string) bool {
+	return startsWithDigit(word) || strings.HasPrefix(word, "#") || len([]rune(word)) == 1
+}
+
+// standardizePrimary abbreviates the pre-directional, street suffix and post-directional of
+// the words before any secondary unit.
+func standardizePrimary(words []string) []string {
+	out := append([]string(nil), words...)
+
+	// The name starts after the house number; a directional is only a pre-directional when
+	// at least two words follow it, otherwise it is the street name itself.
+	start := 0
+	if len(out) > 0 && startsWithDigit(out[0]) {
+		start = 1
+	}
+
+	end := len(out)
+	if end-start >= 3 {
+		if abbr, ok := directionals[out[end-1]]; ok {
+			out[end-1] = abbr
+			end--
+		}
+	}
+
+	if end-start >= 2 {
+		if abbr, ok := streetSuffixes[out[end-1]]; ok {
+			out[end-1] = abbr
+			end--
+		}
+	}
+
+	if end-start >= 2 {
+		if abbr, ok := directionals[out[start]]; ok {
+			out[start] = abbr
+		}
+	}
+
+	return out
+}
+
+// standardizeSecondary abbreviates the unit designator and separates "#" from the unit number.
+// A "#" following a designator is dropped, so "Apt #4" becomes "APT 4".
+func standardizeSecondary(words []string) []string {
+	var out []string
+	for _, word := range words {
+		if abbr, ok := unitDesignators[word]; ok {
+			out = append(out, abbr)
+			continue
+		}
+
+		number, ok := strings.CutPrefix(word, "#")
+		if !ok {
+			out = append(out, word)
+			continue
+		}
+		if len(out) == 0 || !isDesignator(out[len(out)-1]) {
+			out = append(out, "#")
+		}
+		if number != "" {
+			out = append(out, number)
+		}
+	}
+	return out
+}
+
+// splitWords upper-cases s and splits it into words, removing punctuation other than
+// "#", "-" and "/", which USPS retains in address and unit numbers. Diacritics are removed as
+// USPS addresses are written without them, so "Bayamón" becomes "BAYAMON".
+func splitWords(s string) []string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToUpper(s)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '#', r == '-', r == '/':
+			b.WriteRune(r)
+		case r == '.' || r == '\'' || unicode.Is(unicode.Mn, r):
+			// Periods and apostrophes are dropped without splitting so "St." and "O'Neil" stay whole,
+			// as are the accents split from letters.
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Fields(b.String())
+}
+
+func isDesignator(word string) bool {
+	_, ok := unitDesignators[word]
+	return ok
+}
+
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
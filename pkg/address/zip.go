@@ -0,0 +1,71 @@
+package address
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// zipPrefixTable is the bundled table of three-digit ZIP prefix ranges and the states they serve.
+// Rows are applied in order so later rows override ranges for prefixes assigned out of sequence.
+//
+//go:embed zip_prefixes.csv
+var zipPrefixTable string
+
+// zipPrefixStates maps each three-digit ZIP prefix to the state codes it serves.
+var zipPrefixStates = mustParseZipPrefixes(zipPrefixTable)
+
+// zipPattern matches a five-digit ZIP code with an optional ZIP+4 add-on separated by a hyphen or space.
+var zipPattern = regexp.MustCompile(`^(\d{5})(?:[- ]?(\d{4}))?$`)
+
+// mustParseZipPrefixes reads the bundled ZIP prefix table, panicking if it is malformed.
+func mustParseZipPrefixes(table string) map[string][]string {
+	records, err := csv.NewReader(strings.NewReader(table)).ReadAll()
+	if err != nil {
+		panic(fmt.Errorf("failed to read zip prefix table: %w", err))
+	}
+
+	prefixes := make(map[string][]string)
+	for _, record := range records[1:] {
+		start, err := strconv.Atoi(record[0])
+		if err != nil {
+			panic(fmt.Errorf("invalid zip prefix %q: %w", record[0], err))
+		}
+		end, err := strconv.Atoi(record[1])
+		if err != nil {
+			panic(fmt.Errorf("invalid zip prefix %q: %w", record[1], err))
+		}
+
+		states := strings.Split(record[2], "|")
+		for prefix := start; prefix <= end; prefix++ {
+			prefixes[fmt.Sprintf("%03d", prefix)] = states
+		}
+	}
+
+	return prefixes
+}
+
+// NormalizeZip formats a ZIP code as "12345" or "12345-6789".
+// It reports false if the value is not a valid ZIP or ZIP+4 code.
+func NormalizeZip(zip string) (string, bool) {
+	m := zipPattern.FindStringSubmatch(strings.TrimSpace(zip))
+	if m == nil {
+		return zip, false
+	}
+	if m[2] == "" {
+		return m[1], true
+	}
+	return m[1] + "-" + m[2], true
+}
+
+// ZipStates returns the state codes served by a ZIP code's three-digit prefix.
+// It returns nil for prefixes that are unassigned.
+func ZipStates(zip string) []string {
+	if len(zip) < 3 {
+		return nil
+	}
+	return zipPrefixStates[zip[:3]]
+}
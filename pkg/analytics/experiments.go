@@ -0,0 +1,108 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// confidenceZ is the standard normal quantile of the 95% confidence intervals of accuracies.
+const confidenceZ = 1.96
+
+// ExperimentReport compares the accuracy of an experiment's variants, judged by reviewer
+// corrections of the results of jobs completed in a date range.
+type ExperimentReport struct {
+	Experiment string            `json:"experiment"`
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	Variants   []VariantAccuracy `json:"variants"` // Variants ordered by name
+}
+
+// VariantAccuracy is how often reviewers accepted the results of an experiment variant unchanged.
+type VariantAccuracy struct {
+	Variant            string             `json:"variant"`
+	Results            int                `json:"results"`             // Number of completed results, reviewed or not
+	Reviewed           int                `json:"reviewed"`            // Number of reviewed results
+	Accurate           int                `json:"accurate"`            // Number of reviewed results without corrections
+	Accuracy           float64            `json:"accuracy"`            // Accurate divided by reviewed, zero when none were reviewed
+	ConfidenceInterval ConfidenceInterval `json:"confidence_interval"` // 95% confidence interval of the accuracy
+	Fields             []FieldError       `json:"fields"`              // Corrected fields, most often corrected first
+}
+
+// ConfidenceInterval is the range a proportion lies in with 95% confidence.
+type ConfidenceInterval struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// ExperimentAccuracy computes the accuracy of each variant of an experiment from the results
+// completed in [from, to) that were assigned to it. A result is accurate when its reviewer
+// corrected no field. Results that have not been reviewed only count toward a variant's results.
+func ExperimentAccuracy(results []models.ParseResult, experiment string, from, to time.Time) ExperimentReport {
+	report := ExperimentReport{Experiment: experiment, From: from, To: to, Variants: []VariantAccuracy{}}
+
+	variants := map[string]*VariantAccuracy{}
+	fieldCounts := map[string]map[string]int{}
+
+	for _, result := range results {
+		if result.Attributes[jobs.AttributeExperiment] != experiment || result.CompletedAt.Before(from) || !result.CompletedAt.Before(to) {
+			continue
+		}
+
+		name := result.Attributes[jobs.AttributeVariant]
+		variant, ok := variants[name]
+		if !ok {
+			variant = &VariantAccuracy{Variant: name}
+			variants[name] = variant
+			fieldCounts[name] = map[string]int{}
+		}
+
+		variant.Results++
+		if result.Review == nil {
+			continue
+		}
+
+		variant.Reviewed++
+		if len(result.Review.Changes) == 0 {
+			variant.Accurate++
+		}
+		countFields(fieldCounts[name], result.Review)
+	}
+
+	for name, variant := range variants {
+		if variant.Reviewed > 0 {
+			variant.Accuracy = float64(variant.Accurate) / float64(variant.Reviewed)
+		}
+		variant.ConfidenceInterval = wilsonInterval(variant.Accurate, variant.Reviewed)
+		variant.Fields = fieldErrors(fieldCounts[name], variant.Reviewed)
+		report.Variants = append(report.Variants, *variant)
+	}
+
+	sort.Slice(report.Variants, func(i, j int) bool {
+		return report.Variants[i].Variant < report.Variants[j].Variant
+	})
+
+	return report
+}
+
+// wilsonInterval returns the Wilson score interval of a proportion of successes in trials. Unlike
+// the normal approximation, it stays within [0, 1] and is meaningful for the small samples and
+// proportions near one that reviewed accuracies tend to have. Without trials, it spans [0, 1].
+func wilsonInterval(successes, trials int) ConfidenceInterval {
+	if trials == 0 {
+		return ConfidenceInterval{Low: 0, High: 1}
+	}
+
+	n := float64(trials)
+	p := float64(successes) / n
+	z2 := confidenceZ * confidenceZ
+
+	denominator := 1 + z2/n
+	center := (p + z2/(2*n)) / denominator
+	margin := confidenceZ * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / denominator
+
+	return ConfidenceInterval{Low: math.Max(0, center-margin), High: math.Min(1, center+margin)}
+}
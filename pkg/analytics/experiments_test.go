@@ -0,0 +1,76 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func assigned(result models.ParseResult, experiment, variant string) models.ParseResult {
+	result.Attributes = map[string]string{jobs.AttributeExperiment: experiment, jobs.AttributeVariant: variant}
+	return result
+}
+
+func TestExperimentAccuracy(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	mar3 := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+
+	var results []models.ParseResult
+	for i := 0; i < 10; i++ {
+		if i < 8 {
+			results = append(results, assigned(reviewed("", "", mar3), "review-prompt-2", "review-2"))
+		} else {
+			results = append(results, assigned(reviewed("", "", mar3, "prescriber.npi", "medications[0].sig"), "review-prompt-2", "review-2"))
+		}
+	}
+	results = append(results,
+		assigned(reviewed("", "", mar3, "prescriber.npi"), "review-prompt-2", "control"),
+		assigned(models.ParseResult{Status: models.ParseResultComplete, CompletedAt: mar3}, "review-prompt-2", "control"),
+		assigned(reviewed("", "", mar3), "other-experiment", "control"),
+		assigned(reviewed("", "", to), "review-prompt-2", "control"),
+		reviewed("OpenAI", "", mar3),
+	)
+
+	report := ExperimentAccuracy(results, "review-prompt-2", from, to)
+	if len(report.Variants) != 2 {
+		t.Fatalf("Expected 2 variants, got %+v", report.Variants)
+	}
+
+	control, treatment := report.Variants[0], report.Variants[1]
+	if control.Variant != "control" || control.Results != 2 || control.Reviewed != 1 || control.Accurate != 0 || control.Accuracy != 0 {
+		t.Errorf("Unexpected control variant %+v", control)
+	}
+
+	if treatment.Variant != "review-2" || treatment.Results != 10 || treatment.Reviewed != 10 || treatment.Accurate != 8 || treatment.Accuracy != 0.8 {
+		t.Errorf("Unexpected treatment variant %+v", treatment)
+	}
+	if ci := treatment.ConfidenceInterval; math.Abs(ci.Low-0.4902) > 0.0005 || math.Abs(ci.High-0.9433) > 0.0005 {
+		t.Errorf("Expected the Wilson interval [0.4902, 0.9433], got %+v", ci)
+	}
+	if len(treatment.Fields) != 2 || treatment.Fields[0].Field != "medications[*].sig" || treatment.Fields[0].ErrorRate != 0.2 {
+		t.Errorf("Unexpected treatment fields %+v", treatment.Fields)
+	}
+}
+
+func TestWilsonInterval(t *testing.T) {
+	tests := []struct {
+		successes, trials int
+		want              ConfidenceInterval
+	}{
+		{0, 0, ConfidenceInterval{Low: 0, High: 1}},
+		{0, 10, ConfidenceInterval{Low: 0, High: 0.2775}},
+		{10, 10, ConfidenceInterval{Low: 0.7225, High: 1}},
+		{50, 100, ConfidenceInterval{Low: 0.4038, High: 0.5962}},
+	}
+
+	for _, tt := range tests {
+		got := wilsonInterval(tt.successes, tt.trials)
+		if math.Abs(got.Low-tt.want.Low) > 0.0005 || math.Abs(got.High-tt.want.High) > 0.0005 {
+			t.Errorf("wilsonInterval(%d, %d) = %+v, want %+v", tt.successes, tt.trials, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Package analytics aggregates reviewer corrections of parse results into per-field error rates,
+// so prompt and pipeline work can target the fields the parser gets wrong most often in production.
+package analytics
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// Interval is the length of the time windows results are grouped into.
+type Interval string
+
+// Supported intervals. IntervalNone puts every result in a single window.
+const (
+	IntervalNone  Interval = ""
+	IntervalDay   Interval = "day"
+	IntervalWeek  Interval = "week"
+	IntervalMonth Interval = "month"
+)
+
+// ParseInterval validates an interval name.
+func ParseInterval(s string) (Interval, error) {
+	switch interval := Interval(s); interval {
+	case IntervalNone, IntervalDay, IntervalWeek, IntervalMonth:
+		return interval, nil
+	default:
+		return "", fmt.Errorf("unknown interval %q: must be day, week or month", s)
+	}
+}
+
+// Options selects and groups the results to analyze.
+type Options struct {
+	From     time.Time // Start of the range, inclusive; used as the window start when Interval is IntervalNone
+	To       time.Time // End of the range, exclusive
+	Interval Interval
+	Backend  string // Only include results from this parser backend, if set
+	Template string // Only include results identified as this form template, if set
+}
+
+// Report is the field error analysis of the reviewed results in a date range.
+type Report struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Interval Interval  `json:"interval,omitempty"`
+	Groups   []Group   `json:"groups"`
+}
+
+// Group holds the field error rates of the reviewed results of one backend and form template in one
+// time window.
+type Group struct {
+	Backend     string       `json:"backend"`      // Parser backend, or empty if it was not recorded
+	Template    string       `json:"template"`     // Form template ID, or empty if the form was not identified
+	WindowStart time.Time    `json:"window_start"` // Start of the time window, inclusive
+	WindowEnd   time.Time    `json:"window_end"`   // End of the time window, exclusive
+	Reviewed    int          `json:"reviewed"`     // Number of reviewed results
+	Corrected   int          `json:"corrected"`    // Number of reviewed results with at least one correction
+	Fields      []FieldError `json:"fields"`       // Corrected fields, most often corrected first
+}
+
+// FieldError is how often reviewers corrected a field.
+type FieldError struct {
+	Field     string  `json:"field"`      // Field path with array indexes replaced by [*], e.g. medications[*].drug_name
+	Errors    int     `json:"errors"`     // Number of reviewed results in which the field was corrected
+	ErrorRate float64 `json:"error_rate"` // Errors divided by the group's reviewed results
+}
+
+// arrayIndex matches the array indexes in a field path.
+var arrayIndex = regexp.MustCompile(`\[\d+\]`)
+
+// NormalizeField replaces the array indexes in a field path with [*], so corrections to any
+// medication or phone number count toward the same field.
+func NormalizeField(field string) string {
+	return arrayIndex.ReplaceAllString(field, "[*]")
+}
+
+type groupKey struct {
+	backend  string
+	template string
+	window   time.Time
+}
+
+// FieldErrors computes per-field error rates from the reviewed results completed within the
+// options' range. Results that have not been reviewed are ignored. Groups are ordered by window,
+// backend and template.
+func FieldErrors(results []models.ParseResult, opts Options) Report {
+	report := Report{From: opts.From, To: opts.To, Interval: opts.Interval, Groups: []Group{}}
+
+	groups := map[groupKey]*Group{}
+	fieldCounts := map[groupKey]map[string]int{}
+
+	for _, result := range results {
+		if result.Review == nil || result.CompletedAt.Before(opts.From) || !result.CompletedAt.Before(opts.To) {
+			continue
+		}
+
+		backend := result.Attributes[jobs.AttributeBackend]
+		template := result.Attributes[jobs.AttributeTemplate]
+		if (opts.Backend != "" && backend != opts.Backend) || (opts.Template != "" && template != opts.Template) {
+			continue
+		}
+
+		start, end := window(result.CompletedAt, opts)
+		key := groupKey{backend: backend, template: template, window: start}
+		group, ok := groups[key]
+		if !ok {
+			group = &Group{Backend: backend, Template: template, WindowStart: start, WindowEnd: end}
+			groups[key] = group
+			fieldCounts[key] = map[string]int{}
+		}
+
+		group.Reviewed++
+		if len(result.Review.Changes) > 0 {
+			group.Corrected++
+		}
+
+		countFields(fieldCounts[key], result.Review)
+	}
+
+	for key, group := range groups {
+		group.Fields = fieldErrors(fieldCounts[key], group.Reviewed)
+		report.Groups = append(report.Groups, *group)
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if !a.WindowStart.Equal(b.WindowStart) {
+			return a.WindowStart.Before(b.WindowStart)
+		}
+		if a.Backend != b.Backend {
+			return a.Backend < b.Backend
+		}
+		return a.Template < b.Template
+	})
+
+	return report
+}
+
+// countFields counts the fields corrected in a review. A field counts once per result however many
+// of its array elements were corrected.
+func countFields(counts map[string]int, review *models.Review) {
+	fields := map[string]bool{}
+	for _, change := range review.Changes {
+		fields[NormalizeField(change.Field)] = true
+	}
+	for field := range fields {
+		counts[field]++
+	}
+}
+
+// fieldErrors returns the error rates of the counted fields, most often corrected first.
+func fieldErrors(counts map[string]int, reviewed int) []FieldError {
+	fields := make([]FieldError, 0, len(counts))
+	for field, count := range counts {
+		fields = append(fields, FieldError{
+			Field:     field,
+			Errors:    count,
+			ErrorRate: float64(count) / float64(reviewed),
+		})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Errors != fields[j].Errors {
+			return fields[i].Errors > fields[j].Errors
+		}
+		return fields[i].Field < fields[j].Field
+	})
+	return fields
+}
+
+// window returns the UTC time window containing t. Weeks start on Monday.
+func window(t time.Time, opts Options) (time.Time, time.Time) {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch opts.Interval {
+	case IntervalDay:
+		return day, day.AddDate(0, 0, 1)
+	case IntervalWeek:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	case IntervalMonth:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	default:
+		return opts.From, opts.To
+	}
+}
@@ -0,0 +1,103 @@
+package analytics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func reviewed(backend, template string, completedAt time.Time, fields ...string) models.ParseResult {
+	review := &models.Review{Changes: []models.FieldChange{}}
+	for _, field := range fields {
+		review.Changes = append(review.Changes, models.FieldChange{Field: field})
+	}
+	return models.ParseResult{
+		Status:      models.ParseResultReviewed,
+		Attributes:  map[string]string{jobs.AttributeBackend: backend, jobs.AttributeTemplate: template},
+		CompletedAt: completedAt,
+		Review:      review,
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	mar3 := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)  // Monday
+	mar9 := time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC)  // Sunday
+	mar10 := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC) // Monday
+
+	results := []models.ParseResult{
+		reviewed("OpenAI", "", mar3, "prescriber.npi", "patient.phone_numbers[0].label", "patient.phone_numbers[1].label"),
+		reviewed("OpenAI", "", mar9, "prescriber.npi"),
+		reviewed("OpenAI", "", mar10),
+		reviewed("Gemini", "acme-enrollment", mar3, "medications[0].sig"),
+		{Status: models.ParseResultComplete, Attributes: map[string]string{jobs.AttributeBackend: "OpenAI"}, CompletedAt: mar3},
+		reviewed("OpenAI", "", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), "prescriber.npi"),
+	}
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("single window", func(t *testing.T) {
+		report := FieldErrors(results, Options{From: from, To: to})
+		if len(report.Groups) != 2 {
+			t.Fatalf("Expected 2 groups, got %+v", report.Groups)
+		}
+
+		gemini, openai := report.Groups[0], report.Groups[1]
+		if gemini.Backend != "Gemini" || gemini.Template != "acme-enrollment" || gemini.Reviewed != 1 {
+			t.Errorf("Unexpected Gemini group %+v", gemini)
+		}
+		if !openai.WindowStart.Equal(from) || !openai.WindowEnd.Equal(to) {
+			t.Errorf("Expected the whole range as the window, got %s - %s", openai.WindowStart, openai.WindowEnd)
+		}
+		if openai.Reviewed != 3 || openai.Corrected != 2 {
+			t.Errorf("Expected 3 reviewed and 2 corrected results, got %d and %d", openai.Reviewed, openai.Corrected)
+		}
+
+		want := []FieldError{
+			{Field: "prescriber.npi", Errors: 2, ErrorRate: 2.0 / 3},
+			{Field: "patient.phone_numbers[*].label", Errors: 1, ErrorRate: 1.0 / 3},
+		}
+		if !reflect.DeepEqual(openai.Fields, want) {
+			t.Errorf("Expected fields %+v, got %+v", want, openai.Fields)
+		}
+	})
+
+	t.Run("weekly windows", func(t *testing.T) {
+		report := FieldErrors(results, Options{From: from, To: to, Interval: IntervalWeek, Backend: "OpenAI"})
+		if len(report.Groups) != 2 {
+			t.Fatalf("Expected 2 weekly groups, got %+v", report.Groups)
+		}
+
+		first, second := report.Groups[0], report.Groups[1]
+		if !first.WindowStart.Equal(time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)) || first.Reviewed != 2 {
+			t.Errorf("Unexpected first week %+v", first)
+		}
+		if !second.WindowStart.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) || second.Reviewed != 1 || len(second.Fields) != 0 {
+			t.Errorf("Unexpected second week %+v", second)
+		}
+	})
+
+	t.Run("template filter", func(t *testing.T) {
+		report := FieldErrors(results, Options{From: from, To: to, Interval: IntervalMonth, Template: "acme-enrollment"})
+		if len(report.Groups) != 1 || report.Groups[0].Fields[0].Field != "medications[*].sig" {
+			t.Fatalf("Unexpected report %+v", report.Groups)
+		}
+		if !report.Groups[0].WindowStart.Equal(from) || !report.Groups[0].WindowEnd.Equal(to) {
+			t.Errorf("Unexpected month window %+v", report.Groups[0])
+		}
+	})
+}
+
+func TestParseInterval(t *testing.T) {
+	for _, s := range []string{"", "day", "week", "month"} {
+		if _, err := ParseInterval(s); err != nil {
+			t.Errorf("ParseInterval(%q) returned error: %v", s, err)
+		}
+	}
+	if _, err := ParseInterval("hour"); err == nil {
+		t.Errorf("Expected an error for an unknown interval")
+	}
+}
@@ -0,0 +1,71 @@
+// Package blobstore stores sample documents by content hash, so samples do not depend on files
+// held by an LLM provider, which may expire or belong to a different backend than the one parsing.
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+)
+
+// ErrNotFound is returned when no blob is stored under a key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store is a content-addressed blob store. Blobs are immutable: storing the same bytes twice
+// yields the same key and keeps a single copy.
+type Store interface {
+	// Put stores data and returns its key, the hex SHA-256 of the data.
+	Put(ctx context.Context, data []byte) (string, error)
+
+	// Get returns the blob stored under key, or an error wrapping ErrNotFound if there is none.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// Key returns the key data is stored under.
+func Key(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// New creates the blob store selected by cfg.BlobStore: "file" for a directory on the local
+// filesystem or "s3" for an S3-compatible bucket. It returns nil if no store is configured.
+func New(cfg config.Config) (Store, error) {
+	switch cfg.BlobStore {
+	case "":
+		return nil, nil
+	case "file":
+		return NewFileStore(cfg.BlobDir)
+	case "s3":
+		return NewS3Store(context.Background(), S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			UseSSL:    cfg.S3UseSSL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob store: %s. Must be file or s3", cfg.BlobStore)
+	}
+}
+
+// validKey reports whether key is a hex SHA-256, so a key can never name a path outside a store.
+func validKey(key string) bool {
+	if len(key) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
+// verify checks that data read from a store matches its key.
+func verify(key string, data []byte) error {
+	if got := Key(data); got != key {
+		return fmt.Errorf("blob %s is corrupt: content hashes to %s", key, got)
+	}
+	return nil
+}
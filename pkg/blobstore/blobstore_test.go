@@ -0,0 +1,126 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	data := []byte("%PDF-1.7 sample")
+	key, err := store.Put(ctx, data)
+	if err != nil {
+		t.Fatalf("Failed to put blob: %v", err)
+	}
+	if key != Key(data) {
+		t.Errorf("Expected key %s, got %s", Key(data), key)
+	}
+
+	// Storing the same content again is a no-op that returns the same key.
+	again, err := store.Put(ctx, data)
+	if err != nil || again != key {
+		t.Errorf("Expected idempotent put to return %s, got %s (%v)", key, again, err)
+	}
+
+	got, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Failed to get blob: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("Expected %q, got %q", data, got)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{name: "missing", key: Key([]byte("other"))},
+		{name: "not a hash", key: "../../etc/passwd"},
+		{name: "empty", key: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := store.Get(ctx, tt.key); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Expected ErrNotFound, got %v", err)
+			}
+		})
+	}
+
+	// A blob whose content no longer matches its key is reported rather than returned.
+	if err := os.WriteFile(filepath.Join(dir, key[:2], key), []byte("tampered"), 0o600); err != nil {
+		t.Fatalf("Failed to overwrite blob: %v", err)
+	}
+	if _, err := store.Get(ctx, key); err == nil {
+		t.Error("Expected an error for a corrupt blob")
+	}
+}
+
+// TestS3Store runs against an S3-compatible service such as a local MinIO, e.g.
+// docker run -p 9000:9000 minio/minio server /data, with BLOBSTORE_TEST_S3_ENDPOINT=localhost:9000.
+func TestS3Store(t *testing.T) {
+	endpoint := os.Getenv("BLOBSTORE_TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("BLOBSTORE_TEST_S3_ENDPOINT not set")
+	}
+
+	accessKey, secretKey := os.Getenv("BLOBSTORE_TEST_S3_ACCESS_KEY"), os.Getenv("BLOBSTORE_TEST_S3_SECRET_KEY")
+	if accessKey == "" {
+		accessKey, secretKey = "minioadmin", "minioadmin"
+	}
+
+	ctx := context.Background()
+	store, err := NewS3Store(ctx, S3Options{
+		Endpoint:  endpoint,
+		Bucket:    "prescription-parser-test",
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	data := []byte("%PDF-1.7 sample")
+	key, err := store.Put(ctx, data)
+	if err != nil {
+		t.Fatalf("Failed to put blob: %v", err)
+	}
+
+	got, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Failed to get blob: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("Expected %q, got %q", data, got)
+	}
+
+	if _, err := store.Get(ctx, Key([]byte("missing"))); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	store, err := New(config.Config{})
+	if err != nil || store != nil {
+		t.Errorf("Expected no store when none is configured, got %v (%v)", store, err)
+	}
+
+	if _, err := New(config.Config{BlobStore: "file", BlobDir: t.TempDir()}); err != nil {
+		t.Errorf("Expected file store, got %v", err)
+	}
+
+	if _, err := New(config.Config{BlobStore: "ftp"}); err == nil {
+		t.Error("Expected an error for an unknown store")
+	}
+}
@@ -0,0 +1,84 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileStore stores blobs as files in a directory, sharded by the first two characters of the key.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a store in dir, creating the directory if it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("blob directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Put stores data and returns its key. Data is written to a temporary file and renamed into
+// place, so a reader never sees a partially written blob.
+func (s *FileStore) Put(ctx context.Context, data []byte) (string, error) {
+	key := Key(data)
+	path := s.path(key)
+
+	if _, err := os.Stat(path); err == nil {
+		return key, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to store blob: %w", err)
+	}
+
+	return key, nil
+}
+
+// Get returns the blob stored under key.
+func (s *FileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if !validKey(key) {
+		return nil, fmt.Errorf("blob %s: %w", key, ErrNotFound)
+	}
+
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("blob %s: %w", key, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	if err := verify(key, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, key[:2], key)
+}
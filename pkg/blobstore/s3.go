@@ -0,0 +1,116 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Options configures a connection to an S3-compatible bucket such as AWS S3 or MinIO.
+type S3Options struct {
+	Endpoint  string // host[:port] of the S3 API, e.g. s3.amazonaws.com or localhost:9000
+	Region    string // Bucket region, if the service requires one
+	Bucket    string // Bucket to store blobs in; created if it does not exist
+	Prefix    string // Key prefix within the bucket, defaults to "samples"
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+}
+
+// S3Store stores blobs as objects in an S3-compatible bucket.
+type S3Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Store connects to the bucket described by opts, creating the bucket if it does not exist.
+func NewS3Store(ctx context.Context, opts S3Options) (*S3Store, error) {
+	if opts.Endpoint == "" || opts.Bucket == "" {
+		return nil, fmt.Errorf("s3 endpoint and bucket are required")
+	}
+	if opts.Prefix == "" {
+		opts.Prefix = "samples"
+	}
+
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, ""),
+		Secure: opts.UseSSL,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize s3 client: %w", err)
+	}
+
+	exists, err := client.BucketExists(ctx, opts.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check s3 bucket: %w", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, opts.Bucket, minio.MakeBucketOptions{Region: opts.Region}); err != nil {
+			return nil, fmt.Errorf("failed to create s3 bucket: %w", err)
+		}
+	}
+
+	return &S3Store{client: client, bucket: opts.Bucket, prefix: opts.Prefix}, nil
+}
+
+// Put stores data and returns its key. Objects that already exist are not uploaded again.
+func (s *S3Store) Put(ctx context.Context, data []byte) (string, error) {
+	key := Key(data)
+	object := s.object(key)
+
+	if _, err := s.client.StatObject(ctx, s.bucket, object, minio.StatObjectOptions{}); err == nil {
+		return key, nil
+	} else if !isNoSuchKey(err) {
+		return "", fmt.Errorf("failed to check blob: %w", err)
+	}
+
+	_, err := s.client.PutObject(ctx, s.bucket, object, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload blob: %w", err)
+	}
+
+	return key, nil
+}
+
+// Get returns the blob stored under key.
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	if !validKey(key) {
+		return nil, fmt.Errorf("blob %s: %w", key, ErrNotFound)
+	}
+
+	obj, err := s.client.GetObject(ctx, s.bucket, s.object(key), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if isNoSuchKey(err) {
+		return nil, fmt.Errorf("blob %s: %w", key, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	if err := verify(key, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *S3Store) object(key string) string {
+	return path.Join(s.prefix, key[:2], key)
+}
+
+func isNoSuchKey(err error) bool {
+	return err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey"
+}
@@ -4,26 +4,59 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"time"
 )
 
 // Config holds all application configuration settings.
 // Values are loaded from environment variables when the application starts.
 type Config struct {
-	Host             string        // Host address for the HTTP server
-	Port             string        // Port for the HTTP server
-	ReadTimeout      time.Duration // Maximum duration for reading the entire request
-	WriteTimeout     time.Duration // Maximum duration for writing the response
-	IdleTimeout      time.Duration // Maximum duration to wait for the next request
-	DatabaseHost     string        // Host address of the database server
-	DatabasePort     string        // Port of the database server
-	DatabaseName     string        // Name of the database to connect to
-	DatabaseUser     string        // Username for database authentication
-	DatabasePassword string        // Password for database authentication
-	RunMigrations    bool          // Whether to run database migrations on startup
-	OpenAIAPIKey     string        // API key for OpenAI services
-	GeminiAPIKey     string        // API key for Gemini services
-	ParserBackend    string        // Backend to use for prescription parsing ("OpenAI" or "Gemini")
+	Host                 string        // Host address for the HTTP server
+	Port                 string        // Port for the HTTP server
+	ReadTimeout          time.Duration // Maximum duration for reading the entire request
+	WriteTimeout         time.Duration // Maximum duration for writing the response
+	IdleTimeout          time.Duration // Maximum duration to wait for the next request
+	DatabaseHost         string        // Host address of the database server
+	DatabasePort         string        // Port of the database server
+	DatabaseName         string        // Name of the database to connect to
+	DatabaseUser         string        // Username for database authentication
+	DatabasePassword     string        // Password for database authentication
+	RunMigrations        bool          // Whether to run database migrations on startup
+	OpenAIAPIKey         string        // API key for OpenAI services
+	GeminiAPIKey         string        // API key for Gemini services
+	ParserBackend        string        // Backend to use for prescription parsing ("OpenAI" or "Gemini")
+	RxNormDir            string        // Directory containing RxNorm RXNCONSO.RRF and RXNREL.RRF files for drug name normalization
+	ControlledTable      string        // CSV file overriding the bundled controlled substance schedule table
+	RulesFile            string        // YAML or JSON file of validation rule sets selectable per parse request
+	TemplatesFile        string        // YAML or JSON file of form templates documents are identified as
+	PromptsDir           string        // Directory of prompt versions, one subdirectory per version, added to the bundled version
+	PromptVersion        string        // Prompt version used for requests that select none, empty for the bundled version
+	ExperimentsFile      string        // YAML or JSON file of experiments splitting parse jobs between variants
+	Pipeline             string        // Comma-separated stages of the parsing pipeline, empty for the default pipeline
+	SegmentDocuments     bool          // Whether to split documents into their forms by page layout and parse each separately
+	StandardizeAddresses bool          // Whether to standardize parsed addresses to USPS format
+	NormalizePhones      bool          // Whether to normalize parsed phone and fax numbers
+	PersistResults       bool          // Whether to save completed parse results to the database for reporting
+	NcpdpSenderID        string        // Sender identifier in exported NCPDP SCRIPT messages
+	NcpdpPharmacyID      string        // Default NCPDP ID of the pharmacy receiving exported SCRIPT messages
+	Hl7SendingFacility   string        // Sending facility (MSH-4) of exported HL7 messages
+	Hl7ReceivingApp      string        // Receiving application (MSH-5) of exported HL7 messages
+	Hl7ReceivingFacility string        // Receiving facility (MSH-6) of exported HL7 messages
+	Hl7ListenerAddr      string        // host:port of the MLLP listener completed jobs are pushed to
+	BlobStore            string        // Store for sample documents ("file" or "s3"), empty to rely on provider file IDs only
+	BlobDir              string        // Directory of the file blob store
+	S3Endpoint           string        // host:port of the S3-compatible API of the s3 blob store
+	S3Region             string        // Region of the s3 blob store bucket
+	S3Bucket             string        // Bucket of the s3 blob store
+	S3AccessKey          string        // Access key of the s3 blob store
+	S3SecretKey          string        // Secret key of the s3 blob store
+	S3UseSSL             bool          // Whether to connect to the s3 blob store over TLS
+	SampleRetrieval      string        // How samples are found for the second parsing pass ("content" or "layout")
+	SampleCount          int           // Maximum number of samples retrieved for the second parsing pass
+	SampleDistance       string        // Distance metric for content sample retrieval ("l2" or "cosine")
+	SampleMaxDistance    float64       // Content embedding distance beyond which samples are not retrieved, zero for no limit
+	LayoutMaxDistance    float64       // Layout fingerprint distance beyond which samples are not retrieved, zero for no limit
+	SampleMMRLambda      float64       // Relevance weight of MMR diversification of samples in (0, 1), zero to not diversify
 }
 
 // NewConfig creates a new Config instance with values loaded from environment variables.
@@ -51,6 +84,99 @@ func NewConfig() Config {
 		parserBackend = "Gemini"
 	}
 
+	// Drug name normalization is enabled when an RxNorm subset is provided
+	rxNormDir := os.Getenv("RXNORM_DIR")
+
+	// Controlled substance checks use the bundled schedule table unless a replacement is provided
+	controlledTable := os.Getenv("CONTROLLED_SUBSTANCES_FILE")
+
+	// Customer validation rules are only applied when a rule file is provided
+	rulesFile := os.Getenv("RULES_FILE")
+
+	// Documents are only identified as form templates when a template file is provided
+	templatesFile := os.Getenv("TEMPLATES_FILE")
+
+	// Prompt versions beyond the bundled one are loaded from a directory; the bundled version is the default
+	promptsDir := os.Getenv("PROMPTS_DIR")
+	promptVersion := os.Getenv("PROMPT_VERSION")
+
+	// Parse jobs are only split between experiment variants when an experiment file is provided
+	experimentsFile := os.Getenv("EXPERIMENTS_FILE")
+
+	// The default parsing pipeline is used unless one is configured
+	pipeline := os.Getenv("PIPELINE")
+
+	// Documents are parsed as a single prescription unless segmentation is turned on
+	segmentDocuments, _ := strconv.ParseBool(os.Getenv("SEGMENT_DOCUMENTS"))
+
+	// Address standardization is enabled unless explicitly turned off
+	standardizeAddresses := true
+	if v, err := strconv.ParseBool(os.Getenv("STANDARDIZE_ADDRESSES")); err == nil {
+		standardizeAddresses = v
+	}
+
+	// Phone number normalization is enabled unless explicitly turned off
+	normalizePhones := true
+	if v, err := strconv.ParseBool(os.Getenv("NORMALIZE_PHONES")); err == nil {
+		normalizePhones = v
+	}
+
+	// Completed parse results are saved for reporting unless explicitly turned off
+	persistResults := true
+	if v, err := strconv.ParseBool(os.Getenv("PERSIST_RESULTS")); err == nil {
+		persistResults = v
+	}
+
+	// NCPDP SCRIPT exports identify this service as the sender unless configured otherwise
+	ncpdpSenderID := os.Getenv("NCPDP_SENDER_ID")
+	if ncpdpSenderID == "" {
+		ncpdpSenderID = "prescription-parser"
+	}
+	ncpdpPharmacyID := os.Getenv("NCPDP_PHARMACY_ID")
+
+	// HL7 v2 exports are addressed with these MSH values; pushing jobs requires a listener address
+	hl7SendingFacility := os.Getenv("HL7_SENDING_FACILITY")
+	hl7ReceivingApp := os.Getenv("HL7_RECEIVING_APPLICATION")
+	hl7ReceivingFacility := os.Getenv("HL7_RECEIVING_FACILITY")
+	hl7ListenerAddr := os.Getenv("HL7_MLLP_ADDR")
+
+	// Sample documents are only stored locally when a blob store is selected
+	blobStore := os.Getenv("BLOB_STORE")
+	blobDir := os.Getenv("BLOB_DIR")
+	if blobDir == "" {
+		blobDir = "data/blobs"
+	}
+	s3UseSSL := true
+	if v, err := strconv.ParseBool(os.Getenv("S3_USE_SSL")); err == nil {
+		s3UseSSL = v
+	}
+
+	// Samples are retrieved by prescription content unless layout retrieval is selected
+	sampleRetrieval := os.Getenv("SAMPLE_RETRIEVAL")
+	if sampleRetrieval == "" {
+		sampleRetrieval = "content"
+	}
+
+	// Three nearest samples by L2 distance are retrieved unless configured otherwise
+	sampleCount := 3
+	if v, err := strconv.Atoi(os.Getenv("SAMPLE_COUNT")); err == nil {
+		sampleCount = v
+	}
+	sampleDistance := os.Getenv("SAMPLE_DISTANCE")
+	if sampleDistance == "" {
+		sampleDistance = "l2"
+	}
+	var sampleMaxDistance, sampleLayoutMaxDistance, sampleMMRLambda float64
+	if v, err := strconv.ParseFloat(os.Getenv("SAMPLE_MAX_DISTANCE"), 64); err == nil {
+		sampleMaxDistance = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("SAMPLE_LAYOUT_MAX_DISTANCE"), 64); err == nil {
+		sampleLayoutMaxDistance = v
+	}
+	if v, err := strconv.ParseFloat(os.Getenv("SAMPLE_MMR_LAMBDA"), 64); err == nil {
+		sampleMMRLambda = v
+	}
+
 	// Set default server port if not specified
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
@@ -65,19 +191,51 @@ func NewConfig() Config {
 
 	// Return the populated configuration
 	return Config{
-		Host:             host,
-		Port:             port,
-		ReadTimeout:      15 * time.Second,
-		WriteTimeout:     15 * time.Second,
-		IdleTimeout:      60 * time.Second,
-		DatabaseHost:     dbHost,
-		DatabasePort:     dbPort,
-		DatabaseName:     dbName,
-		DatabaseUser:     dbUser,
-		DatabasePassword: dbPass,
-		RunMigrations:    false,
-		OpenAIAPIKey:     openAIAPIKey,
-		GeminiAPIKey:     geminiAPIKey,
-		ParserBackend:    parserBackend,
+		Host:                 host,
+		Port:                 port,
+		ReadTimeout:          15 * time.Second,
+		WriteTimeout:         15 * time.Second,
+		IdleTimeout:          60 * time.Second,
+		DatabaseHost:         dbHost,
+		DatabasePort:         dbPort,
+		DatabaseName:         dbName,
+		DatabaseUser:         dbUser,
+		DatabasePassword:     dbPass,
+		RunMigrations:        false,
+		OpenAIAPIKey:         openAIAPIKey,
+		GeminiAPIKey:         geminiAPIKey,
+		ParserBackend:        parserBackend,
+		RxNormDir:            rxNormDir,
+		ControlledTable:      controlledTable,
+		RulesFile:            rulesFile,
+		TemplatesFile:        templatesFile,
+		PromptsDir:           promptsDir,
+		PromptVersion:        promptVersion,
+		ExperimentsFile:      experimentsFile,
+		Pipeline:             pipeline,
+		SegmentDocuments:     segmentDocuments,
+		StandardizeAddresses: standardizeAddresses,
+		NormalizePhones:      normalizePhones,
+		PersistResults:       persistResults,
+		NcpdpSenderID:        ncpdpSenderID,
+		NcpdpPharmacyID:      ncpdpPharmacyID,
+		Hl7SendingFacility:   hl7SendingFacility,
+		Hl7ReceivingApp:      hl7ReceivingApp,
+		Hl7ReceivingFacility: hl7ReceivingFacility,
+		Hl7ListenerAddr:      hl7ListenerAddr,
+		BlobStore:            blobStore,
+		BlobDir:              blobDir,
+		S3Endpoint:           os.Getenv("S3_ENDPOINT"),
+		S3Region:             os.Getenv("S3_REGION"),
+		S3Bucket:             os.Getenv("S3_BUCKET"),
+		S3AccessKey:          os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:          os.Getenv("S3_SECRET_KEY"),
+		S3UseSSL:             s3UseSSL,
+		SampleRetrieval:      sampleRetrieval,
+		SampleCount:          sampleCount,
+		SampleDistance:       sampleDistance,
+		SampleMaxDistance:    sampleMaxDistance,
+		LayoutMaxDistance:    sampleLayoutMaxDistance,
+		SampleMMRLambda:      sampleMMRLambda,
 	}
 }
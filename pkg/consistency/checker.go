@@ -0,0 +1,126 @@
+// Package consistency checks a parsed prescription for values that cannot all be right as read:
+// NPIs failing their check digit, dates that are unreadable or out of order, and medication
+// entries filled in without a drug name. Such values are usually misreads, so the issues are
+// reported as warnings on the fields to re-check against the document.
+package consistency
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// dateLayout is the format the parsing prompts request dates in.
+const dateLayout = "2006-01-02"
+
+// npiPattern matches a ten-digit National Provider Identifier.
+var npiPattern = regexp.MustCompile(`^[0-9]{10}$`)
+
+// Checker checks the fields of a prescription against each other.
+type Checker struct{}
+
+// NewChecker creates a consistency checker.
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Process reports an invalid prescriber NPI, dates that are not in YYYY-MM-DD form, a patient
+// born after the prescription was written, a need-by date before it was written, and
+// medications with details but no drug name.
+func (c *Checker) Process(ctx context.Context, rx *models.Prescription) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+
+	if rx.Prescriber.Npi != "" && !ValidNPI(rx.Prescriber.Npi) {
+		issues = append(issues, models.ValidationIssue{
+			Field:    "prescriber.npi",
+			Code:     "npi_invalid",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("prescriber NPI %q is not 10 digits with a valid check digit", rx.Prescriber.Npi),
+		})
+	}
+
+	dates := map[string]time.Time{}
+	for _, field := range []struct {
+		path  string
+		value string
+	}{
+		{"date_written", rx.DateWritten},
+		{"date_needed", rx.DateNeeded},
+		{"patient.dob", rx.Patient.Dob},
+		{"prescriber_signature.date", rx.PrescriberSignature.Date},
+	} {
+		if field.value == "" {
+			continue
+		}
+		date, err := time.Parse(dateLayout, field.value)
+		if err != nil {
+			issues = append(issues, models.ValidationIssue{
+				Field:    field.path,
+				Code:     "date_invalid",
+				Severity: models.SeverityWarning,
+				Message:  fmt.Sprintf("%s %q is not a valid YYYY-MM-DD date", field.path, field.value),
+			})
+			continue
+		}
+		dates[field.path] = date
+	}
+
+	if written, ok := dates["date_written"]; ok {
+		if dob, ok := dates["patient.dob"]; ok && dob.After(written) {
+			issues = append(issues, models.ValidationIssue{
+				Field:    "patient.dob",
+				Code:     "date_inconsistent",
+				Severity: models.SeverityWarning,
+				Message:  fmt.Sprintf("patient date of birth %s is after the date written %s", rx.Patient.Dob, rx.DateWritten),
+			})
+		}
+		if needed, ok := dates["date_needed"]; ok && needed.Before(written) {
+			issues = append(issues, models.ValidationIssue{
+				Field:    "date_needed",
+				Code:     "date_inconsistent",
+				Severity: models.SeverityWarning,
+				Message:  fmt.Sprintf("date needed %s is before the date written %s", rx.DateNeeded, rx.DateWritten),
+			})
+		}
+	}
+
+	for i, med := range rx.Medications {
+		if med.DrugName != "" || (med.Strength == "" && med.SIG == "" && med.Quantity == "" && med.Ndc == "") {
+			continue
+		}
+		issues = append(issues, models.ValidationIssue{
+			Field:    fmt.Sprintf("medications[%d].drug_name", i),
+			Code:     "drug_name_missing",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("medication %d has a strength, SIG, quantity or NDC but no drug name", i+1),
+		})
+	}
+
+	return issues
+}
+
+// ValidNPI reports whether npi is ten digits whose last digit is the Luhn check digit of the
+// first nine prefixed with the 80840 health industry identifier, as defined by CMS.
+func ValidNPI(npi string) bool {
+	if !npiPattern.MatchString(npi) {
+		return false
+	}
+
+	// The prefix 80840 contributes a constant 24 to the Luhn sum
+	sum := 24
+	for i := 0; i < 9; i++ {
+		digit := int(npi[8-i] - '0')
+		if i%2 == 0 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+
+	return int(npi[9]-'0') == (10-sum%10)%10
+}
@@ -0,0 +1,83 @@
+package consistency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func TestValidNPI(t *testing.T) {
+	tests := []struct {
+		npi  string
+		want bool
+	}{
+		{npi: "1234567893", want: true},
+		{npi: "1245319599", want: true},
+		{npi: "1234567890", want: false},
+		{npi: "123456789", want: false},
+		{npi: "12345678931", want: false},
+		{npi: "12345G7893", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.npi, func(t *testing.T) {
+			if got := ValidNPI(tt.npi); got != tt.want {
+				t.Errorf("ValidNPI(%q) = %v, want %v", tt.npi, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name string
+		rx   models.Prescription
+		want []string // Fields of the issues, in order
+	}{
+		{
+			name: "consistent",
+			rx: models.Prescription{
+				DateWritten: "2024-03-01",
+				DateNeeded:  "2024-03-05",
+				Patient:     models.Patient{Dob: "1980-07-14"},
+				Prescriber:  models.Prescriber{Npi: "1234567893"},
+				Medications: []models.Medication{{DrugName: "Humira", Strength: "40 mg/0.4 mL"}, {}},
+			},
+		},
+		{
+			name: "invalid npi",
+			rx:   models.Prescription{Prescriber: models.Prescriber{Npi: "1234567890"}},
+			want: []string{"prescriber.npi"},
+		},
+		{
+			name: "unreadable date",
+			rx:   models.Prescription{DateWritten: "03/01/2024", PrescriberSignature: models.SignatureInfo{Date: "2024-02-30"}},
+			want: []string{"date_written", "prescriber_signature.date"},
+		},
+		{
+			name: "dates out of order",
+			rx:   models.Prescription{DateWritten: "2024-03-01", DateNeeded: "2024-02-01", Patient: models.Patient{Dob: "2024-04-01"}},
+			want: []string{"patient.dob", "date_needed"},
+		},
+		{
+			name: "missing drug name",
+			rx:   models.Prescription{Medications: []models.Medication{{DrugName: "Humira"}, {Strength: "40 mg", Quantity: "2"}}},
+			want: []string{"medications[1].drug_name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := NewChecker().Process(context.Background(), &tt.rx)
+			if len(issues) != len(tt.want) {
+				t.Fatalf("Expected %d issues, got %+v", len(tt.want), issues)
+			}
+			for i, issue := range issues {
+				if issue.Field != tt.want[i] || issue.Severity != models.SeverityWarning {
+					t.Errorf("Expected a warning on %s, got %+v", tt.want[i], issue)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package controlled
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/personname"
+)
+
+// DefaultRefillLimits holds the maximum number of refills allowed per schedule.
+// Schedule II prescriptions cannot be refilled and Schedule III and IV prescriptions may be
+// refilled at most five times (21 CFR 1306.12 and 1306.22). Schedule V has no federal limit,
+// but the same cap is applied by default since most state boards impose one.
+var DefaultRefillLimits = map[Schedule]int{
+	ScheduleII:  0,
+	ScheduleIII: 5,
+	ScheduleIV:  5,
+	ScheduleV:   5,
+}
+
+var (
+	// deaPattern matches a DEA registration number: a registrant type letter, the first
+	// letter of the registrant's last name (or 9 for businesses) and seven digits.
+	deaPattern = regexp.MustCompile(`^[ABCDEFGHJKLMPRSTUX][A-Z9][0-9]{7}$`)
+
+	// refillsPattern finds the first count in a refills value such as "x3" or "5 refills".
+	refillsPattern = regexp.MustCompile(`\d+`)
+)
+
+// refillWords maps written refill counts to numbers.
+var refillWords = map[string]int{
+	"none": 0, "no": 0, "nr": 0, "zero": 0, "n/a": 0,
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+// unlimitedRefills lists refills values that authorize open-ended refills.
+var unlimitedRefills = map[string]bool{
+	"prn": true, "unlimited": true, "as needed": true, "ad lib": true,
+}
+
+// Checker flags controlled substances and validates the prescription against schedule rules.
+// Rule violations are reported with error severity, which blocks the job result.
+type Checker struct {
+	table        *Table
+	refillLimits map[Schedule]int
+}
+
+// NewChecker creates a checker using the given schedule table and the default refill limits.
+func NewChecker(table *Table) *Checker {
+	return &Checker{
+		table:        table,
+		refillLimits: DefaultRefillLimits,
+	}
+}
+
+// Process marks each controlled medication with its schedule, checks its refills against the
+// schedule's limit and, when any controlled medication is present, requires a valid prescriber DEA number.
+func (c *Checker) Process(ctx context.Context, rx *models.Prescription) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+	var controlled []string
+
+	for i := range rx.Medications {
+		med := &rx.Medications[i]
+
+		substance, ok := c.table.Lookup(*med)
+		if !ok {
+			continue
+		}
+
+		med.DeaSchedule = string(substance.Schedule)
+		controlled = append(controlled, med.DrugName)
+
+		issues = append(issues, models.ValidationIssue{
+			Field:    fmt.Sprintf("medications[%d].drug_name", i),
+			Code:     "controlled_substance",
+			Severity: models.SeverityInfo,
+			Message:  fmt.Sprintf("%s is a Schedule %s controlled substance (%s)", med.DrugName, substance.Schedule, substance.Name),
+		})
+
+		issues = append(issues, c.checkRefills(i, *med, substance.Schedule)...)
+	}
+
+	if len(controlled) > 0 {
+		issues = append(issues, checkDea(rx.Prescriber, controlled)...)
+	}
+
+	return issues
+}
+
+// checkRefills validates the refills authorized for a controlled medication.
+func (c *Checker) checkRefills(index int, med models.Medication, schedule Schedule) []models.ValidationIssue {
+	field := fmt.Sprintf("medications[%d].refills", index)
+	limit := c.refillLimits[schedule]
+
+	refills, unlimited, ok := ParseRefills(med.Refills)
+	switch {
+	case !ok:
+		return []models.ValidationIssue{{
+			Field:    field,
+			Code:     "controlled_refills_unreadable",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("refills %q could not be read; Schedule %s allows at most %d", med.Refills, schedule, limit),
+		}}
+	case schedule == ScheduleII && (unlimited || refills > 0):
+		return []models.ValidationIssue{{
+			Field:    field,
+			Code:     "schedule_ii_refills",
+			Severity: models.SeverityError,
+			Message:  fmt.Sprintf("Schedule II prescriptions cannot be refilled but %q refills were authorized", med.Refills),
+		}}
+	case unlimited || refills > limit:
+		return []models.ValidationIssue{{
+			Field:    field,
+			Code:     "controlled_refill_limit",
+			Severity: models.SeverityError,
+			Message:  fmt.Sprintf("Schedule %s prescriptions allow at most %d refills but %q were authorized", schedule, limit, med.Refills),
+		}}
+	}
+
+	return nil
+}
+
+// checkDea requires a well-formed DEA number for prescriptions containing controlled substances.
+func checkDea(prescriber models.Prescriber, controlled []string) []models.ValidationIssue {
+	dea := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(prescriber.Dea))
+
+	if dea == "" {
+		return []models.ValidationIssue{{
+			Field:    "prescriber.dea",
+			Code:     "dea_required",
+			Severity: models.SeverityError,
+			Message:  fmt.Sprintf("a prescriber DEA number is required for controlled substances (%s)", strings.Join(controlled, ", ")),
+		}}
+	}
+
+	if !ValidDea(dea) {
+		return []models.ValidationIssue{{
+			Field:    "prescriber.dea",
+			Code:     "dea_invalid",
+			Severity: models.SeverityError,
+			Message:  fmt.Sprintf("prescriber DEA number %q is not a valid DEA registration number", prescriber.Dea),
+		}}
+	}
+
+	if initial := lastNameInitial(prescriber.Name); initial != 0 && dea[1] != '9' && rune(dea[1]) != initial {
+		return []models.ValidationIssue{{
+			Field:    "prescriber.dea",
+			Code:     "dea_name_mismatch",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("prescriber DEA number %q does not match the initial of prescriber %q", prescriber.Dea, prescriber.Name),
+		}}
+	}
+
+	return nil
+}
+
+// ValidDea reports whether s is a correctly formatted DEA registration number with a valid check digit.
+// The check digit is the last digit of the sum of the first, third and fifth digits plus twice
+// the sum of the second, fourth and sixth digits.
+func ValidDea(s string) bool {
+	if !deaPattern.MatchString(s) {
+		return false
+	}
+
+	d := make([]int, 7)
+	for i := range d {
+		d[i] = int(s[i+2] - '0')
+	}
+
+	sum := d[0] + d[2] + d[4] + 2*(d[1]+d[3]+d[5])
+	return sum%10 == d[6]
+}
+
+// ParseRefills reads the number of refills from a free-text value.
+// It reports whether the value authorizes unlimited refills and whether it could be read at all.
+// An empty value is treated as no refills.
+func ParseRefills(s string) (refills int, unlimited bool, ok bool) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	if v == "" {
+		return 0, false, true
+	}
+	if unlimitedRefills[v] {
+		return 0, true, true
+	}
+	if n, found := refillWords[v]; found {
+		return n, false, true
+	}
+	if m := refillsPattern.FindString(v); m != "" {
+		n, err := strconv.Atoi(m)
+		return n, false, err == nil
+	}
+	for word, n := range refillWords {
+		if strings.HasPrefix(v, word+" ") {
+			return n, false, true
+		}
+	}
+
+	return 0, false, false
+}
+
+// lastNameInitial returns the first letter of the prescriber's last name, ignoring
+// titles and credentials, or zero if it cannot be determined.
+func lastNameInitial(name string) rune {
+	last := strings.ToUpper(personname.Split(name).Last)
+	if last == "" || last[0] < 'A' || last[0] > 'Z' {
+		return 0
+	}
+	return rune(last[0])
+}
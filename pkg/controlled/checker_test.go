@@ -0,0 +1,223 @@
+package controlled
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func TestValidDea(t *testing.T) {
+	tests := []struct {
+		dea  string
+		want bool
+	}{
+		{dea: "AB1234563", want: true},
+		{dea: "MB1234563", want: true},
+		{dea: "A91234563", want: true},
+		{dea: "AB1234564", want: false},
+		{dea: "AB123456", want: false},
+		{dea: "QB1234563", want: false},
+		{dea: "1234567890", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dea, func(t *testing.T) {
+			if got := ValidDea(tt.dea); got != tt.want {
+				t.Errorf("ValidDea(%q) = %v, want %v", tt.dea, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	table := DefaultTable()
+
+	tests := []struct {
+		name       string
+		medication models.Medication
+		want       Schedule
+		wantOK     bool
+	}{
+		{name: "generic", medication: models.Medication{DrugName: "Oxycodone HCl"}, want: ScheduleII, wantOK: true},
+		{name: "brand", medication: models.Medication{DrugName: "XANAX XR"}, want: ScheduleIV, wantOK: true},
+		{name: "combination", medication: models.Medication{DrugName: "Acetaminophen/Codeine #3"}, want: ScheduleIII, wantOK: true},
+		{name: "single entity", medication: models.Medication{DrugName: "Codeine sulfate"}, want: ScheduleII, wantOK: true},
+		{
+			name: "normalized generic",
+			medication: models.Medication{
+				DrugName:   "Lyrika",
+				Normalized: &models.DrugNormalization{RxCUI: "1", BrandName: "Lyrica", GenericName: "pregabalin"},
+			},
+			want:   ScheduleV,
+			wantOK: true,
+		},
+		{name: "not controlled", medication: models.Medication{DrugName: "Humira"}, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			substance, ok := table.Lookup(tt.medication)
+			if ok != tt.wantOK {
+				t.Fatalf("Expected ok %v, got %v", tt.wantOK, ok)
+			}
+			if ok && substance.Schedule != tt.want {
+				t.Errorf("Expected schedule %s, got %s (%s)", tt.want, substance.Schedule, substance.Name)
+			}
+		})
+	}
+}
+
+func TestLookupByRxCUI(t *testing.T) {
+	table, err := ParseTable(strings.NewReader("name,rxcui,schedule\n,1049621,II\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse table: %v", err)
+	}
+
+	med := models.Medication{
+		DrugName:   "Roxicodone",
+		Normalized: &models.DrugNormalization{RxCUI: "1049621"},
+	}
+
+	substance, ok := table.Lookup(med)
+	if !ok || substance.Schedule != ScheduleII {
+		t.Errorf("Expected Schedule II match by RxCUI, got %v %v", substance.Schedule, ok)
+	}
+}
+
+func TestParseTableInvalidSchedule(t *testing.T) {
+	_, err := ParseTable(strings.NewReader("name,rxcui,schedule\nheroin,,I\n"))
+	if err == nil {
+		t.Errorf("Expected error for unsupported schedule")
+	}
+}
+
+func TestCheckerProcess(t *testing.T) {
+	checker := NewChecker(DefaultTable())
+
+	prescriber := models.Prescriber{Name: "Jane Brown, MD", Dea: "AB1234563"}
+
+	tests := []struct {
+		name       string
+		rx         models.Prescription
+		wantCodes  []string
+		wantBlocks bool
+	}{
+		{
+			name: "non-controlled medication",
+			rx: models.Prescription{
+				Medications: []models.Medication{{DrugName: "Metformin", Refills: "11"}},
+			},
+			wantCodes: nil,
+		},
+		{
+			name: "schedule II without refills",
+			rx: models.Prescription{
+				Prescriber:  prescriber,
+				Medications: []models.Medication{{DrugName: "Oxycodone", Refills: "0"}},
+			},
+			wantCodes: []string{"controlled_substance"},
+		},
+		{
+			name: "schedule II with refills",
+			rx: models.Prescription{
+				Prescriber:  prescriber,
+				Medications: []models.Medication{{DrugName: "Adderall", Refills: "2"}},
+			},
+			wantCodes:  []string{"controlled_substance", "schedule_ii_refills"},
+			wantBlocks: true,
+		},
+		{
+			name: "schedule IV over refill limit",
+			rx: models.Prescription{
+				Prescriber:  prescriber,
+				Medications: []models.Medication{{DrugName: "Alprazolam", Refills: "x6"}},
+			},
+			wantCodes:  []string{"controlled_substance", "controlled_refill_limit"},
+			wantBlocks: true,
+		},
+		{
+			name: "schedule IV unlimited refills",
+			rx: models.Prescription{
+				Prescriber:  prescriber,
+				Medications: []models.Medication{{DrugName: "Zolpidem", Refills: "PRN"}},
+			},
+			wantCodes:  []string{"controlled_substance", "controlled_refill_limit"},
+			wantBlocks: true,
+		},
+		{
+			name: "schedule III within limit",
+			rx: models.Prescription{
+				Prescriber:  prescriber,
+				Medications: []models.Medication{{DrugName: "Testosterone cypionate", Refills: "five"}},
+			},
+			wantCodes: []string{"controlled_substance"},
+		},
+		{
+			name: "unreadable refills",
+			rx: models.Prescription{
+				Prescriber:  prescriber,
+				Medications: []models.Medication{{DrugName: "Lorazepam", Refills: "see notes"}},
+			},
+			wantCodes: []string{"controlled_substance", "controlled_refills_unreadable"},
+		},
+		{
+			name: "missing DEA",
+			rx: models.Prescription{
+				Prescriber:  models.Prescriber{Name: "Jane Brown"},
+				Medications: []models.Medication{{DrugName: "Tramadol", Refills: "1"}},
+			},
+			wantCodes:  []string{"controlled_substance", "dea_required"},
+			wantBlocks: true,
+		},
+		{
+			name: "invalid DEA check digit",
+			rx: models.Prescription{
+				Prescriber:  models.Prescriber{Name: "Jane Brown", Dea: "AB1234567"},
+				Medications: []models.Medication{{DrugName: "Tramadol", Refills: "1"}},
+			},
+			wantCodes:  []string{"controlled_substance", "dea_invalid"},
+			wantBlocks: true,
+		},
+		{
+			name: "DEA does not match last name",
+			rx: models.Prescription{
+				Prescriber:  models.Prescriber{Name: "Dr. Jane Smith", Dea: "AB1234563"},
+				Medications: []models.Medication{{DrugName: "Tramadol", Refills: "1"}},
+			},
+			wantCodes: []string{"controlled_substance", "dea_name_mismatch"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := checker.Process(context.Background(), &tt.rx)
+
+			var codes []string
+			blocks := false
+			for _, issue := range issues {
+				codes = append(codes, issue.Code)
+				if issue.Severity == models.SeverityError {
+					blocks = true
+				}
+			}
+
+			if strings.Join(codes, ",") != strings.Join(tt.wantCodes, ",") {
+				t.Errorf("Expected issues %v, got %v", tt.wantCodes, codes)
+			}
+			if blocks != tt.wantBlocks {
+				t.Errorf("Expected blocking %v, got %v", tt.wantBlocks, blocks)
+			}
+		})
+	}
+
+	rx := models.Prescription{
+		Prescriber:  prescriber,
+		Medications: []models.Medication{{DrugName: "Oxycodone"}, {DrugName: "Humira"}},
+	}
+	checker.Process(context.Background(), &rx)
+	if rx.Medications[0].DeaSchedule != "II" || rx.Medications[1].DeaSchedule != "" {
+		t.Errorf("Expected only the controlled medication to be flagged, got %q and %q", rx.Medications[0].DeaSchedule, rx.Medications[1].DeaSchedule)
+	}
+}
@@ -0,0 +1,168 @@
+// Package controlled identifies controlled substances on parsed prescriptions and enforces
+// the DEA schedule-specific prescribing rules that apply to them.
+package controlled
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// Schedule is a DEA controlled substance schedule.
+type Schedule string
+
+// Schedules that may appear on a prescription. Schedule I substances have no accepted
+// medical use and cannot be prescribed, so they are not represented.
+const (
+	ScheduleII  Schedule = "II"
+	ScheduleIII Schedule = "III"
+	ScheduleIV  Schedule = "IV"
+	ScheduleV   Schedule = "V"
+)
+
+// defaultTable is the bundled schedule table of commonly prescribed controlled substances.
+//
+//go:embed schedules.csv
+var defaultTable string
+
+// Substance is a single entry in the schedule table.
+type Substance struct {
+	Name     string   // Ingredient or brand name
+	RxCUI    string   // Optional RxNorm concept the entry applies to
+	Schedule Schedule // DEA schedule of the substance
+	tokens   []string // Normalized words of Name
+}
+
+// Table maps drug names and RxCUIs to DEA schedules.
+type Table struct {
+	substances []Substance
+	byRxCUI    map[string]Substance
+}
+
+// DefaultTable returns the bundled schedule table.
+func DefaultTable() *Table {
+	table, err := ParseTable(strings.NewReader(defaultTable))
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+// LoadTable reads a schedule table from a CSV file with name, rxcui and schedule columns.
+func LoadTable(path string) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open controlled substance table: %w", err)
+	}
+	defer f.Close()
+
+	return ParseTable(f)
+}
+
+// ParseTable reads a schedule table from CSV input. The first row is a header naming
+// the name, rxcui and schedule columns; either name or rxcui may be empty on a row.
+func ParseTable(r io.Reader) (*Table, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read controlled substance table header: %w", err)
+	}
+	if strings.ToLower(strings.Join(header, ",")) != "name,rxcui,schedule" {
+		return nil, fmt.Errorf("unexpected controlled substance table header: %s", strings.Join(header, ","))
+	}
+
+	table := &Table{byRxCUI: make(map[string]Substance)}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read controlled substance table: %w", err)
+		}
+
+		substance := Substance{
+			Name:     strings.TrimSpace(record[0]),
+			RxCUI:    strings.TrimSpace(record[1]),
+			Schedule: Schedule(strings.ToUpper(strings.TrimSpace(record[2]))),
+		}
+
+		switch substance.Schedule {
+		case ScheduleII, ScheduleIII, ScheduleIV, ScheduleV:
+		default:
+			return nil, fmt.Errorf("invalid schedule %q for %q", record[2], substance.Name)
+		}
+
+		if substance.RxCUI != "" {
+			table.byRxCUI[substance.RxCUI] = substance
+		}
+
+		substance.tokens = tokenize(substance.Name)
+		if len(substance.tokens) > 0 {
+			table.substances = append(table.substances, substance)
+		}
+	}
+
+	return table, nil
+}
+
+// Lookup returns the controlled substance entry that applies to a medication.
+// RxCUI entries are checked first using the medication's RxNorm normalization. Otherwise the
+// drug name and normalized brand and generic names are compared against named entries, and the
+// entry with the most matching words wins so combination products resolve to their own schedule.
+func (t *Table) Lookup(med models.Medication) (Substance, bool) {
+	names := []string{med.DrugName}
+
+	if med.Normalized != nil {
+		if substance, ok := t.byRxCUI[med.Normalized.RxCUI]; ok {
+			return substance, true
+		}
+		names = append(names, med.Normalized.BrandName, med.Normalized.GenericName, med.Normalized.Name)
+	}
+
+	var best Substance
+	found := false
+	for _, name := range names {
+		words := make(map[string]bool)
+		for _, token := range tokenize(name) {
+			words[token] = true
+		}
+
+		for _, substance := range t.substances {
+			if !containsAll(words, substance.tokens) {
+				continue
+			}
+			if !found || len(substance.tokens) > len(best.tokens) {
+				best = substance
+				found = true
+			}
+		}
+	}
+
+	return best, found
+}
+
+// tokenize upper-cases s and splits it into words of letters only, so "Acetaminophen/Codeine #3"
+// and "codeine-acetaminophen" both yield comparable word sets.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return r < 'A' || r > 'Z'
+	})
+}
+
+func containsAll(words map[string]bool, tokens []string) bool {
+	for _, token := range tokens {
+		if !words[token] {
+			return false
+		}
+	}
+	return true
+}
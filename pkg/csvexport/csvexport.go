@@ -0,0 +1,317 @@
+// Package csvexport flattens parse results into CSV for bulk reporting. Each medication is a
+// row that repeats the job, patient, prescriber, diagnosis and insurance columns, so the file
+// can be filtered and pivoted in a spreadsheet without joins. A document holding several
+// prescriptions has rows for each of them, told apart by the prescription and page columns.
+package csvexport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// Columns is the header row of an export. Columns are only ever appended so that existing
+// reports and spreadsheet formulas keep working.
+var Columns = []string{
+	"job_id",
+	"completed_at",
+	"file_name",
+	"rule_set",
+	"blocked",
+	"validation_issues",
+	"date_written",
+	"date_needed",
+	"patient_first_name",
+	"patient_middle_name",
+	"patient_last_name",
+	"patient_dob",
+	"patient_sex",
+	"patient_street",
+	"patient_city",
+	"patient_state",
+	"patient_zip",
+	"patient_phone",
+	"prescriber_name",
+	"prescriber_specialty",
+	"prescriber_npi",
+	"prescriber_dea",
+	"prescriber_state_license",
+	"office_name",
+	"office_street",
+	"office_city",
+	"office_state",
+	"office_zip",
+	"office_phone",
+	"office_fax",
+	"primary_diagnosis_code",
+	"primary_diagnosis_description",
+	"additional_diagnosis_codes",
+	"primary_insurance_provider",
+	"primary_insurance_id",
+	"primary_insurance_group",
+	"primary_insurance_bin",
+	"primary_insurance_pcn",
+	"secondary_insurance_provider",
+	"secondary_insurance_id",
+	"secondary_insurance_group",
+	"secondary_insurance_bin",
+	"secondary_insurance_pcn",
+	"medication_index",
+	"drug_name",
+	"ndc",
+	"rxcui",
+	"strength",
+	"form",
+	"sig",
+	"quantity",
+	"refills",
+	"duration",
+	"start_date",
+	"dea_schedule",
+	"daw_code",
+	"prescription_index",
+	"first_page",
+	"last_page",
+	"prescription_error",
+}
+
+// Writer writes parse results as CSV rows.
+type Writer struct {
+	w *csv.Writer
+}
+
+// NewWriter creates a writer and writes the header row.
+func NewWriter(w io.Writer) (*Writer, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Columns); err != nil {
+		return nil, fmt.Errorf("failed to write csv header: %w", err)
+	}
+	return &Writer{w: cw}, nil
+}
+
+// Write writes one row per medication of each prescription of the result, or a single row with
+// empty medication columns for a prescription without medications.
+func (w *Writer) Write(result models.ParseResult) error {
+	for _, row := range Rows(result) {
+		if err := w.w.Write(row); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+	return nil
+}
+
+// Flush writes any buffered rows to the underlying writer.
+func (w *Writer) Flush() error {
+	w.w.Flush()
+	if err := w.w.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv: %w", err)
+	}
+	return nil
+}
+
+// Rows flattens a parse result into rows matching Columns. A result saved with the prescriptions
+// of a segmented document has rows for each of them, with their own blocked and validation
+// columns; other results, including those saved before documents were segmented, have rows for
+// their prescription with empty prescription and page columns.
+func Rows(result models.ParseResult) [][]string {
+	if len(result.Prescriptions) == 0 {
+		return prescriptionRows(result, -1, models.DocumentPrescription{
+			Prescription: result.Prescription,
+			Validation:   result.Validation,
+			Blocked:      result.Blocked,
+		})
+	}
+
+	var rows [][]string
+	for i, prescription := range result.Prescriptions {
+		rows = append(rows, prescriptionRows(result, i, prescription)...)
+	}
+	return rows
+}
+
+// prescriptionRows flattens one prescription of a parse result into rows matching Columns. The
+// prescription and page columns are left empty when index is negative.
+func prescriptionRows(result models.ParseResult, index int, prescription models.DocumentPrescription) [][]string {
+	rx := prescription.Prescription
+	p := rx.Patient
+	pr := rx.Prescriber
+	primary, secondary := insurance(p.Insurance)
+
+	var additional []string
+	for _, d := range rx.Diagnosis.AdditionalDiagnoses {
+		if d.Icd10Code != "" {
+			additional = append(additional, d.Icd10Code)
+		}
+	}
+
+	shared := []string{
+		result.ID,
+		formatTime(result.CompletedAt),
+		result.Attributes[jobs.AttributeFileName],
+		result.Attributes[jobs.AttributeRuleSet],
+		strconv.FormatBool(prescription.Blocked),
+		strconv.Itoa(len(prescription.Validation)),
+		rx.DateWritten,
+		rx.DateNeeded,
+		p.FirstName,
+		p.MiddleName,
+		p.LastName,
+		p.Dob,
+		p.Sex,
+		p.Address.Street,
+		p.Address.City,
+		p.Address.State,
+		p.Address.Zip,
+		patientPhone(p.PhoneNumbers),
+		pr.Name,
+		pr.Specialty,
+		pr.Npi,
+		pr.Dea,
+		pr.StateLicense,
+		pr.Office.Name,
+		pr.Office.Address.Street,
+		pr.Office.Address.City,
+		pr.Office.Address.State,
+		pr.Office.Address.Zip,
+		pr.Office.Phone,
+		pr.Office.Fax,
+		rx.Diagnosis.PrimaryDiagnosis.Icd10Code,
+		rx.Diagnosis.PrimaryDiagnosis.Description,
+		strings.Join(additional, ";"),
+		primary.Provider,
+		primary.IdNumber,
+		primary.GroupNumber,
+		primary.RxBin,
+		primary.Pcn,
+		secondary.Provider,
+		secondary.IdNumber,
+		secondary.GroupNumber,
+		secondary.RxBin,
+		secondary.Pcn,
+	}
+
+	medications := rx.Medications
+	if len(medications) == 0 {
+		medications = []models.Medication{{}}
+	}
+
+	var document []string
+	if index >= 0 {
+		document = []string{strconv.Itoa(index), strconv.Itoa(prescription.Pages.First), strconv.Itoa(prescription.Pages.Last), prescription.Error}
+	} else {
+		document = []string{"", "", "", ""}
+	}
+
+	rows := make([][]string, 0, len(medications))
+	for i, med := range medications {
+		medicationIndex := strconv.Itoa(i)
+		if len(rx.Medications) == 0 {
+			medicationIndex = ""
+		}
+
+		rxcui := ""
+		if med.Normalized != nil {
+			rxcui = med.Normalized.RxCUI
+		}
+
+		row := append(append(make([]string, 0, len(Columns)), shared...),
+			medicationIndex,
+			med.DrugName,
+			med.Ndc,
+			rxcui,
+			med.Strength,
+			med.Form,
+			med.SIG,
+			med.Quantity,
+			med.Refills,
+			med.Duration,
+			med.StartDate,
+			med.DeaSchedule,
+			rx.PrescriberSignature.DawCode,
+		)
+		row = append(row, document...)
+
+		for j := range row {
+			row[j] = sanitize(row[j])
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// insurance picks the primary and secondary policies. Policies labeled primary or secondary
+// are used first; unlabeled policies fill the remaining slots in the order they were listed.
+func insurance(policies []models.Insurance) (primary, secondary models.Insurance) {
+	var hasPrimary, hasSecondary bool
+	var unlabeled []models.Insurance
+
+	for _, policy := range policies {
+		label := strings.ToLower(policy.Type)
+		switch {
+		case strings.Contains(label, "primary") && !hasPrimary:
+			primary, hasPrimary = policy, true
+		case strings.Contains(label, "secondary") && !hasSecondary:
+			secondary, hasSecondary = policy, true
+		default:
+			unlabeled = append(unlabeled, policy)
+		}
+	}
+
+	for _, policy := range unlabeled {
+		switch {
+		case !hasPrimary:
+			primary, hasPrimary = policy, true
+		case !hasSecondary:
+			secondary, hasSecondary = policy, true
+		}
+	}
+
+	return primary, secondary
+}
+
+// patientPhone returns the patient's first phone number with its extension, if any.
+func patientPhone(numbers []models.PhoneNumber) string {
+	for _, number := range numbers {
+		if number.Number == "" {
+			continue
+		}
+		if number.Extension != "" {
+			return number.Number + " x" + number.Extension
+		}
+		return number.Number
+	}
+	return ""
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// sanitize prevents a value from being run as a formula when the file is opened in a
+// spreadsheet by prefixing values that start with a formula character with a quote.
+// Signed numbers such as "+442079460958" are left as they are.
+func sanitize(value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch value[0] {
+	case '=', '@', '\t', '\r':
+		return "'" + value
+	case '+', '-':
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "'" + value
+		}
+	}
+	return value
+}
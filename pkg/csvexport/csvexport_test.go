@@ -0,0 +1,178 @@
+package csvexport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func column(t *testing.T, row []string, name string) string {
+	t.Helper()
+	for i, c := range Columns {
+		if c == name {
+			return row[i]
+		}
+	}
+	t.Fatalf("Unknown column %s", name)
+	return ""
+}
+
+func TestRows(t *testing.T) {
+	result := models.ParseResult{
+		ID:          "job-1",
+		CompletedAt: time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+		Attributes:  map[string]string{jobs.AttributeFileName: "rx.pdf"},
+		Validation:  []models.ValidationIssue{{Code: "phone_invalid"}},
+		Prescription: models.Prescription{
+			Patient: models.Patient{
+				FirstName:    "Ann",
+				LastName:     "=HYPERLINK(\"x\")",
+				PhoneNumbers: []models.PhoneNumber{{Number: ""}, {Number: "7035551234", Extension: "12"}},
+				Insurance: []models.Insurance{
+					{Provider: "Medicaid", IdNumber: "M456"},
+					{Type: "Primary", Provider: "Aetna", IdNumber: "W123", RxBin: "610502"},
+				},
+			},
+			Prescriber: models.Prescriber{Name: "Jane Brown, MD", Npi: "1234567893"},
+			Diagnosis: models.PatientDiagnosis{
+				PrimaryDiagnosis:    models.Diagnosis{Icd10Code: "L40.50"},
+				AdditionalDiagnoses: []models.Diagnosis{{Icd10Code: "L40.0"}, {Icd10Code: "M07.3"}},
+			},
+			Medications: []models.Medication{
+				{DrugName: "Humira", Quantity: "2 pens", Normalized: &models.DrugNormalization{RxCUI: "1727500"}},
+				{DrugName: "Methotrexate", Quantity: "-"},
+			},
+			PrescriberSignature: models.SignatureInfo{DawCode: "1"},
+		},
+	}
+
+	rows := Rows(result)
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+
+	tests := []struct {
+		row    int
+		column string
+		want   string
+	}{
+		{0, "job_id", "job-1"},
+		{0, "completed_at", "2025-03-14T09:30:00Z"},
+		{0, "file_name", "rx.pdf"},
+		{0, "validation_issues", "1"},
+		{0, "patient_last_name", "'=HYPERLINK(\"x\")"},
+		{0, "patient_phone", "7035551234 x12"},
+		{0, "additional_diagnosis_codes", "L40.0;M07.3"},
+		{0, "primary_insurance_provider", "Aetna"},
+		{0, "primary_insurance_bin", "610502"},
+		{0, "secondary_insurance_provider", "Medicaid"},
+		{0, "medication_index", "0"},
+		{0, "rxcui", "1727500"},
+		{0, "daw_code", "1"},
+		{1, "prescriber_npi", "1234567893"},
+		{1, "medication_index", "1"},
+		{1, "drug_name", "Methotrexate"},
+		{1, "quantity", "'-"},
+		{1, "prescription_index", ""},
+		{1, "first_page", ""},
+	}
+
+	for _, tt := range tests {
+		row := rows[tt.row]
+		if len(row) != len(Columns) {
+			t.Fatalf("Row %d has %d columns, want %d", tt.row, len(row), len(Columns))
+		}
+		if got := column(t, row, tt.column); got != tt.want {
+			t.Errorf("Row %d %s = %q, want %q", tt.row, tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestRowsPerPrescription(t *testing.T) {
+	result := models.ParseResult{
+		ID:           "job-3",
+		Prescription: models.Prescription{Patient: models.Patient{FirstName: "Ann"}},
+		Blocked:      true,
+		Prescriptions: []models.DocumentPrescription{
+			{
+				Pages:        models.PageRange{First: 1, Last: 2},
+				Prescription: models.Prescription{Patient: models.Patient{FirstName: "Ann"}, Medications: []models.Medication{{DrugName: "Humira"}}},
+			},
+			{Pages: models.PageRange{First: 3, Last: 3}, Blocked: true, Error: "failed in parse stage: model unavailable"},
+			{
+				Pages:        models.PageRange{First: 4, Last: 4},
+				Prescription: models.Prescription{Patient: models.Patient{FirstName: "Bob"}, Medications: []models.Medication{{DrugName: "Enbrel"}, {DrugName: "Methotrexate"}}},
+				Validation:   []models.ValidationIssue{{Field: "prescriber.npi", Severity: models.SeverityError}},
+				Blocked:      true,
+			},
+		},
+	}
+
+	rows := Rows(result)
+	if len(rows) != 4 {
+		t.Fatalf("Expected a row per medication of each prescription, got %d", len(rows))
+	}
+
+	tests := []struct {
+		row    int
+		column string
+		want   string
+	}{
+		{0, "job_id", "job-3"},
+		{0, "patient_first_name", "Ann"},
+		{0, "blocked", "false"},
+		{0, "prescription_index", "0"},
+		{0, "first_page", "1"},
+		{0, "last_page", "2"},
+		{1, "prescription_index", "1"},
+		{1, "first_page", "3"},
+		{1, "blocked", "true"},
+		{1, "medication_index", ""},
+		{1, "prescription_error", "failed in parse stage: model unavailable"},
+		{2, "patient_first_name", "Bob"},
+		{2, "validation_issues", "1"},
+		{2, "prescription_index", "2"},
+		{3, "drug_name", "Methotrexate"},
+		{3, "medication_index", "1"},
+		{3, "last_page", "4"},
+	}
+
+	for _, tt := range tests {
+		row := rows[tt.row]
+		if len(row) != len(Columns) {
+			t.Fatalf("Row %d has %d columns, want %d", tt.row, len(row), len(Columns))
+		}
+		if got := column(t, row, tt.column); got != tt.want {
+			t.Errorf("Row %d %s = %q, want %q", tt.row, tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestWriterWithoutMedications(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := w.Write(models.ParseResult{ID: "job-2", Prescription: models.Prescription{Patient: models.Patient{FirstName: "Bo"}}}); err != nil {
+		t.Fatalf("Failed to write result: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Failed to flush: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read csv: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected header and 1 row, got %d records", len(records))
+	}
+	if column(t, records[1], "patient_first_name") != "Bo" || column(t, records[1], "medication_index") != "" {
+		t.Errorf("Unexpected row %v", records[1])
+	}
+}
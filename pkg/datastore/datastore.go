@@ -4,12 +4,15 @@ package datastore
 
 import (
 	"context"
+	stdsql "database/sql"
 	"fmt"
+	"sync"
 	"time"
 
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"github.com/csotherden/prescription-parser/ent"
+	"github.com/csotherden/prescription-parser/pkg/blobstore"
 	"github.com/csotherden/prescription-parser/pkg/config"
 	"github.com/csotherden/prescription-parser/pkg/models"
 	_ "github.com/lib/pq"
@@ -19,39 +22,109 @@ import (
 // Datastore defines the interface for data persistence operations.
 // It provides methods for retrieving and storing prescription data along with vector embeddings.
 type Datastore interface {
-	// GetSamples retrieves prescription samples similar to the provided embedding vector.
-	// It returns a list of samples ordered by vector similarity.
-	GetSamples(ctx context.Context, embedding []float32) ([]models.SamplePrescription, error)
+	// GetSamples retrieves prescription samples similar to the query's embedding vector.
+	// Only samples embedded with the same model and matching the query's metadata filter are
+	// compared, and samples beyond the query's maximum distance are left out.
+	// It returns up to query.K samples, ordered by vector similarity unless diversified, each with its distance.
+	GetSamples(ctx context.Context, query models.SampleQuery) ([]models.SamplePrescription, error)
 
-	// SaveSamplePrescription stores a prescription sample along with its vector embedding.
-	// It associates the prescription with the given image ID and MIME type.
-	SaveSamplePrescription(ctx context.Context, mimeType, imageID string, prescription models.Prescription, embedding []float32) error
+	// SaveSamplePrescription stores a prescription sample along with its vector embeddings.
+	// It associates the prescription with the given image ID, MIME type and metadata, and keeps the
+	// original document in the blob store, if one is configured.
+	SaveSamplePrescription(ctx context.Context, mimeType, imageID string, document []byte, prescription models.Prescription, metadata models.SampleMetadata, embeddings ...models.Embedding) error
+
+	// ListSamples returns a page of the samples matching the filter, newest first, and the
+	// number of samples matching the filter across all pages.
+	ListSamples(ctx context.Context, filter models.SampleFilter) ([]models.Sample, int, error)
+
+	// GetSample returns a sample, or an error wrapping ErrNotFound if there is no such sample.
+	GetSample(ctx context.Context, id string) (models.Sample, error)
+
+	// UpdateSample replaces a sample's prescription and embedding and returns the updated sample.
+	// Embeddings of the old prescription from other models of the same kind are removed.
+	// It returns an error wrapping ErrNotFound if there is no such sample.
+	UpdateSample(ctx context.Context, id string, prescription models.Prescription, embedding models.Embedding) (models.Sample, error)
+
+	// SaveSampleEmbedding stores a sample's embedding, replacing any embedding from the same model.
+	// It returns an error wrapping ErrNotFound if there is no such sample.
+	SaveSampleEmbedding(ctx context.Context, id string, embedding models.Embedding) error
+
+	// ListSamplesWithoutEmbedding returns the samples with no embedding from the model, oldest first.
+	ListSamplesWithoutEmbedding(ctx context.Context, model models.EmbeddingModel) ([]models.Sample, error)
+
+	// DeleteOtherEmbeddings removes the embeddings of the same kind as model from all other models
+	// and returns how many were removed.
+	DeleteOtherEmbeddings(ctx context.Context, model models.EmbeddingModel) (int, error)
+
+	// GetSampleDocument returns a sample's original document from the blob store. It returns an
+	// error wrapping ErrNotFound if there is no such sample or its document was not kept.
+	GetSampleDocument(ctx context.Context, id string) ([]byte, error)
+
+	// DeleteSample removes a sample and its embedding.
+	// It returns an error wrapping ErrNotFound if there is no such sample.
+	DeleteSample(ctx context.Context, id string) error
+
+	// SaveParseResult stores the outcome of a completed parsing job.
+	// Saving a result for a job that already has one replaces it, keeping its status and review.
+	SaveParseResult(ctx context.Context, result models.ParseResult) error
+
+	// ListParseResults returns the results of jobs completed in [from, to), oldest first.
+	ListParseResults(ctx context.Context, from, to time.Time) ([]models.ParseResult, error)
+
+	// GetParseResult returns the result of a job, or an error wrapping ErrNotFound if it has none.
+	GetParseResult(ctx context.Context, jobID string) (models.ParseResult, error)
+
+	// SaveReview records a reviewer's correction of a job's result and marks the result reviewed.
+	// It returns an error wrapping ErrNotFound if the job has no saved result.
+	SaveReview(ctx context.Context, jobID string, review models.Review) error
+
+	// SavePromptOverlay stores new prompt overlay instructions for a form template as its next
+	// version and returns the stored version.
+	SavePromptOverlay(ctx context.Context, template, instructions, author string) (models.PromptOverlay, error)
+
+	// GetPromptOverlay returns the latest version of a form template's prompt overlay, or an error
+	// wrapping ErrNotFound if the template has none.
+	GetPromptOverlay(ctx context.Context, template string) (models.PromptOverlay, error)
+
+	// ListPromptOverlays returns every version of a form template's prompt overlay, newest first.
+	ListPromptOverlays(ctx context.Context, template string) ([]models.PromptOverlay, error)
 }
 
 // PgEntDatastore implements the Datastore interface using PostgreSQL with Ent ORM.
 // It uses pgvector for vector embedding storage and similarity search.
 type PgEntDatastore struct {
 	dbClient *ent.Client
+	db       *stdsql.DB      // Connection pool of dbClient, for statements ent cannot express
+	indexed  sync.Map        // Embedding models whose similarity search index exists
+	blobs    blobstore.Store // Store for sample documents, nil if documents are not kept
 	logger   *zap.Logger
 }
 
 // NewPgEntDatastore creates a new PostgreSQL-based Datastore implementation.
 // It initializes the database connection and returns a ready-to-use datastore.
 func NewPgEntDatastore(cfg config.Config, logger *zap.Logger) (Datastore, error) {
-	dbClient, err := newEntClient(cfg)
+	dbClient, db, err := newEntClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to initialize datastore: %w", err)
 	}
 
+	blobs, err := blobstore.New(cfg)
+	if err != nil {
+		dbClient.Close()
+		return nil, fmt.Errorf("failed to initialize blob store: %w", err)
+	}
+
 	return &PgEntDatastore{
 		dbClient: dbClient,
+		db:       db,
+		blobs:    blobs,
 		logger:   logger,
 	}, nil
 }
 
 // newEntClient creates and configures a new Ent client with the provided configuration.
 // It sets up connection pooling and runs schema migrations if needed.
-func newEntClient(cfg config.Config) (*ent.Client, error) {
+func newEntClient(cfg config.Config) (*ent.Client, *stdsql.DB, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s "+
 		"password=%s dbname=%s sslmode=disable",
 		cfg.DatabaseHost, cfg.DatabasePort, cfg.DatabaseUser, cfg.DatabasePassword, cfg.DatabaseName)
@@ -59,7 +132,7 @@ func newEntClient(cfg config.Config) (*ent.Client, error) {
 	// Create driver with MaxIdleConns and MaxOpenConns
 	drv, err := sql.Open(dialect.Postgres, dsn)
 	if err != nil {
-		return nil, fmt.Errorf("failed opening connection to postgres: %w", err)
+		return nil, nil, fmt.Errorf("failed opening connection to postgres: %w", err)
 	}
 
 	// Get the underlying sql.DB object
@@ -71,10 +144,17 @@ func newEntClient(cfg config.Config) (*ent.Client, error) {
 	// Create the ent client
 	client := ent.NewClient(ent.Driver(drv))
 
+	// The embedding column used to be vector(1536) with a single HNSW index. That index cannot be
+	// kept once the column has no fixed dimension; per-model indexes replace it.
+	if _, err := db.ExecContext(context.Background(), "DROP INDEX IF EXISTS embedding_embedding"); err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("failed dropping legacy embedding index: %w", err)
+	}
+
 	// Run the auto migration
 	if err := client.Schema.Create(context.Background()); err != nil {
 		client.Close()
-		return nil, fmt.Errorf("failed creating schema resources: %w", err)
+		return nil, nil, fmt.Errorf("failed creating schema resources: %w", err)
 	}
-	return client, nil
+	return client, db, nil
 }
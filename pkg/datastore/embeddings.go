@@ -0,0 +1,204 @@
+package datastore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/csotherden/prescription-parser/ent"
+	"github.com/csotherden/prescription-parser/ent/embedding"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+	"github.com/csotherden/prescription-parser/ent/prescription"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/pgvector/pgvector-go"
+	"go.uber.org/zap"
+)
+
+// SaveSampleEmbedding stores a sample's embedding, replacing any embedding from the same model.
+// It returns an error wrapping ErrNotFound if there is no such sample.
+func (d *PgEntDatastore) SaveSampleEmbedding(ctx context.Context, id string, emb models.Embedding) error {
+	sampleID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("sample %s: %w", id, ErrNotFound)
+	}
+
+	if err := d.ensureEmbeddingIndex(ctx, emb, models.DistanceL2); err != nil {
+		return err
+	}
+
+	tx, err := d.dbClient.Tx(ctx)
+	if err != nil {
+		d.logger.Error("failed to create transaction", zap.Error(err))
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	exists, err := tx.Prescription.Query().Where(prescription.ID(sampleID)).Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sample: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("sample %s: %w", id, ErrNotFound)
+	}
+
+	if err := replaceEmbedding(ctx, tx, sampleID, emb); err != nil {
+		d.logger.Error("failed to save embedding", zap.String("sample_id", id), zap.String("model", emb.Model.String()), zap.Error(err))
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		d.logger.Error("failed to commit transaction", zap.Error(err))
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListSamplesWithoutEmbedding returns the samples with no embedding from the model, oldest first.
+func (d *PgEntDatastore) ListSamplesWithoutEmbedding(ctx context.Context, model models.EmbeddingModel) ([]models.Sample, error) {
+	rows, err := d.dbClient.Prescription.Query().
+		Where(prescription.Not(prescription.HasEmbeddingsWith(embeddingModelIs(model)...))).
+		WithEmbeddings().
+		Order(ent.Asc(prescription.FieldCreatedAt), ent.Asc(prescription.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list samples without embedding: %w", err)
+	}
+
+	samples := make([]models.Sample, 0, len(rows))
+	for _, row := range rows {
+		samples = append(samples, toSample(row))
+	}
+
+	return samples, nil
+}
+
+// DeleteOtherEmbeddings removes the embeddings of the same kind as model from all other models
+// and returns how many were removed.
+func (d *PgEntDatastore) DeleteOtherEmbeddings(ctx context.Context, model models.EmbeddingModel) (int, error) {
+	n, err := d.dbClient.Embedding.Delete().
+		Where(embedding.Kind(model.Kind)).
+		Where(embedding.Not(embedding.And(embeddingModelIs(model)...))).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete embeddings: %w", err)
+	}
+	return n, nil
+}
+
+// replaceEmbedding stores a prescription's embedding within tx, replacing the one from the same model.
+func replaceEmbedding(ctx context.Context, tx *ent.Tx, prescriptionID uuid.UUID, emb models.Embedding) error {
+	if err := validateEmbedding(emb); err != nil {
+		return err
+	}
+
+	_, err := tx.Embedding.Delete().
+		Where(embedding.HasPrescriptionWith(prescription.ID(prescriptionID))).
+		Where(embeddingModelIs(emb.Model)...).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete embedding: %w", err)
+	}
+
+	_, err = tx.Embedding.Create().
+		SetPrescriptionID(prescriptionID).
+		SetEmbedding(pgvector.NewVector(emb.Vector)).
+		SetModel(emb.Model.Name).
+		SetVersion(emb.Model.Version).
+		SetDimensions(emb.Model.Dimensions).
+		SetKind(emb.Model.Kind).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create embedding: %w", err)
+	}
+
+	return nil
+}
+
+// indexKey identifies a similarity search index.
+type indexKey struct {
+	model  models.EmbeddingModel
+	metric models.DistanceMetric
+}
+
+// ensureEmbeddingIndex creates the HNSW index used to search the embeddings of a model by a
+// distance metric, if it does not exist yet. The column holds vectors of any dimension, so each
+// model gets a partial index over its own rows with the vectors cast to the model's dimension.
+func (d *PgEntDatastore) ensureEmbeddingIndex(ctx context.Context, emb models.Embedding, metric models.DistanceMetric) error {
+	if err := validateEmbedding(emb); err != nil {
+		return err
+	}
+
+	model := emb.Model
+	key := indexKey{model: model, metric: metric}
+	if _, ok := d.indexed.Load(key); ok {
+		return nil
+	}
+
+	stmt := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s USING hnsw ((%s::vector(%d)) %s) WHERE %s = %s AND %s = %s AND %s = %d",
+		pq.QuoteIdentifier(embeddingIndexName(model, metric)),
+		pq.QuoteIdentifier(embedding.Table),
+		pq.QuoteIdentifier(embedding.FieldEmbedding), model.Dimensions, operatorClass(metric),
+		pq.QuoteIdentifier(embedding.FieldModel), pq.QuoteLiteral(model.Name),
+		pq.QuoteIdentifier(embedding.FieldVersion), pq.QuoteLiteral(model.Version),
+		pq.QuoteIdentifier(embedding.FieldDimensions), model.Dimensions,
+	)
+	if _, err := d.db.ExecContext(ctx, stmt); err != nil {
+		d.logger.Error("failed to create embedding index", zap.String("model", model.String()), zap.String("metric", string(metric)), zap.Error(err))
+		return fmt.Errorf("failed to create embedding index: %w", err)
+	}
+
+	d.indexed.Store(key, true)
+	return nil
+}
+
+// embeddingIndexName returns the name of a model's similarity search index for a metric. Model
+// names can be longer than identifiers allow, so the name is derived from a hash. L2 indexes keep
+// the names they had before other metrics were supported.
+func embeddingIndexName(model models.EmbeddingModel, metric models.DistanceMetric) string {
+	name := model.String()
+	if metric == models.DistanceCosine {
+		name += "/" + string(metric)
+	}
+	sum := sha256.Sum256([]byte(name))
+	return "embedding_hnsw_" + hex.EncodeToString(sum[:8])
+}
+
+// operatorClass returns the pgvector operator class that indexes a distance metric.
+func operatorClass(metric models.DistanceMetric) string {
+	if metric == models.DistanceCosine {
+		return "vector_cosine_ops"
+	}
+	return "vector_l2_ops"
+}
+
+// distanceOperator returns the pgvector operator that computes a distance metric.
+func distanceOperator(metric models.DistanceMetric) string {
+	if metric == models.DistanceCosine {
+		return "<=>"
+	}
+	return "<->"
+}
+
+// embeddingModelIs matches the embeddings produced by model.
+func embeddingModelIs(model models.EmbeddingModel) []predicate.Embedding {
+	return []predicate.Embedding{
+		embedding.Model(model.Name),
+		embedding.Version(model.Version),
+		embedding.Dimensions(model.Dimensions),
+	}
+}
+
+// validateEmbedding checks that an embedding names its model and matches the model's dimensions.
+func validateEmbedding(emb models.Embedding) error {
+	if emb.Model.Name == "" || emb.Model.Dimensions <= 0 || emb.Model.Kind == "" {
+		return fmt.Errorf("embedding model is required")
+	}
+	if len(emb.Vector) != emb.Model.Dimensions {
+		return fmt.Errorf("embedding has %d dimensions, model %s has %d", len(emb.Vector), emb.Model, emb.Model.Dimensions)
+	}
+	return nil
+}
@@ -8,23 +8,50 @@ import (
 	"github.com/pgvector/pgvector-go"
 
 	"entgo.io/ent/dialect/sql"
+	"github.com/csotherden/prescription-parser/ent"
+	"github.com/csotherden/prescription-parser/ent/embedding"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+	"github.com/csotherden/prescription-parser/ent/prescription"
 	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/retrieval"
 	"go.uber.org/zap"
 )
 
-// GetSamples retrieves prescription samples that are most similar to the provided embedding vector.
-// It uses pgvector's similarity search to find the closest matches in the embedding space.
-// The method returns up to 3 most similar samples, ordered by vector similarity.
+// GetSamples retrieves the prescription samples whose embeddings are nearest to the query embedding.
+// It uses pgvector's similarity search to find the closest matches in the embedding space,
+// among the embeddings produced by the same model and the samples matching the query's metadata filter.
+// Samples farther than the query's maximum distance are left out, so nothing may be returned when no
+// sample is close. With an MMR lambda set, the nearest candidates are diversified so that
+// near-duplicate samples do not crowd out different ones.
+// Samples with a stored document have it loaded from the blob store; if it cannot be read the
+// sample is still returned and parsers fall back to its provider file ID.
 //
 // Parameters:
 //   - ctx: Context for the database operation
-//   - embedding: Vector embedding to use for similarity search
+//   - query: Embedding to search with, and how many samples to return and how to select them
 //
 // Returns:
-//   - A slice of SamplePrescription that are most similar to the embedding
+//   - Up to query.K samples, nearest first unless diversified, each with its distance
 //   - An error if the database operation fails
-func (d *PgEntDatastore) GetSamples(ctx context.Context, embedding []float32) ([]models.SamplePrescription, error) {
-	var samples []models.SamplePrescription
+func (d *PgEntDatastore) GetSamples(ctx context.Context, query models.SampleQuery) ([]models.SamplePrescription, error) {
+	emb := query.Embedding
+	metric := query.Metric
+	if metric == "" {
+		metric = models.DistanceL2
+	}
+	k := query.K
+	if k <= 0 {
+		k = retrieval.DefaultK
+	}
+	diversify := query.MMRLambda > 0 && query.MMRLambda < 1
+	limit := k
+	if diversify {
+		limit = k * retrieval.MMRCandidates
+	}
+
+	if err := d.ensureEmbeddingIndex(ctx, emb, metric); err != nil {
+		return nil, err
+	}
 
 	tx, err := d.dbClient.BeginTx(ctx, nil)
 	if err != nil {
@@ -32,38 +59,111 @@ func (d *PgEntDatastore) GetSamples(ctx context.Context, embedding []float32) ([
 	}
 	defer tx.Rollback()
 
-	embVec := pgvector.NewVector(embedding)
-	embs, err := tx.Embedding.Query().
+	// The cast matches the expression of the model's partial index, so the index is used.
+	embVec := pgvector.NewVector(emb.Vector)
+	distance := func(s *sql.Selector, b *sql.Builder) {
+		b.WriteString(fmt.Sprintf("%s::vector(%d) %s ", s.C(embedding.FieldEmbedding), emb.Model.Dimensions, distanceOperator(metric)))
+		b.Arg(embVec)
+	}
+
+	q := tx.Embedding.Query().
+		Where(embeddingModelIs(emb.Model)...)
+	if filter := metadataIs(query.Filter); len(filter) > 0 {
+		q = q.Where(embedding.HasPrescriptionWith(filter...))
+	}
+	if query.MaxDistance > 0 {
+		q = q.Where(func(s *sql.Selector) {
+			s.Where(sql.P(func(b *sql.Builder) {
+				distance(s, b)
+				b.WriteString(" <= ")
+				b.Arg(query.MaxDistance)
+			}))
+		})
+	}
+	embs, err := q.
 		Order(func(s *sql.Selector) {
-			s.OrderExpr(sql.ExprP("embedding <-> $1", embVec))
+			s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) { distance(s, b) }))
 		}).
 		WithPrescription().
-		Limit(3).
+		Limit(limit).
 		All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get samples: %w", err)
 	}
 
-	for _, emb := range embs {
-		if emb.Edges.Prescription != nil {
-			content, err := json.Marshal(emb.Edges.Prescription.Content)
-			if err != nil {
-				d.logger.Error("failed to marshal prescription content", zap.Error(err))
-				continue
-			}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
 
-			samples = append(samples, models.SamplePrescription{
-				ID:       emb.Edges.Prescription.ID,
-				FileID:   emb.Edges.Prescription.FileID,
-				MIMEType: emb.Edges.Prescription.MimeType,
-				Content:  string(content),
-			})
+	// Distances are recomputed from the vectors for the response and for diversification.
+	var candidates []*ent.Embedding
+	var vectors [][]float32
+	var distances []float64
+	for _, candidate := range embs {
+		if candidate.Edges.Prescription == nil {
+			continue
 		}
+		candidates = append(candidates, candidate)
+		vectors = append(vectors, candidate.Embedding.Slice())
+		distances = append(distances, retrieval.Distance(metric, emb.Vector, candidate.Embedding.Slice()))
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	picked := make([]int, 0, min(k, len(candidates)))
+	if diversify {
+		picked = retrieval.Diversify(metric, vectors, distances, k, query.MMRLambda)
+	} else {
+		for i := range min(k, len(candidates)) {
+			picked = append(picked, i)
+		}
+	}
+
+	samples := make([]models.SamplePrescription, 0, len(picked))
+	for _, i := range picked {
+		rx := candidates[i].Edges.Prescription
+		content, err := json.Marshal(rx.Content)
+		if err != nil {
+			d.logger.Error("failed to marshal prescription content", zap.Error(err))
+			continue
+		}
+
+		samples = append(samples, models.SamplePrescription{
+			ID:           rx.ID,
+			FileID:       rx.FileID,
+			MIMEType:     rx.MimeType,
+			Content:      string(content),
+			DocumentHash: rx.DocumentHash,
+			Distance:     distances[i],
+		})
+	}
+
+	if d.blobs != nil {
+		for i := range samples {
+			if samples[i].DocumentHash == "" {
+				continue
+			}
+			document, err := d.blobs.Get(ctx, samples[i].DocumentHash)
+			if err != nil {
+				d.logger.Warn("failed to load sample document", zap.String("sample_id", samples[i].ID.String()), zap.String("document_hash", samples[i].DocumentHash), zap.Error(err))
+				continue
+			}
+			samples[i].Document = document
+		}
 	}
 
 	return samples, nil
 }
+
+// metadataIs matches the samples with the metadata values that are set.
+func metadataIs(metadata models.SampleMetadata) []predicate.Prescription {
+	var predicates []predicate.Prescription
+	if metadata.Template != "" {
+		predicates = append(predicates, prescription.Template(metadata.Template))
+	}
+	if metadata.Tenant != "" {
+		predicates = append(predicates, prescription.Tenant(metadata.Tenant))
+	}
+	if metadata.DrugClass != "" {
+		predicates = append(predicates, prescription.DrugClass(metadata.DrugClass))
+	}
+	return predicates
+}
@@ -0,0 +1,147 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/csotherden/prescription-parser/ent"
+	"github.com/csotherden/prescription-parser/ent/parseresult"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ErrNotFound is returned when a requested record does not exist.
+var ErrNotFound = errors.New("not found")
+
+// SaveParseResult stores the outcome of a completed parsing job, replacing any result
+// previously saved for the same job. A result saved again keeps its status and review, so a
+// reviewed job stays reviewed.
+func (d *PgEntDatastore) SaveParseResult(ctx context.Context, result models.ParseResult) error {
+	id, err := uuid.Parse(result.ID)
+	if err != nil {
+		return fmt.Errorf("failed to parse job id: %w", err)
+	}
+
+	err = d.dbClient.ParseResult.UpdateOneID(id).
+		SetContent(result.Prescription).
+		SetValidation(result.Validation).
+		SetBlocked(result.Blocked).
+		SetVerification(result.Verification).
+		SetOrigins(result.Origins).
+		SetPrescriptions(result.Prescriptions).
+		SetAttributes(result.Attributes).
+		SetStartedAt(result.StartedAt).
+		SetCompletedAt(result.CompletedAt).
+		Exec(ctx)
+	if err == nil {
+		return nil
+	}
+	if !ent.IsNotFound(err) {
+		d.logger.Error("failed to update parse result", zap.String("job_id", result.ID), zap.Error(err))
+		return fmt.Errorf("failed to update parse result: %w", err)
+	}
+
+	err = d.dbClient.ParseResult.Create().
+		SetID(id).
+		SetStatus(models.ParseResultComplete).
+		SetContent(result.Prescription).
+		SetValidation(result.Validation).
+		SetBlocked(result.Blocked).
+		SetVerification(result.Verification).
+		SetOrigins(result.Origins).
+		SetPrescriptions(result.Prescriptions).
+		SetAttributes(result.Attributes).
+		SetStartedAt(result.StartedAt).
+		SetCompletedAt(result.CompletedAt).
+		Exec(ctx)
+	if err != nil {
+		d.logger.Error("failed to create parse result", zap.String("job_id", result.ID), zap.Error(err))
+		return fmt.Errorf("failed to create parse result: %w", err)
+	}
+
+	return nil
+}
+
+// ListParseResults returns the results of jobs completed in [from, to), oldest first.
+func (d *PgEntDatastore) ListParseResults(ctx context.Context, from, to time.Time) ([]models.ParseResult, error) {
+	rows, err := d.dbClient.ParseResult.Query().
+		Where(
+			parseresult.CompletedAtGTE(from),
+			parseresult.CompletedAtLT(to),
+		).
+		Order(ent.Asc(parseresult.FieldCompletedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parse results: %w", err)
+	}
+
+	results := make([]models.ParseResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, toParseResult(row))
+	}
+
+	return results, nil
+}
+
+// GetParseResult returns the result of a job. It returns an error wrapping ErrNotFound if the
+// job has no saved result.
+func (d *PgEntDatastore) GetParseResult(ctx context.Context, jobID string) (models.ParseResult, error) {
+	id, err := uuid.Parse(jobID)
+	if err != nil {
+		return models.ParseResult{}, fmt.Errorf("parse result %s: %w", jobID, ErrNotFound)
+	}
+
+	row, err := d.dbClient.ParseResult.Get(ctx, id)
+	if ent.IsNotFound(err) {
+		return models.ParseResult{}, fmt.Errorf("parse result %s: %w", jobID, ErrNotFound)
+	}
+	if err != nil {
+		return models.ParseResult{}, fmt.Errorf("failed to get parse result: %w", err)
+	}
+
+	return toParseResult(row), nil
+}
+
+// SaveReview records a reviewer's correction of a job's result and marks the result reviewed.
+// A later review replaces an earlier one. It returns an error wrapping ErrNotFound if the job
+// has no saved result.
+func (d *PgEntDatastore) SaveReview(ctx context.Context, jobID string, review models.Review) error {
+	id, err := uuid.Parse(jobID)
+	if err != nil {
+		return fmt.Errorf("parse result %s: %w", jobID, ErrNotFound)
+	}
+
+	err = d.dbClient.ParseResult.UpdateOneID(id).
+		SetStatus(models.ParseResultReviewed).
+		SetReview(&review).
+		Exec(ctx)
+	if ent.IsNotFound(err) {
+		return fmt.Errorf("parse result %s: %w", jobID, ErrNotFound)
+	}
+	if err != nil {
+		d.logger.Error("failed to save review", zap.String("job_id", jobID), zap.Error(err))
+		return fmt.Errorf("failed to save review: %w", err)
+	}
+
+	return nil
+}
+
+func toParseResult(row *ent.ParseResult) models.ParseResult {
+	return models.ParseResult{
+		ID:            row.ID.String(),
+		Status:        row.Status,
+		Prescription:  row.Content,
+		Validation:    row.Validation,
+		Blocked:       row.Blocked,
+		Verification:  row.Verification,
+		Origins:       row.Origins,
+		Prescriptions: row.Prescriptions,
+		Attributes:    row.Attributes,
+		StartedAt:     row.StartedAt,
+		CompletedAt:   row.CompletedAt,
+		Review:        row.Review,
+	}
+}
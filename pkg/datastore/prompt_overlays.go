@@ -0,0 +1,97 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/csotherden/prescription-parser/ent"
+	"github.com/csotherden/prescription-parser/ent/promptoverlay"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"go.uber.org/zap"
+)
+
+// SavePromptOverlay stores new instructions for a form template as the version after its
+// latest one and returns the stored version. Concurrent saves for the same template may fail
+// on the unique version rather than overwrite each other.
+func (d *PgEntDatastore) SavePromptOverlay(ctx context.Context, template, instructions, author string) (models.PromptOverlay, error) {
+	tx, err := d.dbClient.Tx(ctx)
+	if err != nil {
+		return models.PromptOverlay{}, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	latest, err := tx.PromptOverlay.Query().
+		Where(promptoverlay.Template(template)).
+		Order(ent.Desc(promptoverlay.FieldVersion)).
+		First(ctx)
+	version := 1
+	switch {
+	case err == nil:
+		version = latest.Version + 1
+	case !ent.IsNotFound(err):
+		return models.PromptOverlay{}, fmt.Errorf("failed to get latest prompt overlay: %w", err)
+	}
+
+	row, err := tx.PromptOverlay.Create().
+		SetTemplate(template).
+		SetVersion(version).
+		SetInstructions(instructions).
+		SetAuthor(author).
+		Save(ctx)
+	if err != nil {
+		d.logger.Error("failed to save prompt overlay", zap.String("template", template), zap.Int("version", version), zap.Error(err))
+		return models.PromptOverlay{}, fmt.Errorf("failed to save prompt overlay: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.PromptOverlay{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return toPromptOverlay(row), nil
+}
+
+// GetPromptOverlay returns the latest version of a form template's prompt overlay. It returns an
+// error wrapping ErrNotFound if the template has none.
+func (d *PgEntDatastore) GetPromptOverlay(ctx context.Context, template string) (models.PromptOverlay, error) {
+	row, err := d.dbClient.PromptOverlay.Query().
+		Where(promptoverlay.Template(template)).
+		Order(ent.Desc(promptoverlay.FieldVersion)).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return models.PromptOverlay{}, fmt.Errorf("prompt overlay %s: %w", template, ErrNotFound)
+	}
+	if err != nil {
+		return models.PromptOverlay{}, fmt.Errorf("failed to get prompt overlay: %w", err)
+	}
+
+	return toPromptOverlay(row), nil
+}
+
+// ListPromptOverlays returns every version of a form template's prompt overlay, newest first.
+func (d *PgEntDatastore) ListPromptOverlays(ctx context.Context, template string) ([]models.PromptOverlay, error) {
+	rows, err := d.dbClient.PromptOverlay.Query().
+		Where(promptoverlay.Template(template)).
+		Order(ent.Desc(promptoverlay.FieldVersion)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt overlays: %w", err)
+	}
+
+	overlays := make([]models.PromptOverlay, 0, len(rows))
+	for _, row := range rows {
+		overlays = append(overlays, toPromptOverlay(row))
+	}
+
+	return overlays, nil
+}
+
+func toPromptOverlay(row *ent.PromptOverlay) models.PromptOverlay {
+	return models.PromptOverlay{
+		ID:           row.ID.String(),
+		Template:     row.Template,
+		Version:      row.Version,
+		Instructions: row.Instructions,
+		Author:       row.Author,
+		CreatedAt:    row.CreatedAt,
+	}
+}
@@ -0,0 +1,251 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/csotherden/prescription-parser/ent"
+	"github.com/csotherden/prescription-parser/ent/embedding"
+	"github.com/csotherden/prescription-parser/ent/predicate"
+	"github.com/csotherden/prescription-parser/ent/prescription"
+	"github.com/csotherden/prescription-parser/pkg/blobstore"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ListSamples returns a page of the samples matching the filter, newest first, and the number of
+// samples matching the filter across all pages.
+func (d *PgEntDatastore) ListSamples(ctx context.Context, filter models.SampleFilter) ([]models.Sample, int, error) {
+	query := d.dbClient.Prescription.Query()
+	if filter.FileID != "" {
+		query = query.Where(prescription.FileID(filter.FileID))
+	}
+	if filter.Drug != "" {
+		query = query.Where(drugNameContains(filter.Drug))
+	}
+	if filter.Query != "" {
+		query = query.Where(contentContains(filter.Query))
+	}
+	query = query.Where(metadataIs(filter.Metadata)...)
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count samples: %w", err)
+	}
+
+	rows, err := query.
+		WithEmbeddings().
+		Order(ent.Desc(prescription.FieldCreatedAt), ent.Asc(prescription.FieldID)).
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		All(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list samples: %w", err)
+	}
+
+	samples := make([]models.Sample, 0, len(rows))
+	for _, row := range rows {
+		samples = append(samples, toSample(row))
+	}
+
+	return samples, total, nil
+}
+
+// GetSample returns a sample by ID, or an error wrapping ErrNotFound if there is no such sample.
+func (d *PgEntDatastore) GetSample(ctx context.Context, id string) (models.Sample, error) {
+	sampleID, err := uuid.Parse(id)
+	if err != nil {
+		return models.Sample{}, fmt.Errorf("sample %s: %w", id, ErrNotFound)
+	}
+
+	row, err := d.dbClient.Prescription.Query().
+		Where(prescription.ID(sampleID)).
+		WithEmbeddings().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return models.Sample{}, fmt.Errorf("sample %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return models.Sample{}, fmt.Errorf("failed to get sample: %w", err)
+	}
+
+	return toSample(row), nil
+}
+
+// UpdateSample replaces a sample's prescription and its embedding in a single transaction and
+// returns the updated sample. Embeddings of the old prescription from other models of the same
+// kind are removed; layout embeddings still describe the document and are kept.
+// It returns an error wrapping ErrNotFound if there is no such sample.
+func (d *PgEntDatastore) UpdateSample(ctx context.Context, id string, rx models.Prescription, emb models.Embedding) (models.Sample, error) {
+	sampleID, err := uuid.Parse(id)
+	if err != nil {
+		return models.Sample{}, fmt.Errorf("sample %s: %w", id, ErrNotFound)
+	}
+
+	if err := d.ensureEmbeddingIndex(ctx, emb, models.DistanceL2); err != nil {
+		return models.Sample{}, err
+	}
+
+	tx, err := d.dbClient.Tx(ctx)
+	if err != nil {
+		d.logger.Error("failed to create transaction", zap.Error(err))
+		return models.Sample{}, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	row, err := tx.Prescription.UpdateOneID(sampleID).
+		SetContent(rx).
+		Save(ctx)
+	if ent.IsNotFound(err) {
+		return models.Sample{}, fmt.Errorf("sample %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		d.logger.Error("failed to update prescription", zap.String("sample_id", id), zap.Error(err))
+		return models.Sample{}, fmt.Errorf("failed to update prescription: %w", err)
+	}
+
+	// Embeddings from other models describe the old prescription; they are recreated by re-embedding.
+	_, err = tx.Embedding.Delete().
+		Where(embedding.HasPrescriptionWith(prescription.ID(sampleID))).
+		Where(embedding.Kind(emb.Model.Kind)).
+		Where(embedding.Not(embedding.And(embeddingModelIs(emb.Model)...))).
+		Exec(ctx)
+	if err != nil {
+		d.logger.Error("failed to delete stale embeddings", zap.String("sample_id", id), zap.Error(err))
+		return models.Sample{}, fmt.Errorf("failed to delete stale embeddings: %w", err)
+	}
+
+	if err := replaceEmbedding(ctx, tx, sampleID, emb); err != nil {
+		d.logger.Error("failed to update embedding", zap.String("sample_id", id), zap.Error(err))
+		return models.Sample{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		d.logger.Error("failed to commit transaction", zap.Error(err))
+		return models.Sample{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// The sample is read back so that the embeddings it kept are listed too.
+	return d.GetSample(ctx, row.ID.String())
+}
+
+// GetSampleDocument returns a sample's original document from the blob store. It returns an
+// error wrapping ErrNotFound if there is no such sample or its document was not kept.
+func (d *PgEntDatastore) GetSampleDocument(ctx context.Context, id string) ([]byte, error) {
+	sample, err := d.GetSample(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.blobs == nil || sample.DocumentHash == "" {
+		return nil, fmt.Errorf("document of sample %s: %w", id, ErrNotFound)
+	}
+
+	document, err := d.blobs.Get(ctx, sample.DocumentHash)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, fmt.Errorf("document of sample %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sample document: %w", err)
+	}
+	return document, nil
+}
+
+// DeleteSample removes a sample and its embedding in a single transaction, so it is no longer
+// retrieved for parsing. The uploaded image is left at the parser backend. It returns an error
+// wrapping ErrNotFound if there is no such sample.
+func (d *PgEntDatastore) DeleteSample(ctx context.Context, id string) error {
+	sampleID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("sample %s: %w", id, ErrNotFound)
+	}
+
+	tx, err := d.dbClient.Tx(ctx)
+	if err != nil {
+		d.logger.Error("failed to create transaction", zap.Error(err))
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Embedding.Delete().
+		Where(embedding.HasPrescriptionWith(prescription.ID(sampleID))).
+		Exec(ctx)
+	if err != nil {
+		d.logger.Error("failed to delete embedding", zap.String("sample_id", id), zap.Error(err))
+		return fmt.Errorf("failed to delete embedding: %w", err)
+	}
+
+	err = tx.Prescription.DeleteOneID(sampleID).Exec(ctx)
+	if ent.IsNotFound(err) {
+		return fmt.Errorf("sample %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		d.logger.Error("failed to delete prescription", zap.String("sample_id", id), zap.Error(err))
+		return fmt.Errorf("failed to delete prescription: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		d.logger.Error("failed to commit transaction", zap.Error(err))
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// drugNameContains matches samples with a medication whose drug name contains s, ignoring case.
+func drugNameContains(s string) predicate.Prescription {
+	return func(sel *sql.Selector) {
+		medications := sel.C(prescription.FieldContent) + "->'medications'"
+		sel.Where(sql.P(func(b *sql.Builder) {
+			b.WriteString("EXISTS (SELECT 1 FROM jsonb_array_elements(CASE WHEN jsonb_typeof(" + medications + ") = 'array' THEN " + medications + " ELSE '[]'::jsonb END) AS m WHERE m->>'drug_name' ILIKE ")
+			b.Arg(likePattern(s))
+			b.WriteString(")")
+		}))
+	}
+}
+
+// contentContains matches samples whose prescription JSON contains s, ignoring case.
+func contentContains(s string) predicate.Prescription {
+	return func(sel *sql.Selector) {
+		sel.Where(sql.P(func(b *sql.Builder) {
+			b.WriteString(sel.C(prescription.FieldContent) + "::text ILIKE ")
+			b.Arg(likePattern(s))
+		}))
+	}
+}
+
+// likePattern returns an ILIKE pattern matching any value containing s literally.
+func likePattern(s string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s) + "%"
+}
+
+// toSample converts a prescription row, with its embeddings loaded, to a sample.
+func toSample(row *ent.Prescription) models.Sample {
+	embeddings := make([]models.EmbeddingModel, 0, len(row.Edges.Embeddings))
+	for _, emb := range row.Edges.Embeddings {
+		embeddings = append(embeddings, models.EmbeddingModel{
+			Name:       emb.Model,
+			Version:    emb.Version,
+			Dimensions: emb.Dimensions,
+			Kind:       emb.Kind,
+		})
+	}
+
+	return models.Sample{
+		ID:           row.ID.String(),
+		FileID:       row.FileID,
+		MIMEType:     row.MimeType,
+		DocumentHash: row.DocumentHash,
+		Prescription: row.Content,
+		Embeddings:   embeddings,
+		CreatedAt:    row.CreatedAt,
+		SampleMetadata: models.SampleMetadata{
+			Template:  row.Template,
+			Tenant:    row.Tenant,
+			DrugClass: row.DrugClass,
+		},
+	}
+}
@@ -5,23 +5,43 @@ import (
 	"fmt"
 
 	"github.com/csotherden/prescription-parser/pkg/models"
-	"github.com/pgvector/pgvector-go"
 	"go.uber.org/zap"
 )
 
-// SaveSamplePrescription stores a prescription and its vector embedding in the database.
-// It creates both the prescription record and its associated embedding in a single transaction.
+// SaveSamplePrescription stores a prescription and its vector embeddings in the database.
+// It creates both the prescription record and its associated embeddings in a single transaction.
+// When a blob store is configured the original document is stored first and referenced by its
+// content hash, so the sample stays usable after the provider's copy of the file is gone.
 //
 // Parameters:
 //   - ctx: Context for the database operation
 //   - mimeType: MIME type of the prescription image
 //   - imageID: ID of the image file associated with this prescription
+//   - document: Original document bytes, or nil if they are not available
 //   - prescription: Prescription data to store
-//   - embedding: Vector embedding representing the prescription content for similarity search
+//   - metadata: Form template, tenant and drug class that retrieval can be limited to
+//   - embeddings: Vector embeddings of the prescription content, and of its layout if the
+//     document could be fingerprinted, for similarity search, and their models
 //
 // Returns:
 //   - An error if the database operation fails, nil on success
-func (d *PgEntDatastore) SaveSamplePrescription(ctx context.Context, mimeType, imageID string, prescription models.Prescription, embedding []float32) error {
+func (d *PgEntDatastore) SaveSamplePrescription(ctx context.Context, mimeType, imageID string, document []byte, prescription models.Prescription, metadata models.SampleMetadata, embeddings ...models.Embedding) error {
+	for _, embedding := range embeddings {
+		if err := d.ensureEmbeddingIndex(ctx, embedding, models.DistanceL2); err != nil {
+			return err
+		}
+	}
+
+	var documentHash string
+	if d.blobs != nil && len(document) > 0 {
+		var err error
+		documentHash, err = d.blobs.Put(ctx, document)
+		if err != nil {
+			d.logger.Error("failed to store sample document", zap.String("image_id", imageID), zap.Error(err))
+			return fmt.Errorf("failed to store sample document: %w", err)
+		}
+	}
+
 	tx, err := d.dbClient.Tx(ctx)
 	if err != nil {
 		d.logger.Error("failed to create transaction", zap.Error(err))
@@ -33,19 +53,22 @@ func (d *PgEntDatastore) SaveSamplePrescription(ctx context.Context, mimeType, i
 		SetFileID(imageID).
 		SetMimeType(mimeType).
 		SetContent(prescription).
+		SetDocumentHash(documentHash).
+		SetTemplate(metadata.Template).
+		SetTenant(metadata.Tenant).
+		SetDrugClass(metadata.DrugClass).
 		Save(ctx)
 	if err != nil {
 		d.logger.Error("failed to create prescription", zap.Error(err))
 		return fmt.Errorf("failed to create prescription: %w", err)
 	}
 
-	_, err = tx.Embedding.Create().
-		SetPrescriptionID(dbPrescription.ID).
-		SetEmbedding(pgvector.NewVector(embedding)).
-		Save(ctx)
-	if err != nil {
-		d.logger.Error("failed to create embedding", zap.Error(err))
-		return fmt.Errorf("failed to create embedding: %w", err)
+	for _, embedding := range embeddings {
+		err = replaceEmbedding(ctx, tx, dbPrescription.ID, embedding)
+		if err != nil {
+			d.logger.Error("failed to create embedding", zap.String("model", embedding.Model.String()), zap.Error(err))
+			return err
+		}
 	}
 
 	err = tx.Commit()
@@ -0,0 +1,138 @@
+// Package experiments splits parse jobs between variants of the parsing setup, such as a new prompt
+// version, another backend or another pipeline, so the variants can be compared on production
+// traffic. Experiments are declared in a YAML or JSON experiment file; at most one is enabled at a
+// time, and each job it receives is assigned one of its variants by the variants' percentage
+// weights. The experiment and variant are recorded on the job, so reviewer corrections can be
+// attributed to them.
+package experiments
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is the structure of an experiment file.
+type File struct {
+	Experiments []Experiment `yaml:"experiments"`
+}
+
+// Experiment splits parse jobs between variants.
+type Experiment struct {
+	ID          string    `yaml:"id" json:"id"`                             // Identifier recorded on jobs
+	Description string    `yaml:"description" json:"description,omitempty"` // What the experiment tests
+	Enabled     bool      `yaml:"enabled" json:"enabled"`                   // Whether jobs are assigned to the experiment
+	Variants    []Variant `yaml:"variants" json:"variants"`                 // Variants, whose weights add up to 100
+}
+
+// Variant is one way of parsing in an experiment. Empty settings keep the service's configuration.
+type Variant struct {
+	Name            string `yaml:"name" json:"name"`                                   // Identifier recorded on jobs
+	Weight          int    `yaml:"weight" json:"weight"`                               // Percentage of the experiment's jobs assigned the variant
+	PromptVersion   string `yaml:"prompt_version" json:"prompt_version,omitempty"`     // Prompt version to parse with
+	Backend         string `yaml:"backend" json:"backend,omitempty"`                   // Parser backend ("OpenAI" or "Gemini")
+	SampleRetrieval string `yaml:"sample_retrieval" json:"sample_retrieval,omitempty"` // How samples are found for the second parsing pass
+	Pipeline        string `yaml:"pipeline" json:"pipeline,omitempty"`                 // Comma-separated pipeline stages to run
+}
+
+// Registry holds the experiments loaded from an experiment file.
+type Registry struct {
+	experiments map[string]Experiment
+	active      string // ID of the enabled experiment, empty if none is
+}
+
+// Load reads a YAML or JSON experiment file.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read experiment file: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse compiles experiment file contents. Since JSON is valid YAML, either format is accepted.
+func Parse(data []byte) (*Registry, error) {
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse experiment file: %w", err)
+	}
+
+	registry := &Registry{experiments: make(map[string]Experiment, len(file.Experiments))}
+
+	for _, experiment := range file.Experiments {
+		if experiment.ID == "" {
+			return nil, fmt.Errorf("experiment is missing an id")
+		}
+		if _, exists := registry.experiments[experiment.ID]; exists {
+			return nil, fmt.Errorf("duplicate experiment %q", experiment.ID)
+		}
+		if err := validateVariants(experiment.Variants); err != nil {
+			return nil, fmt.Errorf("experiment %q: %w", experiment.ID, err)
+		}
+
+		if experiment.Enabled {
+			if registry.active != "" {
+				return nil, fmt.Errorf("experiments %q and %q are both enabled; only one experiment can run at a time", registry.active, experiment.ID)
+			}
+			registry.active = experiment.ID
+		}
+		registry.experiments[experiment.ID] = experiment
+	}
+
+	return registry, nil
+}
+
+// validateVariants checks that an experiment has at least two uniquely named variants whose
+// weights add up to 100.
+func validateVariants(variants []Variant) error {
+	if len(variants) < 2 {
+		return fmt.Errorf("needs at least two variants")
+	}
+
+	names := make(map[string]bool, len(variants))
+	total := 0
+	for _, variant := range variants {
+		if variant.Name == "" {
+			return fmt.Errorf("variant is missing a name")
+		}
+		if names[variant.Name] {
+			return fmt.Errorf("duplicate variant %q", variant.Name)
+		}
+		names[variant.Name] = true
+
+		if variant.Weight < 0 {
+			return fmt.Errorf("variant %q has a negative weight", variant.Name)
+		}
+		total += variant.Weight
+	}
+
+	if total != 100 {
+		return fmt.Errorf("variant weights add up to %d, not 100", total)
+	}
+
+	return nil
+}
+
+// Active returns the enabled experiment. It returns false if no experiment is enabled or the
+// registry is nil.
+func (r *Registry) Active() (Experiment, bool) {
+	if r == nil || r.active == "" {
+		return Experiment{}, false
+	}
+	return r.experiments[r.active], true
+}
+
+// Assign returns the variant for a roll in [0, 100). Each variant covers a range of rolls as wide
+// as its weight, in the order the variants are declared, so a uniformly random roll assigns jobs
+// to the variants by their weights.
+func (e Experiment) Assign(roll int) Variant {
+	for _, variant := range e.Variants {
+		if roll < variant.Weight {
+			return variant
+		}
+		roll -= variant.Weight
+	}
+	return e.Variants[len(e.Variants)-1]
+}
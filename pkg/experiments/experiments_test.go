@@ -0,0 +1,103 @@
+package experiments
+
+import (
+	"strings"
+	"testing"
+)
+
+const testExperiments = `
+experiments:
+  - id: review-prompt-2
+    description: New review prompt on a tenth of traffic
+    enabled: true
+    variants:
+      - name: control
+        weight: 90
+      - name: review-2
+        weight: 10
+        prompt_version: "2"
+  - id: gemini
+    variants:
+      - name: openai
+        weight: 50
+        backend: OpenAI
+      - name: gemini
+        weight: 50
+        backend: Gemini
+`
+
+func TestAssign(t *testing.T) {
+	registry, err := Parse([]byte(testExperiments))
+	if err != nil {
+		t.Fatalf("Failed to parse experiments: %v", err)
+	}
+
+	experiment, ok := registry.Active()
+	if !ok || experiment.ID != "review-prompt-2" {
+		t.Fatalf("Expected review-prompt-2 to be active, got %+v", experiment)
+	}
+
+	tests := []struct {
+		roll int
+		want string
+	}{
+		{0, "control"},
+		{89, "control"},
+		{90, "review-2"},
+		{99, "review-2"},
+	}
+
+	for _, tt := range tests {
+		if got := experiment.Assign(tt.roll); got.Name != tt.want {
+			t.Errorf("Assign(%d) = %s, want %s", tt.roll, got.Name, tt.want)
+		}
+	}
+
+	if got := experiment.Assign(90); got.PromptVersion != "2" {
+		t.Errorf("Expected the variant's prompt version, got %+v", got)
+	}
+}
+
+func TestActive(t *testing.T) {
+	var empty *Registry
+	if _, ok := empty.Active(); ok {
+		t.Error("Expected no active experiment in a nil registry")
+	}
+
+	registry, err := Parse([]byte("experiments:\n  - id: a\n    variants:\n      - name: x\n        weight: 50\n      - name: y\n        weight: 50\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse experiments: %v", err)
+	}
+	if _, ok := registry.Active(); ok {
+		t.Error("Expected no active experiment when none is enabled")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	variants := "    variants:\n      - name: x\n        weight: 50\n      - name: y\n        weight: 50\n"
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"missing id", "experiments:\n  - enabled: true\n" + variants, "missing an id"},
+		{"duplicate id", "experiments:\n  - id: a\n" + variants + "  - id: a\n" + variants, "duplicate experiment"},
+		{"two enabled", "experiments:\n  - id: a\n    enabled: true\n" + variants + "  - id: b\n    enabled: true\n" + variants, "both enabled"},
+		{"one variant", "experiments:\n  - id: a\n    variants:\n      - name: x\n        weight: 100\n", "at least two variants"},
+		{"unnamed variant", "experiments:\n  - id: a\n    variants:\n      - weight: 50\n      - name: y\n        weight: 50\n", "missing a name"},
+		{"duplicate variant", "experiments:\n  - id: a\n    variants:\n      - name: x\n        weight: 50\n      - name: x\n        weight: 50\n", "duplicate variant"},
+		{"negative weight", "experiments:\n  - id: a\n    variants:\n      - name: x\n        weight: -10\n      - name: y\n        weight: 110\n", "negative weight"},
+		{"weights under 100", "experiments:\n  - id: a\n    variants:\n      - name: x\n        weight: 50\n      - name: y\n        weight: 40\n", "add up to 90"},
+		{"invalid yaml", "experiments: [", "failed to parse experiment file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
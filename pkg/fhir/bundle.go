@@ -0,0 +1,406 @@
+package fhir
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/controlled"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/personname"
+	"github.com/google/uuid"
+)
+
+// quantityPattern reads the leading amount and unit from a quantity such as "30 tablets".
+var quantityPattern = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?)\s*(.*)$`)
+
+// BuildBundle converts a parsed prescription to a FHIR R4 transaction bundle. Resources refer to
+// each other by urn:uuid fullUrls, which the receiving server resolves when it processes the
+// transaction. The bundle holds a Patient, a Practitioner, PractitionerRole and Organization for
+// the prescriber and their office, a Coverage for each insurance policy, a Condition for each
+// diagnosis and a MedicationRequest for each medication. Fields that were not parsed are omitted.
+func BuildBundle(rx models.Prescription) *Bundle {
+	b := &bundler{bundle: &Bundle{
+		ResourceType: "Bundle",
+		Type:         "transaction",
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+	}}
+
+	patientRef := b.add("Patient", patient(rx.Patient), "")
+
+	var requester *Reference
+	if practitioner := practitioner(rx.Prescriber); practitioner != nil {
+		ref := b.add("Practitioner", practitioner, conditionalNPI(practitioner))
+		requester = &ref
+
+		role := PractitionerRole{ResourceType: "PractitionerRole", Practitioner: ref}
+		if rx.Prescriber.Specialty != "" {
+			role.Specialty = []CodeableConcept{{Text: rx.Prescriber.Specialty}}
+		}
+		if office := organization(rx.Prescriber.Office); office != nil {
+			officeRef := b.add("Organization", office, "")
+			role.Organization = &officeRef
+		}
+		b.add("PractitionerRole", role, "")
+	}
+
+	var coverages []Reference
+	order := 0
+	for _, ins := range rx.Patient.Insurance {
+		if ins.Provider == "" && ins.IdNumber == "" {
+			continue
+		}
+		order++
+		coverages = append(coverages, b.add("Coverage", coverage(ins, rx.Patient, patientRef, order), ""))
+	}
+
+	var conditions []Reference
+	for i, d := range diagnoses(rx.Diagnosis) {
+		if d.Icd10Code == "" && d.Description == "" {
+			continue
+		}
+		onset := ""
+		if i == 0 {
+			onset = date(rx.Diagnosis.DateOfDiagnosis)
+		}
+		conditions = append(conditions, b.add("Condition", condition(d, patientRef, onset), ""))
+	}
+
+	for _, med := range rx.Medications {
+		request := medicationRequest(rx, med, patientRef)
+		request.Requester = requester
+		request.ReasonReference = conditions
+		request.Insurance = coverages
+		if med.Indication != "" && len(conditions) == 0 {
+			request.ReasonCode = []CodeableConcept{{Text: med.Indication}}
+		}
+		b.add("MedicationRequest", request, "")
+	}
+
+	return b.bundle
+}
+
+// bundler appends resources to a transaction bundle.
+type bundler struct {
+	bundle *Bundle
+}
+
+// add appends a resource as a POST entry and returns a reference to it. A non-empty
+// ifNoneExist makes the create conditional so existing resources are reused.
+func (b *bundler) add(resourceType string, resource any, ifNoneExist string) Reference {
+	fullURL := "urn:uuid:" + uuid.NewString()
+	b.bundle.Entry = append(b.bundle.Entry, BundleEntry{
+		FullURL:  fullURL,
+		Resource: resource,
+		Request: &BundleRequest{
+			Method:      "POST",
+			URL:         resourceType,
+			IfNoneExist: ifNoneExist,
+		},
+	})
+	return Reference{Reference: fullURL}
+}
+
+func patient(p models.Patient) Patient {
+	out := Patient{
+		ResourceType: "Patient",
+		Gender:       gender(p.Sex),
+		BirthDate:    date(p.Dob),
+		Address:      address(p.Address, "home"),
+	}
+
+	name := HumanName{Use: "official", Family: p.LastName}
+	for _, given := range []string{p.FirstName, p.MiddleName} {
+		if given != "" {
+			name.Given = append(name.Given, given)
+		}
+	}
+	if name.Family != "" || len(name.Given) > 0 {
+		out.Name = []HumanName{name}
+	}
+
+	for _, phone := range p.PhoneNumbers {
+		if phone.Number == "" {
+			continue
+		}
+		value := phone.Number
+		if phone.Extension != "" {
+			value += " x" + phone.Extension
+		}
+		out.Telecom = append(out.Telecom, telecom(phone.Label, value))
+	}
+
+	if c := p.EmergencyContact; c.Name != "" || c.Phone != "" {
+		contact := PatientContact{}
+		if c.Relationship != "" {
+			contact.Relationship = []CodeableConcept{{Text: c.Relationship}}
+		}
+		if c.Name != "" {
+			contact.Name = &HumanName{Text: c.Name}
+		}
+		if c.Phone != "" {
+			contact.Telecom = []ContactPoint{{System: "phone", Value: c.Phone}}
+		}
+		out.Contact = []PatientContact{contact}
+	}
+
+	return out
+}
+
+// practitioner returns the prescriber as a Practitioner, or nil if neither a name nor an NPI was parsed.
+func practitioner(p models.Prescriber) *Practitioner {
+	if p.Name == "" && p.Npi == "" {
+		return nil
+	}
+
+	out := &Practitioner{ResourceType: "Practitioner"}
+	if npi := digits(p.Npi); npi != "" {
+		out.Identifier = append(out.Identifier, Identifier{System: SystemNPI, Value: npi})
+	}
+	if dea := strings.ToUpper(strings.ReplaceAll(p.Dea, " ", "")); dea != "" {
+		out.Identifier = append(out.Identifier, Identifier{System: SystemDEA, Value: dea})
+	}
+
+	if p.Name != "" {
+		parts := personname.Split(p.Name)
+		name := HumanName{Use: "official", Text: p.Name, Family: parts.Last}
+		for _, given := range []string{parts.First, parts.Middle} {
+			if given != "" {
+				name.Given = append(name.Given, given)
+			}
+		}
+		if parts.Prefix != "" {
+			name.Prefix = []string{parts.Prefix}
+		}
+		name.Suffix = strings.Fields(parts.Suffix)
+		out.Name = []HumanName{name}
+	}
+
+	return out
+}
+
+// conditionalNPI returns the search that finds an existing practitioner with the same NPI.
+func conditionalNPI(p *Practitioner) string {
+	for _, id := range p.Identifier {
+		if id.System == SystemNPI {
+			return "identifier=" + SystemNPI + "|" + id.Value
+		}
+	}
+	return ""
+}
+
+// organization returns the prescriber's office as an Organization, or nil if nothing was parsed for it.
+func organization(o models.PrescriberOffice) *Organization {
+	if o.Name == "" && o.Address == (models.Address{}) && o.Phone == "" && o.Fax == "" {
+		return nil
+	}
+
+	out := &Organization{
+		ResourceType: "Organization",
+		Name:         o.Name,
+		Address:      address(o.Address, "work"),
+	}
+	if o.Phone != "" {
+		out.Telecom = append(out.Telecom, ContactPoint{System: "phone", Value: o.Phone, Use: "work"})
+	}
+	if o.Fax != "" {
+		out.Telecom = append(out.Telecom, ContactPoint{System: "fax", Value: o.Fax, Use: "work"})
+	}
+	if o.ContactEmail != "" {
+		out.Telecom = append(out.Telecom, ContactPoint{System: "email", Value: o.ContactEmail, Use: "work"})
+	}
+
+	return out
+}
+
+// coverage converts an insurance policy to a Coverage. Payers are referenced by name because
+// forms do not carry a payer identifier the receiving system could resolve.
+func coverage(ins models.Insurance, p models.Patient, beneficiary Reference, order int) Coverage {
+	out := Coverage{
+		ResourceType: "Coverage",
+		Status:       "active",
+		SubscriberID: ins.IdNumber,
+		Beneficiary:  beneficiary,
+		Payor:        []Reference{{Display: ins.Provider}},
+		Order:        order,
+	}
+	if ins.Provider == "" {
+		out.Payor = []Reference{{Display: "Unknown payer"}}
+	}
+
+	holder := strings.ToUpper(strings.Join(strings.Fields(ins.PolicyholderName), " "))
+	self := strings.ToUpper(strings.Join(strings.Fields(p.FirstName+" "+p.LastName), " "))
+	if holder == "" || holder == self {
+		out.Relationship = &CodeableConcept{Coding: []Coding{{System: SystemSubscriberRel, Code: "self"}}}
+	}
+
+	for _, class := range []struct{ code, value string }{
+		{"group", ins.GroupNumber},
+		{"rxbin", ins.RxBin},
+		{"rxpcn", ins.Pcn},
+	} {
+		if class.value != "" {
+			out.Class = append(out.Class, CoverageClass{
+				Type:  CodeableConcept{Coding: []Coding{{System: SystemCoverageClass, Code: class.code}}},
+				Value: class.value,
+			})
+		}
+	}
+
+	return out
+}
+
+// diagnoses returns the primary diagnosis followed by the additional diagnoses.
+func diagnoses(d models.PatientDiagnosis) []models.Diagnosis {
+	return append([]models.Diagnosis{d.PrimaryDiagnosis}, d.AdditionalDiagnoses...)
+}
+
+func condition(d models.Diagnosis, subject Reference, onset string) Condition {
+	code := CodeableConcept{Text: d.Description}
+	if d.Icd10Code != "" {
+		code.Coding = []Coding{{
+			System:  SystemICD10CM,
+			Code:    strings.ToUpper(strings.TrimSpace(d.Icd10Code)),
+			Display: d.Description,
+		}}
+	}
+
+	return Condition{
+		ResourceType:   "Condition",
+		ClinicalStatus: &CodeableConcept{Coding: []Coding{{System: SystemConditionClin, Code: "active"}}},
+		Category:       []CodeableConcept{{Coding: []Coding{{System: SystemConditionCat, Code: "encounter-diagnosis"}}}},
+		Code:           code,
+		Subject:        subject,
+		OnsetDateTime:  onset,
+	}
+}
+
+func medicationRequest(rx models.Prescription, med models.Medication, subject Reference) MedicationRequest {
+	out := MedicationRequest{
+		ResourceType:              "MedicationRequest",
+		Status:                    "active",
+		Intent:                    "order",
+		MedicationCodeableConcept: medicationCode(med),
+		Subject:                   subject,
+		AuthoredOn:                date(rx.DateWritten),
+	}
+	if out.AuthoredOn == "" {
+		out.AuthoredOn = date(rx.PrescriberSignature.Date)
+	}
+
+	if med.SIG != "" || med.AdministrationNotes != "" {
+		out.DosageInstruction = []Dosage{{Text: med.SIG, PatientInstruction: med.AdministrationNotes}}
+	}
+	if med.Duration != "" {
+		out.Note = []Annotation{{Text: "Duration: " + med.Duration}}
+	}
+
+	dispense := DispenseRequest{}
+	if refills, unlimited, ok := controlled.ParseRefills(med.Refills); ok && !unlimited && med.Refills != "" {
+		dispense.NumberOfRepeatsAllowed = &refills
+	}
+	if m := quantityPattern.FindStringSubmatch(med.Quantity); m != nil {
+		if value, err := strconv.ParseFloat(m[1], 64); err == nil {
+			dispense.Quantity = &Quantity{Value: value, Unit: strings.TrimSpace(m[2])}
+		}
+	}
+	if start := date(med.StartDate); start != "" {
+		dispense.ValidityPeriod = &Period{Start: start}
+	}
+	if dispense != (DispenseRequest{}) {
+		out.DispenseRequest = &dispense
+	}
+
+	if daw := strings.TrimSpace(rx.PrescriberSignature.DawCode); daw != "" {
+		out.Substitution = &MedicationSubstitution{AllowedBoolean: daw[0] == '0'}
+	}
+
+	return out
+}
+
+// medicationCode codes a medication with its RxNorm concept and NDC, when known, and describes
+// it with the drug name, strength and form.
+func medicationCode(med models.Medication) CodeableConcept {
+	text := med.DrugName
+	if med.Strength != "" && !strings.Contains(text, med.Strength) {
+		text += " " + med.Strength
+	}
+	if med.Form != "" && !strings.Contains(strings.ToLower(text), strings.ToLower(med.Form)) {
+		text += " " + med.Form
+	}
+
+	code := CodeableConcept{Text: strings.TrimSpace(text)}
+	if n := med.Normalized; n != nil && n.RxCUI != "" {
+		code.Coding = append(code.Coding, Coding{System: SystemRxNorm, Code: n.RxCUI, Display: n.Name})
+	}
+	if med.Ndc != "" {
+		code.Coding = append(code.Coding, Coding{System: SystemNDC, Code: strings.TrimSpace(med.Ndc)})
+	}
+
+	return code
+}
+
+func address(a models.Address, use string) []Address {
+	if a == (models.Address{}) {
+		return nil
+	}
+
+	out := Address{Use: use, City: a.City, State: a.State, PostalCode: a.Zip, Country: "US"}
+	if a.Street != "" {
+		out.Line = []string{a.Street}
+	}
+	return []Address{out}
+}
+
+// telecom converts a labeled phone number to a contact point.
+func telecom(label, value string) ContactPoint {
+	switch strings.ToLower(strings.TrimSpace(label)) {
+	case "fax":
+		return ContactPoint{System: "fax", Value: value}
+	case "mobile", "cell":
+		return ContactPoint{System: "phone", Value: value, Use: "mobile"}
+	case "work":
+		return ContactPoint{System: "phone", Value: value, Use: "work"}
+	case "home":
+		return ContactPoint{System: "phone", Value: value, Use: "home"}
+	default:
+		return ContactPoint{System: "phone", Value: value}
+	}
+}
+
+// gender maps a parsed sex to the FHIR administrative gender.
+func gender(sex string) string {
+	switch strings.ToUpper(strings.TrimSpace(sex)) {
+	case "":
+		return ""
+	case "M", "MALE":
+		return "male"
+	case "F", "FEMALE":
+		return "female"
+	case "U", "UNKNOWN":
+		return "unknown"
+	default:
+		return "other"
+	}
+}
+
+// date returns a YYYY-MM-DD date unchanged, or an empty string if it is not a valid date.
+func date(s string) string {
+	s = strings.TrimSpace(s)
+	if _, err := time.Parse("2006-01-02", s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// digits returns only the digits of s.
+func digits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
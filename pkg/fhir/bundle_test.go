@@ -0,0 +1,212 @@
+package fhir
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func testPrescription() models.Prescription {
+	return models.Prescription{
+		DateWritten: "2025-03-14",
+		Patient: models.Patient{
+			FirstName:    "Ann",
+			MiddleName:   "B",
+			LastName:     "Lee",
+			Dob:          "1980-02-01",
+			Sex:          "Female",
+			Address:      models.Address{Street: "12 OAK ST", City: "RESTON", State: "VA", Zip: "20190"},
+			PhoneNumbers: []models.PhoneNumber{{Label: "Mobile", Number: "7035551234"}, {Label: "Work", Number: "7035550000", Extension: "12"}},
+			Insurance: []models.Insurance{
+				{Type: "Primary", Provider: "Aetna", IdNumber: "W123", GroupNumber: "G1", RxBin: "610502", Pcn: "MEDDPRIME"},
+				{Type: "Secondary", Provider: "Medicaid", IdNumber: "M456", PolicyholderName: "John Lee"},
+				{},
+			},
+		},
+		Prescriber: models.Prescriber{
+			Name:      "Dr. Jane A. Brown, MD",
+			Specialty: "Dermatology",
+			Npi:       "1234567893",
+			Dea:       "AB1234563",
+			Office: models.PrescriberOffice{
+				Name:    "Reston Dermatology",
+				Address: models.Address{Street: "1 MAIN ST", City: "RESTON", State: "VA", Zip: "20190"},
+				Phone:   "7035550100",
+				Fax:     "7035550101",
+			},
+		},
+		Diagnosis: models.PatientDiagnosis{
+			DateOfDiagnosis:     "2024-11-02",
+			PrimaryDiagnosis:    models.Diagnosis{Description: "Psoriatic arthritis", Icd10Code: "L40.50"},
+			AdditionalDiagnoses: []models.Diagnosis{{Description: "Psoriasis", Icd10Code: "L40.0"}},
+		},
+		Medications: []models.Medication{{
+			DrugName:            "Humira",
+			Ndc:                 "0074-0554-02",
+			Strength:            "40 mg/0.4 mL",
+			Form:                "pen",
+			SIG:                 "Inject 40 mg SC every other week",
+			AdministrationNotes: "Inject one pen under the skin every 14 days",
+			Quantity:            "2 pens",
+			Refills:             "5",
+			Normalized:          &models.DrugNormalization{RxCUI: "1727500", Name: "adalimumab 40 MG in 0.4 ML Auto-Injector [Humira]"},
+		}},
+		PrescriberSignature: models.SignatureInfo{Date: "2025-03-14", DawCode: "1"},
+	}
+}
+
+// entries decodes the bundle's JSON and groups its resources by resource type.
+func entries(t *testing.T, bundle *Bundle) (map[string][]map[string]any, map[string]bool) {
+	t.Helper()
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("Failed to marshal bundle: %v", err)
+	}
+
+	var decoded struct {
+		Entry []struct {
+			FullURL  string         `json:"fullUrl"`
+			Resource map[string]any `json:"resource"`
+			Request  BundleRequest  `json:"request"`
+		} `json:"entry"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal bundle: %v", err)
+	}
+
+	byType := map[string][]map[string]any{}
+	urls := map[string]bool{}
+	for _, entry := range decoded.Entry {
+		resourceType, _ := entry.Resource["resourceType"].(string)
+		if entry.Request.Method != "POST" || entry.Request.URL != resourceType {
+			t.Errorf("Unexpected request %+v for %s", entry.Request, resourceType)
+		}
+		byType[resourceType] = append(byType[resourceType], entry.Resource)
+		urls[entry.FullURL] = true
+	}
+
+	return byType, urls
+}
+
+func TestBuildBundle(t *testing.T) {
+	bundle := BuildBundle(testPrescription())
+	if bundle.ResourceType != "Bundle" || bundle.Type != "transaction" {
+		t.Fatalf("Unexpected bundle %s/%s", bundle.ResourceType, bundle.Type)
+	}
+
+	byType, urls := entries(t, bundle)
+
+	wantCounts := map[string]int{
+		"Patient":           1,
+		"Practitioner":      1,
+		"PractitionerRole":  1,
+		"Organization":      1,
+		"Coverage":          2,
+		"Condition":         2,
+		"MedicationRequest": 1,
+	}
+	for resourceType, want := range wantCounts {
+		if got := len(byType[resourceType]); got != want {
+			t.Errorf("Expected %d %s resources, got %d", want, resourceType, got)
+		}
+	}
+
+	patient := byType["Patient"][0]
+	if patient["gender"] != "female" || patient["birthDate"] != "1980-02-01" {
+		t.Errorf("Unexpected patient %v", patient)
+	}
+
+	if practitioner := bundle.Entry[1]; practitioner.Request.IfNoneExist != "identifier="+SystemNPI+"|1234567893" {
+		t.Errorf("Expected conditional create on NPI, got %q", practitioner.Request.IfNoneExist)
+	}
+	name := byType["Practitioner"][0]["name"].([]any)[0].(map[string]any)
+	if name["family"] != "Brown" || name["prefix"].([]any)[0] != "DR" {
+		t.Errorf("Unexpected practitioner name %v", name)
+	}
+
+	primary, secondary := byType["Coverage"][0], byType["Coverage"][1]
+	if primary["order"] != float64(1) || primary["subscriberId"] != "W123" || len(primary["class"].([]any)) != 3 {
+		t.Errorf("Unexpected primary coverage %v", primary)
+	}
+	if _, ok := primary["relationship"]; !ok {
+		t.Errorf("Expected self relationship on primary coverage")
+	}
+	if _, ok := secondary["relationship"]; ok {
+		t.Errorf("Expected no relationship for a coverage held by someone else")
+	}
+
+	condition := byType["Condition"][0]
+	code := condition["code"].(map[string]any)["coding"].([]any)[0].(map[string]any)
+	if code["system"] != SystemICD10CM || code["code"] != "L40.50" || condition["onsetDateTime"] != "2024-11-02" {
+		t.Errorf("Unexpected condition %v", condition)
+	}
+	if _, ok := byType["Condition"][1]["onsetDateTime"]; ok {
+		t.Errorf("Expected no onset on additional diagnoses")
+	}
+
+	request := byType["MedicationRequest"][0]
+	medication := request["medicationCodeableConcept"].(map[string]any)
+	if medication["text"] != "Humira 40 mg/0.4 mL pen" || len(medication["coding"].([]any)) != 2 {
+		t.Errorf("Unexpected medication %v", medication)
+	}
+	dosage := request["dosageInstruction"].([]any)[0].(map[string]any)
+	if dosage["text"] != "Inject 40 mg SC every other week" {
+		t.Errorf("Unexpected dosage %v", dosage)
+	}
+	dispense := request["dispenseRequest"].(map[string]any)
+	if dispense["numberOfRepeatsAllowed"] != float64(5) || dispense["quantity"].(map[string]any)["value"] != float64(2) {
+		t.Errorf("Unexpected dispense request %v", dispense)
+	}
+	if request["substitution"].(map[string]any)["allowedBoolean"] != false {
+		t.Errorf("Expected substitution not allowed for DAW 1")
+	}
+
+	// Every reference must resolve to an entry in the bundle.
+	references := []string{request["subject"].(map[string]any)["reference"].(string), request["requester"].(map[string]any)["reference"].(string)}
+	for _, field := range []string{"reasonReference", "insurance"} {
+		for _, ref := range request[field].([]any) {
+			references = append(references, ref.(map[string]any)["reference"].(string))
+		}
+	}
+	if len(references) != 6 {
+		t.Errorf("Expected 6 references, got %d", len(references))
+	}
+	for _, ref := range references {
+		if !strings.HasPrefix(ref, "urn:uuid:") || !urls[ref] {
+			t.Errorf("Reference %s does not resolve within the bundle", ref)
+		}
+	}
+}
+
+func TestBuildBundleSparse(t *testing.T) {
+	rx := models.Prescription{
+		Patient:     models.Patient{FirstName: "Ann", Dob: "02/01/1980"},
+		Medications: []models.Medication{{DrugName: "Metformin", Refills: "PRN", Indication: "Type 2 diabetes"}},
+	}
+
+	byType, _ := entries(t, BuildBundle(rx))
+
+	for _, resourceType := range []string{"Practitioner", "Organization", "Coverage", "Condition"} {
+		if len(byType[resourceType]) != 0 {
+			t.Errorf("Expected no %s resources, got %v", resourceType, byType[resourceType])
+		}
+	}
+
+	if _, ok := byType["Patient"][0]["birthDate"]; ok {
+		t.Errorf("Expected an unparseable birth date to be omitted")
+	}
+
+	request := byType["MedicationRequest"][0]
+	if _, ok := request["requester"]; ok {
+		t.Errorf("Expected no requester")
+	}
+	if _, ok := request["dispenseRequest"]; ok {
+		t.Errorf("Expected no dispense request for unlimited refills and no quantity")
+	}
+	if request["reasonCode"].([]any)[0].(map[string]any)["text"] != "Type 2 diabetes" {
+		t.Errorf("Expected the indication as the reason, got %v", request["reasonCode"])
+	}
+}
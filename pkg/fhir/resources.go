@@ -0,0 +1,213 @@
+// Package fhir converts parsed prescriptions to FHIR R4 transaction bundles for EHR
+// integration engines. Only the resources and elements the converter populates are modeled.
+package fhir
+
+// Code systems and identifier systems used in exported resources.
+const (
+	SystemNPI           = "http://hl7.org/fhir/sid/us-npi"
+	SystemDEA           = "urn:oid:2.16.840.1.113883.4.814"
+	SystemICD10CM       = "http://hl7.org/fhir/sid/icd-10-cm"
+	SystemRxNorm        = "http://www.nlm.nih.gov/research/umls/rxnorm"
+	SystemNDC           = "http://hl7.org/fhir/sid/ndc"
+	SystemCoverageClass = "http://terminology.hl7.org/CodeSystem/coverage-class"
+	SystemConditionClin = "http://terminology.hl7.org/CodeSystem/condition-clinical"
+	SystemConditionCat  = "http://terminology.hl7.org/CodeSystem/condition-category"
+	SystemSubscriberRel = "http://terminology.hl7.org/CodeSystem/subscriber-relationship"
+)
+
+// Bundle is a FHIR Bundle resource.
+type Bundle struct {
+	ResourceType string        `json:"resourceType"`
+	Type         string        `json:"type"`
+	Timestamp    string        `json:"timestamp,omitempty"`
+	Entry        []BundleEntry `json:"entry"`
+}
+
+// BundleEntry is a resource in a bundle together with the request that creates it.
+type BundleEntry struct {
+	FullURL  string         `json:"fullUrl"`
+	Resource any            `json:"resource"`
+	Request  *BundleRequest `json:"request,omitempty"`
+}
+
+// BundleRequest is the transaction request for a bundle entry.
+type BundleRequest struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	IfNoneExist string `json:"ifNoneExist,omitempty"`
+}
+
+// Identifier is a business identifier such as an NPI.
+type Identifier struct {
+	System string `json:"system,omitempty"`
+	Value  string `json:"value"`
+}
+
+// HumanName is a person's name.
+type HumanName struct {
+	Use    string   `json:"use,omitempty"`
+	Text   string   `json:"text,omitempty"`
+	Family string   `json:"family,omitempty"`
+	Given  []string `json:"given,omitempty"`
+	Prefix []string `json:"prefix,omitempty"`
+	Suffix []string `json:"suffix,omitempty"`
+}
+
+// ContactPoint is a phone number, fax number or email address.
+type ContactPoint struct {
+	System string `json:"system"`
+	Value  string `json:"value"`
+	Use    string `json:"use,omitempty"`
+}
+
+// Address is a postal address.
+type Address struct {
+	Use        string   `json:"use,omitempty"`
+	Line       []string `json:"line,omitempty"`
+	City       string   `json:"city,omitempty"`
+	State      string   `json:"state,omitempty"`
+	PostalCode string   `json:"postalCode,omitempty"`
+	Country    string   `json:"country,omitempty"`
+}
+
+// Coding is a code from a code system.
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code"`
+	Display string `json:"display,omitempty"`
+}
+
+// CodeableConcept is a concept given by codes and/or text.
+type CodeableConcept struct {
+	Coding []Coding `json:"coding,omitempty"`
+	Text   string   `json:"text,omitempty"`
+}
+
+// Reference refers to another resource, by fullUrl within the bundle or by display text alone.
+type Reference struct {
+	Reference string `json:"reference,omitempty"`
+	Display   string `json:"display,omitempty"`
+}
+
+// Quantity is a measured amount.
+type Quantity struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit,omitempty"`
+}
+
+// Annotation is a free-text note.
+type Annotation struct {
+	Text string `json:"text"`
+}
+
+// Patient is a FHIR Patient resource.
+type Patient struct {
+	ResourceType string           `json:"resourceType"`
+	Name         []HumanName      `json:"name,omitempty"`
+	Telecom      []ContactPoint   `json:"telecom,omitempty"`
+	Gender       string           `json:"gender,omitempty"`
+	BirthDate    string           `json:"birthDate,omitempty"`
+	Address      []Address        `json:"address,omitempty"`
+	Contact      []PatientContact `json:"contact,omitempty"`
+}
+
+// PatientContact is an emergency or other contact for a patient.
+type PatientContact struct {
+	Relationship []CodeableConcept `json:"relationship,omitempty"`
+	Name         *HumanName        `json:"name,omitempty"`
+	Telecom      []ContactPoint    `json:"telecom,omitempty"`
+}
+
+// Practitioner is a FHIR Practitioner resource.
+type Practitioner struct {
+	ResourceType string       `json:"resourceType"`
+	Identifier   []Identifier `json:"identifier,omitempty"`
+	Name         []HumanName  `json:"name,omitempty"`
+}
+
+// Organization is a FHIR Organization resource.
+type Organization struct {
+	ResourceType string         `json:"resourceType"`
+	Name         string         `json:"name,omitempty"`
+	Telecom      []ContactPoint `json:"telecom,omitempty"`
+	Address      []Address      `json:"address,omitempty"`
+}
+
+// PractitionerRole is a FHIR PractitionerRole resource linking a practitioner to their office.
+type PractitionerRole struct {
+	ResourceType string            `json:"resourceType"`
+	Practitioner Reference         `json:"practitioner"`
+	Organization *Reference        `json:"organization,omitempty"`
+	Specialty    []CodeableConcept `json:"specialty,omitempty"`
+}
+
+// Coverage is a FHIR Coverage resource.
+type Coverage struct {
+	ResourceType string           `json:"resourceType"`
+	Status       string           `json:"status"`
+	SubscriberID string           `json:"subscriberId,omitempty"`
+	Beneficiary  Reference        `json:"beneficiary"`
+	Relationship *CodeableConcept `json:"relationship,omitempty"`
+	Payor        []Reference      `json:"payor"`
+	Class        []CoverageClass  `json:"class,omitempty"`
+	Order        int              `json:"order,omitempty"`
+}
+
+// CoverageClass is a plan identifier such as a group number or pharmacy BIN.
+type CoverageClass struct {
+	Type  CodeableConcept `json:"type"`
+	Value string          `json:"value"`
+}
+
+// Condition is a FHIR Condition resource.
+type Condition struct {
+	ResourceType   string            `json:"resourceType"`
+	ClinicalStatus *CodeableConcept  `json:"clinicalStatus,omitempty"`
+	Category       []CodeableConcept `json:"category,omitempty"`
+	Code           CodeableConcept   `json:"code"`
+	Subject        Reference         `json:"subject"`
+	OnsetDateTime  string            `json:"onsetDateTime,omitempty"`
+}
+
+// MedicationRequest is a FHIR MedicationRequest resource.
+type MedicationRequest struct {
+	ResourceType              string                  `json:"resourceType"`
+	Status                    string                  `json:"status"`
+	Intent                    string                  `json:"intent"`
+	MedicationCodeableConcept CodeableConcept         `json:"medicationCodeableConcept"`
+	Subject                   Reference               `json:"subject"`
+	AuthoredOn                string                  `json:"authoredOn,omitempty"`
+	Requester                 *Reference              `json:"requester,omitempty"`
+	ReasonCode                []CodeableConcept       `json:"reasonCode,omitempty"`
+	ReasonReference           []Reference             `json:"reasonReference,omitempty"`
+	Insurance                 []Reference             `json:"insurance,omitempty"`
+	Note                      []Annotation            `json:"note,omitempty"`
+	DosageInstruction         []Dosage                `json:"dosageInstruction,omitempty"`
+	DispenseRequest           *DispenseRequest        `json:"dispenseRequest,omitempty"`
+	Substitution              *MedicationSubstitution `json:"substitution,omitempty"`
+}
+
+// Dosage holds the directions for taking a medication.
+type Dosage struct {
+	Text               string `json:"text,omitempty"`
+	PatientInstruction string `json:"patientInstruction,omitempty"`
+}
+
+// DispenseRequest holds the quantity and refills authorized for dispensing.
+type DispenseRequest struct {
+	ValidityPeriod         *Period   `json:"validityPeriod,omitempty"`
+	NumberOfRepeatsAllowed *int      `json:"numberOfRepeatsAllowed,omitempty"`
+	Quantity               *Quantity `json:"quantity,omitempty"`
+}
+
+// Period is a time range.
+type Period struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+// MedicationSubstitution states whether generic substitution is allowed.
+type MedicationSubstitution struct {
+	AllowedBoolean bool             `json:"allowedBoolean"`
+	Reason         *CodeableConcept `json:"reason,omitempty"`
+}
@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"net/http"
+
+	"github.com/csotherden/prescription-parser/pkg/analytics"
+	"github.com/csotherden/prescription-parser/pkg/handlerutils"
+	"github.com/gorilla/mux"
+)
+
+// GetFieldAnalytics handles the request for per-field error rates of the reviewed results of jobs
+// completed between the from and to dates (YYYY-MM-DD, inclusive, UTC). Results are grouped by
+// parser backend, form template and, with the optional interval parameter, by day, week or month.
+// The optional backend and template parameters restrict the report to one backend or template.
+func (h *Handler) GetFieldAnalytics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	from, to, err := handlerutils.ParseDateRange(query.Get("from"), query.Get("to"))
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Invalid date range", err)
+		return
+	}
+
+	interval, err := analytics.ParseInterval(query.Get("interval"))
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Invalid interval", err)
+		return
+	}
+
+	results, err := h.ds.ListParseResults(r.Context(), from, to)
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to load parse results", err)
+		return
+	}
+
+	report := analytics.FieldErrors(results, analytics.Options{
+		From:     from,
+		To:       to,
+		Interval: interval,
+		Backend:  query.Get("backend"),
+		Template: query.Get("template"),
+	})
+
+	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, report)
+}
+
+// GetExperimentAnalytics handles the request for the per-variant accuracy of an experiment, judged by
+// reviewer corrections of the results of its jobs completed between the from and to dates
+// (YYYY-MM-DD, inclusive, UTC).
+func (h *Handler) GetExperimentAnalytics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	from, to, err := handlerutils.ParseDateRange(query.Get("from"), query.Get("to"))
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Invalid date range", err)
+		return
+	}
+
+	results, err := h.ds.ListParseResults(r.Context(), from, to)
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to load parse results", err)
+		return
+	}
+
+	report := analytics.ExperimentAccuracy(results, mux.Vars(r)["id"], from, to)
+
+	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, report)
+}
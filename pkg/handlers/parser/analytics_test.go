@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/analytics"
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/mocks"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func TestGetFieldAnalytics(t *testing.T) {
+	ds := mocks.NewMockDatastore()
+	handler := NewHandler(config.Config{}, mocks.NewMockParser(), ds, zap.NewNop())
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	ctx := context.Background()
+	for i, changes := range [][]models.FieldChange{
+		{{Field: "prescriber.npi"}},
+		{{Field: "prescriber.npi"}, {Field: "patient.phone_numbers[0].label"}},
+		nil,
+	} {
+		result := models.ParseResult{
+			ID:          fmt.Sprintf("job-%d", i),
+			Attributes:  map[string]string{jobs.AttributeBackend: "OpenAI"},
+			CompletedAt: time.Date(2025, 3, 14, i, 0, 0, 0, time.UTC),
+		}
+		if err := ds.SaveParseResult(ctx, result); err != nil {
+			t.Fatalf("Failed to save parse result: %v", err)
+		}
+		if changes != nil {
+			if err := ds.SaveReview(ctx, result.ID, models.Review{Reviewer: "pharmacist-1", Changes: changes}); err != nil {
+				t.Fatalf("Failed to save review: %v", err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantGroups int
+	}{
+		{name: "by day", query: "from=2025-03-14&interval=day", wantStatus: http.StatusOK, wantGroups: 1},
+		{name: "other backend", query: "from=2025-03-14&backend=Gemini", wantStatus: http.StatusOK, wantGroups: 0},
+		{name: "invalid interval", query: "from=2025-03-14&interval=hour", wantStatus: http.StatusBadRequest},
+		{name: "missing from", query: "", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/parser/analytics/fields?"+tt.query, nil))
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var report analytics.Report
+			if err := json.Unmarshal(rr.Body.Bytes(), &report); err != nil {
+				t.Fatalf("Failed to decode report: %v", err)
+			}
+			if len(report.Groups) != tt.wantGroups {
+				t.Fatalf("Expected %d groups, got %+v", tt.wantGroups, report.Groups)
+			}
+			if tt.wantGroups == 0 {
+				return
+			}
+
+			group := report.Groups[0]
+			if group.Reviewed != 2 || group.Fields[0].Field != "prescriber.npi" || group.Fields[0].ErrorRate != 1 {
+				t.Errorf("Unexpected group %+v", group)
+			}
+		})
+	}
+}
+
+func TestGetExperimentAnalytics(t *testing.T) {
+	ds := mocks.NewMockDatastore()
+	handler := NewHandler(config.Config{}, mocks.NewMockParser(), ds, zap.NewNop())
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	ctx := context.Background()
+	for i, variant := range []string{"control", "control", "review-2"} {
+		result := models.ParseResult{
+			ID:          fmt.Sprintf("job-%d", i),
+			Attributes:  map[string]string{jobs.AttributeExperiment: "review-prompt-2", jobs.AttributeVariant: variant},
+			CompletedAt: time.Date(2025, 3, 14, i, 0, 0, 0, time.UTC),
+		}
+		if err := ds.SaveParseResult(ctx, result); err != nil {
+			t.Fatalf("Failed to save parse result: %v", err)
+		}
+		if err := ds.SaveReview(ctx, result.ID, models.Review{Reviewer: "pharmacist-1"}); err != nil {
+			t.Fatalf("Failed to save review: %v", err)
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/parser/analytics/experiments/review-prompt-2?from=2025-03-14", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, http.StatusOK, rr.Body.String())
+	}
+
+	var report analytics.ExperimentReport
+	if err := json.Unmarshal(rr.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to decode report: %v", err)
+	}
+	if report.Experiment != "review-prompt-2" || len(report.Variants) != 2 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	if control := report.Variants[0]; control.Variant != "control" || control.Reviewed != 2 || control.Accuracy != 1 || control.ConfidenceInterval.High != 1 {
+		t.Errorf("Unexpected control variant %+v", control)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/parser/analytics/experiments/review-prompt-2", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected a missing date range to be rejected, got %v", rr.Code)
+	}
+}
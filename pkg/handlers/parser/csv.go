@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/csotherden/prescription-parser/pkg/csvexport"
+	"github.com/csotherden/prescription-parser/pkg/handlerutils"
+)
+
+// ExportCSV handles the request to export the results of all jobs completed between the
+// from and to dates (YYYY-MM-DD, inclusive, UTC) as CSV with one row per medication.
+// The to date defaults to the from date.
+func (h *Handler) ExportCSV(w http.ResponseWriter, r *http.Request) {
+	fromDate := r.URL.Query().Get("from")
+	toDate := r.URL.Query().Get("to")
+
+	from, to, err := handlerutils.ParseDateRange(fromDate, toDate)
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Invalid date range", err)
+		return
+	}
+
+	results, err := h.ds.ListParseResults(r.Context(), from, to)
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to load parse results", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	writer, err := csvexport.NewWriter(&buf)
+	if err == nil {
+		for _, result := range results {
+			if err = writer.Write(result); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to write CSV", err)
+		return
+	}
+
+	if toDate == "" {
+		toDate = fromDate
+	}
+	handlerutils.RespondWithCSV(w, h.logger, http.StatusOK, fmt.Sprintf("prescriptions_%s_%s.csv", fromDate, toDate), buf.Bytes())
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/csvexport"
+	"github.com/csotherden/prescription-parser/pkg/mocks"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func TestExportCSV(t *testing.T) {
+	ds := mocks.NewMockDatastore()
+	handler := NewHandler(config.Config{}, mocks.NewMockParser(), ds, zap.NewNop())
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	for _, result := range []models.ParseResult{
+		{ID: "job-1", Prescription: exportPrescription(), CompletedAt: time.Date(2025, 3, 14, 23, 59, 0, 0, time.UTC)},
+		{ID: "job-2", Prescription: exportPrescription(), CompletedAt: time.Date(2025, 3, 15, 8, 0, 0, 0, time.UTC)},
+		{ID: "job-3", Prescription: exportPrescription(), CompletedAt: time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+	} {
+		if err := ds.SaveParseResult(context.Background(), result); err != nil {
+			t.Fatalf("Failed to save parse result: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantJobs   []string
+	}{
+		{name: "single day", query: "from=2025-03-14", wantStatus: http.StatusOK, wantJobs: []string{"job-1"}},
+		{name: "inclusive range", query: "from=2025-03-14&to=2025-03-15", wantStatus: http.StatusOK, wantJobs: []string{"job-1", "job-2"}},
+		{name: "no results", query: "from=2025-01-01", wantStatus: http.StatusOK},
+		{name: "missing from", query: "", wantStatus: http.StatusBadRequest},
+		{name: "reversed range", query: "from=2025-03-15&to=2025-03-14", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/parser/export/csv?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+				t.Errorf("Expected CSV content type, got %q", ct)
+			}
+			if cd := rec.Header().Get("Content-Disposition"); !strings.Contains(cd, "attachment") {
+				t.Errorf("Expected an attachment, got %q", cd)
+			}
+
+			records, err := csv.NewReader(rec.Body).ReadAll()
+			if err != nil {
+				t.Fatalf("Failed to read CSV: %v", err)
+			}
+			if len(records) != len(tt.wantJobs)+1 || len(records[0]) != len(csvexport.Columns) {
+				t.Fatalf("Expected header and %d rows, got %v", len(tt.wantJobs), records)
+			}
+			for i, jobID := range tt.wantJobs {
+				if records[i+1][0] != jobID {
+					t.Errorf("Expected row %d for %s, got %s", i+1, jobID, records[i+1][0])
+				}
+			}
+		})
+	}
+}
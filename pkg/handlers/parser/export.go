@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/handlerutils"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/ncpdp"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// GetNcpdpNewRx handles the request to export a completed job's prescription as an NCPDP SCRIPT NewRx message.
+// The optional medication query parameter selects which medication to export (default 0) and the
+// optional to parameter overrides the configured receiving pharmacy ID. The prescription is
+// selected, and blocked prescriptions refused, as described for exportablePrescription.
+func (h *Handler) GetNcpdpNewRx(w http.ResponseWriter, r *http.Request) {
+	selected, ok := h.exportablePrescription(w, r)
+	if !ok {
+		return
+	}
+	rx := selected.rx
+
+	medication := handlerutils.ParseIntParam(r.URL.Query().Get("medication"), 0)
+	if medication < 0 || medication >= len(rx.Medications) {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("Medication %d not found; prescription has %d medications", medication, len(rx.Medications)), nil)
+		return
+	}
+
+	to := r.URL.Query().Get("to")
+	if to == "" {
+		to = h.cfg.NcpdpPharmacyID
+	}
+
+	msg, err := ncpdp.BuildNewRx(rx, medication, ncpdp.Options{
+		From:      h.cfg.NcpdpSenderID,
+		To:        to,
+		MessageID: ncpdpMessageID(selected.job.ID, selected.index, medication),
+		SentTime:  time.Now(),
+	})
+
+	var validationErr *ncpdp.ValidationError
+	if errors.As(err, &validationErr) {
+		handlerutils.RespondWithJSON(w, h.logger, http.StatusUnprocessableEntity, models.ErrorResponse{
+			Message: "Prescription cannot be exported as a valid NCPDP SCRIPT NewRx",
+			Error:   strings.Join(validationErr.Problems, "; "),
+		})
+		return
+	}
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to build NCPDP message", err)
+		return
+	}
+
+	out, err := msg.Marshal()
+	if err != nil {
+		h.logger.Error("failed to marshal ncpdp message", zap.Error(err))
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to build NCPDP message", err)
+		return
+	}
+
+	w.Header().Set("X-Medication-Count", strconv.Itoa(len(rx.Medications)))
+	handlerutils.RespondWithXML(w, h.logger, http.StatusOK, out)
+}
+
+// completedPrescription looks up the job named in the request path and returns its parsed prescription.
+// It writes an error response and returns false if the job does not exist or has not completed.
+func (h *Handler) completedPrescription(w http.ResponseWriter, r *http.Request) (*jobs.Job, models.Prescription, bool) {
+	jobID := mux.Vars(r)["id"]
+	if jobID == "" {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Job ID is required", nil)
+		return nil, models.Prescription{}, false
+	}
+
+	job, exists := jobs.GlobalTracker.GetJob(jobID)
+	if !exists || job == nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusNotFound, "Job not found", nil)
+		return nil, models.Prescription{}, false
+	}
+
+	rx, ok := job.Result.(models.Prescription)
+	if job.Status != jobs.JobStatusComplete || !ok {
+		handlerutils.RespondWithError(w, h.logger, http.StatusConflict, fmt.Sprintf("Job is %s and has no prescription to export", job.Status), nil)
+		return nil, models.Prescription{}, false
+	}
+
+	return job, rx, true
+}
+
+// blockedResponse is the body of the response refusing to export a prescription that validation blocked.
+type blockedResponse struct {
+	Message      string                   `json:"message"`
+	Error        string                   `json:"error"`
+	Prescription int                      `json:"prescription"` // Index of the prescription among those found in the job's document
+	Validation   []models.ValidationIssue `json:"validation"`   // Issues of the prescription, including the blocking errors
+}
+
+// exportable is a prescription of a completed job selected for export.
+type exportable struct {
+	job   *jobs.Job
+	index int // Index of the prescription among those found in the job's document
+	rx    models.Prescription
+}
+
+// id identifies the prescription in exported messages: the job ID for the first prescription of
+// the document, as before documents were segmented, and the job ID with the index for the others.
+func (e exportable) id() string {
+	if e.index == 0 {
+		return e.job.ID
+	}
+	return fmt.Sprintf("%s-p%d", e.job.ID, e.index)
+}
+
+// exportablePrescription looks up the completed job named in the request path and returns the
+// prescription selected by the optional prescription query parameter, the zero-based index of
+// the prescriptions found in the job's document (default 0), for export to another system. It
+// writes an error response and returns false if the job does not exist or has not completed, if
+// there is no such prescription or it could not be parsed, and responds with 422 and the
+// prescription's validation issues if validation blocked it. Only the selected prescription's
+// block applies. A block is only overridden by force=true with the X-Reviewer header naming who
+// overrides it; overrides are logged and recorded on the job.
+func (h *Handler) exportablePrescription(w http.ResponseWriter, r *http.Request) (exportable, bool) {
+	job, rx, ok := h.completedPrescription(w, r)
+	if !ok {
+		return exportable{}, false
+	}
+
+	// Jobs completed without a list of prescriptions have the result alone
+	snapshot, _ := jobs.GlobalTracker.Snapshot(job.ID)
+	prescriptions := snapshot.Prescriptions
+	if len(prescriptions) == 0 {
+		prescriptions = []models.DocumentPrescription{{Prescription: rx, Validation: snapshot.Validation, Blocked: snapshot.Blocked}}
+	}
+
+	index := handlerutils.ParseIntParam(r.URL.Query().Get("prescription"), 0)
+	if index < 0 || index >= len(prescriptions) {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("Prescription %d not found; document has %d prescriptions", index, len(prescriptions)), nil)
+		return exportable{}, false
+	}
+	prescription := prescriptions[index]
+	if prescription.Error != "" {
+		handlerutils.RespondWithError(w, h.logger, http.StatusConflict, fmt.Sprintf("Prescription %d could not be parsed and has nothing to export", index), errors.New(prescription.Error))
+		return exportable{}, false
+	}
+
+	selected := exportable{job: job, index: index, rx: prescription.Prescription}
+	if !prescription.Blocked {
+		return selected, true
+	}
+
+	reviewer := strings.TrimSpace(r.Header.Get(reviewerHeader))
+	if !handlerutils.ParseBoolParam(r.URL.Query().Get("force"), false) || reviewer == "" {
+		handlerutils.RespondWithJSON(w, h.logger, http.StatusUnprocessableEntity, blockedResponse{
+			Message:      "Prescription is blocked by validation and cannot be exported",
+			Error:        fmt.Sprintf("blocked by validation; set force=true and the %s header to override", reviewerHeader),
+			Prescription: index,
+			Validation:   prescription.Validation,
+		})
+		return exportable{}, false
+	}
+
+	h.logger.Warn("exporting blocked prescription", zap.String("job_id", job.ID), zap.Int("prescription", index), zap.String("reviewer", reviewer), zap.String("path", r.URL.Path))
+	jobs.GlobalTracker.SetAttribute(job.ID, jobs.AttributeBlockOverride, reviewer)
+	return selected, true
+}
+
+// maxNcpdpMessageID is the length limit of the NCPDP SCRIPT Header/MessageID.
+const maxNcpdpMessageID = 35
+
+// ncpdpMessageID derives a message ID of at most 35 characters from a job ID and the indexes of a
+// prescription of its document and of a medication. The prescription index is left out for the
+// first prescription, as before documents were segmented. The job ID is shortened as needed to
+// keep the indexes, so each medication's message is distinct.
+func ncpdpMessageID(jobID string, prescription, medication int) string {
+	suffix := fmt.Sprintf("-%d", medication)
+	if prescription > 0 {
+		suffix = fmt.Sprintf("-p%d%s", prescription, suffix)
+	}
+	id := strings.ReplaceAll(jobID, "-", "")
+	if len(id)+len(suffix) > maxNcpdpMessageID {
+		id = id[:max(maxNcpdpMessageID-len(suffix), 0)]
+	}
+	return id + suffix
+}
@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/mocks"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/parser"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func exportPrescription() models.Prescription {
+	return models.Prescription{
+		DateWritten: "2025-03-14",
+		Patient: models.Patient{
+			FirstName: "Ann",
+			LastName:  "Lee",
+			Dob:       "1980-02-01",
+			Sex:       "F",
+		},
+		Prescriber: models.Prescriber{
+			Name: "Jane Brown, MD",
+			Npi:  "1234567893",
+			Office: models.PrescriberOffice{
+				Address: models.Address{Street: "1 HOSPITAL PLZ", City: "NEW YORK", State: "NY", Zip: "10001"},
+				Phone:   "2125550100",
+			},
+		},
+		Medications: []models.Medication{
+			{DrugName: "Metformin 500 mg tablet", SIG: "1 tab po bid", Quantity: "60 tablets", Refills: "3"},
+		},
+	}
+}
+
+func TestGetNcpdpNewRx(t *testing.T) {
+	cfg := config.Config{NcpdpSenderID: "prescription-parser", NcpdpPharmacyID: "7654321"}
+	handler := NewHandler(cfg, mocks.NewMockParser(), mocks.NewMockDatastore(), zap.NewNop())
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	completeJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: ncpdp.pdf")
+	jobs.GlobalTracker.UpdateJob(completeJobID, jobs.JobStatusComplete, nil, exportPrescription())
+
+	invalid := exportPrescription()
+	invalid.Prescriber.Npi = ""
+	invalidJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: invalid.pdf")
+	jobs.GlobalTracker.UpdateJob(invalidJobID, jobs.JobStatusComplete, nil, invalid)
+
+	blockedJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: blocked.pdf")
+	jobs.GlobalTracker.SetValidation(blockedJobID, []models.ValidationIssue{{Field: "prescriber.dea", Code: "dea_missing", Severity: models.SeverityError, Message: "controlled substance requires a DEA number"}})
+	jobs.GlobalTracker.UpdateJob(blockedJobID, jobs.JobStatusComplete, nil, exportPrescription())
+
+	// A fax whose first prescription is valid, second blocked and third could not be parsed
+	second := exportPrescription()
+	second.Patient.FirstName = "Bob"
+	faxJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: fax.pdf")
+	jobs.GlobalTracker.SetPrescriptions(faxJobID, []models.DocumentPrescription{
+		{Pages: models.PageRange{First: 1, Last: 1}, Prescription: exportPrescription()},
+		{Pages: models.PageRange{First: 2, Last: 2}, Prescription: second, Validation: []models.ValidationIssue{{Field: "prescriber.npi", Code: "npi_missing", Severity: models.SeverityError}}, Blocked: true},
+		{Pages: models.PageRange{First: 3, Last: 3}, Blocked: true, Error: "failed in parse stage: model unavailable"},
+	})
+	jobs.GlobalTracker.UpdateJob(faxJobID, jobs.JobStatusComplete, nil, exportPrescription())
+
+	many := exportPrescription()
+	for len(many.Medications) <= 100 {
+		many.Medications = append(many.Medications, many.Medications[0])
+	}
+	manyJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: many.pdf")
+	jobs.GlobalTracker.UpdateJob(manyJobID, jobs.JobStatusComplete, nil, many)
+
+	pendingJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: pending.pdf")
+
+	tests := []struct {
+		name       string
+		path       string
+		reviewer   string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "complete job", path: "/parser/prescription/" + completeJobID + "/ncpdp", wantStatus: http.StatusOK, wantBody: `<To Qualifier="P">7654321</To>`},
+		{name: "pharmacy override", path: "/parser/prescription/" + completeJobID + "/ncpdp?to=1111111", wantStatus: http.StatusOK, wantBody: `<To Qualifier="P">1111111</To>`},
+		{name: "missing medication", path: "/parser/prescription/" + completeJobID + "/ncpdp?medication=3", wantStatus: http.StatusBadRequest},
+		{name: "hundred and first medication", path: "/parser/prescription/" + manyJobID + "/ncpdp?medication=100", wantStatus: http.StatusOK, wantBody: "-100</MessageID>"},
+		{name: "invalid prescription", path: "/parser/prescription/" + invalidJobID + "/ncpdp", wantStatus: http.StatusUnprocessableEntity, wantBody: "NPI"},
+		{name: "blocked job", path: "/parser/prescription/" + blockedJobID + "/ncpdp", wantStatus: http.StatusUnprocessableEntity, wantBody: "dea_missing"},
+		{name: "blocked job forced without reviewer", path: "/parser/prescription/" + blockedJobID + "/ncpdp?force=true", wantStatus: http.StatusUnprocessableEntity, wantBody: "dea_missing"},
+		{name: "blocked job forced by reviewer", path: "/parser/prescription/" + blockedJobID + "/ncpdp?force=true", reviewer: "jdoe", wantStatus: http.StatusOK, wantBody: "<NewRx>"},
+		{name: "first prescription with another blocked", path: "/parser/prescription/" + faxJobID + "/ncpdp", wantStatus: http.StatusOK, wantBody: "<FirstName>Ann</FirstName>"},
+		{name: "blocked second prescription", path: "/parser/prescription/" + faxJobID + "/ncpdp?prescription=1", wantStatus: http.StatusUnprocessableEntity, wantBody: "npi_missing"},
+		{name: "blocked second prescription forced by reviewer", path: "/parser/prescription/" + faxJobID + "/ncpdp?prescription=1&force=true", reviewer: "jdoe", wantStatus: http.StatusOK, wantBody: "-p1-0</MessageID>"},
+		{name: "unparsed prescription", path: "/parser/prescription/" + faxJobID + "/ncpdp?prescription=2", wantStatus: http.StatusConflict},
+		{name: "missing prescription", path: "/parser/prescription/" + faxJobID + "/ncpdp?prescription=3", wantStatus: http.StatusBadRequest},
+		{name: "prescription of unsegmented job", path: "/parser/prescription/" + completeJobID + "/ncpdp?prescription=1", wantStatus: http.StatusBadRequest},
+		{name: "pending job", path: "/parser/prescription/" + pendingJobID + "/ncpdp", wantStatus: http.StatusConflict},
+		{name: "unknown job", path: "/parser/prescription/missing/ncpdp", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.reviewer != "" {
+				req.Header.Set(reviewerHeader, tt.reviewer)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantBody != "" && !strings.Contains(rr.Body.String(), tt.wantBody) {
+				t.Errorf("Expected body to contain %s, got %s", tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+
+	if job, _ := jobs.GlobalTracker.GetJob(blockedJobID); job.Attributes[jobs.AttributeBlockOverride] != "jdoe" {
+		t.Errorf("Expected the override to be recorded on the job, got %v", job.Attributes)
+	}
+}
+
+func TestNcpdpMessageID(t *testing.T) {
+	jobID := "33589cd1-bbea-4117-a904-7904da52c64c"
+
+	tests := []struct {
+		prescription int
+		medication   int
+		want         string
+	}{
+		{0, 0, "33589cd1bbea4117a9047904da52c64c-0"},
+		{0, 9, "33589cd1bbea4117a9047904da52c64c-9"},
+		{0, 10, "33589cd1bbea4117a9047904da52c64c-10"},
+		{0, 100, "33589cd1bbea4117a9047904da52c64-100"},
+		{0, 1000, "33589cd1bbea4117a9047904da52c6-1000"},
+		{1, 0, "33589cd1bbea4117a9047904da52c6-p1-0"},
+		{12, 100, "33589cd1bbea4117a9047904da5-p12-100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.prescription)+"/"+strconv.Itoa(tt.medication), func(t *testing.T) {
+			got := ncpdpMessageID(jobID, tt.prescription, tt.medication)
+			if got != tt.want || len(got) > maxNcpdpMessageID {
+				t.Errorf("ncpdpMessageID(%q, %d, %d) = %q (%d characters), want %q", jobID, tt.prescription, tt.medication, got, len(got), tt.want)
+			}
+		})
+	}
+}
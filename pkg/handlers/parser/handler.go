@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"github.com/csotherden/prescription-parser/pkg/config"
 	"github.com/csotherden/prescription-parser/pkg/datastore"
 	parserPkg "github.com/csotherden/prescription-parser/pkg/parser"
 
@@ -10,14 +11,16 @@ import (
 
 // Handler handles parser-related API requests
 type Handler struct {
+	cfg    config.Config
 	ds     datastore.Datastore
 	logger *zap.Logger
 	parser parserPkg.Parser
 }
 
 // NewHandler creates a new parser handler instance
-func NewHandler(parser parserPkg.Parser, ds datastore.Datastore, logger *zap.Logger) *Handler {
+func NewHandler(cfg config.Config, parser parserPkg.Parser, ds datastore.Datastore, logger *zap.Logger) *Handler {
 	return &Handler{
+		cfg:    cfg,
 		ds:     ds,
 		logger: logger,
 		parser: parser,
@@ -31,4 +34,20 @@ func (h *Handler) RegisterRoutes(router *mux.Router) {
 	parserRouter.HandleFunc("/prescription", h.ParsePrescription).Methods("POST")
 	parserRouter.HandleFunc("/prescription/sample", h.SaveSamplePrescription).Methods("POST")
 	parserRouter.HandleFunc("/prescription/{id}", h.GetJobStatus).Methods("GET")
+	parserRouter.HandleFunc("/prescription/{id}/ncpdp", h.GetNcpdpNewRx).Methods("GET")
+	parserRouter.HandleFunc("/prescription/{id}/hl7", h.GetHl7Order).Methods("GET")
+	parserRouter.HandleFunc("/prescription/{id}/hl7", h.PushHl7Order).Methods("POST")
+	parserRouter.HandleFunc("/prescription/{id}/review", h.GetReviewSheet).Methods("GET")
+	parserRouter.HandleFunc("/prescription/{id}/review", h.SubmitReview).Methods("PUT")
+	parserRouter.HandleFunc("/samples", h.ListSamples).Methods("GET")
+	parserRouter.HandleFunc("/samples/{id}", h.GetSample).Methods("GET")
+	parserRouter.HandleFunc("/samples/{id}", h.UpdateSample).Methods("PUT")
+	parserRouter.HandleFunc("/samples/{id}", h.DeleteSample).Methods("DELETE")
+	parserRouter.HandleFunc("/templates", h.ListTemplates).Methods("GET")
+	parserRouter.HandleFunc("/templates/{id}/overlay", h.GetPromptOverlay).Methods("GET")
+	parserRouter.HandleFunc("/templates/{id}/overlay", h.UpdatePromptOverlay).Methods("PUT")
+	parserRouter.HandleFunc("/templates/{id}/overlay/versions", h.ListPromptOverlayVersions).Methods("GET")
+	parserRouter.HandleFunc("/export/csv", h.ExportCSV).Methods("GET")
+	parserRouter.HandleFunc("/analytics/fields", h.GetFieldAnalytics).Methods("GET")
+	parserRouter.HandleFunc("/analytics/experiments/{id}", h.GetExperimentAnalytics).Methods("GET")
 }
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/handlerutils"
+	"github.com/csotherden/prescription-parser/pkg/hl7"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GetHl7Order handles the request to export a completed job's prescription as an HL7 v2.5 RDE^O11 message.
+// The prescription is selected, and blocked prescriptions refused, as described for exportablePrescription.
+func (h *Handler) GetHl7Order(w http.ResponseWriter, r *http.Request) {
+	selected, ok := h.exportablePrescription(w, r)
+	if !ok {
+		return
+	}
+
+	handlerutils.RespondWithHL7(w, h.logger, http.StatusOK, h.buildRDE(selected).Encode())
+}
+
+// PushHl7Order handles the request to send a completed job's prescription as an RDE^O11 message
+// to the configured MLLP listener. It responds with the listener's acknowledgment. The
+// prescription is selected as described for exportablePrescription, and blocked prescriptions
+// are refused before the message is built or the listener is contacted.
+func (h *Handler) PushHl7Order(w http.ResponseWriter, r *http.Request) {
+	if h.cfg.Hl7ListenerAddr == "" {
+		handlerutils.RespondWithError(w, h.logger, http.StatusServiceUnavailable, "No HL7 listener is configured", nil)
+		return
+	}
+
+	selected, ok := h.exportablePrescription(w, r)
+	if !ok {
+		return
+	}
+	job := selected.job
+
+	msg := h.buildRDE(selected)
+	ack, err := hl7.NewClient(h.cfg.Hl7ListenerAddr, 0).Send(r.Context(), msg.Encode())
+	if ack != nil {
+		jobs.GlobalTracker.SetAttribute(job.ID, jobs.AttributeHl7Ack, ack.Code+" "+ack.ControlID)
+	}
+
+	switch {
+	case errors.Is(err, hl7.ErrRejected):
+		handlerutils.RespondWithJSON(w, h.logger, http.StatusBadGateway, models.ErrorResponse{
+			Message: "HL7 listener rejected the message",
+			Error:   err.Error(),
+		})
+	case err != nil:
+		h.logger.Error("failed to push hl7 message", zap.String("job_id", job.ID), zap.Error(err))
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadGateway, "Failed to deliver HL7 message", err)
+	default:
+		handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, ack)
+	}
+}
+
+// buildRDE builds the RDE^O11 message for a prescription of a job, using the prescription's
+// export ID (see exportable.id) as the placer order number prefix and a new control ID for each
+// message.
+func (h *Handler) buildRDE(selected exportable) *hl7.Message {
+	return hl7.BuildRDE(selected.rx, hl7.Options{
+		SendingFacility:      h.cfg.Hl7SendingFacility,
+		ReceivingApplication: h.cfg.Hl7ReceivingApp,
+		ReceivingFacility:    h.cfg.Hl7ReceivingFacility,
+		ControlID:            strings.ReplaceAll(uuid.NewString(), "-", "")[:20],
+		PlacerOrderNumber:    selected.id(),
+		Time:                 time.Now(),
+	})
+}
@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/hl7"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/mocks"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/parser"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func TestGetHl7Order(t *testing.T) {
+	cfg := config.Config{Hl7SendingFacility: "CLINIC", Hl7ReceivingApp: "PHARMACY"}
+	handler := NewHandler(cfg, mocks.NewMockParser(), mocks.NewMockDatastore(), zap.NewNop())
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	jobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: hl7.pdf")
+	jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, exportPrescription())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/parser/prescription/"+jobID+"/hl7", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	if !strings.HasPrefix(rr.Body.String(), `MSH|^~\&|PRESCRIPTION-PARSER|CLINIC|PHARMACY|`) {
+		t.Errorf("Unexpected message header %q", rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), "\rORC|NW|"+jobID+"-1^") {
+		t.Errorf("Expected an order for the job, got %q", rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), "\rPID|1|||") {
+		t.Errorf("Expected no patient identifier without a member ID, got %q", rr.Body.String())
+	}
+
+	// Prescriptions after the first of a document are ordered under their own placer order number
+	second := exportPrescription()
+	second.Patient.FirstName = "Bob"
+	faxJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: fax.pdf")
+	jobs.GlobalTracker.SetPrescriptions(faxJobID, []models.DocumentPrescription{
+		{Pages: models.PageRange{First: 1, Last: 1}, Prescription: exportPrescription()},
+		{Pages: models.PageRange{First: 2, Last: 2}, Prescription: second},
+	})
+	jobs.GlobalTracker.UpdateJob(faxJobID, jobs.JobStatusComplete, nil, exportPrescription())
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/parser/prescription/"+faxJobID+"/hl7?prescription=1", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), "\rORC|NW|"+faxJobID+"-p1-1^") || !strings.Contains(rr.Body.String(), "|Lee^Bob|") {
+		t.Errorf("Expected an order for the second prescription, got %q", rr.Body.String())
+	}
+}
+
+func TestPushHl7Order(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start MLLP stub: %v", err)
+	}
+	defer listener.Close()
+
+	var connections atomic.Int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			connections.Add(1)
+			if message, err := hl7.ReadFrame(bufio.NewReader(conn)); err == nil {
+				conn.Write(hl7.Frame(hl7.NewAck(message, "AA", "", time.Now())))
+			}
+			conn.Close()
+		}
+	}()
+
+	jobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: push.pdf")
+	jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, exportPrescription())
+
+	blockedJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: blocked.pdf")
+	jobs.GlobalTracker.SetValidation(blockedJobID, []models.ValidationIssue{{Field: "prescriber.dea", Code: "dea_missing", Severity: models.SeverityError}})
+	jobs.GlobalTracker.UpdateJob(blockedJobID, jobs.JobStatusComplete, nil, exportPrescription())
+
+	tests := []struct {
+		name            string
+		cfg             config.Config
+		jobID           string
+		wantStatus      int
+		wantConnections int32 // Connections made to the listener by the request
+	}{
+		{name: "listener configured", cfg: config.Config{Hl7ListenerAddr: listener.Addr().String()}, jobID: jobID, wantStatus: http.StatusOK, wantConnections: 1},
+		{name: "no listener", cfg: config.Config{}, jobID: jobID, wantStatus: http.StatusServiceUnavailable},
+		{name: "blocked job", cfg: config.Config{Hl7ListenerAddr: listener.Addr().String()}, jobID: blockedJobID, wantStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			NewHandler(tt.cfg, mocks.NewMockParser(), mocks.NewMockDatastore(), zap.NewNop()).RegisterRoutes(router)
+
+			before := connections.Load()
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/parser/prescription/"+tt.jobID+"/hl7", nil))
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if got := connections.Load() - before; got != tt.wantConnections {
+				t.Errorf("Expected %d connections to the listener, got %d", tt.wantConnections, got)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var ack hl7.Ack
+			if err := json.Unmarshal(rr.Body.Bytes(), &ack); err != nil {
+				t.Fatalf("Failed to decode acknowledgment: %v", err)
+			}
+			if ack.Code != "AA" {
+				t.Errorf("Expected AA acknowledgment, got %+v", ack)
+			}
+
+			job, _ := jobs.GlobalTracker.GetJob(jobID)
+			if job.Attributes[jobs.AttributeHl7Ack] != "AA "+ack.ControlID {
+				t.Errorf("Expected the acknowledgment recorded on the job, got %v", job.Attributes)
+			}
+		})
+	}
+}
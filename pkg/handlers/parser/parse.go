@@ -1,11 +1,14 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/csotherden/prescription-parser/pkg/handlerutils"
 	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	parserPkg "github.com/csotherden/prescription-parser/pkg/parser"
 	"go.uber.org/zap"
 )
 
@@ -30,10 +33,30 @@ func (h *Handler) ParsePrescription(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	jobID, err := h.parser.ParseImage(r.Context(), header.Filename, file)
+	opts := models.ParseOptions{
+		RuleSet:       r.FormValue("rule_set"),
+		Samples:       sampleMetadata(r),
+		PromptVersion: r.FormValue("prompt_version"),
+		Pipeline:      r.FormValue("pipeline"),
+	}
+
+	jobID, err := h.parser.ParseImage(r.Context(), header.Filename, file, opts)
+	if errors.Is(err, parserPkg.ErrUnknownRuleSet) {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("Unknown rule set: %s", opts.RuleSet), err)
+		return
+	}
+	if errors.Is(err, parserPkg.ErrUnknownPromptVersion) {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("Unknown prompt version: %s", opts.PromptVersion), err)
+		return
+	}
+	if errors.Is(err, parserPkg.ErrInvalidPipeline) {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("Invalid pipeline: %s", opts.Pipeline), err)
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to parse image", zap.Error(err))
 		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "failed to process image", err)
+		return
 	}
 
 	job, exists := jobs.GlobalTracker.GetJob(jobID)
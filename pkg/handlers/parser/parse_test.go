@@ -3,13 +3,17 @@ package parser
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
+	"github.com/csotherden/prescription-parser/pkg/config"
 	"github.com/csotherden/prescription-parser/pkg/jobs"
 	"github.com/csotherden/prescription-parser/pkg/mocks"
+	"github.com/csotherden/prescription-parser/pkg/models"
 	"github.com/csotherden/prescription-parser/pkg/parser"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
@@ -30,7 +34,7 @@ func TestParsePrescription(t *testing.T) {
 	mockParser.SetParseImageResponse("test.pdf", createdJobID, nil)
 
 	// Create test handler
-	handler := NewHandler(mockParser, mockDatastore, logger)
+	handler := NewHandler(config.Config{}, mockParser, mockDatastore, logger)
 
 	// Set up test router
 	router := mux.NewRouter()
@@ -85,3 +89,149 @@ func TestParsePrescription(t *testing.T) {
 		t.Errorf("Expected 1 parser call, got %d", len(calls))
 	}
 }
+
+func TestParsePrescriptionRuleSet(t *testing.T) {
+	logger := zap.NewNop()
+
+	mockParser := mocks.NewMockParser()
+	mockDatastore := mocks.NewMockDatastore()
+
+	createdJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: rules.pdf")
+	mockParser.SetParseImageResponse("rules.pdf", createdJobID, nil)
+	mockParser.SetParseImageResponse("unknown.pdf", "", fmt.Errorf("%w: missing", parser.ErrUnknownRuleSet))
+
+	handler := NewHandler(config.Config{}, mockParser, mockDatastore, logger)
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	tests := []struct {
+		name       string
+		fileName   string
+		ruleSet    string
+		wantStatus int
+	}{
+		{name: "selected rule set", fileName: "rules.pdf", ruleSet: "specialty-pharmacy", wantStatus: http.StatusOK},
+		{name: "unknown rule set", fileName: "unknown.pdf", ruleSet: "missing", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			part, _ := writer.CreateFormFile("image", tt.fileName)
+			part.Write([]byte("test data"))
+			writer.WriteField("rule_set", tt.ruleSet)
+			writer.WriteField("tenant", "acme")
+			writer.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/parser/prescription", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", rr.Code, tt.wantStatus)
+			}
+		})
+	}
+
+	opts := mockParser.GetParseImageOptions()
+	want := models.ParseOptions{RuleSet: "specialty-pharmacy", Samples: models.SampleMetadata{Tenant: "acme"}}
+	if len(opts) != 2 || !reflect.DeepEqual(opts[0], want) {
+		t.Errorf("Expected rule set and sample filter to be passed to the parser, got %+v", opts)
+	}
+}
+
+func TestParsePrescriptionPromptVersion(t *testing.T) {
+	mockParser := mocks.NewMockParser()
+
+	createdJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: prompts.pdf")
+	mockParser.SetParseImageResponse("prompts.pdf", createdJobID, nil)
+	mockParser.SetParseImageResponse("unknown.pdf", "", fmt.Errorf("%w: 9", parser.ErrUnknownPromptVersion))
+
+	handler := NewHandler(config.Config{}, mockParser, mocks.NewMockDatastore(), zap.NewNop())
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	tests := []struct {
+		name          string
+		fileName      string
+		promptVersion string
+		wantStatus    int
+	}{
+		{name: "selected prompt version", fileName: "prompts.pdf", promptVersion: "2", wantStatus: http.StatusOK},
+		{name: "unknown prompt version", fileName: "unknown.pdf", promptVersion: "9", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			part, _ := writer.CreateFormFile("image", tt.fileName)
+			part.Write([]byte("test data"))
+			writer.WriteField("prompt_version", tt.promptVersion)
+			writer.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/parser/prescription", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", rr.Code, tt.wantStatus)
+			}
+		})
+	}
+
+	opts := mockParser.GetParseImageOptions()
+	if len(opts) != 2 || opts[0].PromptVersion != "2" {
+		t.Errorf("Expected the prompt version to be passed to the parser, got %+v", opts)
+	}
+}
+
+func TestParsePrescriptionPipeline(t *testing.T) {
+	mockParser := mocks.NewMockParser()
+
+	createdJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: pipeline.pdf")
+	mockParser.SetParseImageResponse("pipeline.pdf", createdJobID, nil)
+	mockParser.SetParseImageResponse("unknown.pdf", "", fmt.Errorf("%w: unknown stage", parser.ErrInvalidPipeline))
+
+	handler := NewHandler(config.Config{}, mockParser, mocks.NewMockDatastore(), zap.NewNop())
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	tests := []struct {
+		name       string
+		fileName   string
+		pipeline   string
+		wantStatus int
+	}{
+		{name: "selected pipeline", fileName: "pipeline.pdf", pipeline: "parse,validate", wantStatus: http.StatusOK},
+		{name: "invalid pipeline", fileName: "unknown.pdf", pipeline: "parse,guess", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			part, _ := writer.CreateFormFile("image", tt.fileName)
+			part.Write([]byte("test data"))
+			writer.WriteField("pipeline", tt.pipeline)
+			writer.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/parser/prescription", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", rr.Code, tt.wantStatus)
+			}
+		})
+	}
+
+	opts := mockParser.GetParseImageOptions()
+	if len(opts) != 2 || opts[0].Pipeline != "parse,validate" {
+		t.Errorf("Expected the pipeline to be passed to the parser, got %+v", opts)
+	}
+}
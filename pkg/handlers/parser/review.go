@@ -0,0 +1,257 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/handlerutils"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/review"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// reviewerHeader identifies the reviewer submitting a correction.
+const reviewerHeader = "X-Reviewer"
+
+// GetReviewSheet handles the request to render a completed job as a printable review sheet for
+// pharmacist verification. The optional format query parameter selects "html" (default) or "pdf".
+func (h *Handler) GetReviewSheet(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "html" && format != "pdf" {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("Unsupported format %q", format), nil)
+		return
+	}
+
+	job, rx, ok := h.completedPrescription(w, r)
+	if !ok {
+		return
+	}
+
+	result := models.ParseResult{ID: job.ID, Prescription: rx}
+	if snapshot, ok := jobs.GlobalTracker.Snapshot(job.ID); ok {
+		result.Validation = snapshot.Validation
+		result.Blocked = snapshot.Blocked
+		result.Attributes = snapshot.Attributes
+		if snapshot.CompletedAt != nil {
+			result.CompletedAt = *snapshot.CompletedAt
+		}
+	}
+	sheet := review.NewSheet(result)
+
+	var buf bytes.Buffer
+	if format == "pdf" {
+		if err := review.RenderPDF(&buf, sheet); err != nil {
+			handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to render review sheet", err)
+			return
+		}
+		handlerutils.RespondWithPDF(w, h.logger, http.StatusOK, fmt.Sprintf("review_%s.pdf", job.ID), buf.Bytes())
+		return
+	}
+
+	if err := review.RenderHTML(&buf, sheet); err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to render review sheet", err)
+		return
+	}
+	handlerutils.RespondWithHTML(w, h.logger, http.StatusOK, buf.String())
+}
+
+// SubmitReview handles the request to record a reviewer's correction of a completed job. The body
+// is the corrected prescription as JSON, or a multipart form with the prescription in the json
+// field and the original document in the image field. The reviewer is identified by the
+// X-Reviewer header. With sample=true the correction is also saved as a sample prescription,
+// which requires the image.
+func (h *Handler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["id"]
+
+	reviewer := strings.TrimSpace(r.Header.Get(reviewerHeader))
+	if reviewer == "" {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Reviewer is required", fmt.Errorf("%s header is required", reviewerHeader))
+		return
+	}
+
+	rx, image, header, ok := h.readReview(w, r)
+	if !ok {
+		return
+	}
+	if image != nil {
+		defer image.Close()
+	}
+
+	promote := handlerutils.ParseBoolParam(r.URL.Query().Get("sample"), false)
+	var contentType string
+	if promote {
+		if image == nil {
+			handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "The original image is required to save the correction as a sample", fmt.Errorf("image is required"))
+			return
+		}
+
+		var err error
+		contentType, err = sampleContentType(header.Filename)
+		if err != nil {
+			handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+	}
+
+	result, ok := h.reviewableResult(w, r)
+	if !ok {
+		return
+	}
+
+	changes, err := review.Diff(result.Prescription, rx)
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to compare prescriptions", err)
+		return
+	}
+
+	rev := models.Review{
+		Reviewer:     reviewer,
+		ReviewedAt:   time.Now().UTC(),
+		Prescription: rx,
+		Changes:      changes,
+	}
+
+	if promote {
+		// The sample is on the form template the job was identified as, unless the reviewer says otherwise.
+		metadata := sampleMetadata(r)
+		if metadata.Template == "" {
+			metadata.Template = result.Attributes[jobs.AttributeTemplate]
+		}
+		rev.SampleImageID, err = h.saveSample(r.Context(), header.Filename, contentType, image, rx, metadata)
+		if err != nil {
+			handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to save sample prescription", err)
+			return
+		}
+	}
+
+	if err := h.ds.SaveReview(r.Context(), jobID, rev); err != nil {
+		// A sample without the review it was promoted from would be retrieved with no record of its origin
+		if rev.SampleImageID != "" {
+			h.removeSample(r.Context(), rev.SampleImageID)
+		}
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to save review", err)
+		return
+	}
+
+	h.logger.Info("saved review", zap.String("job_id", jobID), zap.String("reviewer", reviewer), zap.Int("changes", len(changes)), zap.Bool("sample", promote))
+
+	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, rev)
+}
+
+// removeSample deletes the samples of an uploaded image, and their embeddings, so they are no
+// longer retrieved. Failures are logged, since the request has already failed.
+func (h *Handler) removeSample(ctx context.Context, imageID string) {
+	samples, _, err := h.ds.ListSamples(ctx, models.SampleFilter{FileID: imageID, Limit: maxSamplePageSize})
+	if err != nil {
+		h.logger.Error("failed to find promoted sample to remove", zap.String("image_id", imageID), zap.Error(err))
+		return
+	}
+	for _, sample := range samples {
+		if err := h.ds.DeleteSample(ctx, sample.ID); err != nil {
+			h.logger.Error("failed to remove promoted sample", zap.String("sample_id", sample.ID), zap.Error(err))
+			continue
+		}
+		h.logger.Info("removed promoted sample of unsaved review", zap.String("sample_id", sample.ID), zap.String("image_id", imageID))
+	}
+}
+
+// readReview reads the corrected prescription, and the original image if one was uploaded, from
+// a review request. It writes an error response and returns false if the body is invalid.
+func (h *Handler) readReview(w http.ResponseWriter, r *http.Request) (models.Prescription, multipart.File, *multipart.FileHeader, bool) {
+	var rx models.Prescription
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := json.NewDecoder(r.Body).Decode(&rx); err != nil {
+			handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Invalid prescription JSON", fmt.Errorf("invalid prescription JSON: %w", err))
+			return rx, nil, nil, false
+		}
+		return rx, nil, nil, true
+	}
+
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "File exceeds max upload size", fmt.Errorf("file exceeds max upload size: %w", err))
+		return rx, nil, nil, false
+	}
+
+	rxJson := r.FormValue("json")
+	if rxJson == "" {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Prescription JSON is required", fmt.Errorf("json is required"))
+		return rx, nil, nil, false
+	}
+	if err := json.Unmarshal([]byte(rxJson), &rx); err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Invalid prescription JSON", fmt.Errorf("invalid prescription JSON: %w", err))
+		return rx, nil, nil, false
+	}
+
+	image, header, err := r.FormFile("image")
+	if errors.Is(err, http.ErrMissingFile) {
+		return rx, nil, nil, true
+	}
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("Failed to read image file: %s", err.Error()), fmt.Errorf("failed to read image file: %w", err))
+		return rx, nil, nil, false
+	}
+
+	return rx, image, header, true
+}
+
+// reviewableResult returns the saved result of the job named in the request path. A completed job whose result was not saved,
+// because result persistence is off, is saved first so that its review can be recorded. It writes
+// an error response and returns false if the job does not exist or has not completed.
+func (h *Handler) reviewableResult(w http.ResponseWriter, r *http.Request) (models.ParseResult, bool) {
+	jobID := mux.Vars(r)["id"]
+
+	result, err := h.ds.GetParseResult(r.Context(), jobID)
+	if err == nil {
+		return result, true
+	}
+	if !errors.Is(err, datastore.ErrNotFound) {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to load parse result", err)
+		return models.ParseResult{}, false
+	}
+
+	job, exists := jobs.GlobalTracker.Snapshot(jobID)
+	if !exists {
+		handlerutils.RespondWithError(w, h.logger, http.StatusNotFound, "Job not found", nil)
+		return models.ParseResult{}, false
+	}
+
+	rx, ok := job.Result.(models.Prescription)
+	if job.Status != jobs.JobStatusComplete || !ok || job.CompletedAt == nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusConflict, fmt.Sprintf("Job is %s and has no prescription to review", job.Status), nil)
+		return models.ParseResult{}, false
+	}
+
+	result = models.ParseResult{
+		ID:           job.ID,
+		Status:       models.ParseResultComplete,
+		Prescription: rx,
+		Validation:   job.Validation,
+		Blocked:      job.Blocked,
+		Verification: job.Verification,
+		Origins:      job.Origins,
+		Attributes:   job.Attributes,
+		StartedAt:    job.StartedAt,
+		CompletedAt:  *job.CompletedAt,
+	}
+	if len(job.Prescriptions) > 1 {
+		result.Prescriptions = job.Prescriptions
+	}
+	if err := h.ds.SaveParseResult(r.Context(), result); err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to save parse result", err)
+		return models.ParseResult{}, false
+	}
+
+	return result, true
+}
@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/mocks"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/parser"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func TestGetReviewSheet(t *testing.T) {
+	handler := NewHandler(config.Config{}, mocks.NewMockParser(), mocks.NewMockDatastore(), zap.NewNop())
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	jobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: review.pdf")
+	jobs.GlobalTracker.SetValidation(jobID, []models.ValidationIssue{
+		{Field: "medications[0].refills", Code: "controlled_refill_limit", Severity: models.SeverityError, Message: "too many refills"},
+	})
+	jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, exportPrescription())
+
+	pendingJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: pending.pdf")
+
+	tests := []struct {
+		name            string
+		path            string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "html", path: "/parser/prescription/" + jobID + "/review", wantStatus: http.StatusOK, wantContentType: "text/html", wantBody: "too many refills"},
+		{name: "pdf", path: "/parser/prescription/" + jobID + "/review?format=pdf", wantStatus: http.StatusOK, wantContentType: "application/pdf", wantBody: "%PDF-"},
+		{name: "unsupported format", path: "/parser/prescription/" + jobID + "/review?format=docx", wantStatus: http.StatusBadRequest},
+		{name: "pending", path: "/parser/prescription/" + pendingJobID + "/review", wantStatus: http.StatusConflict},
+		{name: "missing", path: "/parser/prescription/missing/review", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantContentType == "" {
+				return
+			}
+			if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.wantContentType) {
+				t.Errorf("Expected content type %s, got %s", tt.wantContentType, ct)
+			}
+			if !bytes.Contains(rr.Body.Bytes(), []byte(tt.wantBody)) {
+				t.Errorf("Expected body to contain %q", tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestSubmitReview(t *testing.T) {
+	mockParser := mocks.NewMockParser()
+	ds := mocks.NewMockDatastore()
+	handler := NewHandler(config.Config{}, mockParser, ds, zap.NewNop())
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	newCompleteJob := func() string {
+		jobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: review.pdf")
+		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, exportPrescription())
+		return jobID
+	}
+	pendingJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: pending.pdf")
+
+	corrected := exportPrescription()
+	corrected.Patient.Dob = "1980-01-02"
+	correctedJSON, err := json.Marshal(corrected)
+	if err != nil {
+		t.Fatalf("Failed to marshal prescription: %v", err)
+	}
+
+	multipartBody := func(withImage bool) (*bytes.Buffer, string) {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		writer.WriteField("json", string(correctedJSON))
+		if withImage {
+			part, _ := writer.CreateFormFile("image", "corrected.pdf")
+			part.Write([]byte("test pdf content"))
+		}
+		writer.Close()
+		return body, writer.FormDataContentType()
+	}
+
+	tests := []struct {
+		name        string
+		jobID       string
+		query       string
+		reviewer    string
+		multipart   bool
+		image       bool
+		body        string
+		wantStatus  int
+		wantSample  bool
+		wantChanges int
+	}{
+		{name: "json correction", jobID: newCompleteJob(), reviewer: "pharmacist-1", body: string(correctedJSON), wantStatus: http.StatusOK, wantChanges: 1},
+		{name: "promoted to sample", jobID: newCompleteJob(), query: "?sample=true", reviewer: "pharmacist-1", multipart: true, image: true, wantStatus: http.StatusOK, wantSample: true, wantChanges: 1},
+		{name: "multipart without promotion", jobID: newCompleteJob(), reviewer: "pharmacist-1", multipart: true, wantStatus: http.StatusOK, wantChanges: 1},
+		{name: "sample without image", jobID: newCompleteJob(), query: "?sample=true", reviewer: "pharmacist-1", body: string(correctedJSON), wantStatus: http.StatusBadRequest},
+		{name: "missing reviewer", jobID: newCompleteJob(), body: string(correctedJSON), wantStatus: http.StatusBadRequest},
+		{name: "invalid json", jobID: newCompleteJob(), reviewer: "pharmacist-1", body: "{", wantStatus: http.StatusBadRequest},
+		{name: "pending", jobID: pendingJobID, reviewer: "pharmacist-1", body: string(correctedJSON), wantStatus: http.StatusConflict},
+		{name: "missing", jobID: "missing", reviewer: "pharmacist-1", body: string(correctedJSON), wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType := bytes.NewBufferString(tt.body), "application/json"
+			if tt.multipart {
+				body, contentType = multipartBody(tt.image)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/parser/prescription/"+tt.jobID+"/review"+tt.query, body)
+			req.Header.Set("Content-Type", contentType)
+			if tt.reviewer != "" {
+				req.Header.Set("X-Reviewer", tt.reviewer)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			result, err := ds.GetParseResult(context.Background(), tt.jobID)
+			if err != nil {
+				t.Fatalf("Expected the parse result to be saved: %v", err)
+			}
+			if result.Status != models.ParseResultReviewed || result.Review == nil {
+				t.Fatalf("Expected a reviewed result, got %+v", result)
+			}
+
+			review := result.Review
+			if review.Reviewer != tt.reviewer || review.Prescription.Patient.Dob != "1980-01-02" || len(review.Changes) != tt.wantChanges {
+				t.Errorf("Unexpected review %+v", review)
+			}
+			if len(review.Changes) > 0 && review.Changes[0].Field != "patient.dob" {
+				t.Errorf("Expected the date of birth change, got %+v", review.Changes)
+			}
+
+			if tt.wantSample {
+				if review.SampleImageID == "" {
+					t.Fatalf("Expected the sample image ID to be recorded")
+				}
+				if sample, ok := ds.GetSavedPrescription(review.SampleImageID); !ok || sample.Patient.Dob != "1980-01-02" {
+					t.Errorf("Expected the correction to be saved as a sample, got %+v", sample)
+				}
+			} else if review.SampleImageID != "" {
+				t.Errorf("Expected no sample, got %s", review.SampleImageID)
+			}
+		})
+	}
+}
+
+func TestSubmitReviewRemovesSampleWhenReviewFails(t *testing.T) {
+	ds := mocks.NewMockDatastore()
+	router := mux.NewRouter()
+	NewHandler(config.Config{}, mocks.NewMockParser(), ds, zap.NewNop()).RegisterRoutes(router)
+
+	jobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: review.pdf")
+	jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, exportPrescription())
+	ds.SetSaveReviewError(jobID, errors.New("connection reset"))
+
+	correctedJSON, err := json.Marshal(exportPrescription())
+	if err != nil {
+		t.Fatalf("Failed to marshal prescription: %v", err)
+	}
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	writer.WriteField("json", string(correctedJSON))
+	part, _ := writer.CreateFormFile("image", "corrected.pdf")
+	part.Write([]byte("test pdf content"))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/parser/prescription/"+jobID+"/review?sample=true", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-Reviewer", "pharmacist-1")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, http.StatusInternalServerError, rr.Body.String())
+	}
+	if samples, total, err := ds.ListSamples(context.Background(), models.SampleFilter{Limit: 10}); err != nil || total != 0 {
+		t.Errorf("Expected the promoted sample to be removed, got %+v (%v)", samples, err)
+	}
+}
@@ -1,8 +1,11 @@
 package parser
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"path/filepath"
 	"strings"
@@ -10,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/csotherden/prescription-parser/pkg/handlerutils"
+	"github.com/csotherden/prescription-parser/pkg/layout"
 	"github.com/csotherden/prescription-parser/pkg/models"
 )
 
@@ -30,13 +34,9 @@ func (h *Handler) SaveSamplePrescription(w http.ResponseWriter, r *http.Request)
 	}
 	defer file.Close()
 
-	fileExt := strings.ToLower(filepath.Ext(header.Filename))
-	var contentType string
-	switch fileExt {
-	case ".pdf":
-		contentType = "application/pdf"
-	default:
-		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("unsupported file type. file must be PDF not %s", fileExt), fmt.Errorf("unsupported file type. file must be PDF not %s", fileExt))
+	contentType, err := sampleContentType(header.Filename)
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, err.Error(), err)
 		return
 	}
 
@@ -52,34 +52,88 @@ func (h *Handler) SaveSamplePrescription(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	h.logger.Info("saving sample prescription image", zap.String("file_name", header.Filename))
+	if _, err := h.saveSample(r.Context(), header.Filename, contentType, file, rx, sampleMetadata(r)); err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to save sample prescription", err)
+		return
+	}
+
+	handlerutils.RespondWithNoContent(w)
+}
+
+// sampleContentType returns the MIME type of a sample image file, which must be a PDF.
+func sampleContentType(fileName string) (string, error) {
+	fileExt := strings.ToLower(filepath.Ext(fileName))
+	switch fileExt {
+	case ".pdf":
+		return "application/pdf", nil
+	default:
+		return "", fmt.Errorf("unsupported file type. file must be PDF not %s", fileExt)
+	}
+}
 
-	imageID, err := h.parser.UploadImage(r.Context(), header.Filename, file)
+// sampleMetadata reads the template, tenant and drug_class form fields of a request, which
+// describe a sample when saving one and limit the samples retrieved when parsing.
+func sampleMetadata(r *http.Request) models.SampleMetadata {
+	return models.SampleMetadata{
+		Template:  strings.TrimSpace(r.FormValue("template")),
+		Tenant:    strings.TrimSpace(r.FormValue("tenant")),
+		DrugClass: strings.TrimSpace(r.FormValue("drug_class")),
+	}
+}
+
+// saveSample uploads a sample image, embeds its validated prescription and stores both so the
+// sample can guide later parsing passes. It returns the uploaded image's ID.
+func (h *Handler) saveSample(ctx context.Context, fileName, contentType string, file io.Reader, rx models.Prescription, metadata models.SampleMetadata) (string, error) {
+	h.logger.Info("saving sample prescription image", zap.String("file_name", fileName))
+
+	// The document is kept as well as uploaded, since uploaded files can expire.
+	document, err := io.ReadAll(file)
+	if err != nil {
+		h.logger.Error("failed to read sample image", zap.Error(err))
+		return "", fmt.Errorf("failed to read image: %w", err)
+	}
+
+	// Samples are tagged with the form template they were filled in on, so they can be preferred
+	// for documents identified as the same template.
+	if metadata.Template == "" {
+		if match, err := h.parser.Templates().Identify(document); err == nil {
+			metadata.Template = match.Template.ID
+		}
+	}
+
+	imageID, err := h.parser.UploadImage(ctx, fileName, bytes.NewReader(document))
 	if err != nil {
 		h.logger.Error("failed to upload sample image", zap.Error(err))
-		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to upload image", fmt.Errorf("failed to upload image: %w", err))
-		return
+		return "", fmt.Errorf("failed to upload image: %w", err)
 	}
 
-	h.logger.Info("uploaded sample prescription image", zap.String("file_name", header.Filename), zap.String("image_id", imageID))
+	h.logger.Info("uploaded sample prescription image", zap.String("file_name", fileName), zap.String("image_id", imageID))
 
-	embedding, err := h.parser.GetEmbedding(r.Context(), rx)
+	embedding, err := h.parser.GetEmbedding(ctx, rx)
 	if err != nil {
 		h.logger.Error("failed to generate embedding", zap.Error(err))
-		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to generate embedding", fmt.Errorf("failed to generate embedding: %w", err))
-		return
+		return "", fmt.Errorf("failed to generate embedding: %w", err)
 	}
 
-	h.logger.Info("generated embedding", zap.String("file_name", header.Filename), zap.String("image_id", imageID))
+	h.logger.Info("generated embedding", zap.String("file_name", fileName), zap.String("image_id", imageID))
 
-	err = h.ds.SaveSamplePrescription(r.Context(), contentType, imageID, rx, embedding)
+	// The layout fingerprint lets the sample be retrieved for other documents of the same form.
+	// Documents without a scanned page have none and are only retrieved by content.
+	embeddings := []models.Embedding{embedding}
+	fingerprint, err := layout.Fingerprint(document)
+	if err != nil {
+		h.logger.Warn("failed to fingerprint sample layout", zap.String("file_name", fileName), zap.Error(err))
+	} else {
+		embeddings = append(embeddings, fingerprint)
+	}
+
+	err = h.ds.SaveSamplePrescription(ctx, contentType, imageID, document, rx, metadata, embeddings...)
 	if err != nil {
 		h.logger.Error("failed to save sample prescription", zap.Error(err))
-		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to save sample prescription", fmt.Errorf("failed to save sample prescription: %w", err))
-		return
+		return "", fmt.Errorf("failed to save sample prescription: %w", err)
 	}
 
-	h.logger.Info("successfully saved sample prescription", zap.String("file_name", header.Filename), zap.String("image_id", imageID))
+	h.logger.Info("successfully saved sample prescription", zap.String("file_name", fileName), zap.String("image_id", imageID))
 
-	handlerutils.RespondWithNoContent(w)
+	return imageID, nil
 }
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/csotherden/prescription-parser/pkg/config"
 	"github.com/csotherden/prescription-parser/pkg/mocks"
 	"github.com/csotherden/prescription-parser/pkg/models"
 	"github.com/gorilla/mux"
@@ -60,7 +61,7 @@ func TestSaveSamplePrescription(t *testing.T) {
 	mockParser.SetEmbedding(prescription.Medications[0].DrugName, testEmbedding, nil)
 
 	// Create test handler
-	handler := NewHandler(mockParser, mockDatastore, logger)
+	handler := NewHandler(config.Config{}, mockParser, mockDatastore, logger)
 
 	// Set up test router
 	router := mux.NewRouter()
@@ -80,6 +81,10 @@ func TestSaveSamplePrescription(t *testing.T) {
 		t.Fatalf("Failed to add JSON field: %v", err)
 	}
 
+	// Add sample metadata
+	writer.WriteField("template", "humira-enrollment")
+	writer.WriteField("drug_class", "biologic")
+
 	// Add test file
 	part, err := writer.CreateFormFile("image", "test.pdf")
 	if err != nil {
@@ -140,15 +145,28 @@ func TestSaveSamplePrescription(t *testing.T) {
 			t.Errorf("Expected MIME type %s, got %s", expectedMimeType, call.MimeType)
 		}
 
+		// Check sample metadata
+		if call.Metadata != (models.SampleMetadata{Template: "humira-enrollment", DrugClass: "biologic"}) {
+			t.Errorf("Unexpected sample metadata %+v", call.Metadata)
+		}
+
+		// Check the original document is passed on for the blob store
+		if string(call.Document) != "test pdf content" {
+			t.Errorf("Expected document %q, got %q", "test pdf content", call.Document)
+		}
+
 		// Check prescription (using the first medication's drug name as identifier)
 		if len(call.Prescription.Medications) == 0 ||
 			call.Prescription.Medications[0].DrugName != prescription.Medications[0].DrugName {
 			t.Errorf("Prescription data mismatch")
 		}
 
-		// Check embedding
-		if len(call.Embedding) != len(testEmbedding) {
-			t.Errorf("Expected embedding length %d, got %d", len(testEmbedding), len(call.Embedding))
+		// Check embedding; the test document is not a scan, so it has no layout fingerprint
+		if len(call.Embeddings) != 1 {
+			t.Fatalf("Expected 1 embedding, got %d", len(call.Embeddings))
+		}
+		if len(call.Embeddings[0].Vector) != len(testEmbedding) {
+			t.Errorf("Expected embedding length %d, got %d", len(testEmbedding), len(call.Embeddings[0].Vector))
 		}
 	}
 }
@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/handlerutils"
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// Paging limits of the sample list.
+const (
+	defaultSamplePageSize = 20
+	maxSamplePageSize     = 100
+)
+
+// ListSamples handles the request for a page of the stored sample prescriptions, newest first.
+// The optional drug, q and file_id parameters filter by medication drug name, by any text in the
+// prescription JSON and by uploaded image ID. limit and offset select the page.
+func (h *Handler) ListSamples(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, err := pageParam(query.Get("limit"), defaultSamplePageSize)
+	if err != nil || limit < 1 || limit > maxSamplePageSize {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxSamplePageSize), err)
+		return
+	}
+
+	offset, err := pageParam(query.Get("offset"), 0)
+	if err != nil || offset < 0 {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "offset must be a non-negative integer", err)
+		return
+	}
+
+	samples, total, err := h.ds.ListSamples(r.Context(), models.SampleFilter{
+		Drug:   query.Get("drug"),
+		Query:  query.Get("q"),
+		FileID: query.Get("file_id"),
+		Metadata: models.SampleMetadata{
+			Template:  query.Get("template"),
+			Tenant:    query.Get("tenant"),
+			DrugClass: query.Get("drug_class"),
+		},
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to list samples", err)
+		return
+	}
+
+	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, models.SamplePage{
+		Samples: samples,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	})
+}
+
+// GetSample handles the request for a stored sample prescription.
+func (h *Handler) GetSample(w http.ResponseWriter, r *http.Request) {
+	sample, err := h.ds.GetSample(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		h.respondWithSampleError(w, "Failed to load sample", err)
+		return
+	}
+
+	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, sample)
+}
+
+// UpdateSample handles the request to replace a sample's prescription with the JSON in the body.
+// The sample's embedding is regenerated from the new prescription so that similarity search
+// reflects the correction.
+func (h *Handler) UpdateSample(w http.ResponseWriter, r *http.Request) {
+	sampleID := mux.Vars(r)["id"]
+
+	var rx models.Prescription
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := json.NewDecoder(r.Body).Decode(&rx); err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Invalid prescription JSON", fmt.Errorf("invalid prescription JSON: %w", err))
+		return
+	}
+
+	// Check the sample exists before paying for an embedding.
+	if _, err := h.ds.GetSample(r.Context(), sampleID); err != nil {
+		h.respondWithSampleError(w, "Failed to load sample", err)
+		return
+	}
+
+	embedding, err := h.parser.GetEmbedding(r.Context(), rx)
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to generate embedding", fmt.Errorf("failed to generate embedding: %w", err))
+		return
+	}
+
+	sample, err := h.ds.UpdateSample(r.Context(), sampleID, rx, embedding)
+	if err != nil {
+		h.respondWithSampleError(w, "Failed to update sample", err)
+		return
+	}
+
+	h.logger.Info("updated sample prescription", zap.String("sample_id", sampleID))
+
+	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, sample)
+}
+
+// DeleteSample handles the request to delete a sample prescription so it no longer guides parsing.
+func (h *Handler) DeleteSample(w http.ResponseWriter, r *http.Request) {
+	sampleID := mux.Vars(r)["id"]
+
+	if err := h.ds.DeleteSample(r.Context(), sampleID); err != nil {
+		h.respondWithSampleError(w, "Failed to delete sample", err)
+		return
+	}
+
+	h.logger.Info("deleted sample prescription", zap.String("sample_id", sampleID))
+
+	handlerutils.RespondWithNoContent(w)
+}
+
+// respondWithSampleError responds 404 if err wraps datastore.ErrNotFound and 500 otherwise.
+func (h *Handler) respondWithSampleError(w http.ResponseWriter, message string, err error) {
+	if errors.Is(err, datastore.ErrNotFound) {
+		handlerutils.RespondWithError(w, h.logger, http.StatusNotFound, "Sample not found", nil)
+		return
+	}
+	handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, message, err)
+}
+
+// pageParam parses an optional integer paging parameter.
+func pageParam(v string, d int) (int, error) {
+	if v == "" {
+		return d, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid paging parameter %q: %w", v, err)
+	}
+	return n, nil
+}
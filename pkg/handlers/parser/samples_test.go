@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/mocks"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+func newSamplesRouter(t *testing.T, drugs ...string) (*mux.Router, *mocks.MockParser, *mocks.MockDatastore) {
+	t.Helper()
+
+	mockParser := mocks.NewMockParser()
+	ds := mocks.NewMockDatastore()
+	for i, drug := range drugs {
+		rx := models.Prescription{Medications: []models.Medication{{DrugName: drug}}}
+		embedding := models.Embedding{Model: models.EmbeddingModel{Name: "old-model", Version: "1", Dimensions: 1, Kind: models.EmbeddingKindContent}, Vector: []float32{float32(i)}}
+		if err := ds.SaveSamplePrescription(context.Background(), "application/pdf", drug+".pdf", nil, rx, models.SampleMetadata{}, embedding); err != nil {
+			t.Fatalf("Failed to save sample: %v", err)
+		}
+	}
+
+	router := mux.NewRouter()
+	NewHandler(config.Config{}, mockParser, ds, zap.NewNop()).RegisterRoutes(router)
+	return router, mockParser, ds
+}
+
+func TestListSamples(t *testing.T) {
+	router, _, _ := newSamplesRouter(t, "Humira", "Metformin", "Humira Pen")
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantTotal  int
+		wantCount  int
+	}{
+		{name: "all", query: "", wantStatus: http.StatusOK, wantTotal: 3, wantCount: 3},
+		{name: "paged", query: "limit=2&offset=2", wantStatus: http.StatusOK, wantTotal: 3, wantCount: 1},
+		{name: "drug filter ignores case", query: "drug=humira", wantStatus: http.StatusOK, wantTotal: 2, wantCount: 2},
+		{name: "file id filter", query: "file_id=Metformin.pdf", wantStatus: http.StatusOK, wantTotal: 1, wantCount: 1},
+		{name: "text filter", query: "q=PEN", wantStatus: http.StatusOK, wantTotal: 1, wantCount: 1},
+		{name: "limit too large", query: "limit=1000", wantStatus: http.StatusBadRequest},
+		{name: "invalid offset", query: "offset=abc", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", "/parser/samples?"+tt.query, nil))
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var page models.SamplePage
+			if err := json.Unmarshal(rr.Body.Bytes(), &page); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if page.Total != tt.wantTotal {
+				t.Errorf("Expected total %d, got %d", tt.wantTotal, page.Total)
+			}
+			if len(page.Samples) != tt.wantCount {
+				t.Errorf("Expected %d samples, got %d", tt.wantCount, len(page.Samples))
+			}
+		})
+	}
+}
+
+func TestSampleLifecycle(t *testing.T) {
+	router, mockParser, ds := newSamplesRouter(t, "Humira")
+
+	samples, _, err := ds.ListSamples(context.Background(), models.SampleFilter{Limit: 1})
+	if err != nil || len(samples) != 1 {
+		t.Fatalf("Failed to list samples: %v", err)
+	}
+	id := samples[0].ID
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/parser/samples/"+id, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 getting sample, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// Updating the sample re-embeds the corrected prescription.
+	updatedEmbedding := []float32{0.9, 0.8}
+	mockParser.SetEmbedding("Humira Pen", updatedEmbedding, nil)
+	body, _ := json.Marshal(models.Prescription{Medications: []models.Medication{{DrugName: "Humira Pen"}}})
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("PUT", "/parser/samples/"+id, bytes.NewReader(body)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 updating sample, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var updated models.Sample
+	if err := json.Unmarshal(rr.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if updated.Prescription.Medications[0].DrugName != "Humira Pen" {
+		t.Errorf("Expected updated drug name, got %q", updated.Prescription.Medications[0].DrugName)
+	}
+	model := mocks.MockEmbeddingModel
+	model.Dimensions = len(updatedEmbedding)
+	if embedding, _ := ds.GetSampleEmbedding(id, model); !slices.Equal(embedding, updatedEmbedding) {
+		t.Errorf("Expected embedding %v, got %v", updatedEmbedding, embedding)
+	}
+	if len(updated.Embeddings) != 1 || updated.Embeddings[0] != model {
+		t.Errorf("Expected only the %s embedding to remain, got %v", model, updated.Embeddings)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("PUT", "/parser/samples/"+id, bytes.NewReader([]byte("{"))))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid JSON, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("DELETE", "/parser/samples/"+id, nil))
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204 deleting sample, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	for _, method := range []string{"GET", "PUT", "DELETE"} {
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(method, "/parser/samples/"+id, bytes.NewReader(body)))
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for %s of deleted sample, got %d", method, rr.Code)
+		}
+	}
+}
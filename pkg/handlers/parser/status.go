@@ -1,14 +1,36 @@
 package parser
 
 import (
+	"fmt"
+	"github.com/csotherden/prescription-parser/pkg/fhir"
 	"github.com/csotherden/prescription-parser/pkg/handlerutils"
 	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
 	"github.com/gorilla/mux"
 	"net/http"
 )
 
-// GetJobStatus handles the request to get the status of a background job
+// GetJobStatus handles the request to get the status of a background job.
+// With format=fhir it returns the job's prescription as a FHIR R4 transaction bundle instead.
+// With api_version=2 the result is the list of every prescription found in the document, with
+// its pages; by default it is the first prescription alone, as before documents were segmented.
 func (h *Handler) GetJobStatus(w http.ResponseWriter, r *http.Request) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+	case "fhir":
+		h.getFhirBundle(w, r)
+		return
+	default:
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("Unsupported format %q", format), nil)
+		return
+	}
+
+	apiVersion := r.URL.Query().Get("api_version")
+	if apiVersion != "" && apiVersion != "1" && apiVersion != "2" {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, fmt.Sprintf("Unsupported API version %q. Must be 1 or 2", apiVersion), nil)
+		return
+	}
+
 	// Get job ID from URL
 	vars := mux.Vars(r)
 	jobID := vars["id"]
@@ -24,6 +46,57 @@ func (h *Handler) GetJobStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if apiVersion == "2" {
+		snapshot, _ := jobs.GlobalTracker.Snapshot(jobID)
+		handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, withPrescriptions(snapshot))
+		return
+	}
+
 	// Return job status
 	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, job)
 }
+
+// withPrescriptions returns a completed job with every prescription found in its document as
+// the result. The validation issues, verification changes and field origins of each
+// prescription are given with it rather than on the job, and the job is blocked if any
+// prescription is. Jobs that are not complete are returned as they are.
+func withPrescriptions(job jobs.Job) jobs.Job {
+	rx, ok := job.Result.(models.Prescription)
+	if job.Status != jobs.JobStatusComplete || !ok {
+		return job
+	}
+
+	prescriptions := job.Prescriptions
+	if len(prescriptions) == 0 {
+		prescriptions = []models.DocumentPrescription{{
+			Pages:        models.PageRange{First: 1},
+			Prescription: rx,
+			Template:     job.Attributes[jobs.AttributeTemplate],
+			Validation:   job.Validation,
+			Blocked:      job.Blocked,
+			Verification: job.Verification,
+			Origins:      job.Origins,
+		}}
+	}
+
+	job.Result = prescriptions
+	job.Validation = nil
+	job.Verification = nil
+	job.Origins = nil
+	job.Blocked = false
+	for _, prescription := range prescriptions {
+		job.Blocked = job.Blocked || prescription.Blocked
+	}
+	return job
+}
+
+// getFhirBundle responds with a completed job's prescription as a FHIR R4 transaction bundle.
+// The prescription is selected, and blocked prescriptions refused, as described for exportablePrescription.
+func (h *Handler) getFhirBundle(w http.ResponseWriter, r *http.Request) {
+	selected, ok := h.exportablePrescription(w, r)
+	if !ok {
+		return
+	}
+
+	handlerutils.RespondWithFHIR(w, h.logger, http.StatusOK, fhir.BuildBundle(selected.rx))
+}
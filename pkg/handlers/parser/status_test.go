@@ -4,10 +4,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/fhir"
 	"github.com/csotherden/prescription-parser/pkg/jobs"
 	"github.com/csotherden/prescription-parser/pkg/mocks"
+	"github.com/csotherden/prescription-parser/pkg/models"
 	"github.com/csotherden/prescription-parser/pkg/parser"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
@@ -22,7 +26,7 @@ func TestGetJobStatus(t *testing.T) {
 	mockDatastore := mocks.NewMockDatastore()
 
 	// Create a handler
-	handler := NewHandler(mockParser, mockDatastore, logger)
+	handler := NewHandler(config.Config{}, mockParser, mockDatastore, logger)
 
 	// Set up test router
 	router := mux.NewRouter()
@@ -110,3 +114,145 @@ func TestGetJobStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestGetJobStatusFhir(t *testing.T) {
+	handler := NewHandler(config.Config{}, mocks.NewMockParser(), mocks.NewMockDatastore(), zap.NewNop())
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	completeJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: fhir.pdf")
+	jobs.GlobalTracker.UpdateJob(completeJobID, jobs.JobStatusComplete, nil, exportPrescription())
+	pendingJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: pending.pdf")
+	blockedJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: blocked.pdf")
+	jobs.GlobalTracker.SetValidation(blockedJobID, []models.ValidationIssue{{Field: "medications[0].refills", Code: "c2_refills", Severity: models.SeverityError}})
+	jobs.GlobalTracker.UpdateJob(blockedJobID, jobs.JobStatusComplete, nil, exportPrescription())
+
+	tests := []struct {
+		name       string
+		path       string
+		reviewer   string
+		wantStatus int
+	}{
+		{name: "complete job", path: "/parser/prescription/" + completeJobID + "?format=fhir", wantStatus: http.StatusOK},
+		{name: "blocked job", path: "/parser/prescription/" + blockedJobID + "?format=fhir", wantStatus: http.StatusUnprocessableEntity},
+		{name: "blocked job forced by reviewer", path: "/parser/prescription/" + blockedJobID + "?format=fhir&force=true", reviewer: "jdoe", wantStatus: http.StatusOK},
+		{name: "pending job", path: "/parser/prescription/" + pendingJobID + "?format=fhir", wantStatus: http.StatusConflict},
+		{name: "unknown format", path: "/parser/prescription/" + completeJobID + "?format=hl7", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.reviewer != "" {
+				req.Header.Set(reviewerHeader, tt.reviewer)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if contentType := rr.Header().Get("Content-Type"); contentType != "application/fhir+json" {
+				t.Errorf("Expected FHIR content type, got %s", contentType)
+			}
+
+			var bundle fhir.Bundle
+			if err := json.Unmarshal(rr.Body.Bytes(), &bundle); err != nil {
+				t.Fatalf("Failed to decode bundle: %v", err)
+			}
+			if bundle.Type != "transaction" || len(bundle.Entry) == 0 {
+				t.Errorf("Unexpected bundle %+v", bundle)
+			}
+		})
+	}
+}
+
+func TestGetJobStatusAPIVersion(t *testing.T) {
+	handler := NewHandler(config.Config{}, mocks.NewMockParser(), mocks.NewMockDatastore(), zap.NewNop())
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	first := models.DocumentPrescription{Pages: models.PageRange{First: 1, Last: 2}, Prescription: models.Prescription{Patient: models.Patient{FirstName: "Ann"}}}
+	second := models.DocumentPrescription{
+		Pages:        models.PageRange{First: 3, Last: 3},
+		Prescription: models.Prescription{Patient: models.Patient{FirstName: "Bob"}},
+		Validation:   []models.ValidationIssue{{Field: "prescriber.npi", Severity: models.SeverityError}},
+		Blocked:      true,
+	}
+	faxJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: fax.pdf")
+	jobs.GlobalTracker.SetPrescriptions(faxJobID, []models.DocumentPrescription{first, second})
+	jobs.GlobalTracker.UpdateJob(faxJobID, jobs.JobStatusComplete, nil, first.Prescription)
+
+	singleJobID := jobs.GlobalTracker.CreateJob(parser.JobTypeParsePrescription, "Processing image: single.pdf")
+	jobs.GlobalTracker.UpdateJob(singleJobID, jobs.JobStatusComplete, nil, first.Prescription)
+
+	tests := []struct {
+		name        string
+		path        string
+		wantStatus  int
+		wantNames   []string // First names of the prescriptions in the result, nil for a single object
+		wantBlocked bool
+		wantCount   int // Prescription count given with a single object
+	}{
+		{name: "default", path: "/parser/prescription/" + faxJobID, wantStatus: http.StatusOK, wantBlocked: true, wantCount: 2},
+		{name: "version 1", path: "/parser/prescription/" + faxJobID + "?api_version=1", wantStatus: http.StatusOK, wantBlocked: true, wantCount: 2},
+		{name: "version 1 single prescription", path: "/parser/prescription/" + singleJobID + "?api_version=1", wantStatus: http.StatusOK},
+		{name: "version 2", path: "/parser/prescription/" + faxJobID + "?api_version=2", wantStatus: http.StatusOK, wantNames: []string{"Ann", "Bob"}, wantBlocked: true},
+		{name: "version 2 single prescription", path: "/parser/prescription/" + singleJobID + "?api_version=2", wantStatus: http.StatusOK, wantNames: []string{"Ann"}},
+		{name: "unsupported version", path: "/parser/prescription/" + faxJobID + "?api_version=3", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v (%s)", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if tt.wantNames == nil {
+				// Old clients see the whole document's block and that the result is part of it
+				var job struct {
+					Result            models.Prescription `json:"result"`
+					Blocked           bool                `json:"blocked"`
+					PrescriptionCount int                 `json:"prescription_count"`
+				}
+				if err := json.Unmarshal(rr.Body.Bytes(), &job); err != nil {
+					t.Fatalf("Failed to decode job: %v", err)
+				}
+				if job.Result.Patient.FirstName != "Ann" || job.Blocked != tt.wantBlocked || job.PrescriptionCount != tt.wantCount {
+					t.Errorf("Expected the first prescription as the result with blocked %v and %d prescriptions, got %+v", tt.wantBlocked, tt.wantCount, job)
+				}
+				return
+			}
+
+			var job struct {
+				Result  []models.DocumentPrescription `json:"result"`
+				Blocked bool                          `json:"blocked"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &job); err != nil {
+				t.Fatalf("Failed to decode job: %v", err)
+			}
+			var names []string
+			for _, prescription := range job.Result {
+				names = append(names, prescription.Prescription.Patient.FirstName)
+			}
+			if !slices.Equal(names, tt.wantNames) || job.Blocked != tt.wantBlocked {
+				t.Errorf("Expected prescriptions of %v with blocked %v, got %+v", tt.wantNames, tt.wantBlocked, job)
+			}
+			if job.Result[0].Pages.First != 1 {
+				t.Errorf("Expected the first prescription to start on page 1, got %+v", job.Result[0].Pages)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/handlerutils"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// maxOverlaySize limits the body of a prompt overlay request.
+const maxOverlaySize = 64 << 10
+
+// promptOverlayRequest is the body of a request to change a form template's prompt overlay.
+type promptOverlayRequest struct {
+	Instructions string `json:"instructions"`     // Instructions appended to the system prompt, empty to turn the overlay off
+	Author       string `json:"author,omitempty"` // Who is making the change
+}
+
+// ListTemplates handles the request for the form templates parsed documents are identified as,
+// ordered by ID. The list is empty when no template file is configured.
+func (h *Handler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, h.parser.Templates().Templates())
+}
+
+// GetPromptOverlay handles the request for the current version of a form template's prompt overlay.
+func (h *Handler) GetPromptOverlay(w http.ResponseWriter, r *http.Request) {
+	template, ok := h.registeredTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	overlay, err := h.ds.GetPromptOverlay(r.Context(), template)
+	if errors.Is(err, datastore.ErrNotFound) {
+		handlerutils.RespondWithError(w, h.logger, http.StatusNotFound, "Template has no prompt overlay", nil)
+		return
+	}
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to load prompt overlay", err)
+		return
+	}
+
+	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, overlay)
+}
+
+// ListPromptOverlayVersions handles the request for every version of a form template's prompt
+// overlay, newest first.
+func (h *Handler) ListPromptOverlayVersions(w http.ResponseWriter, r *http.Request) {
+	template, ok := h.registeredTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	overlays, err := h.ds.ListPromptOverlays(r.Context(), template)
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to list prompt overlays", err)
+		return
+	}
+
+	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, overlays)
+}
+
+// UpdatePromptOverlay handles the request to change a form template's prompt overlay. The JSON
+// body's instructions are stored as a new version, which later parses of the template use.
+// Earlier versions are kept; a previous version is restored by saving its instructions again.
+func (h *Handler) UpdatePromptOverlay(w http.ResponseWriter, r *http.Request) {
+	template, ok := h.registeredTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	var req promptOverlayRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxOverlaySize)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusBadRequest, "Invalid prompt overlay JSON", fmt.Errorf("invalid prompt overlay JSON: %w", err))
+		return
+	}
+
+	overlay, err := h.ds.SavePromptOverlay(r.Context(), template, req.Instructions, req.Author)
+	if err != nil {
+		handlerutils.RespondWithError(w, h.logger, http.StatusInternalServerError, "Failed to save prompt overlay", err)
+		return
+	}
+
+	h.logger.Info("saved prompt overlay", zap.String("template", template), zap.Int("version", overlay.Version), zap.String("author", req.Author))
+
+	handlerutils.RespondWithJSON(w, h.logger, http.StatusOK, overlay)
+}
+
+// registeredTemplate returns the template ID of the request's path. It writes a 404 response and
+// returns false if the template is not in the registry, since its overlay would never be applied.
+func (h *Handler) registeredTemplate(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := mux.Vars(r)["id"]
+	if _, ok := h.parser.Templates().Template(id); !ok {
+		handlerutils.RespondWithError(w, h.logger, http.StatusNotFound, "Template not found", nil)
+		return "", false
+	}
+	return id, true
+}
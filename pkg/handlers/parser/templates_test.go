@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/mocks"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/templates"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const testTemplates = `
+templates:
+  - id: humira-complete
+    name: Humira Complete Enrollment
+    reference_pages:
+      - file: Humira1.pdf
+    hints:
+      - field: prescriber.npi
+        location: Prescriber box, right of the name
+  - id: gleevec
+    reference_pages:
+      - file: Gleevec.pdf
+`
+
+func newTemplateRegistry(t *testing.T) *templates.Registry {
+	t.Helper()
+
+	registry, err := templates.Parse([]byte(testTemplates), filepath.Join("..", "..", "..", "samples"))
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+	return registry
+}
+
+func TestListTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		registry *templates.Registry
+		wantIDs  []string
+	}{
+		{"configured", newTemplateRegistry(t), []string{"gleevec", "humira-complete"}},
+		{"not configured", nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockParser := mocks.NewMockParser()
+			mockParser.SetTemplates(tt.registry)
+			handler := NewHandler(config.Config{}, mockParser, mocks.NewMockDatastore(), zap.NewNop())
+
+			router := mux.NewRouter()
+			handler.RegisterRoutes(router)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/parser/templates", nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+			}
+
+			var got []templates.Template
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Failed to decode templates: %v", err)
+			}
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("Expected %d templates, got %+v", len(tt.wantIDs), got)
+			}
+			for i, id := range tt.wantIDs {
+				if got[i].ID != id {
+					t.Errorf("Expected template %d to be %s, got %s", i, id, got[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestSaveSampleIdentifiesTemplate(t *testing.T) {
+	document, err := os.ReadFile(filepath.Join("..", "..", "..", "samples", "Humira2.pdf"))
+	if err != nil {
+		t.Fatalf("Failed to read sample document: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		template     string
+		wantTemplate string
+	}{
+		{"identified", "", "humira-complete"},
+		{"given", "humira-2024", "humira-2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockParser := mocks.NewMockParser()
+			mockParser.SetTemplates(newTemplateRegistry(t))
+			ds := mocks.NewMockDatastore()
+			handler := NewHandler(config.Config{}, mockParser, ds, zap.NewNop())
+
+			router := mux.NewRouter()
+			handler.RegisterRoutes(router)
+
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			writer.WriteField("json", `{"medications":[{"drug_name":"Humira"}]}`)
+			if tt.template != "" {
+				writer.WriteField("template", tt.template)
+			}
+			part, err := writer.CreateFormFile("image", "Humira2.pdf")
+			if err != nil {
+				t.Fatalf("Failed to create form file: %v", err)
+			}
+			part.Write(document)
+			writer.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/parser/prescription/sample", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNoContent {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+			}
+
+			calls := ds.GetSaveSamplePrescriptionCalls()
+			if len(calls) != 1 || calls[0].Metadata.Template != tt.wantTemplate {
+				t.Errorf("Expected the sample to be saved with template %s, got %+v", tt.wantTemplate, calls)
+			}
+		})
+	}
+}
+
+func TestPromptOverlay(t *testing.T) {
+	mockParser := mocks.NewMockParser()
+	mockParser.SetTemplates(newTemplateRegistry(t))
+	handler := NewHandler(config.Config{}, mockParser, mocks.NewMockDatastore(), zap.NewNop())
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	if rec := do(http.MethodGet, "/parser/templates/humira-complete/overlay", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d before an overlay is saved, got %d", http.StatusNotFound, rec.Code)
+	}
+
+	for i, instructions := range []string{"The DAW box is in the lower right.", "The M/H/W letters are phone labels."} {
+		rec := do(http.MethodPut, "/parser/templates/humira-complete/overlay", `{"instructions":"`+instructions+`","author":"jdoe"}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var overlay models.PromptOverlay
+		if err := json.Unmarshal(rec.Body.Bytes(), &overlay); err != nil {
+			t.Fatalf("Failed to decode overlay: %v", err)
+		}
+		if overlay.Version != i+1 || overlay.Template != "humira-complete" || overlay.Author != "jdoe" {
+			t.Errorf("Unexpected overlay %+v", overlay)
+		}
+	}
+
+	rec := do(http.MethodGet, "/parser/templates/humira-complete/overlay", "")
+	var current models.PromptOverlay
+	if err := json.Unmarshal(rec.Body.Bytes(), &current); err != nil {
+		t.Fatalf("Failed to decode overlay: %v", err)
+	}
+	if current.Version != 2 || current.Instructions != "The M/H/W letters are phone labels." {
+		t.Errorf("Expected the latest version, got %+v", current)
+	}
+
+	rec = do(http.MethodGet, "/parser/templates/humira-complete/overlay/versions", "")
+	var versions []models.PromptOverlay
+	if err := json.Unmarshal(rec.Body.Bytes(), &versions); err != nil {
+		t.Fatalf("Failed to decode versions: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 2 || versions[1].Version != 1 {
+		t.Errorf("Expected versions newest first, got %+v", versions)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"unknown template", http.MethodGet, "/parser/templates/unknown/overlay", "", http.StatusNotFound},
+		{"update unknown template", http.MethodPut, "/parser/templates/unknown/overlay", `{"instructions":"x"}`, http.StatusNotFound},
+		{"versions of unknown template", http.MethodGet, "/parser/templates/unknown/overlay/versions", "", http.StatusNotFound},
+		{"invalid json", http.MethodPut, "/parser/templates/gleevec/overlay", "{", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := do(tt.method, tt.path, tt.body); rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
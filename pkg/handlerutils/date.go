@@ -1,6 +1,7 @@
 package handlerutils
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -37,3 +38,25 @@ func parseDate(dateStr string) time.Time {
 	// Default to zero time if no format matches
 	return time.Time{}
 }
+
+// ParseDateRange converts inclusive YYYY-MM-DD start and end dates to the half-open UTC time range
+// [from, to) covering both days. An empty end date selects the start date only.
+func ParseDateRange(fromDate, toDate string) (time.Time, time.Time, error) {
+	from, err := time.Parse(time.DateOnly, fromDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from date %q: must be YYYY-MM-DD", fromDate)
+	}
+
+	to := from
+	if toDate != "" {
+		to, err = time.Parse(time.DateOnly, toDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to date %q: must be YYYY-MM-DD", toDate)
+		}
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to date %s is before from date %s", toDate, fromDate)
+	}
+
+	return from, to.AddDate(0, 0, 1), nil
+}
@@ -0,0 +1,31 @@
+package handlerutils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseParseDateRange(t *testing.T) {
+	tests := []struct {
+		from, to         string
+		wantFrom, wantTo string
+		wantErr          bool
+	}{
+		{from: "2025-03-14", wantFrom: "2025-03-14", wantTo: "2025-03-15"},
+		{from: "2025-03-01", to: "2025-03-31", wantFrom: "2025-03-01", wantTo: "2025-04-01"},
+		{from: "2025-03-14", to: "2025-03-13", wantErr: true},
+		{from: "03/14/2025", wantErr: true},
+		{from: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		from, to, err := ParseDateRange(tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDateRange(%q, %q) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (from.Format(time.DateOnly) != tt.wantFrom || to.Format(time.DateOnly) != tt.wantTo) {
+			t.Errorf("ParseDateRange(%q, %q) = %s, %s", tt.from, tt.to, from, to)
+		}
+	}
+}
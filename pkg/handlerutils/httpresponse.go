@@ -2,6 +2,7 @@ package handlerutils
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/csotherden/prescription-parser/pkg/models"
 	"go.uber.org/zap"
 	"net/http"
@@ -17,6 +18,48 @@ func RespondWithHTML(w http.ResponseWriter, logger *zap.Logger, code int, payloa
 	}
 }
 
+// RespondWithXML responds with an XML payload
+func RespondWithXML(w http.ResponseWriter, logger *zap.Logger, code int, payload []byte) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(code)
+	_, err := w.Write(payload)
+	if err != nil {
+		logger.Error("failed to write xml response", zap.Error(err))
+	}
+}
+
+// RespondWithHL7 responds with an HL7 v2 ER7-encoded payload
+func RespondWithHL7(w http.ResponseWriter, logger *zap.Logger, code int, payload []byte) {
+	w.Header().Set("Content-Type", "x-application/hl7-v2+er7")
+	w.WriteHeader(code)
+	_, err := w.Write(payload)
+	if err != nil {
+		logger.Error("failed to write hl7 response", zap.Error(err))
+	}
+}
+
+// RespondWithCSV responds with a CSV payload served as a file download
+func RespondWithCSV(w http.ResponseWriter, logger *zap.Logger, code int, fileName string, payload []byte) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.WriteHeader(code)
+	_, err := w.Write(payload)
+	if err != nil {
+		logger.Error("failed to write csv response", zap.Error(err))
+	}
+}
+
+// RespondWithPDF responds with a PDF payload displayed inline under the given file name
+func RespondWithPDF(w http.ResponseWriter, logger *zap.Logger, code int, fileName string, payload []byte) {
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", fileName))
+	w.WriteHeader(code)
+	_, err := w.Write(payload)
+	if err != nil {
+		logger.Error("failed to write pdf response", zap.Error(err))
+	}
+}
+
 // RespondWithJSON responds with a JSON payload
 func RespondWithJSON(w http.ResponseWriter, logger *zap.Logger, statusCode int, payload any) {
 	response, err := json.Marshal(payload)
@@ -34,6 +77,23 @@ func RespondWithJSON(w http.ResponseWriter, logger *zap.Logger, statusCode int,
 	}
 }
 
+// RespondWithFHIR responds with a FHIR JSON payload
+func RespondWithFHIR(w http.ResponseWriter, logger *zap.Logger, statusCode int, payload any) {
+	response, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("failed to marshal fhir response", zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/fhir+json")
+	w.WriteHeader(statusCode)
+	_, err = w.Write(response)
+	if err != nil {
+		logger.Error("failed to write fhir response", zap.Error(err))
+	}
+}
+
 // RespondWithError responds with an error message
 func RespondWithError(w http.ResponseWriter, logger *zap.Logger, statusCode int, message string, err error) {
 	response := models.ErrorResponse{
@@ -17,3 +17,16 @@ func ParseIntParam(v string, d int) int {
 
 	return i
 }
+
+func ParseBoolParam(v string, d bool) bool {
+	if strings.TrimSpace(v) == "" {
+		return d
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return d
+	}
+
+	return b
+}
@@ -0,0 +1,165 @@
+// Package hl7 encodes parsed prescriptions as HL7 v2.5 RDE^O11 pharmacy order messages and
+// delivers them to HL7 listeners over MLLP.
+package hl7
+
+import "strings"
+
+// Delimiters used in encoded messages.
+const (
+	fieldSeparator      = '|'
+	componentSeparator  = '^'
+	repetitionSeparator = '~'
+	escapeCharacter     = '\\'
+	subcomponentSep     = '&'
+	segmentTerminator   = "\r"
+	encodingCharacters  = "^~\\&"
+)
+
+// Segment is a message segment. Fields are stored already encoded, indexed from field 1.
+type Segment struct {
+	Name   string
+	fields []string
+}
+
+func newSegment(name string) *Segment {
+	return &Segment{Name: name}
+}
+
+// set stores an encoded value in the numbered field.
+func (s *Segment) set(field int, value string) *Segment {
+	for len(s.fields) < field {
+		s.fields = append(s.fields, "")
+	}
+	s.fields[field-1] = value
+	return s
+}
+
+// Field returns the encoded value of the numbered field, or an empty string if it is not set.
+func (s *Segment) Field(field int) string {
+	if field < 1 || field > len(s.fields) {
+		return ""
+	}
+	return s.fields[field-1]
+}
+
+// encode writes the segment with trailing empty fields removed. MSH-1 is the field separator
+// itself, so MSH values start at MSH-2.
+func (s *Segment) encode() string {
+	fields := s.fields
+	if s.Name == "MSH" && len(fields) > 0 {
+		fields = fields[1:]
+	}
+	for len(fields) > 0 && fields[len(fields)-1] == "" {
+		fields = fields[:len(fields)-1]
+	}
+	return s.Name + string(fieldSeparator) + strings.Join(fields, string(fieldSeparator))
+}
+
+// Message is an HL7 v2 message.
+type Message struct {
+	Segments []*Segment
+}
+
+// Segment returns the first segment with the given name, or nil if there is none.
+func (m *Message) Segment(name string) *Segment {
+	for _, segment := range m.Segments {
+		if segment.Name == name {
+			return segment
+		}
+	}
+	return nil
+}
+
+// Encode writes the message in ER7 pipe-delimited form with carriage-return segment terminators.
+func (m *Message) Encode() []byte {
+	var b strings.Builder
+	for _, segment := range m.Segments {
+		b.WriteString(segment.encode())
+		b.WriteString(segmentTerminator)
+	}
+	return []byte(b.String())
+}
+
+// Escape replaces the delimiters and line breaks in a value with HL7 escape sequences.
+func Escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case fieldSeparator:
+			b.WriteString(`\F\`)
+		case componentSeparator:
+			b.WriteString(`\S\`)
+		case repetitionSeparator:
+			b.WriteString(`\R\`)
+		case subcomponentSep:
+			b.WriteString(`\T\`)
+		case escapeCharacter:
+			b.WriteString(`\E\`)
+		case '\r':
+			b.WriteString(`\X0D\`)
+		case '\n':
+			b.WriteString(`\X0A\`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Unescape reverses Escape.
+func Unescape(s string) string {
+	replacer := strings.NewReplacer(
+		`\F\`, string(fieldSeparator),
+		`\S\`, string(componentSeparator),
+		`\R\`, string(repetitionSeparator),
+		`\T\`, string(subcomponentSep),
+		`\E\`, string(escapeCharacter),
+		`\X0D\`, "\r",
+		`\X0A\`, "\n",
+	)
+	return replacer.Replace(s)
+}
+
+// components escapes each value and joins them into a composite field, dropping trailing empty components.
+func components(values ...string) string {
+	for len(values) > 0 && strings.TrimSpace(values[len(values)-1]) == "" {
+		values = values[:len(values)-1]
+	}
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = Escape(strings.TrimSpace(v))
+	}
+	return strings.Join(escaped, string(componentSeparator))
+}
+
+// repetitions joins encoded values into a repeating field, skipping empty values.
+func repetitions(values ...string) string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return strings.Join(out, string(repetitionSeparator))
+}
+
+// parse splits an ER7 message into segments. Field values are kept encoded.
+func parse(data []byte) *Message {
+	msg := &Message{}
+	for _, line := range strings.FieldsFunc(string(data), func(r rune) bool { return r == '\r' || r == '\n' }) {
+		parts := strings.Split(line, string(fieldSeparator))
+		segment := newSegment(parts[0])
+		if segment.Name == "MSH" {
+			segment.set(1, string(fieldSeparator))
+			for i, value := range parts[1:] {
+				segment.set(i+2, value)
+			}
+		} else {
+			for i, value := range parts[1:] {
+				segment.set(i+1, value)
+			}
+		}
+		msg.Segments = append(msg.Segments, segment)
+	}
+	return msg
+}
@@ -0,0 +1,186 @@
+package hl7
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// MLLP frame delimiters.
+const (
+	startBlock = 0x0b
+	endBlock   = 0x1c
+	carriageCR = 0x0d
+)
+
+// ErrRejected is returned when the listener acknowledges a message with an error or reject code.
+var ErrRejected = errors.New("hl7 message rejected")
+
+// Ack is a listener's acknowledgment of a message.
+type Ack struct {
+	Code      string `json:"code"`           // MSA-1 acknowledgment code (AA, AE, AR, CA, CE or CR)
+	ControlID string `json:"control_id"`     // MSA-2 control ID of the acknowledged message
+	Text      string `json:"text,omitempty"` // MSA-3 text message, or ERR-8 user message
+	Raw       string `json:"raw,omitempty"`  // The encoded acknowledgment message
+}
+
+// Accepted reports whether the message was accepted.
+func (a *Ack) Accepted() bool {
+	return a.Code == "AA" || a.Code == "CA"
+}
+
+// Client sends messages to an HL7 listener over the Minimal Lower Layer Protocol.
+type Client struct {
+	addr    string
+	timeout time.Duration
+}
+
+// NewClient creates a client for the listener at addr (host:port). The timeout bounds
+// connecting, sending and waiting for the acknowledgment; zero means 30 seconds.
+func NewClient(addr string, timeout time.Duration) *Client {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &Client{addr: addr, timeout: timeout}
+}
+
+// Send delivers a message and waits for its acknowledgment. It returns the acknowledgment together
+// with an error wrapping ErrRejected if the listener did not accept the message.
+func (c *Client) Send(ctx context.Context, message []byte) (*Ack, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to hl7 listener: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("failed to set hl7 connection deadline: %w", err)
+		}
+	}
+
+	if _, err := conn.Write(Frame(message)); err != nil {
+		return nil, fmt.Errorf("failed to send hl7 message: %w", err)
+	}
+
+	response, err := ReadFrame(bufio.NewReader(conn))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hl7 acknowledgment: %w", err)
+	}
+
+	ack, err := parseAck(response)
+	if err != nil {
+		return nil, err
+	}
+	if !ack.Accepted() {
+		return ack, fmt.Errorf("%w: %s %s", ErrRejected, ack.Code, ack.Text)
+	}
+
+	return ack, nil
+}
+
+// Frame wraps a message in an MLLP block.
+func Frame(message []byte) []byte {
+	framed := make([]byte, 0, len(message)+3)
+	framed = append(framed, startBlock)
+	framed = append(framed, message...)
+	return append(framed, endBlock, carriageCR)
+}
+
+// ReadFrame reads one MLLP block and returns the message it carries. Bytes before the start
+// block are discarded.
+func ReadFrame(r *bufio.Reader) ([]byte, error) {
+	if _, err := r.ReadBytes(startBlock); err != nil {
+		return nil, err
+	}
+
+	var message []byte
+	for {
+		chunk, err := r.ReadBytes(endBlock)
+		if err != nil {
+			return nil, err
+		}
+		message = append(message, chunk[:len(chunk)-1]...)
+
+		next, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if next == carriageCR {
+			return message, nil
+		}
+		// An end block byte not followed by a carriage return is part of the message.
+		message = append(message, endBlock)
+		if err := r.UnreadByte(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// NewAck builds the acknowledgment of a message with the given code and text, as a listener would.
+func NewAck(message []byte, code, text string, now time.Time) []byte {
+	received := parse(message)
+	msh := received.Segment("MSH")
+	if msh == nil {
+		msh = newSegment("MSH")
+	}
+
+	ack := &Message{Segments: []*Segment{
+		newSegment("MSH").
+			set(1, string(fieldSeparator)).
+			set(2, encodingCharacters).
+			set(3, msh.Field(5)).
+			set(4, msh.Field(6)).
+			set(5, msh.Field(3)).
+			set(6, msh.Field(4)).
+			set(7, now.Format("20060102150405")).
+			set(9, components("ACK", triggerEvent(msh.Field(9)), "ACK")).
+			set(10, msh.Field(10)+"A").
+			set(11, msh.Field(11)).
+			set(12, msh.Field(12)),
+		newSegment("MSA").
+			set(1, code).
+			set(2, msh.Field(10)).
+			set(3, Escape(text)),
+	}}
+
+	return ack.Encode()
+}
+
+// triggerEvent returns the trigger event component of an encoded MSH-9 message type.
+func triggerEvent(messageType string) string {
+	parts := strings.Split(messageType, string(componentSeparator))
+	if len(parts) < 2 {
+		return ""
+	}
+	return Unescape(parts[1])
+}
+
+// parseAck reads the MSA segment, and the ERR user message if there is no MSA text, from an acknowledgment.
+func parseAck(response []byte) (*Ack, error) {
+	msg := parse(response)
+	msa := msg.Segment("MSA")
+	if msa == nil {
+		return nil, fmt.Errorf("hl7 acknowledgment has no MSA segment")
+	}
+
+	ack := &Ack{
+		Code:      msa.Field(1),
+		ControlID: Unescape(msa.Field(2)),
+		Text:      Unescape(msa.Field(3)),
+		Raw:       string(response),
+	}
+	if errSegment := msg.Segment("ERR"); ack.Text == "" && errSegment != nil {
+		ack.Text = Unescape(errSegment.Field(8))
+	}
+
+	return ack, nil
+}
@@ -0,0 +1,84 @@
+package hl7
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// startStub starts a local MLLP listener that answers each message with the given acknowledgment
+// code and sends the messages it receives on the returned channel.
+func startStub(t *testing.T, code string) (string, <-chan []byte) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start MLLP stub: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []byte, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			message, err := ReadFrame(bufio.NewReader(conn))
+			if err == nil {
+				received <- message
+				conn.Write(Frame(NewAck(message, code, "stub "+code, time.Now())))
+			}
+			conn.Close()
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestClientSend(t *testing.T) {
+	message := BuildRDE(testPrescription(), Options{ControlID: "MSG002", PlacerOrderNumber: "JOB2", Time: time.Now()}).Encode()
+
+	t.Run("accepted", func(t *testing.T) {
+		addr, received := startStub(t, "AA")
+
+		ack, err := NewClient(addr, time.Second).Send(context.Background(), message)
+		if err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+		if !ack.Accepted() || ack.ControlID != "MSG002" || ack.Text != "stub AA" {
+			t.Errorf("Unexpected acknowledgment %+v", ack)
+		}
+		if got := <-received; string(got) != string(message) {
+			t.Errorf("Listener received %q, want %q", got, message)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		addr, _ := startStub(t, "AE")
+
+		ack, err := NewClient(addr, time.Second).Send(context.Background(), message)
+		if !errors.Is(err, ErrRejected) {
+			t.Fatalf("Expected ErrRejected, got %v", err)
+		}
+		if ack == nil || ack.Code != "AE" {
+			t.Errorf("Unexpected acknowledgment %+v", ack)
+		}
+	})
+
+	t.Run("no listener", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to reserve a port: %v", err)
+		}
+		addr := listener.Addr().String()
+		listener.Close()
+
+		if _, err := NewClient(addr, time.Second).Send(context.Background(), message); err == nil {
+			t.Errorf("Expected a connection error")
+		}
+	})
+}
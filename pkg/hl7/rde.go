@@ -0,0 +1,355 @@
+package hl7
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/controlled"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/ncpdp"
+	"github.com/csotherden/prescription-parser/pkg/personname"
+	"github.com/csotherden/prescription-parser/pkg/phone"
+)
+
+// SendingApplication identifies this service in MSH-3.
+const SendingApplication = "PRESCRIPTION-PARSER"
+
+// amountPattern reads the leading amount and unit from a value such as "30 tablets" or "40 mg/0.4 mL".
+var amountPattern = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?)\s*([A-Za-z]*)`)
+
+// routes maps words in the SIG or dosage form to HL7 table 0162 routes of administration.
+var routes = []struct {
+	words []string
+	code  string
+	text  string
+}{
+	{[]string{"SC", "SQ", "SUBQ", "SUBCUTANEOUS", "SUBCUTANEOUSLY", "PEN", "AUTOINJECTOR"}, "SC", "Subcutaneous"},
+	{[]string{"IM", "INTRAMUSCULAR", "INTRAMUSCULARLY"}, "IM", "Intramuscular"},
+	{[]string{"IV", "INTRAVENOUS", "INTRAVENOUSLY", "INFUSE", "INFUSION"}, "IV", "Intravenous"},
+	{[]string{"SL", "SUBLINGUAL", "SUBLINGUALLY"}, "SL", "Sublingual"},
+	{[]string{"INHALE", "INHALATION", "INHALER", "NEBULIZE"}, "IH", "Inhalation"},
+	{[]string{"TOPICAL", "TOPICALLY", "APPLY", "CREAM", "OINTMENT", "GEL", "LOTION"}, "TP", "Topical"},
+	{[]string{"TRANSDERMAL", "PATCH"}, "TD", "Transdermal"},
+	{[]string{"OPHTHALMIC", "EYE", "EYES"}, "OP", "Ophthalmic"},
+	{[]string{"NASAL", "NOSTRIL", "NOSTRILS"}, "NS", "Nasal"},
+	{[]string{"RECTAL", "RECTALLY", "SUPPOSITORY"}, "PR", "Rectal"},
+	{[]string{"PO", "ORAL", "ORALLY", "MOUTH", "TABLET", "TABLETS", "TAB", "TABS", "CAPSULE", "CAPSULES", "CAP", "CAPS"}, "PO", "Oral"},
+}
+
+// Options identifies the parties, message and order for an RDE^O11 message.
+type Options struct {
+	SendingFacility      string    // MSH-4
+	ReceivingApplication string    // MSH-5
+	ReceivingFacility    string    // MSH-6
+	ControlID            string    // MSH-10, unique per message
+	PlacerOrderNumber    string    // Prefix of each order's placer order number (ORC-2); orders are numbered from 1
+	Time                 time.Time // Message date/time (MSH-7) and order transaction time (ORC-9)
+}
+
+// BuildRDE builds an RDE^O11 message carrying one order group (ORC, RXE, RXR and DG1) for each
+// medication on the prescription. The patient group holds the PID, an outpatient PV1 and an IN1
+// for each insurance policy. DG1 segments follow the RXR segment of each order, where receiving
+// pharmacy systems expect the order's diagnoses. Receiving systems match patients on PID-3, so it
+// only carries the member ID of an insurance policy the patient holds, and is otherwise empty.
+func BuildRDE(rx models.Prescription, opts Options) *Message {
+	timestamp := opts.Time.Format("20060102150405")
+
+	msg := &Message{}
+	msg.Segments = append(msg.Segments, newSegment("MSH").
+		set(1, string(fieldSeparator)).
+		set(2, encodingCharacters).
+		set(3, components(SendingApplication)).
+		set(4, components(opts.SendingFacility)).
+		set(5, components(opts.ReceivingApplication)).
+		set(6, components(opts.ReceivingFacility)).
+		set(7, timestamp).
+		set(9, components("RDE", "O11", "RDE_O11")).
+		set(10, Escape(opts.ControlID)).
+		set(11, "P").
+		set(12, "2.5"))
+
+	msg.Segments = append(msg.Segments, pid(rx.Patient))
+	msg.Segments = append(msg.Segments, newSegment("PV1").set(1, "1").set(2, "O"))
+
+	setID := 0
+	for _, ins := range rx.Patient.Insurance {
+		if ins.Provider == "" && ins.IdNumber == "" {
+			continue
+		}
+		setID++
+		msg.Segments = append(msg.Segments, in1(setID, ins, rx.Patient))
+	}
+
+	for i, med := range rx.Medications {
+		placer := opts.PlacerOrderNumber + "-" + strconv.Itoa(i+1)
+		msg.Segments = append(msg.Segments,
+			orc(placer, timestamp, rx.Prescriber),
+			rxe(rx, med),
+			rxr(med),
+		)
+		msg.Segments = append(msg.Segments, dg1s(rx.Diagnosis)...)
+	}
+
+	return msg
+}
+
+func pid(p models.Patient) *Segment {
+	s := newSegment("PID").
+		set(1, "1").
+		set(3, memberID(p)).
+		set(5, components(p.LastName, p.FirstName, p.MiddleName)).
+		set(7, date(p.Dob)).
+		set(8, sex(p.Sex)).
+		set(11, address(p.Address, "H"))
+
+	var home, business []string
+	for _, number := range p.PhoneNumbers {
+		label := strings.ToLower(strings.TrimSpace(number.Label))
+		value := number.Number
+		if number.Extension != "" {
+			value += " x" + number.Extension
+		}
+		switch label {
+		case "work":
+			business = append(business, telephone(value, "WPN", "PH"))
+		case "fax":
+			business = append(business, telephone(value, "WPN", "FX"))
+		case "mobile", "cell":
+			home = append(home, telephone(value, "PRN", "CP"))
+		default:
+			home = append(home, telephone(value, "PRN", "PH"))
+		}
+	}
+
+	return s.set(13, repetitions(home...)).set(14, repetitions(business...))
+}
+
+func in1(setID int, ins models.Insurance, p models.Patient) *Segment {
+	s := newSegment("IN1").
+		set(1, strconv.Itoa(setID)).
+		set(2, components(ins.Type, ins.Provider)).
+		set(3, components(ins.RxBin)).
+		set(4, components(ins.Provider)).
+		set(7, telephone(ins.PhoneNumber, "WPN", "PH")).
+		set(8, Escape(ins.GroupNumber)).
+		set(22, strconv.Itoa(setID)).
+		set(36, Escape(ins.IdNumber))
+
+	if isPolicyholder(ins, p) {
+		return s.set(16, components(p.LastName, p.FirstName, p.MiddleName)).
+			set(17, components("SEL", "Self", "HL70063")).
+			set(18, date(p.Dob))
+	}
+
+	name := personname.Split(ins.PolicyholderName)
+	return s.set(16, components(name.Last, name.First, name.Middle)).set(18, date(ins.PolicyholderDob))
+}
+
+// memberID returns the patient identifier (CX) of the member ID of the first insurance policy the
+// patient holds, with the insurer as assigning authority and identifier type MB (member number).
+// It returns an empty field if the patient holds no policy with a member ID, since a dependent's
+// card may carry the policyholder's ID.
+func memberID(p models.Patient) string {
+	for _, ins := range p.Insurance {
+		if ins.IdNumber != "" && isPolicyholder(ins, p) {
+			return components(ins.IdNumber, "", "", ins.Provider, "MB")
+		}
+	}
+	return ""
+}
+
+// isPolicyholder reports whether the patient holds an insurance policy: the policyholder is not
+// named or is named as the patient.
+func isPolicyholder(ins models.Insurance, p models.Patient) bool {
+	holder := strings.ToUpper(strings.Join(strings.Fields(ins.PolicyholderName), " "))
+	self := strings.ToUpper(strings.Join(strings.Fields(p.FirstName+" "+p.LastName), " "))
+	return holder == "" || holder == self
+}
+
+func orc(placer, timestamp string, p models.Prescriber) *Segment {
+	office := p.Office
+	return newSegment("ORC").
+		set(1, "NW").
+		set(2, components(placer, SendingApplication)).
+		set(9, timestamp).
+		set(12, provider(p, digitsOnly(p.Npi), "NPI")).
+		set(14, telephone(office.Phone, "WPN", "PH")).
+		set(21, components(office.Name)).
+		set(22, address(office.Address, "B")).
+		set(23, repetitions(telephone(office.Phone, "WPN", "PH"), telephone(office.Fax, "WPN", "FX")))
+}
+
+func rxe(rx models.Prescription, med models.Medication) *Segment {
+	s := newSegment("RXE").
+		set(2, giveCode(med)).
+		set(7, components("", administrationInstructions(med))).
+		set(13, provider(rx.Prescriber, strings.ToUpper(strings.ReplaceAll(rx.Prescriber.Dea, " ", "")), "DEA"))
+
+	if m := amountPattern.FindStringSubmatch(med.Strength); m != nil {
+		s.set(3, m[1]).set(5, components("", m[2]))
+	}
+	s.set(6, components("", med.Form))
+
+	if daw := strings.TrimSpace(rx.PrescriberSignature.DawCode); daw != "" && daw[0] >= '0' && daw[0] <= '9' {
+		s.set(9, daw[:1])
+	}
+
+	if m := amountPattern.FindStringSubmatch(med.Quantity); m != nil {
+		unit := m[2]
+		if unit == "" {
+			unit = med.Form
+		}
+		s.set(10, m[1]).set(11, components("", unit))
+	}
+
+	if refills, unlimited, ok := controlled.ParseRefills(med.Refills); ok && !unlimited {
+		s.set(12, strconv.Itoa(refills))
+	}
+
+	if med.Indication != "" {
+		s.set(27, components("", med.Indication))
+	}
+
+	return s
+}
+
+// giveCode codes the medication with its NDC or RxNorm concept, falling back to the drug name as text.
+func giveCode(med models.Medication) string {
+	description := med.DrugName
+	if med.Strength != "" && !strings.Contains(description, med.Strength) {
+		description += " " + med.Strength
+	}
+
+	switch {
+	case med.Ndc != "":
+		ndc, ok := ncpdp.NormalizeNDC(med.Ndc)
+		if !ok {
+			ndc = digitsOnly(med.Ndc)
+		}
+		return components(ndc, description, "NDC")
+	case med.Normalized != nil && med.Normalized.RxCUI != "":
+		return components(med.Normalized.RxCUI, description, "RXNORM")
+	default:
+		return components("", description)
+	}
+}
+
+// administrationInstructions returns the SIG, or its plain-language translation if the SIG was not read.
+func administrationInstructions(med models.Medication) string {
+	if med.SIG != "" {
+		return med.SIG
+	}
+	return med.AdministrationNotes
+}
+
+func rxr(med models.Medication) *Segment {
+	s := newSegment("RXR")
+	words := " " + strings.Join(strings.FieldsFunc(strings.ToUpper(med.SIG+" "+med.Form), func(r rune) bool {
+		return (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	}), " ") + " "
+
+	for _, route := range routes {
+		for _, word := range route.words {
+			if strings.Contains(words, " "+word+" ") {
+				return s.set(1, components(route.code, route.text, "HL70162"))
+			}
+		}
+	}
+
+	return s
+}
+
+func dg1s(d models.PatientDiagnosis) []*Segment {
+	var out []*Segment
+	for i, diagnosis := range append([]models.Diagnosis{d.PrimaryDiagnosis}, d.AdditionalDiagnoses...) {
+		if diagnosis.Icd10Code == "" && diagnosis.Description == "" {
+			continue
+		}
+
+		s := newSegment("DG1").
+			set(1, strconv.Itoa(len(out)+1)).
+			set(3, components(strings.ToUpper(strings.TrimSpace(diagnosis.Icd10Code)), diagnosis.Description, "I10")).
+			set(6, "W")
+		if i == 0 {
+			s.set(5, date(d.DateOfDiagnosis)).set(15, "1")
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// provider encodes the prescriber as an XCN with the given identifier and identifier type.
+func provider(p models.Prescriber, id, idType string) string {
+	if p.Name == "" && id == "" {
+		return ""
+	}
+	if id == "" {
+		idType = ""
+	}
+	name := personname.Split(p.Name)
+	return components(id, name.Last, name.First, name.Middle, name.Suffix, name.Prefix, "", "", "", "", "", "", idType)
+}
+
+// address encodes an XAD with the given address type.
+func address(a models.Address, addressType string) string {
+	if a == (models.Address{}) {
+		return ""
+	}
+	return components(a.Street, "", a.City, a.State, a.Zip, "USA", addressType)
+}
+
+// telephone encodes an XTN. North American numbers are split into area code, local number and
+// extension; other numbers are sent unformatted.
+func telephone(value, use, equipment string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+
+	n, ok := phone.Parse(value)
+	switch {
+	case !ok:
+		return components(value, use, equipment)
+	case n.NANP:
+		return components("", use, equipment, "", "", n.Digits[:3], n.Digits[3:], n.Extension)
+	default:
+		return components(n.String(), use, equipment)
+	}
+}
+
+// date converts a YYYY-MM-DD date to the HL7 YYYYMMDD form, or returns an empty string if it is not a valid date.
+func date(s string) string {
+	t, err := time.Parse("2006-01-02", strings.TrimSpace(s))
+	if err != nil {
+		return ""
+	}
+	return t.Format("20060102")
+}
+
+// sex maps a parsed sex to HL7 table 0001.
+func sex(s string) string {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "":
+		return ""
+	case "M", "MALE":
+		return "M"
+	case "F", "FEMALE":
+		return "F"
+	case "U", "UNKNOWN":
+		return "U"
+	default:
+		return "O"
+	}
+}
+
+// digitsOnly returns only the digits of s.
+func digitsOnly(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
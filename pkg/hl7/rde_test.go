@@ -0,0 +1,146 @@
+package hl7
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func testPrescription() models.Prescription {
+	return models.Prescription{
+		DateWritten: "2025-03-14",
+		Patient: models.Patient{
+			FirstName:    "Ann",
+			LastName:     "O'Neil|Smith",
+			Dob:          "1980-02-01",
+			Sex:          "Female",
+			Address:      models.Address{Street: "12 OAK ST", City: "RESTON", State: "VA", Zip: "20190"},
+			PhoneNumbers: []models.PhoneNumber{{Label: "Mobile", Number: "7035551234"}, {Label: "Work", Number: "7035550000", Extension: "12"}},
+			Insurance: []models.Insurance{
+				{Type: "Primary", Provider: "Aetna", IdNumber: "W123", GroupNumber: "G1", RxBin: "610502"},
+				{Type: "Secondary", Provider: "Medicaid", IdNumber: "M456", PolicyholderName: "John Smith", PolicyholderDob: "1979-05-06"},
+			},
+		},
+		Prescriber: models.Prescriber{
+			Name: "Dr. Jane A. Brown, MD",
+			Npi:  "1234567893",
+			Dea:  "AB1234563",
+			Office: models.PrescriberOffice{
+				Name:    "Reston Derm & Skin",
+				Address: models.Address{Street: "1 MAIN ST", City: "RESTON", State: "VA", Zip: "20190"},
+				Phone:   "7035550100",
+				Fax:     "7035550101",
+			},
+		},
+		Diagnosis: models.PatientDiagnosis{
+			DateOfDiagnosis:     "2024-11-02",
+			PrimaryDiagnosis:    models.Diagnosis{Description: "Psoriatic arthritis", Icd10Code: "L40.50"},
+			AdditionalDiagnoses: []models.Diagnosis{{Description: "Psoriasis", Icd10Code: "L40.0"}},
+		},
+		Medications: []models.Medication{
+			{
+				DrugName: "Humira",
+				Ndc:      "0074-0554-02",
+				Strength: "40 mg/0.4 mL",
+				Form:     "pen",
+				SIG:      "Inject 40 mg SC every other week ^ as directed",
+				Quantity: "2 pens",
+				Refills:  "5",
+			},
+			{DrugName: "Methotrexate", Strength: "2.5 mg", Form: "tablet", SIG: "Take 6 tabs by mouth weekly", Quantity: "24", Refills: "PRN"},
+		},
+		PrescriberSignature: models.SignatureInfo{DawCode: "1"},
+	}
+}
+
+func TestBuildRDE(t *testing.T) {
+	msg := BuildRDE(testPrescription(), Options{
+		SendingFacility:      "CLINIC",
+		ReceivingApplication: "PHARMACY",
+		ReceivingFacility:    "STORE1",
+		ControlID:            "MSG001",
+		PlacerOrderNumber:    "JOB1",
+		Time:                 time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+	})
+
+	var names []string
+	for _, segment := range msg.Segments {
+		names = append(names, segment.Name)
+	}
+	wantNames := "MSH,PID,PV1,IN1,IN1,ORC,RXE,RXR,DG1,DG1,ORC,RXE,RXR,DG1,DG1"
+	if got := strings.Join(names, ","); got != wantNames {
+		t.Fatalf("Expected segments %s, got %s", wantNames, got)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(msg.Encode()), "\r"), "\r")
+
+	tests := []struct {
+		line int
+		want string
+	}{
+		{0, `MSH|^~\&|PRESCRIPTION-PARSER|CLINIC|PHARMACY|STORE1|20250314093000||RDE^O11^RDE_O11|MSG001|P|2.5`},
+		{1, `PID|1||W123^^^Aetna^MB||O'Neil\F\Smith^Ann||19800201|F|||12 OAK ST^^RESTON^VA^20190^USA^H||^PRN^CP^^^703^5551234|^WPN^PH^^^703^5550000^12`},
+		{2, `PV1|1|O`},
+		{3, `IN1|1|Primary^Aetna|610502|Aetna||||G1||||||||O'Neil\F\Smith^Ann|SEL^Self^HL70063|19800201||||1||||||||||||||W123`},
+		{4, `IN1|2|Secondary^Medicaid||Medicaid||||||||||||Smith^John||19790506||||2||||||||||||||M456`},
+		{5, `ORC|NW|JOB1-1^PRESCRIPTION-PARSER|||||||20250314093000|||1234567893^Brown^Jane^A.^MD^DR^^^^^^^NPI||^WPN^PH^^^703^5550100|||||||Reston Derm \T\ Skin|1 MAIN ST^^RESTON^VA^20190^USA^B|^WPN^PH^^^703^5550100~^WPN^FX^^^703^5550101`},
+		{6, `RXE||00074055402^Humira 40 mg/0.4 mL^NDC|40||^mg|^pen|^Inject 40 mg SC every other week \S\ as directed||1|2|^pens|5|AB1234563^Brown^Jane^A.^MD^DR^^^^^^^DEA`},
+		{7, `RXR|SC^Subcutaneous^HL70162`},
+		{8, `DG1|1||L40.50^Psoriatic arthritis^I10||20241102|W|||||||||1`},
+		{9, `DG1|2||L40.0^Psoriasis^I10|||W`},
+		{11, `RXE||^Methotrexate 2.5 mg|2.5||^mg|^tablet|^Take 6 tabs by mouth weekly||1|24|^tablet||AB1234563^Brown^Jane^A.^MD^DR^^^^^^^DEA`},
+		{12, `RXR|PO^Oral^HL70162`},
+	}
+
+	for _, tt := range tests {
+		if lines[tt.line] != tt.want {
+			t.Errorf("Segment %d:\n got  %s\n want %s", tt.line, lines[tt.line], tt.want)
+		}
+	}
+}
+
+func TestMemberID(t *testing.T) {
+	tests := []struct {
+		name      string
+		insurance []models.Insurance
+		want      string
+	}{
+		{"patient's policy", []models.Insurance{{Provider: "Aetna", IdNumber: "W123"}}, "W123^^^Aetna^MB"},
+		{"policy held by the patient by name", []models.Insurance{{Provider: "Aetna", IdNumber: "W123", PolicyholderName: "ann o'neil-smith"}}, "W123^^^Aetna^MB"},
+		{"first held policy with an ID", []models.Insurance{{Provider: "Aetna"}, {Provider: "Cigna", IdNumber: "C9"}}, "C9^^^Cigna^MB"},
+		{"dependent", []models.Insurance{{Provider: "Medicaid", IdNumber: "M456", PolicyholderName: "John Smith"}}, ""},
+		{"no insurance", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patient := models.Patient{FirstName: "Ann", LastName: "O'Neil-Smith", Insurance: tt.insurance}
+			if got := memberID(patient); got != tt.want {
+				t.Errorf("memberID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "plain", want: "plain"},
+		{in: `a|b^c~d&e\f`, want: `a\F\b\S\c\R\d\T\e\E\f`},
+		{in: "line1\r\nline2", want: `line1\X0D\\X0A\line2`},
+	}
+
+	for _, tt := range tests {
+		got := Escape(tt.in)
+		if got != tt.want {
+			t.Errorf("Escape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if back := Unescape(got); back != tt.in {
+			t.Errorf("Unescape(%q) = %q, want %q", got, back, tt.in)
+		}
+	}
+}
@@ -5,9 +5,12 @@ package jobs
 
 import (
 	"log"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/csotherden/prescription-parser/pkg/models"
 	"github.com/google/uuid"
 )
 
@@ -32,16 +35,39 @@ const (
 // Job represents a generic asynchronous job with its metadata and results.
 // It includes tracking information such as timing and current status.
 type Job struct {
-	ID          string     `json:"id"`                     // Unique identifier for the job
-	Type        string     `json:"type"`                   // Type of job being processed
-	Reference   string     `json:"reference"`              // Human-readable reference or description
-	Status      JobStatus  `json:"status"`                 // Current status of the job
-	StartedAt   time.Time  `json:"started_at"`             // When the job was created
-	CompletedAt *time.Time `json:"completed_at,omitempty"` // When the job finished (if completed)
-	Error       string     `json:"error,omitempty"`        // Error message if job failed
-	Result      any        `json:"result"`                 // Result data from the job (if any)
+	ID                string                        `json:"id"`                           // Unique identifier for the job
+	Type              string                        `json:"type"`                         // Type of job being processed
+	Reference         string                        `json:"reference"`                    // Human-readable reference or description
+	Status            JobStatus                     `json:"status"`                       // Current status of the job
+	StartedAt         time.Time                     `json:"started_at"`                   // When the job was created
+	CompletedAt       *time.Time                    `json:"completed_at,omitempty"`       // When the job finished (if completed)
+	Error             string                        `json:"error,omitempty"`              // Error message if job failed
+	Result            any                           `json:"result"`                       // Result data from the job (if any)
+	Validation        []models.ValidationIssue      `json:"validation,omitempty"`         // Validation issues found in the result
+	Blocked           bool                          `json:"blocked"`                      // Whether any validation issue blocks the result or another prescription of the document
+	Verification      []models.FieldChange          `json:"verification,omitempty"`       // Fields changed by re-checking flagged fields against the document
+	Origins           map[string]string             `json:"origins,omitempty"`            // Pipeline stage each field of the result was taken from, by field path
+	Prescriptions     []models.DocumentPrescription `json:"-"`                            // Every prescription found in the document; Result is the first parsed
+	PrescriptionCount int                           `json:"prescription_count,omitempty"` // Number of prescriptions found in the document; more than one means Result is only part of it
+	Attributes        map[string]string             `json:"attributes,omitempty"`         // Settings and provenance recorded for the job
 }
 
+// Job attribute keys.
+const (
+	AttributeRuleSet         = "rule_set"         // Validation rule set applied to the result
+	AttributeFileName        = "file_name"        // Name of the uploaded file
+	AttributeHl7Ack          = "hl7_ack"          // Acknowledgment code and control ID of the last HL7 push, e.g. "AA MSG001"
+	AttributeBackend         = "backend"          // Parser backend that produced the result (OpenAI or Gemini)
+	AttributeTemplate        = "template"         // ID of the form template the document was identified as
+	AttributeSampleRetrieval = "sample_retrieval" // How the second pass's samples were found (content or layout)
+	AttributePromptOverlay   = "prompt_overlay"   // Version of the form template's prompt overlay appended to the system prompt
+	AttributePromptVersion   = "prompt_version"   // Version of the prompts the document was parsed with
+	AttributeExperiment      = "experiment"       // ID of the experiment the job was assigned to
+	AttributeVariant         = "variant"          // Name of the experiment variant the job was parsed with
+	AttributePipeline        = "pipeline"         // Comma-separated pipeline stages run for the job
+	AttributeBlockOverride   = "block_override"   // Reviewer who last forced the export of a blocked result
+)
+
 // Tracker manages jobs throughout their lifecycle.
 // It provides thread-safe access to job information and handles job cleanup.
 type Tracker struct {
@@ -125,6 +151,121 @@ func (t *Tracker) UpdateJob(jobID string, status JobStatus, err error, result an
 	return true
 }
 
+// SetValidation records the validation issues found in a job's result.
+// The job is marked blocked if any issue has error severity, or any of its prescriptions is blocked.
+// It returns true if the job was found and updated, false if the job doesn't exist.
+func (t *Tracker) SetValidation(jobID string, issues []models.ValidationIssue) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	job, exists := t.jobs[jobID]
+	if !exists {
+		return false
+	}
+
+	job.Validation = issues
+	job.Blocked = blocked(job)
+
+	return true
+}
+
+// SetVerification records the fields the verify stage of a job's pipeline changed.
+// It returns true if the job was found and updated, false if the job doesn't exist.
+func (t *Tracker) SetVerification(jobID string, changes []models.FieldChange) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	job, exists := t.jobs[jobID]
+	if !exists {
+		return false
+	}
+
+	job.Verification = changes
+	return true
+}
+
+// SetOrigins records the pipeline stage each field of a job's result was taken from.
+// It returns true if the job was found and updated, false if the job doesn't exist.
+func (t *Tracker) SetOrigins(jobID string, origins map[string]string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	job, exists := t.jobs[jobID]
+	if !exists {
+		return false
+	}
+
+	job.Origins = origins
+	return true
+}
+
+// SetPrescriptions records every prescription found in a job's document, with its pages.
+// The job is marked blocked if any of them is blocked, so a block on a prescription other than
+// the result is not missed by clients reading the result alone.
+// It returns true if the job was found and updated, false if the job doesn't exist.
+func (t *Tracker) SetPrescriptions(jobID string, prescriptions []models.DocumentPrescription) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	job, exists := t.jobs[jobID]
+	if !exists {
+		return false
+	}
+
+	job.Prescriptions = prescriptions
+	job.PrescriptionCount = len(prescriptions)
+	job.Blocked = blocked(job)
+	return true
+}
+
+// blocked reports whether any validation issue of a job's result, or any of its prescriptions,
+// blocks the job.
+func blocked(job *Job) bool {
+	return models.Blocking(job.Validation) || slices.ContainsFunc(job.Prescriptions, func(p models.DocumentPrescription) bool {
+		return p.Blocked
+	})
+}
+
+// SetAttribute records a named attribute on a job, replacing any previous value.
+// It returns true if the job was found and updated, false if the job doesn't exist.
+func (t *Tracker) SetAttribute(jobID, key, value string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	job, exists := t.jobs[jobID]
+	if !exists {
+		return false
+	}
+
+	if job.Attributes == nil {
+		job.Attributes = make(map[string]string)
+	}
+	job.Attributes[key] = value
+
+	return true
+}
+
+// Snapshot returns a copy of a job that is safe to read while the job continues to be updated.
+// It returns false if the job doesn't exist.
+func (t *Tracker) Snapshot(jobID string) (Job, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	job, exists := t.jobs[jobID]
+	if !exists {
+		return Job{}, false
+	}
+
+	snapshot := *job
+	snapshot.Validation = slices.Clone(job.Validation)
+	snapshot.Verification = slices.Clone(job.Verification)
+	snapshot.Origins = maps.Clone(job.Origins)
+	snapshot.Prescriptions = slices.Clone(job.Prescriptions)
+	snapshot.Attributes = maps.Clone(job.Attributes)
+
+	return snapshot, true
+}
+
 // CleanupOldJobs removes jobs older than the specified duration.
 // It only removes jobs that have been completed or failed.
 func (t *Tracker) CleanupOldJobs(olderThan time.Duration) {
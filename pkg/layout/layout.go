@@ -0,0 +1,163 @@
+// Package layout fingerprints the page layout of scanned prescription forms, so samples of the
+// same form template can be retrieved whatever was written on them. Fingerprints are computed
+// locally from the first page's scan, without calling a model provider.
+package layout
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"strconv"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	_ "golang.org/x/image/tiff"
+)
+
+// gridSize is the number of cells per side of the grid a page is reduced to. Each cell is
+// compared with its right and lower neighbours, giving 2*gridSize*gridSize bits.
+const gridSize = 16
+
+// maxSamples caps the pixels averaged per side of the page, so large scans are sampled sparsely.
+const maxSamples = 512
+
+// Model identifies layout fingerprints among sample embeddings. Each dimension is 0 or 1, so the
+// squared L2 distance between two fingerprints is the number of bits in which they differ.
+var Model = models.EmbeddingModel{
+	Name:       "layout-dhash",
+	Version:    "1",
+	Dimensions: 2 * gridSize * gridSize,
+	Kind:       models.EmbeddingKindLayout,
+}
+
+// ErrNoImage is returned for documents whose page has no scanned image, such as PDFs generated
+// by an e-prescribing system. They have to be retrieved by content instead.
+var ErrNoImage = errors.New("page has no image")
+
+func init() {
+	// pdfcpu otherwise writes a default configuration to the user's config directory.
+	api.DisableConfigDir()
+}
+
+// Fingerprint computes the layout fingerprint of a PDF from the largest image on its first page.
+// It returns an error wrapping ErrNoImage if the page has no image.
+func Fingerprint(document []byte) (models.Embedding, error) {
+	return FingerprintPage(document, 1)
+}
+
+// FingerprintPage computes the layout fingerprint of a PDF from the largest image on a page,
+// numbered from 1. It returns an error wrapping ErrNoImage if the page has no image.
+func FingerprintPage(document []byte, page int) (models.Embedding, error) {
+	img, err := pageImage(document, page)
+	if err != nil {
+		return models.Embedding{}, err
+	}
+	return models.Embedding{Model: Model, Vector: Hash(img)}, nil
+}
+
+// Hash computes the difference hash of an image: the image is reduced to a grid of average
+// brightness, and each bit records whether a cell is darker than its right or lower neighbour.
+// Printed boxes, headings and logos fix most bits, while handwriting only moves a few.
+func Hash(img image.Image) []float32 {
+	grid := brightness(img, gridSize+1)
+
+	vector := make([]float32, 0, Model.Dimensions)
+	for y := 0; y < gridSize; y++ {
+		for x := 0; x < gridSize; x++ {
+			vector = append(vector, bit(grid[y][x] < grid[y][x+1]))
+		}
+	}
+	for y := 0; y < gridSize; y++ {
+		for x := 0; x < gridSize; x++ {
+			vector = append(vector, bit(grid[y][x] < grid[y+1][x]))
+		}
+	}
+	return vector
+}
+
+// Distance returns the number of bits in which two fingerprints differ.
+func Distance(a, b []float32) int {
+	n := 0
+	for i := range min(len(a), len(b)) {
+		if a[i] != b[i] {
+			n++
+		}
+	}
+	return n + max(len(a), len(b)) - min(len(a), len(b))
+}
+
+// pageImage decodes the largest image on a page of a PDF.
+func pageImage(document []byte, pageNr int) (image.Image, error) {
+	conf := model.NewDefaultConfiguration()
+	conf.ValidationMode = model.ValidationRelaxed
+
+	var page image.Image
+	var area int
+	err := api.ExtractImages(bytes.NewReader(document), []string{strconv.Itoa(pageNr)}, func(img model.Image, _ bool, _ int) error {
+		if img.Thumb || img.IsImgMask {
+			return nil
+		}
+		// Image sizes are not always reported, so every image is decoded to measure it.
+		decoded, _, err := image.Decode(img)
+		if err != nil {
+			// Images in formats Go cannot decode are skipped in favour of the others.
+			return nil
+		}
+		if size := decoded.Bounds().Size(); size.X*size.Y > area {
+			page, area = decoded, size.X*size.Y
+		}
+		return nil
+	}, conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract page images: %w", err)
+	}
+	if page == nil {
+		return nil, ErrNoImage
+	}
+	return page, nil
+}
+
+// brightness averages the image's brightness over an n by n grid of cells.
+func brightness(img image.Image, n int) [][]float64 {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	stepX, stepY := max(1, width/maxSamples), max(1, height/maxSamples)
+
+	sums := make([][]float64, n)
+	counts := make([][]int, n)
+	for i := range sums {
+		sums[i] = make([]float64, n)
+		counts[i] = make([]int, n)
+	}
+
+	for y := 0; y < height; y += stepY {
+		row := y * n / height
+		for x := 0; x < width; x += stepX {
+			col := x * n / width
+			gray := color.GrayModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray)
+			sums[row][col] += float64(gray.Y)
+			counts[row][col]++
+		}
+	}
+
+	for row := range sums {
+		for col := range sums[row] {
+			if counts[row][col] > 0 {
+				sums[row][col] /= float64(counts[row][col])
+			}
+		}
+	}
+	return sums
+}
+
+func bit(set bool) float32 {
+	if set {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package layout
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-pdf/fpdf"
+)
+
+func fingerprintSample(t *testing.T, name string) []float32 {
+	t.Helper()
+
+	document, err := os.ReadFile(filepath.Join("..", "..", "samples", name))
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	fingerprint, err := Fingerprint(document)
+	if err != nil {
+		t.Fatalf("Failed to fingerprint %s: %v", name, err)
+	}
+	if fingerprint.Model != Model || len(fingerprint.Vector) != Model.Dimensions {
+		t.Fatalf("Expected a %s fingerprint, got %s with %d dimensions", Model, fingerprint.Model, len(fingerprint.Vector))
+	}
+	return fingerprint.Vector
+}
+
+func TestFingerprintMatchesFormTemplate(t *testing.T) {
+	humira := []string{"Humira1.pdf", "Humira2.pdf", "Humira3.pdf", "Humira4.pdf"}
+	gleevec := fingerprintSample(t, "Gleevec.pdf")
+
+	fingerprints := make([][]float32, len(humira))
+	for i, name := range humira {
+		fingerprints[i] = fingerprintSample(t, name)
+	}
+
+	// Every filled-in copy of the Humira form is closer to the others than to the Gleevec form.
+	for i := range humira {
+		other := Distance(fingerprints[i], gleevec)
+		for j := range humira {
+			if i == j {
+				continue
+			}
+			if same := Distance(fingerprints[i], fingerprints[j]); same >= other {
+				t.Errorf("%s is %d bits from %s but only %d bits from Gleevec.pdf", humira[i], same, humira[j], other)
+			}
+		}
+	}
+
+	again := fingerprintSample(t, "Humira1.pdf")
+	if d := Distance(fingerprints[0], again); d != 0 {
+		t.Errorf("Expected fingerprints to be deterministic, got %d bits apart", d)
+	}
+}
+
+func TestFingerprintWithoutImage(t *testing.T) {
+	pdf := fpdf.New("P", "mm", "Letter", "")
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.Cell(40, 10, "Generated prescription")
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatalf("Failed to generate pdf: %v", err)
+	}
+
+	if _, err := Fingerprint(buf.Bytes()); !errors.Is(err, ErrNoImage) {
+		t.Errorf("Expected ErrNoImage for a generated pdf, got %v", err)
+	}
+
+	if _, err := Fingerprint([]byte("not a pdf")); err == nil || errors.Is(err, ErrNoImage) {
+		t.Errorf("Expected an extraction error for an invalid document, got %v", err)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float32
+		want int
+	}{
+		{"equal", []float32{1, 0, 1}, []float32{1, 0, 1}, 0},
+		{"different bits", []float32{1, 0, 1}, []float32{0, 0, 0}, 2},
+		{"different lengths", []float32{1, 0}, []float32{1, 0, 1, 1}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); got != tt.want {
+				t.Errorf("Distance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
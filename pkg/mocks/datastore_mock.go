@@ -2,10 +2,18 @@ package mocks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/csotherden/prescription-parser/pkg/blobstore"
+	"github.com/csotherden/prescription-parser/pkg/datastore"
 	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/google/uuid"
 )
 
 // MockDatastore implements the datastore.Datastore interface for testing
@@ -17,42 +25,56 @@ type MockDatastore struct {
 	samplesErr                  map[string]error
 	savedSamples                map[string]models.Prescription
 	saveSampleErr               map[string]error
+	parseResults                map[string]models.ParseResult
+	saveReviewErr               map[string]error
+	sampleRecords               map[string]models.Sample
+	sampleEmbeddings            map[string]map[models.EmbeddingModel][]float32
+	sampleDocuments             map[string][]byte
+	promptOverlays              map[string][]models.PromptOverlay
 }
 
 type getSamplesCall struct {
-	Ctx       context.Context
-	Embedding []float32
+	Ctx   context.Context
+	Query models.SampleQuery
 }
 
 type saveSamplePrescriptionCall struct {
 	Ctx          context.Context
 	MimeType     string
 	ImageID      string
+	Document     []byte
 	Prescription models.Prescription
-	Embedding    []float32
+	Metadata     models.SampleMetadata
+	Embeddings   []models.Embedding
 }
 
 // NewMockDatastore creates a new mock datastore
 func NewMockDatastore() *MockDatastore {
 	return &MockDatastore{
-		samples:       make(map[string][]models.SamplePrescription),
-		samplesErr:    make(map[string]error),
-		savedSamples:  make(map[string]models.Prescription),
-		saveSampleErr: make(map[string]error),
+		samples:          make(map[string][]models.SamplePrescription),
+		samplesErr:       make(map[string]error),
+		savedSamples:     make(map[string]models.Prescription),
+		saveSampleErr:    make(map[string]error),
+		parseResults:     make(map[string]models.ParseResult),
+		saveReviewErr:    make(map[string]error),
+		sampleRecords:    make(map[string]models.Sample),
+		sampleEmbeddings: make(map[string]map[models.EmbeddingModel][]float32),
+		sampleDocuments:  make(map[string][]byte),
+		promptOverlays:   make(map[string][]models.PromptOverlay),
 	}
 }
 
 // GetSamples mocks the GetSamples method
-func (m *MockDatastore) GetSamples(ctx context.Context, embedding []float32) ([]models.SamplePrescription, error) {
+func (m *MockDatastore) GetSamples(ctx context.Context, query models.SampleQuery) ([]models.SamplePrescription, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// Create a simple key based on the first few elements of the embedding
-	key := createEmbeddingKey(embedding)
+	key := createEmbeddingKey(query.Embedding.Vector)
 
 	m.getSamplesCalls = append(m.getSamplesCalls, getSamplesCall{
-		Ctx:       ctx,
-		Embedding: embedding,
+		Ctx:   ctx,
+		Query: query,
 	})
 
 	if err, ok := m.samplesErr[key]; ok && err != nil {
@@ -65,12 +87,22 @@ func (m *MockDatastore) GetSamples(ctx context.Context, embedding []float32) ([]
 		return []models.SamplePrescription{}, nil
 	}
 
+	if query.K > 0 && len(samples) > query.K {
+		samples = samples[:query.K]
+	}
 	return samples, nil
 }
 
+// metadataMatches reports whether metadata has the values set in filter.
+func metadataMatches(metadata, filter models.SampleMetadata) bool {
+	return (filter.Template == "" || metadata.Template == filter.Template) &&
+		(filter.Tenant == "" || metadata.Tenant == filter.Tenant) &&
+		(filter.DrugClass == "" || metadata.DrugClass == filter.DrugClass)
+}
+
 // SaveSamplePrescription mocks the SaveSamplePrescription method
-func (m *MockDatastore) SaveSamplePrescription(ctx context.Context, mimeType, imageID string,
-	prescription models.Prescription, embedding []float32) error {
+func (m *MockDatastore) SaveSamplePrescription(ctx context.Context, mimeType, imageID string, document []byte,
+	prescription models.Prescription, metadata models.SampleMetadata, embeddings ...models.Embedding) error {
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -81,8 +113,10 @@ func (m *MockDatastore) SaveSamplePrescription(ctx context.Context, mimeType, im
 		Ctx:          ctx,
 		MimeType:     mimeType,
 		ImageID:      imageID,
+		Document:     document,
 		Prescription: prescription,
-		Embedding:    embedding,
+		Metadata:     metadata,
+		Embeddings:   embeddings,
 	})
 
 	if err, ok := m.saveSampleErr[key]; ok && err != nil {
@@ -91,9 +125,199 @@ func (m *MockDatastore) SaveSamplePrescription(ctx context.Context, mimeType, im
 
 	m.savedSamples[key] = prescription
 
+	id := uuid.NewString()
+	sample := models.Sample{
+		ID:             id,
+		FileID:         imageID,
+		MIMEType:       mimeType,
+		Prescription:   prescription,
+		CreatedAt:      time.Now().UTC(),
+		SampleMetadata: metadata,
+	}
+	if len(document) > 0 {
+		sample.DocumentHash = blobstore.Key(document)
+		m.sampleDocuments[id] = document
+	}
+	m.sampleRecords[id] = sample
+	m.sampleEmbeddings[id] = map[models.EmbeddingModel][]float32{}
+	for _, embedding := range embeddings {
+		m.sampleEmbeddings[id][embedding.Model] = embedding.Vector
+	}
+
 	return nil
 }
 
+// ListSamples mocks the ListSamples method
+func (m *MockDatastore) ListSamples(ctx context.Context, filter models.SampleFilter) ([]models.Sample, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var matches []models.Sample
+	for _, sample := range m.sampleRecords {
+		if filter.FileID != "" && sample.FileID != filter.FileID {
+			continue
+		}
+		if !metadataMatches(sample.SampleMetadata, filter.Metadata) {
+			continue
+		}
+		if filter.Drug != "" && !slices.ContainsFunc(sample.Prescription.Medications, func(med models.Medication) bool {
+			return containsFold(med.DrugName, filter.Drug)
+		}) {
+			continue
+		}
+		if filter.Query != "" {
+			content, _ := json.Marshal(sample.Prescription)
+			if !containsFold(string(content), filter.Query) {
+				continue
+			}
+		}
+		matches = append(matches, m.withEmbeddings(sample))
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].CreatedAt.Equal(matches[j].CreatedAt) {
+			return matches[i].CreatedAt.After(matches[j].CreatedAt)
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	total := len(matches)
+	start := min(filter.Offset, total)
+	end := min(start+filter.Limit, total)
+	return matches[start:end], total, nil
+}
+
+// GetSample mocks the GetSample method
+func (m *MockDatastore) GetSample(ctx context.Context, id string) (models.Sample, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sample, ok := m.sampleRecords[id]
+	if !ok {
+		return models.Sample{}, fmt.Errorf("sample %s: %w", id, datastore.ErrNotFound)
+	}
+	return m.withEmbeddings(sample), nil
+}
+
+// UpdateSample mocks the UpdateSample method
+func (m *MockDatastore) UpdateSample(ctx context.Context, id string, prescription models.Prescription, embedding models.Embedding) (models.Sample, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sample, ok := m.sampleRecords[id]
+	if !ok {
+		return models.Sample{}, fmt.Errorf("sample %s: %w", id, datastore.ErrNotFound)
+	}
+	sample.Prescription = prescription
+	m.sampleRecords[id] = sample
+	for model := range m.sampleEmbeddings[id] {
+		if model.Kind == embedding.Model.Kind {
+			delete(m.sampleEmbeddings[id], model)
+		}
+	}
+	if m.sampleEmbeddings[id] == nil {
+		m.sampleEmbeddings[id] = map[models.EmbeddingModel][]float32{}
+	}
+	m.sampleEmbeddings[id][embedding.Model] = embedding.Vector
+	return m.withEmbeddings(sample), nil
+}
+
+// GetSampleDocument mocks the GetSampleDocument method
+func (m *MockDatastore) GetSampleDocument(ctx context.Context, id string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	document, ok := m.sampleDocuments[id]
+	if !ok {
+		return nil, fmt.Errorf("document of sample %s: %w", id, datastore.ErrNotFound)
+	}
+	return document, nil
+}
+
+// DeleteSample mocks the DeleteSample method
+func (m *MockDatastore) DeleteSample(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sampleRecords[id]; !ok {
+		return fmt.Errorf("sample %s: %w", id, datastore.ErrNotFound)
+	}
+	delete(m.sampleRecords, id)
+	delete(m.sampleEmbeddings, id)
+	delete(m.sampleDocuments, id)
+	return nil
+}
+
+// SaveSampleEmbedding mocks the SaveSampleEmbedding method
+func (m *MockDatastore) SaveSampleEmbedding(ctx context.Context, id string, embedding models.Embedding) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sampleRecords[id]; !ok {
+		return fmt.Errorf("sample %s: %w", id, datastore.ErrNotFound)
+	}
+	if m.sampleEmbeddings[id] == nil {
+		m.sampleEmbeddings[id] = map[models.EmbeddingModel][]float32{}
+	}
+	m.sampleEmbeddings[id][embedding.Model] = embedding.Vector
+	return nil
+}
+
+// ListSamplesWithoutEmbedding mocks the ListSamplesWithoutEmbedding method
+func (m *MockDatastore) ListSamplesWithoutEmbedding(ctx context.Context, model models.EmbeddingModel) ([]models.Sample, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var samples []models.Sample
+	for id, sample := range m.sampleRecords {
+		if _, ok := m.sampleEmbeddings[id][model]; !ok {
+			samples = append(samples, m.withEmbeddings(sample))
+		}
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		if !samples[i].CreatedAt.Equal(samples[j].CreatedAt) {
+			return samples[i].CreatedAt.Before(samples[j].CreatedAt)
+		}
+		return samples[i].ID < samples[j].ID
+	})
+	return samples, nil
+}
+
+// DeleteOtherEmbeddings mocks the DeleteOtherEmbeddings method
+func (m *MockDatastore) DeleteOtherEmbeddings(ctx context.Context, model models.EmbeddingModel) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := 0
+	for _, embeddings := range m.sampleEmbeddings {
+		for other := range embeddings {
+			if other.Kind == model.Kind && other != model {
+				delete(embeddings, other)
+				deleted++
+			}
+		}
+	}
+	return deleted, nil
+}
+
+// GetSampleEmbedding returns the embedding stored for a sample by ID and model
+func (m *MockDatastore) GetSampleEmbedding(id string, model models.EmbeddingModel) ([]float32, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	embedding, ok := m.sampleEmbeddings[id][model]
+	return embedding, ok
+}
+
+// withEmbeddings sets the models a sample has embeddings from. The caller must hold m.mu.
+func (m *MockDatastore) withEmbeddings(sample models.Sample) models.Sample {
+	sample.Embeddings = []models.EmbeddingModel{}
+	for model := range m.sampleEmbeddings[sample.ID] {
+		sample.Embeddings = append(sample.Embeddings, model)
+	}
+	sort.Slice(sample.Embeddings, func(i, j int) bool { return sample.Embeddings[i].String() < sample.Embeddings[j].String() })
+	return sample
+}
+
 // SetSamplePrescriptions configures the mock to return specific sample prescriptions for a given embedding
 func (m *MockDatastore) SetSamplePrescriptions(embedding []float32, samples []models.SamplePrescription, err error) {
 	m.mu.Lock()
@@ -115,6 +339,14 @@ func (m *MockDatastore) SetSaveError(imageID string, err error) {
 	m.saveSampleErr[imageID] = err
 }
 
+// SetSaveReviewError configures the mock to return a specific error when saving a job's review
+func (m *MockDatastore) SetSaveReviewError(jobID string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.saveReviewErr[jobID] = err
+}
+
 // GetSavedPrescription retrieves a saved prescription by imageID
 func (m *MockDatastore) GetSavedPrescription(imageID string) (models.Prescription, bool) {
 	m.mu.Lock()
@@ -138,6 +370,114 @@ func (m *MockDatastore) GetSaveSamplePrescriptionCalls() []saveSamplePrescriptio
 	return m.saveSamplePrescriptionCalls
 }
 
+// SaveParseResult mocks the SaveParseResult method
+func (m *MockDatastore) SaveParseResult(ctx context.Context, result models.ParseResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result.Status = models.ParseResultComplete
+	result.Review = nil
+	if saved, ok := m.parseResults[result.ID]; ok {
+		result.Status = saved.Status
+		result.Review = saved.Review
+	}
+	m.parseResults[result.ID] = result
+	return nil
+}
+
+// ListParseResults mocks the ListParseResults method
+func (m *MockDatastore) ListParseResults(ctx context.Context, from, to time.Time) ([]models.ParseResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var results []models.ParseResult
+	for _, result := range m.parseResults {
+		if !result.CompletedAt.Before(from) && result.CompletedAt.Before(to) {
+			results = append(results, result)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].CompletedAt.Before(results[j].CompletedAt) })
+
+	return results, nil
+}
+
+// GetParseResult mocks the GetParseResult method
+func (m *MockDatastore) GetParseResult(ctx context.Context, jobID string) (models.ParseResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result, ok := m.parseResults[jobID]
+	if !ok {
+		return models.ParseResult{}, fmt.Errorf("parse result %s: %w", jobID, datastore.ErrNotFound)
+	}
+	return result, nil
+}
+
+// SaveReview mocks the SaveReview method
+func (m *MockDatastore) SaveReview(ctx context.Context, jobID string, review models.Review) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err, ok := m.saveReviewErr[jobID]; ok && err != nil {
+		return err
+	}
+
+	result, ok := m.parseResults[jobID]
+	if !ok {
+		return fmt.Errorf("parse result %s: %w", jobID, datastore.ErrNotFound)
+	}
+	result.Status = models.ParseResultReviewed
+	result.Review = &review
+	m.parseResults[jobID] = result
+	return nil
+}
+
+// SavePromptOverlay mocks the SavePromptOverlay method
+func (m *MockDatastore) SavePromptOverlay(ctx context.Context, template, instructions, author string) (models.PromptOverlay, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	overlay := models.PromptOverlay{
+		ID:           uuid.NewString(),
+		Template:     template,
+		Version:      len(m.promptOverlays[template]) + 1,
+		Instructions: instructions,
+		Author:       author,
+		CreatedAt:    time.Now().UTC(),
+	}
+	m.promptOverlays[template] = append(m.promptOverlays[template], overlay)
+	return overlay, nil
+}
+
+// GetPromptOverlay mocks the GetPromptOverlay method
+func (m *MockDatastore) GetPromptOverlay(ctx context.Context, template string) (models.PromptOverlay, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	versions := m.promptOverlays[template]
+	if len(versions) == 0 {
+		return models.PromptOverlay{}, fmt.Errorf("prompt overlay %s: %w", template, datastore.ErrNotFound)
+	}
+	return versions[len(versions)-1], nil
+}
+
+// ListPromptOverlays mocks the ListPromptOverlays method
+func (m *MockDatastore) ListPromptOverlays(ctx context.Context, template string) ([]models.PromptOverlay, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	overlays := slices.Clone(m.promptOverlays[template])
+	slices.Reverse(overlays)
+	if overlays == nil {
+		overlays = []models.PromptOverlay{}
+	}
+	return overlays, nil
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
 // Helper function to create a simple key from an embedding
 func createEmbeddingKey(embedding []float32) string {
 	if len(embedding) == 0 {
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/templates"
 )
 
 // MockParser implements the parser.Parser interface for testing
@@ -20,11 +21,18 @@ type MockParser struct {
 	embeddingErr       map[string]error
 	uploadImageIDs     map[string]string
 	uploadImageErr     map[string]error
+	embeddingModel     models.EmbeddingModel
+	templates          *templates.Registry
 }
 
+// MockEmbeddingModel is the embedding model reported by the mock parser. Embeddings it returns
+// have this name and version, with the dimensions of the configured vector.
+var MockEmbeddingModel = models.EmbeddingModel{Name: "mock-embedding", Version: "1", Dimensions: 3, Kind: models.EmbeddingKindContent}
+
 type parseImageCall struct {
 	ctx      context.Context
 	fileName string
+	opts     models.ParseOptions
 }
 
 type getEmbeddingCall struct {
@@ -46,17 +54,19 @@ func NewMockParser() *MockParser {
 		embeddingErr:       make(map[string]error),
 		uploadImageIDs:     make(map[string]string),
 		uploadImageErr:     make(map[string]error),
+		embeddingModel:     MockEmbeddingModel,
 	}
 }
 
 // ParseImage mocks the ParseImage method
-func (m *MockParser) ParseImage(ctx context.Context, fileName string, file io.Reader) (string, error) {
+func (m *MockParser) ParseImage(ctx context.Context, fileName string, file io.Reader, opts models.ParseOptions) (string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.parseImageCalls = append(m.parseImageCalls, parseImageCall{
 		ctx:      ctx,
 		fileName: fileName,
+		opts:     opts,
 	})
 
 	if err, ok := m.parseImageErr[fileName]; ok && err != nil {
@@ -73,7 +83,7 @@ func (m *MockParser) ParseImage(ctx context.Context, fileName string, file io.Re
 }
 
 // GetEmbedding mocks the GetEmbedding method
-func (m *MockParser) GetEmbedding(ctx context.Context, prescription models.Prescription) ([]float32, error) {
+func (m *MockParser) GetEmbedding(ctx context.Context, prescription models.Prescription) (models.Embedding, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -89,16 +99,32 @@ func (m *MockParser) GetEmbedding(ctx context.Context, prescription models.Presc
 	}
 
 	if err, ok := m.embeddingErr[key]; ok && err != nil {
-		return nil, err
+		return models.Embedding{}, err
 	}
 
 	embedding, ok := m.embeddings[key]
 	if !ok {
 		// Return a default embedding if none is set
-		return []float32{0.1, 0.2, 0.3}, nil
+		embedding = []float32{0.1, 0.2, 0.3}
 	}
 
-	return embedding, nil
+	model := m.embeddingModel
+	model.Dimensions = len(embedding)
+	return models.Embedding{Model: model, Vector: embedding}, nil
+}
+
+// EmbeddingModel mocks the EmbeddingModel method
+func (m *MockParser) EmbeddingModel() models.EmbeddingModel {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.embeddingModel
+}
+
+// Templates mocks the Templates method
+func (m *MockParser) Templates() *templates.Registry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.templates
 }
 
 // UploadImage mocks the UploadImage method
@@ -144,6 +170,13 @@ func (m *MockParser) SetEmbedding(key string, embedding []float32, err error) {
 	}
 }
 
+// SetTemplates sets the form template registry returned by Templates
+func (m *MockParser) SetTemplates(registry *templates.Registry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.templates = registry
+}
+
 // SetUploadImageResponse sets the response for a particular file name
 func (m *MockParser) SetUploadImageResponse(fileName, imageID string, err error) {
 	m.mu.Lock()
@@ -161,6 +194,17 @@ func (m *MockParser) GetParseImageCalls() []parseImageCall {
 	return m.parseImageCalls
 }
 
+// GetParseImageOptions returns the options passed to each recorded ParseImage call
+func (m *MockParser) GetParseImageOptions() []models.ParseOptions {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	opts := make([]models.ParseOptions, 0, len(m.parseImageCalls))
+	for _, call := range m.parseImageCalls {
+		opts = append(opts, call.opts)
+	}
+	return opts
+}
+
 // GetEmbeddingCalls returns the recorded GetEmbedding calls
 func (m *MockParser) GetEmbeddingCalls() []getEmbeddingCall {
 	m.mu.Lock()
@@ -0,0 +1,20 @@
+package models
+
+// PageRange is a range of pages of a document, numbered from 1.
+type PageRange struct {
+	First int `json:"first"` // First page of the range
+	Last  int `json:"last"`  // Last page of the range, inclusive, or 0 if the document's pages could not be counted
+}
+
+// DocumentPrescription is one of the prescriptions found in a document, with the pages it was
+// found on. A document such as a fax can hold the forms of several patients.
+type DocumentPrescription struct {
+	Pages        PageRange         `json:"pages"`                  // Pages of the document the prescription was parsed from
+	Prescription Prescription      `json:"prescription"`           // Parsed and post-processed prescription
+	Template     string            `json:"template,omitempty"`     // ID of the form template the pages were identified as
+	Validation   []ValidationIssue `json:"validation,omitempty"`   // Validation issues found in the prescription
+	Blocked      bool              `json:"blocked"`                // Whether any validation issue blocks the prescription
+	Verification []FieldChange     `json:"verification,omitempty"` // Fields changed by re-checking flagged fields against the document
+	Origins      map[string]string `json:"origins,omitempty"`      // Pipeline stage each field of the prescription was taken from, by field path
+	Error        string            `json:"error,omitempty"`        // Why the pages could not be parsed, in which case the prescription is empty and blocked
+}
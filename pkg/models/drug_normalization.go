@@ -0,0 +1,13 @@
+package models
+
+// DrugNormalization holds the RxNorm concept a parsed medication was matched to.
+type DrugNormalization struct {
+	RxCUI       string  `json:"rxcui"`                  // RxNorm concept unique identifier
+	Name        string  `json:"name"`                   // RxNorm name of the matched concept
+	TermType    string  `json:"term_type"`              // RxNorm term type of the matched concept (e.g. SBD, SCD, BN, IN)
+	BrandName   string  `json:"brand_name,omitempty"`   // Brand name, if the medication resolved to a branded concept
+	GenericName string  `json:"generic_name,omitempty"` // Generic ingredient name(s)
+	DoseForm    string  `json:"dose_form,omitempty"`    // RxNorm dose form of the matched product
+	MatchType   string  `json:"match_type"`             // How the drug name was matched ("exact" or "fuzzy")
+	Confidence  float64 `json:"confidence"`             // Similarity between the parsed and matched names (0.0 - 1.0)
+}
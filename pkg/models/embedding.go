@@ -0,0 +1,30 @@
+package models
+
+import "fmt"
+
+// EmbeddingModel identifies how an embedding was produced. Embeddings can only be compared with
+// embeddings of the same model, version and dimensions.
+type EmbeddingModel struct {
+	Name       string `json:"name"`       // Provider model ID, e.g. text-embedding-3-small
+	Version    string `json:"version"`    // Version of the text embedded for a prescription
+	Dimensions int    `json:"dimensions"` // Length of the vectors
+	Kind       string `json:"kind"`       // What is embedded, EmbeddingKindContent or EmbeddingKindLayout
+}
+
+// Kinds of embeddings. Content embeddings describe a sample's prescription and are replaced when
+// it is corrected; layout embeddings describe the page layout of its document.
+const (
+	EmbeddingKindContent = "content"
+	EmbeddingKindLayout  = "layout"
+)
+
+// String returns the model in the form name@version/dimensions.
+func (m EmbeddingModel) String() string {
+	return fmt.Sprintf("%s@%s/%d", m.Name, m.Version, m.Dimensions)
+}
+
+// Embedding is a vector and the model that produced it.
+type Embedding struct {
+	Model  EmbeddingModel
+	Vector []float32
+}
@@ -12,4 +12,8 @@ type Medication struct {
 	Duration            string `json:"duration" jsonschema_description:"Intended treatment duration (e.g., 12 weeks)"`
 	AdministrationNotes string `json:"administration_notes" jsonschema_description:"Plain English translation of SIG directions"`
 	Indication          string `json:"indication" jsonschema_description:"Diagnosis or condition the drug is intended to treat"`
+
+	// Normalized and DeaSchedule are populated after parsing and are excluded from the LLM response schema.
+	Normalized  *DrugNormalization `json:"normalized,omitempty" jsonschema:"-"`
+	DeaSchedule string             `json:"dea_schedule,omitempty" jsonschema:"-"` // DEA controlled substance schedule (II-V), if controlled
 }
@@ -0,0 +1,11 @@
+package models
+
+// ParseOptions holds per-request settings for parsing a prescription.
+type ParseOptions struct {
+	RuleSet         string            // Validation rule set to apply; empty selects the configured default
+	Samples         SampleMetadata    // Only retrieve samples with these metadata values; empty fields do not filter
+	PromptVersion   string            // Prompt version to parse with; empty selects the configured default
+	SampleRetrieval string            // How samples are found for the second parsing pass; empty keeps the configured mode
+	Pipeline        string            // Comma-separated pipeline stages to run; empty keeps the configured pipeline
+	Attributes      map[string]string // Additional attributes recorded on the job, such as its experiment variant
+}
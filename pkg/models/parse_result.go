@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Parse result statuses.
+const (
+	ParseResultComplete = "complete" // Parsed and awaiting review
+	ParseResultReviewed = "reviewed" // Checked and corrected by a reviewer
+)
+
+// ParseResult is the persisted outcome of a completed prescription parsing job.
+type ParseResult struct {
+	ID            string                 `json:"id"`                      // ID of the job that produced the result
+	Status        string                 `json:"status"`                  // ParseResultComplete or ParseResultReviewed
+	Prescription  Prescription           `json:"prescription"`            // Parsed and post-processed prescription
+	Validation    []ValidationIssue      `json:"validation,omitempty"`    // Validation issues found in the prescription
+	Blocked       bool                   `json:"blocked"`                 // Whether any validation issue blocks the result or another prescription of the document
+	Verification  []FieldChange          `json:"verification,omitempty"`  // Fields changed by re-checking flagged fields against the document
+	Origins       map[string]string      `json:"origins,omitempty"`       // Pipeline stage each field of the prescription was taken from, by field path
+	Prescriptions []DocumentPrescription `json:"prescriptions,omitempty"` // Every prescription of a document that held several; Prescription is the first
+	Attributes    map[string]string      `json:"attributes,omitempty"`    // Settings and provenance recorded for the job
+	StartedAt     time.Time              `json:"started_at"`              // When the job was created
+	CompletedAt   time.Time              `json:"completed_at"`            // When the job completed
+	Review        *Review                `json:"review,omitempty"`        // Reviewer's correction, if the result was reviewed
+}
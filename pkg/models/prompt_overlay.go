@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PromptOverlay is a version of the instructions appended to the system prompt for documents
+// identified as a form template, such as where a form puts its DAW box.
+type PromptOverlay struct {
+	ID           string    `json:"id"`
+	Template     string    `json:"template"`         // ID of the form template the instructions apply to
+	Version      int       `json:"version"`          // Version number, from 1, increasing with each change
+	Instructions string    `json:"instructions"`     // Instructions appended to the system prompt, empty when turned off
+	Author       string    `json:"author,omitempty"` // Who wrote this version
+	CreatedAt    time.Time `json:"created_at"`
+}
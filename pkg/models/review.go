@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Review is a reviewer's correction of a parse result.
+type Review struct {
+	Reviewer      string        `json:"reviewer"`                  // Identity of the reviewer
+	ReviewedAt    time.Time     `json:"reviewed_at"`               // When the review was submitted
+	Prescription  Prescription  `json:"prescription"`              // Corrected prescription
+	Changes       []FieldChange `json:"changes"`                   // Fields the reviewer changed, compared to the parser output
+	SampleImageID string        `json:"sample_image_id,omitempty"` // Image ID of the sample the correction was saved as, if any
+}
+
+// FieldChange is a field whose value differs between the parser output and the reviewed prescription.
+type FieldChange struct {
+	Field     string `json:"field"`     // Path to the field in dot notation (e.g. medications[0].drug_name)
+	Original  any    `json:"original"`  // Value produced by the parser, or null if it had none
+	Corrected any    `json:"corrected"` // Value set by the reviewer, or null if it was removed
+}
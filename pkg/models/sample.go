@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Sample is a stored sample prescription: a validated prescription and the uploaded image it was
+// read from. Samples are retrieved by embedding similarity to guide later parsing passes.
+type Sample struct {
+	ID           string           `json:"id"`
+	FileID       string           `json:"file_id"`                 // ID of the uploaded image at the parser backend
+	MIMEType     string           `json:"mime_type"`               // MIME type of the uploaded image
+	DocumentHash string           `json:"document_hash,omitempty"` // Blob store key of the original document, if stored
+	Prescription Prescription     `json:"prescription"`
+	Embeddings   []EmbeddingModel `json:"embeddings"` // Models the sample has an embedding from
+	CreatedAt    time.Time        `json:"created_at"`
+	SampleMetadata
+}
+
+// SampleMetadata describes where a sample comes from, so retrieval can be limited to samples
+// of the same form, customer or kind of drug.
+type SampleMetadata struct {
+	Template  string `json:"template,omitempty"`   // ID of the form template the document is filled in on
+	Tenant    string `json:"tenant,omitempty"`     // Customer the sample belongs to
+	DrugClass string `json:"drug_class,omitempty"` // Drug class of the prescribed medications, e.g. biologic
+}
+
+// SampleFilter selects a page of samples. Empty fields do not filter.
+type SampleFilter struct {
+	Drug     string         // Case-insensitive substring of any medication's drug name
+	Query    string         // Case-insensitive substring of the sample's prescription JSON
+	FileID   string         // Exact uploaded image ID
+	Metadata SampleMetadata // Exact metadata values
+	Limit    int            // Maximum number of samples to return
+	Offset   int            // Number of matching samples to skip
+}
+
+// SamplePage is one page of the samples matching a filter, newest first.
+type SamplePage struct {
+	Samples []Sample `json:"samples"`
+	Total   int      `json:"total"`  // Number of samples matching the filter across all pages
+	Limit   int      `json:"limit"`  // Page size used
+	Offset  int      `json:"offset"` // Number of matching samples skipped
+}
@@ -3,8 +3,11 @@ package models
 import "github.com/google/uuid"
 
 type SamplePrescription struct {
-	ID       uuid.UUID `json:"id"`
-	FileID   string    `json:"file_id"`
-	MIMEType string    `json:"mime_type"`
-	Content  string    `json:"content"`
+	ID           uuid.UUID `json:"id"`
+	FileID       string    `json:"file_id"`
+	MIMEType     string    `json:"mime_type"`
+	Content      string    `json:"content"`
+	DocumentHash string    `json:"document_hash,omitempty"` // Blob store key of the original document, if stored
+	Document     []byte    `json:"-"`                       // Original document, loaded from the blob store when available
+	Distance     float64   `json:"distance"`                // Distance of the sample's embedding from the query embedding
 }
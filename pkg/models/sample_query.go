@@ -0,0 +1,20 @@
+package models
+
+// DistanceMetric is how the distance between two embeddings is measured.
+type DistanceMetric string
+
+// Supported distance metrics.
+const (
+	DistanceL2     DistanceMetric = "l2"     // Euclidean distance
+	DistanceCosine DistanceMetric = "cosine" // One minus the cosine similarity
+)
+
+// SampleQuery selects the samples retrieved for a parsing pass.
+type SampleQuery struct {
+	Embedding   Embedding      // Embedding to find the nearest samples to
+	K           int            // Maximum number of samples to return
+	MaxDistance float64        // Samples farther than this are not returned; zero for no limit
+	Metric      DistanceMetric // Distance metric, DistanceL2 if empty
+	MMRLambda   float64        // Weight of relevance against diversity in (0, 1); zero or one does not diversify
+	Filter      SampleMetadata // Only samples with these metadata values; empty fields do not filter
+}
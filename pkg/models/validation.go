@@ -0,0 +1,33 @@
+package models
+
+// Severity describes how serious a validation issue is.
+type Severity string
+
+const (
+	// SeverityInfo marks an informational finding that needs no action.
+	SeverityInfo Severity = "info"
+
+	// SeverityWarning marks a finding a reviewer should verify against the document.
+	SeverityWarning Severity = "warning"
+
+	// SeverityError marks a violation that blocks the prescription from being dispensed until resolved.
+	SeverityError Severity = "error"
+)
+
+// ValidationIssue describes a problem or notable finding on a parsed prescription field.
+type ValidationIssue struct {
+	Field    string   `json:"field"`    // Path to the field in dot notation (e.g. medications[0].drug_name)
+	Code     string   `json:"code"`     // Machine-readable identifier of the check that raised the issue
+	Severity Severity `json:"severity"` // How serious the issue is
+	Message  string   `json:"message"`  // Human-readable description of the issue
+}
+
+// Blocking reports whether any of the issues has error severity and so blocks the result.
+func Blocking(issues []ValidationIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
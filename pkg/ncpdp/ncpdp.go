@@ -0,0 +1,220 @@
+// Package ncpdp exports parsed prescriptions as NCPDP SCRIPT 2017071 NewRx messages for
+// pharmacy management systems. Each NewRx carries a single prescribed medication, so a
+// prescription with several medications produces one message per medication.
+package ncpdp
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Namespace is the XML namespace of NCPDP SCRIPT messages.
+const Namespace = "http://www.ncpdp.org/schema/SCRIPT"
+
+// Version is the SCRIPT standard version of exported messages.
+const Version = "2017071"
+
+// Message is the root element of a SCRIPT message.
+type Message struct {
+	XMLName            xml.Name `xml:"Message"`
+	Xmlns              string   `xml:"xmlns,attr"`
+	DatatypesVersion   string   `xml:"DatatypesVersion,attr"`
+	TransportVersion   string   `xml:"TransportVersion,attr"`
+	TransactionDomain  string   `xml:"TransactionDomain,attr"`
+	TransactionVersion string   `xml:"TransactionVersion,attr"`
+	StructuresVersion  string   `xml:"StructuresVersion,attr"`
+	ECLVersion         string   `xml:"ECLVersion,attr"`
+	Header             Header   `xml:"Header"`
+	Body               Body     `xml:"Body"`
+}
+
+// Header identifies the sender, receiver and message.
+type Header struct {
+	To                    Party          `xml:"To"`
+	From                  Party          `xml:"From"`
+	MessageID             string         `xml:"MessageID"`
+	SentTime              string         `xml:"SentTime"`
+	SenderSoftware        SenderSoftware `xml:"SenderSoftware"`
+	PrescriberOrderNumber string         `xml:"PrescriberOrderNumber"`
+}
+
+// Party is a qualified sender or receiver identifier.
+type Party struct {
+	Qualifier string `xml:"Qualifier,attr"`
+	Value     string `xml:",chardata"`
+}
+
+// SenderSoftware describes the software that produced the message.
+type SenderSoftware struct {
+	Developer      string `xml:"SenderSoftwareDeveloper"`
+	Product        string `xml:"SenderSoftwareProduct"`
+	VersionRelease string `xml:"SenderSoftwareVersionRelease"`
+}
+
+// Body holds the transaction.
+type Body struct {
+	NewRx NewRx `xml:"NewRx"`
+}
+
+// NewRx is a new prescription transaction.
+type NewRx struct {
+	BenefitsCoordination []BenefitsCoordination `xml:"BenefitsCoordination"`
+	Patient              Patient                `xml:"Patient"`
+	Prescriber           Prescriber             `xml:"Prescriber"`
+	MedicationPrescribed MedicationPrescribed   `xml:"MedicationPrescribed"`
+}
+
+// BenefitsCoordination identifies a pharmacy benefit payer and the patient's coverage.
+type BenefitsCoordination struct {
+	PayerIdentification *PayerIdentification `xml:"PayerIdentification,omitempty"`
+	PayerName           string               `xml:"PayerName,omitempty"`
+	CardholderID        string               `xml:"CardholderID,omitempty"`
+	GroupID             string               `xml:"GroupID,omitempty"`
+}
+
+// PayerIdentification holds the BIN and PCN used to route pharmacy claims.
+type PayerIdentification struct {
+	ProcessorIdentificationNumber string `xml:"ProcessorIdentificationNumber,omitempty"`
+	IINNumber                     string `xml:"IINNumber,omitempty"`
+}
+
+// Patient is the patient the medication is prescribed for.
+type Patient struct {
+	HumanPatient HumanPatient `xml:"HumanPatient"`
+}
+
+// HumanPatient holds the patient's demographics.
+type HumanPatient struct {
+	Name                 Name                  `xml:"Name"`
+	Gender               string                `xml:"Gender"`
+	DateOfBirth          DateElement           `xml:"DateOfBirth"`
+	Address              *Address              `xml:"Address,omitempty"`
+	CommunicationNumbers *CommunicationNumbers `xml:"CommunicationNumbers,omitempty"`
+}
+
+// Name is a person's name.
+type Name struct {
+	LastName   string `xml:"LastName"`
+	FirstName  string `xml:"FirstName"`
+	MiddleName string `xml:"MiddleName,omitempty"`
+	Suffix     string `xml:"Suffix,omitempty"`
+	Prefix     string `xml:"Prefix,omitempty"`
+}
+
+// DateElement wraps a YYYY-MM-DD date.
+type DateElement struct {
+	Date string `xml:"Date"`
+}
+
+// Address is a postal address.
+type Address struct {
+	AddressLine1  string `xml:"AddressLine1"`
+	City          string `xml:"City"`
+	StateProvince string `xml:"StateProvince"`
+	PostalCode    string `xml:"PostalCode"`
+	CountryCode   string `xml:"CountryCode"`
+}
+
+// CommunicationNumbers holds telephone and fax numbers.
+type CommunicationNumbers struct {
+	PrimaryTelephone Telephone  `xml:"PrimaryTelephone"`
+	Fax              *Telephone `xml:"Fax,omitempty"`
+}
+
+// Telephone is a phone number with an optional extension.
+type Telephone struct {
+	Number    string `xml:"Number"`
+	Extension string `xml:"Extension,omitempty"`
+}
+
+// Prescriber is the prescribing clinician.
+type Prescriber struct {
+	NonVeterinarian NonVeterinarian `xml:"NonVeterinarian"`
+}
+
+// NonVeterinarian holds a human-medicine prescriber's identifiers, name and practice.
+type NonVeterinarian struct {
+	Identification       Identification        `xml:"Identification"`
+	PracticeLocation     *PracticeLocation     `xml:"PracticeLocation,omitempty"`
+	Name                 Name                  `xml:"Name"`
+	Address              *Address              `xml:"Address,omitempty"`
+	CommunicationNumbers *CommunicationNumbers `xml:"CommunicationNumbers,omitempty"`
+}
+
+// Identification holds a prescriber's license and registration numbers.
+type Identification struct {
+	StateLicenseNumber string `xml:"StateLicenseNumber,omitempty"`
+	DEANumber          string `xml:"DEANumber,omitempty"`
+	NPI                string `xml:"NPI,omitempty"`
+}
+
+// PracticeLocation names the prescriber's practice.
+type PracticeLocation struct {
+	BusinessName string `xml:"BusinessName"`
+}
+
+// MedicationPrescribed describes the prescribed medication and its directions.
+type MedicationPrescribed struct {
+	DrugDescription string      `xml:"DrugDescription"`
+	DrugCoded       *DrugCoded  `xml:"DrugCoded,omitempty"`
+	Quantity        Quantity    `xml:"Quantity"`
+	WrittenDate     DateElement `xml:"WrittenDate"`
+	Substitutions   string      `xml:"Substitutions"`
+	NumberOfRefills int         `xml:"NumberOfRefills"`
+	Diagnosis       *Diagnosis  `xml:"Diagnosis,omitempty"`
+	Note            string      `xml:"Note,omitempty"`
+	Sig             Sig         `xml:"Sig"`
+}
+
+// DrugCoded holds the drug's product code and DEA schedule.
+type DrugCoded struct {
+	ProductCode *ProductCode `xml:"ProductCode,omitempty"`
+	DEASchedule *Code        `xml:"DEASchedule,omitempty"`
+}
+
+// ProductCode is a qualified drug product code such as an NDC.
+type ProductCode struct {
+	Code      string `xml:"Code"`
+	Qualifier string `xml:"Qualifier"`
+}
+
+// Code is a coded value from an NCPDP code list.
+type Code struct {
+	Code string `xml:"Code"`
+}
+
+// Quantity is the amount to dispense.
+type Quantity struct {
+	Value                 string `xml:"Value"`
+	CodeListQualifier     string `xml:"CodeListQualifier"`
+	QuantityUnitOfMeasure Code   `xml:"QuantityUnitOfMeasure"`
+}
+
+// Diagnosis holds the primary and secondary diagnoses for the medication.
+type Diagnosis struct {
+	ClinicalInformationQualifier string         `xml:"ClinicalInformationQualifier"`
+	Primary                      DiagnosisCode  `xml:"Primary"`
+	Secondary                    *DiagnosisCode `xml:"Secondary,omitempty"`
+}
+
+// DiagnosisCode is a qualified diagnosis code.
+type DiagnosisCode struct {
+	Code        string `xml:"Code"`
+	Qualifier   string `xml:"Qualifier"`
+	Description string `xml:"Description,omitempty"`
+}
+
+// Sig holds the directions for use.
+type Sig struct {
+	SigText string `xml:"SigText"`
+}
+
+// Marshal encodes the message as an indented XML document with an XML declaration.
+func (m *Message) Marshal() ([]byte, error) {
+	out, err := xml.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ncpdp message: %w", err)
+	}
+
+	return append([]byte(xml.Header), out...), nil
+}
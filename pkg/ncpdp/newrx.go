@@ -0,0 +1,370 @@
+package ncpdp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/controlled"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/personname"
+)
+
+// Code list values used in exported messages.
+const (
+	qualifierPharmacy   = "P"   // Header party is a pharmacy
+	qualifierClinic     = "C"   // Header party is a clinic or prescriber system
+	productCodeNDC      = "ND"  // ProductCode qualifier for an NDC
+	quantityOriginal    = "38"  // Quantity code list qualifier for the original quantity
+	diagnosisICD10CM    = "ABF" // Diagnosis qualifier for ICD-10-CM
+	diagnosisPrescriber = "1"   // Clinical information was provided by the prescriber
+	unitUnspecified     = "C38046"
+)
+
+// deaScheduleCodes maps DEA schedules to NCI Thesaurus codes used by SCRIPT.
+var deaScheduleCodes = map[string]string{
+	"II":  "C48672",
+	"III": "C48675",
+	"IV":  "C48676",
+	"V":   "C48677",
+}
+
+// unitCodes maps dispensing units to NCI Thesaurus quantity unit of measure codes.
+var unitCodes = map[string]string{
+	"TABLET": "C48542", "TABLETS": "C48542", "TAB": "C48542", "TABS": "C48542",
+	"CAPSULE": "C48480", "CAPSULES": "C48480", "CAP": "C48480", "CAPS": "C48480",
+	"ML": "C28254", "MILLILITER": "C28254", "MILLILITERS": "C28254",
+	"G": "C48155", "GM": "C48155", "GRAM": "C48155", "GRAMS": "C48155",
+	"EACH": "C64933", "EA": "C64933",
+}
+
+// quantityPattern reads the leading amount and unit from a quantity such as "30 tablets".
+var quantityPattern = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?)\s*([A-Za-z]*)`)
+
+// Options identifies the parties and message for an exported NewRx.
+type Options struct {
+	From      string    // Identifier of the sending system
+	To        string    // NCPDP ID of the receiving pharmacy
+	MessageID string    // Unique message identifier, also used as the prescriber order number
+	SentTime  time.Time // Time the message is sent
+}
+
+// BuildNewRx builds and validates a NewRx message for the medication at the given index.
+// It returns a *ValidationError listing every problem if the prescription cannot be
+// expressed as a valid SCRIPT message.
+func BuildNewRx(rx models.Prescription, medication int, opts Options) (*Message, error) {
+	if medication < 0 || medication >= len(rx.Medications) {
+		return nil, fmt.Errorf("medication %d not found; prescription has %d medications", medication, len(rx.Medications))
+	}
+
+	b := &builder{}
+	msg := &Message{
+		Xmlns:              Namespace,
+		DatatypesVersion:   Version,
+		TransportVersion:   Version,
+		TransactionDomain:  "SCRIPT",
+		TransactionVersion: Version,
+		StructuresVersion:  Version,
+		ECLVersion:         Version,
+		Header: Header{
+			To:        Party{Qualifier: qualifierPharmacy, Value: opts.To},
+			From:      Party{Qualifier: qualifierClinic, Value: opts.From},
+			MessageID: opts.MessageID,
+			SentTime:  opts.SentTime.UTC().Format(time.RFC3339),
+			SenderSoftware: SenderSoftware{
+				Developer:      "prescription-parser",
+				Product:        "prescription-parser",
+				VersionRelease: "1",
+			},
+			PrescriberOrderNumber: opts.MessageID,
+		},
+		Body: Body{NewRx: NewRx{
+			BenefitsCoordination: b.benefits(rx.Patient.Insurance),
+			Patient:              Patient{HumanPatient: b.patient(rx.Patient)},
+			Prescriber:           Prescriber{NonVeterinarian: b.prescriber(rx.Prescriber)},
+			MedicationPrescribed: b.medication(rx, rx.Medications[medication]),
+		}},
+	}
+
+	problems := append(b.problems, msg.validate()...)
+	if len(problems) > 0 {
+		return msg, &ValidationError{Problems: problems}
+	}
+
+	return msg, nil
+}
+
+// builder maps prescription fields to SCRIPT elements and collects values that cannot be mapped.
+type builder struct {
+	problems []string
+}
+
+func (b *builder) problemf(format string, args ...any) {
+	b.problems = append(b.problems, fmt.Sprintf(format, args...))
+}
+
+func (b *builder) benefits(insurance []models.Insurance) []BenefitsCoordination {
+	var out []BenefitsCoordination
+	for _, ins := range insurance {
+		if ins.Provider == "" && ins.IdNumber == "" && ins.RxBin == "" {
+			continue
+		}
+
+		coverage := BenefitsCoordination{
+			PayerName:    ins.Provider,
+			CardholderID: ins.IdNumber,
+			GroupID:      ins.GroupNumber,
+		}
+		if ins.RxBin != "" || ins.Pcn != "" {
+			coverage.PayerIdentification = &PayerIdentification{
+				ProcessorIdentificationNumber: ins.Pcn,
+				IINNumber:                     ins.RxBin,
+			}
+		}
+		out = append(out, coverage)
+	}
+	return out
+}
+
+func (b *builder) patient(p models.Patient) HumanPatient {
+	patient := HumanPatient{
+		Name: Name{
+			LastName:   p.LastName,
+			FirstName:  p.FirstName,
+			MiddleName: p.MiddleName,
+		},
+		Gender:      gender(p.Sex),
+		DateOfBirth: DateElement{Date: p.Dob},
+		Address:     address(p.Address),
+	}
+
+	for _, phone := range p.PhoneNumbers {
+		if phone.Number != "" {
+			patient.CommunicationNumbers = &CommunicationNumbers{
+				PrimaryTelephone: Telephone{Number: digits(phone.Number), Extension: phone.Extension},
+			}
+			break
+		}
+	}
+
+	return patient
+}
+
+func (b *builder) prescriber(p models.Prescriber) NonVeterinarian {
+	name := personname.Split(p.Name)
+
+	prescriber := NonVeterinarian{
+		Identification: Identification{
+			StateLicenseNumber: p.StateLicense,
+			DEANumber:          strings.ToUpper(strings.ReplaceAll(p.Dea, " ", "")),
+			NPI:                digits(p.Npi),
+		},
+		Name: Name{
+			LastName:   name.Last,
+			FirstName:  name.First,
+			MiddleName: name.Middle,
+			Suffix:     name.Suffix,
+			Prefix:     name.Prefix,
+		},
+		Address: address(p.Office.Address),
+	}
+
+	if p.Office.Name != "" {
+		prescriber.PracticeLocation = &PracticeLocation{BusinessName: p.Office.Name}
+	}
+
+	if p.Office.Phone != "" {
+		number, ext, _ := strings.Cut(p.Office.Phone, " x")
+		prescriber.CommunicationNumbers = &CommunicationNumbers{
+			PrimaryTelephone: Telephone{Number: digits(number), Extension: ext},
+		}
+		if p.Office.Fax != "" {
+			number, ext, _ := strings.Cut(p.Office.Fax, " x")
+			prescriber.CommunicationNumbers.Fax = &Telephone{Number: digits(number), Extension: ext}
+		}
+	}
+
+	return prescriber
+}
+
+func (b *builder) medication(rx models.Prescription, med models.Medication) MedicationPrescribed {
+	out := MedicationPrescribed{
+		DrugDescription: drugDescription(med),
+		WrittenDate:     DateElement{Date: rx.DateWritten},
+		Substitutions:   substitutions(rx.PrescriberSignature.DawCode),
+		Sig:             Sig{SigText: med.SIG},
+	}
+
+	if out.WrittenDate.Date == "" {
+		out.WrittenDate.Date = rx.PrescriberSignature.Date
+	}
+	if out.Sig.SigText == "" {
+		out.Sig.SigText = med.AdministrationNotes
+	}
+	if med.Duration != "" {
+		out.Note = "Duration: " + med.Duration
+	}
+
+	var coded DrugCoded
+	if med.Ndc != "" {
+		// An unrecognized NDC is kept as written and reported by validation.
+		ndc, _ := NormalizeNDC(med.Ndc)
+		coded.ProductCode = &ProductCode{Code: ndc, Qualifier: productCodeNDC}
+	}
+	if code, ok := deaScheduleCodes[med.DeaSchedule]; ok {
+		coded.DEASchedule = &Code{Code: code}
+	}
+	if coded.ProductCode != nil || coded.DEASchedule != nil {
+		out.DrugCoded = &coded
+	}
+
+	out.Quantity = b.quantity(med)
+
+	refills, unlimited, ok := controlled.ParseRefills(med.Refills)
+	switch {
+	case !ok:
+		b.problemf("refills %q could not be read as a number", med.Refills)
+	case unlimited:
+		b.problemf("refills %q cannot be expressed as a number of refills", med.Refills)
+	default:
+		out.NumberOfRefills = refills
+	}
+
+	primary := rx.Diagnosis.PrimaryDiagnosis
+	if primary.Icd10Code != "" {
+		out.Diagnosis = &Diagnosis{
+			ClinicalInformationQualifier: diagnosisPrescriber,
+			Primary:                      diagnosisCode(primary),
+		}
+		for _, additional := range rx.Diagnosis.AdditionalDiagnoses {
+			if additional.Icd10Code != "" {
+				secondary := diagnosisCode(additional)
+				out.Diagnosis.Secondary = &secondary
+				break
+			}
+		}
+	}
+
+	return out
+}
+
+// quantity reads the amount to dispense and its unit from the quantity, falling back to the dose form for the unit.
+func (b *builder) quantity(med models.Medication) Quantity {
+	q := Quantity{CodeListQualifier: quantityOriginal, QuantityUnitOfMeasure: Code{Code: unitUnspecified}}
+
+	m := quantityPattern.FindStringSubmatch(med.Quantity)
+	if m == nil {
+		b.problemf("quantity %q does not start with a number", med.Quantity)
+		return q
+	}
+
+	q.Value = m[1]
+	for _, unit := range []string{m[2], med.Form} {
+		if code, ok := unitCodes[strings.ToUpper(strings.TrimSpace(unit))]; ok {
+			q.QuantityUnitOfMeasure.Code = code
+			break
+		}
+	}
+
+	return q
+}
+
+// drugDescription combines the drug name, strength and form, e.g. "Humira 40 mg/0.4 mL pen".
+func drugDescription(med models.Medication) string {
+	parts := []string{med.DrugName}
+	if med.Strength != "" && !strings.Contains(med.DrugName, med.Strength) {
+		parts = append(parts, med.Strength)
+	}
+	if med.Form != "" && !strings.Contains(strings.ToLower(med.DrugName), strings.ToLower(med.Form)) {
+		parts = append(parts, med.Form)
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+func diagnosisCode(d models.Diagnosis) DiagnosisCode {
+	return DiagnosisCode{
+		Code:        strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(d.Icd10Code), ".", "")),
+		Qualifier:   diagnosisICD10CM,
+		Description: d.Description,
+	}
+}
+
+func address(a models.Address) *Address {
+	if a == (models.Address{}) {
+		return nil
+	}
+	return &Address{
+		AddressLine1:  a.Street,
+		City:          a.City,
+		StateProvince: strings.ToUpper(a.State),
+		PostalCode:    digits(a.Zip),
+		CountryCode:   "US",
+	}
+}
+
+// gender maps a parsed sex to the SCRIPT gender code.
+func gender(sex string) string {
+	switch strings.ToUpper(strings.TrimSpace(sex)) {
+	case "M", "MALE":
+		return "M"
+	case "F", "FEMALE":
+		return "F"
+	default:
+		return "U"
+	}
+}
+
+// substitutions returns the DAW code digit, defaulting to 0 (substitution allowed).
+func substitutions(daw string) string {
+	daw = strings.TrimSpace(daw)
+	if daw == "" {
+		return "0"
+	}
+	return daw[:1]
+}
+
+// NormalizeNDC converts an NDC in 4-4-2, 5-3-2, 5-4-1 or 5-4-2 hyphenated form, or 11 plain
+// digits, to the 11-digit 5-4-2 form. It reports false if the value is not a valid NDC.
+func NormalizeNDC(ndc string) (string, bool) {
+	segments := strings.Split(strings.TrimSpace(ndc), "-")
+	for _, segment := range segments {
+		if segment == "" || digits(segment) != segment {
+			return ndc, false
+		}
+	}
+
+	switch {
+	case len(segments) == 1 && len(segments[0]) == 11:
+		return segments[0], true
+	case len(segments) != 3:
+		return ndc, false
+	}
+
+	widths := []int{5, 4, 2}
+	var b strings.Builder
+	for i, segment := range segments {
+		if len(segment) > widths[i] {
+			return ndc, false
+		}
+		b.WriteString(strings.Repeat("0", widths[i]-len(segment)))
+		b.WriteString(segment)
+	}
+
+	if lengths := len(segments[0]) + len(segments[1]) + len(segments[2]); lengths != 10 && lengths != 11 {
+		return ndc, false
+	}
+
+	return b.String(), true
+}
+
+// digits returns only the digits of s.
+func digits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
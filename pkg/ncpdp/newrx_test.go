@@ -0,0 +1,184 @@
+package ncpdp
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func testPrescription() models.Prescription {
+	return models.Prescription{
+		DateWritten: "2025-03-14",
+		Patient: models.Patient{
+			FirstName: "Ann",
+			LastName:  "Lee",
+			Dob:       "1980-02-01",
+			Sex:       "Female",
+			Address:   models.Address{Street: "12 MAPLE AVE APT 3", City: "BROOKLYN", State: "NY", Zip: "11201-1234"},
+			PhoneNumbers: []models.PhoneNumber{
+				{Label: "Mobile", Number: "7038015897"},
+			},
+			Insurance: []models.Insurance{
+				{Provider: "Acme Health", IdNumber: "XYZ123", GroupNumber: "G1", RxBin: "610014", Pcn: "MEDDPRIME"},
+			},
+		},
+		Prescriber: models.Prescriber{
+			Name: "Dr. Jane Brown, MD",
+			Npi:  "1234567893",
+			Dea:  "AB1234563",
+			Office: models.PrescriberOffice{
+				Name:    "Brown Dermatology",
+				Address: models.Address{Street: "1 HOSPITAL PLZ", City: "NEW YORK", State: "NY", Zip: "10001"},
+				Phone:   "2125550100 x12",
+				Fax:     "2125550101",
+			},
+		},
+		Diagnosis: models.PatientDiagnosis{
+			PrimaryDiagnosis:    models.Diagnosis{Description: "Psoriatic arthritis", Icd10Code: "L40.50"},
+			AdditionalDiagnoses: []models.Diagnosis{{Description: "Psoriasis", Icd10Code: "L40.0"}},
+		},
+		Medications: []models.Medication{
+			{DrugName: "Humira", Ndc: "0074-4339-02", Form: "pen", Strength: "40 mg/0.4 mL", SIG: "Inject 40 mg every other week", Quantity: "2 pens", Refills: "5", Duration: "12 weeks"},
+			{DrugName: "Oxycodone", Form: "tablet", SIG: "1 tab q6h prn", Quantity: "20", Refills: "PRN", DeaSchedule: "II"},
+		},
+		PrescriberSignature: models.SignatureInfo{DawCode: "1"},
+	}
+}
+
+func testOptions() Options {
+	return Options{
+		From:      "prescription-parser",
+		To:        "1234567",
+		MessageID: "7f4c0a3e9d8b4c1a",
+		SentTime:  time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestBuildNewRx(t *testing.T) {
+	msg, err := BuildNewRx(testPrescription(), 0, testOptions())
+	if err != nil {
+		t.Fatalf("Failed to build NewRx: %v", err)
+	}
+
+	out, err := msg.Marshal()
+	if err != nil {
+		t.Fatalf("Failed to marshal NewRx: %v", err)
+	}
+	xml := string(out)
+
+	for _, want := range []string{
+		`<Message xmlns="http://www.ncpdp.org/schema/SCRIPT" DatatypesVersion="2017071"`,
+		`<To Qualifier="P">1234567</To>`,
+		`<SentTime>2025-03-14T12:00:00Z</SentTime>`,
+		`<IINNumber>610014</IINNumber>`,
+		`<CardholderID>XYZ123</CardholderID>`,
+		`<Gender>F</Gender>`,
+		`<PostalCode>112011234</PostalCode>`,
+		`<DEANumber>AB1234563</DEANumber>`,
+		`<NPI>1234567893</NPI>`,
+		`<LastName>Brown</LastName>`,
+		`<Extension>12</Extension>`,
+		`<DrugDescription>Humira 40 mg/0.4 mL pen</DrugDescription>`,
+		`<Code>00074433902</Code>`,
+		`<Value>2</Value>`,
+		`<Substitutions>1</Substitutions>`,
+		`<NumberOfRefills>5</NumberOfRefills>`,
+		`<Code>L4050</Code>`,
+		`<SigText>Inject 40 mg every other week</SigText>`,
+	} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("Expected message to contain %s", want)
+		}
+	}
+
+	if strings.Index(xml, "<BenefitsCoordination>") > strings.Index(xml, "<Patient>") {
+		t.Errorf("Expected BenefitsCoordination before Patient")
+	}
+}
+
+func TestBuildNewRxInvalid(t *testing.T) {
+	rx := testPrescription()
+	rx.Prescriber.Npi = ""
+	rx.Patient.Dob = "02/01/1980"
+
+	_, err := BuildNewRx(rx, 1, testOptions())
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+
+	problems := strings.Join(validationErr.Problems, "\n")
+	for _, want := range []string{"refills \"PRN\"", "Patient/DateOfBirth", "Prescriber/Identification/NPI"} {
+		if !strings.Contains(problems, want) {
+			t.Errorf("Expected a problem mentioning %s, got:\n%s", want, problems)
+		}
+	}
+
+	if _, err := BuildNewRx(rx, 2, testOptions()); err == nil {
+		t.Errorf("Expected error for missing medication")
+	}
+}
+
+func TestNormalizeNDC(t *testing.T) {
+	tests := []struct {
+		ndc    string
+		want   string
+		wantOK bool
+	}{
+		{ndc: "0074-4339-02", want: "00074433902", wantOK: true},
+		{ndc: "12345-678-90", want: "12345067890", wantOK: true},
+		{ndc: "12345-6789-0", want: "12345678900", wantOK: true},
+		{ndc: "12345-6789-01", want: "12345678901", wantOK: true},
+		{ndc: "12345678901", want: "12345678901", wantOK: true},
+		{ndc: "1234567890", want: "1234567890", wantOK: false},
+		{ndc: "12-34-56", want: "12-34-56", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ndc, func(t *testing.T) {
+			got, ok := NormalizeNDC(tt.ndc)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizeNDC(%q) = %q, %v, want %q, %v", tt.ndc, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestNewRxSchema validates an exported message against the NCPDP SCRIPT 2017071 XSD with xmllint.
+// The schema is licensed by NCPDP and not distributed with this repository, so the test only
+// runs when NCPDP_SCRIPT_XSD points at the schema's transport.xsd.
+func TestNewRxSchema(t *testing.T) {
+	xsd := os.Getenv("NCPDP_SCRIPT_XSD")
+	if xsd == "" {
+		t.Skip("NCPDP_SCRIPT_XSD is not set")
+	}
+	xmllint, err := exec.LookPath("xmllint")
+	if err != nil {
+		t.Skip("xmllint is not installed")
+	}
+
+	msg, err := BuildNewRx(testPrescription(), 0, testOptions())
+	if err != nil {
+		t.Fatalf("Failed to build NewRx: %v", err)
+	}
+	out, err := msg.Marshal()
+	if err != nil {
+		t.Fatalf("Failed to marshal NewRx: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "newrx.xml")
+	if err := os.WriteFile(path, out, 0o600); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+
+	if output, err := exec.Command(xmllint, "--noout", "--schema", xsd, path).CombinedOutput(); err != nil {
+		t.Errorf("Message failed schema validation: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,192 @@
+package ncpdp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/controlled"
+)
+
+// ValidationError lists the reasons a message does not conform to the SCRIPT schema.
+type ValidationError struct {
+	Problems []string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return "invalid ncpdp message: " + strings.Join(e.Problems, "; ")
+}
+
+var (
+	datePattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	decimalPattern  = regexp.MustCompile(`^\d{1,11}(\.\d{1,5})?$`)
+	statePattern    = regexp.MustCompile(`^[A-Z]{2}$`)
+	postalPattern   = regexp.MustCompile(`^(\d{5}|\d{9})$`)
+	npiPattern      = regexp.MustCompile(`^\d{10}$`)
+	ndcPattern      = regexp.MustCompile(`^\d{11}$`)
+	numberPattern   = regexp.MustCompile(`^\d{10,15}$`)
+	binPattern      = regexp.MustCompile(`^\d{6}$`)
+	diagnosisFormat = regexp.MustCompile(`^[A-Z][0-9][0-9A-Z]{1,5}$`)
+)
+
+// validate checks the message against the cardinality, length and format constraints of the
+// SCRIPT 2017071 schema for the elements this package produces.
+func (m *Message) validate() []string {
+	v := &validator{}
+
+	h := m.Header
+	v.required("Header/To", h.To.Value, 35)
+	v.required("Header/From", h.From.Value, 35)
+	v.required("Header/MessageID", h.MessageID, 35)
+	v.required("Header/PrescriberOrderNumber", h.PrescriberOrderNumber, 35)
+
+	for i, coverage := range m.Body.NewRx.BenefitsCoordination {
+		path := fmt.Sprintf("BenefitsCoordination[%d]", i)
+		v.optional(path+"/PayerName", coverage.PayerName, 70)
+		v.optional(path+"/CardholderID", coverage.CardholderID, 35)
+		v.optional(path+"/GroupID", coverage.GroupID, 35)
+		if id := coverage.PayerIdentification; id != nil {
+			v.optional(path+"/PayerIdentification/ProcessorIdentificationNumber", id.ProcessorIdentificationNumber, 10)
+			if id.IINNumber != "" && !binPattern.MatchString(id.IINNumber) {
+				v.problemf("%s/PayerIdentification/IINNumber %q must be a 6-digit BIN", path, id.IINNumber)
+			}
+		}
+	}
+
+	p := m.Body.NewRx.Patient.HumanPatient
+	v.name("Patient", p.Name)
+	if p.Gender != "M" && p.Gender != "F" && p.Gender != "U" {
+		v.problemf("Patient/Gender %q must be M, F or U", p.Gender)
+	}
+	v.date("Patient/DateOfBirth", p.DateOfBirth.Date)
+	v.address("Patient/Address", p.Address)
+	v.communication("Patient/CommunicationNumbers", p.CommunicationNumbers)
+
+	pr := m.Body.NewRx.Prescriber.NonVeterinarian
+	if !npiPattern.MatchString(pr.Identification.NPI) {
+		v.problemf("Prescriber/Identification/NPI %q must be 10 digits", pr.Identification.NPI)
+	}
+	if pr.Identification.DEANumber != "" && !controlled.ValidDea(pr.Identification.DEANumber) {
+		v.problemf("Prescriber/Identification/DEANumber %q is not a valid DEA number", pr.Identification.DEANumber)
+	}
+	v.optional("Prescriber/Identification/StateLicenseNumber", pr.Identification.StateLicenseNumber, 35)
+	if pr.PracticeLocation != nil {
+		v.required("Prescriber/PracticeLocation/BusinessName", pr.PracticeLocation.BusinessName, 70)
+	}
+	v.name("Prescriber", pr.Name)
+	if pr.Address == nil {
+		v.problemf("Prescriber/Address is required")
+	}
+	v.address("Prescriber/Address", pr.Address)
+	if pr.CommunicationNumbers == nil {
+		v.problemf("Prescriber/CommunicationNumbers is required")
+	}
+	v.communication("Prescriber/CommunicationNumbers", pr.CommunicationNumbers)
+
+	med := m.Body.NewRx.MedicationPrescribed
+	v.required("MedicationPrescribed/DrugDescription", med.DrugDescription, 105)
+	if med.DrugCoded != nil && med.DrugCoded.ProductCode != nil && !ndcPattern.MatchString(med.DrugCoded.ProductCode.Code) {
+		v.problemf("MedicationPrescribed/DrugCoded/ProductCode %q must be an 11-digit NDC", med.DrugCoded.ProductCode.Code)
+	}
+	if !decimalPattern.MatchString(med.Quantity.Value) {
+		v.problemf("MedicationPrescribed/Quantity/Value %q must be a decimal number", med.Quantity.Value)
+	}
+	v.date("MedicationPrescribed/WrittenDate", med.WrittenDate.Date)
+	if len(med.Substitutions) != 1 || med.Substitutions[0] < '0' || med.Substitutions[0] > '9' {
+		v.problemf("MedicationPrescribed/Substitutions %q must be a DAW code from 0 to 9", med.Substitutions)
+	}
+	if med.NumberOfRefills < 0 || med.NumberOfRefills > 99 {
+		v.problemf("MedicationPrescribed/NumberOfRefills %d must be between 0 and 99", med.NumberOfRefills)
+	}
+	if d := med.Diagnosis; d != nil {
+		v.diagnosis("MedicationPrescribed/Diagnosis/Primary", d.Primary)
+		if d.Secondary != nil {
+			v.diagnosis("MedicationPrescribed/Diagnosis/Secondary", *d.Secondary)
+		}
+	}
+	v.optional("MedicationPrescribed/Note", med.Note, 210)
+	v.required("MedicationPrescribed/Sig/SigText", med.Sig.SigText, 1000)
+
+	return v.problems
+}
+
+// validator accumulates schema violations.
+type validator struct {
+	problems []string
+}
+
+func (v *validator) problemf(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(path, value string, maxLen int) {
+	if strings.TrimSpace(value) == "" {
+		v.problemf("%s is required", path)
+		return
+	}
+	v.optional(path, value, maxLen)
+}
+
+func (v *validator) optional(path, value string, maxLen int) {
+	if len([]rune(value)) > maxLen {
+		v.problemf("%s must be at most %d characters", path, maxLen)
+	}
+}
+
+func (v *validator) date(path, value string) {
+	if !datePattern.MatchString(value) {
+		v.problemf("%s %q must be a YYYY-MM-DD date", path, value)
+		return
+	}
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		v.problemf("%s %q is not a valid date", path, value)
+	}
+}
+
+func (v *validator) name(path string, n Name) {
+	v.required(path+"/Name/LastName", n.LastName, 35)
+	v.required(path+"/Name/FirstName", n.FirstName, 35)
+	v.optional(path+"/Name/MiddleName", n.MiddleName, 35)
+	v.optional(path+"/Name/Suffix", n.Suffix, 10)
+	v.optional(path+"/Name/Prefix", n.Prefix, 10)
+}
+
+func (v *validator) address(path string, a *Address) {
+	if a == nil {
+		return
+	}
+	v.required(path+"/AddressLine1", a.AddressLine1, 40)
+	v.required(path+"/City", a.City, 35)
+	if !statePattern.MatchString(a.StateProvince) {
+		v.problemf("%s/StateProvince %q must be a two-letter state code", path, a.StateProvince)
+	}
+	if !postalPattern.MatchString(a.PostalCode) {
+		v.problemf("%s/PostalCode %q must be a 5 or 9 digit ZIP code", path, a.PostalCode)
+	}
+}
+
+func (v *validator) communication(path string, c *CommunicationNumbers) {
+	if c == nil {
+		return
+	}
+	v.telephone(path+"/PrimaryTelephone", c.PrimaryTelephone)
+	if c.Fax != nil {
+		v.telephone(path+"/Fax", *c.Fax)
+	}
+}
+
+func (v *validator) telephone(path string, t Telephone) {
+	if !numberPattern.MatchString(t.Number) {
+		v.problemf("%s/Number %q must be a 10 to 15 digit phone number", path, t.Number)
+	}
+	v.optional(path+"/Extension", t.Extension, 10)
+}
+
+func (v *validator) diagnosis(path string, d DiagnosisCode) {
+	if !diagnosisFormat.MatchString(d.Code) {
+		v.problemf("%s/Code %q must be an ICD-10-CM code", path, d.Code)
+	}
+	v.optional(path+"/Description", d.Description, 254)
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/experiments"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/prompts"
+	"go.uber.org/zap"
+)
+
+// experimentParser splits parse jobs between the variants of an experiment. Variants on another
+// backend than the configured one are parsed by a parser of that backend; everything but parsing
+// is handled by the parser of the configured backend.
+type experimentParser struct {
+	Parser
+	experiment experiments.Experiment
+	backends   map[string]Parser // Parsers by backend, including the configured one
+	logger     *zap.Logger
+	roll       func() int // Returns a roll in [0, 100) for assigning variants
+}
+
+// withExperiment returns parser wrapped to run the enabled experiment of the configured
+// experiment file, or parser itself when there is no experiment file or no enabled experiment.
+// The variants' prompt versions, sample retrieval modes and pipelines are validated, and a parser
+// is created for each other backend they use.
+func withExperiment(cfg config.Config, ds datastore.Datastore, logger *zap.Logger, parser Parser) (Parser, error) {
+	if cfg.ExperimentsFile == "" {
+		return parser, nil
+	}
+
+	registry, err := experiments.Load(cfg.ExperimentsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load experiment file: %w", err)
+	}
+
+	experiment, ok := registry.Active()
+	if !ok {
+		logger.Info("no experiment enabled", zap.String("experiments_file", cfg.ExperimentsFile))
+		return parser, nil
+	}
+
+	promptRegistry, err := prompts.Load(cfg.PromptsDir, cfg.PromptVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prompts: %w", err)
+	}
+
+	backends := map[string]Parser{cfg.ParserBackend: parser}
+	for _, variant := range experiment.Variants {
+		if _, ok := promptRegistry.Get(variant.PromptVersion); !ok {
+			return nil, fmt.Errorf("experiment %q variant %q: %w: %s", experiment.ID, variant.Name, ErrUnknownPromptVersion, variant.PromptVersion)
+		}
+		if err := checkRetrieval(variant.SampleRetrieval); err != nil {
+			return nil, fmt.Errorf("experiment %q variant %q: %w", experiment.ID, variant.Name, err)
+		}
+		if variant.Pipeline != "" {
+			if _, err := ParseRequestPipeline(variant.Pipeline); err != nil {
+				return nil, fmt.Errorf("experiment %q variant %q: %w", experiment.ID, variant.Name, err)
+			}
+		}
+
+		if variant.Backend == "" || backends[variant.Backend] != nil {
+			continue
+		}
+		backendCfg := cfg
+		backendCfg.ParserBackend = variant.Backend
+		backendParser, err := newBackendParser(backendCfg, ds, logger)
+		if err != nil {
+			return nil, fmt.Errorf("experiment %q variant %q: %w", experiment.ID, variant.Name, err)
+		}
+		backends[variant.Backend] = backendParser
+	}
+
+	logger.Info("running experiment", zap.String("experiment", experiment.ID), zap.Int("variant_count", len(experiment.Variants)))
+
+	return &experimentParser{
+		Parser:     parser,
+		experiment: experiment,
+		backends:   backends,
+		logger:     logger,
+		roll:       func() int { return rand.IntN(100) },
+	}, nil
+}
+
+// ParseImage assigns the job a variant of the experiment and parses it with the variant's
+// settings, recording the experiment and variant on the job. A request that selects a prompt
+// version or pipeline is parsed as requested and left out of the experiment.
+func (p *experimentParser) ParseImage(ctx context.Context, fileName string, file io.Reader, opts models.ParseOptions) (string, error) {
+	if opts.PromptVersion != "" || opts.Pipeline != "" {
+		return p.Parser.ParseImage(ctx, fileName, file, opts)
+	}
+
+	variant := p.experiment.Assign(p.roll())
+
+	opts.PromptVersion = variant.PromptVersion
+	opts.SampleRetrieval = variant.SampleRetrieval
+	opts.Pipeline = variant.Pipeline
+	opts.Attributes = maps.Clone(opts.Attributes)
+	if opts.Attributes == nil {
+		opts.Attributes = make(map[string]string, 2)
+	}
+	opts.Attributes[jobs.AttributeExperiment] = p.experiment.ID
+	opts.Attributes[jobs.AttributeVariant] = variant.Name
+
+	parser := p.Parser
+	if variant.Backend != "" {
+		parser = p.backends[variant.Backend]
+	}
+
+	p.logger.Debug("assigned experiment variant", zap.String("file_name", fileName), zap.String("experiment", p.experiment.ID), zap.String("variant", variant.Name))
+	return parser.ParseImage(ctx, fileName, file, opts)
+}
@@ -10,8 +10,9 @@ import (
 
 	"github.com/csotherden/prescription-parser/pkg/config"
 	"github.com/csotherden/prescription-parser/pkg/datastore"
-	"github.com/csotherden/prescription-parser/pkg/jobs"
 	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/prompts"
+	"github.com/csotherden/prescription-parser/pkg/templates"
 	"go.uber.org/zap"
 	"google.golang.org/genai"
 )
@@ -20,8 +21,7 @@ import (
 // It leverages Gemini's multimodal capabilities to process prescription images
 // and extract structured data from them.
 type GeminiParser struct {
-	ds     datastore.Datastore
-	logger *zap.Logger
+	*pipeline
 	client *genai.Client
 }
 
@@ -37,209 +37,187 @@ func NewGeminiParser(cfg config.Config, ds datastore.Datastore, logger *zap.Logg
 		return nil, fmt.Errorf("failed to initialize gemini client: %w", err)
 	}
 
+	pipeline, err := newPipeline(cfg, ds, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	return &GeminiParser{
-		ds:     ds,
-		logger: logger,
-		client: client,
+		pipeline: pipeline,
+		client:   client,
 	}, nil
 }
 
 // ParseImage handles parsing a prescription image using Gemini multimodal API.
 // It creates an asynchronous job to process the image and returns the job ID.
-// The actual processing is done in a separate goroutine.
-func (p *GeminiParser) ParseImage(ctx context.Context, fileName string, file io.Reader) (string, error) {
-	// Create a job for asynchronous processing
-	jobID := jobs.GlobalTracker.CreateJob(
-		JobTypeParsePrescription,
-		fmt.Sprintf("Processing image: %s", fileName),
-	)
-
-	p.logger.Info("starting image parsing", zap.String("job_id", jobID), zap.String("file_name", fileName))
-
-	go p.parseImageProcess(context.Background(), jobID, fileName, file)
-
-	return jobID, nil
+// The actual processing is done by the pipeline in a separate goroutine.
+func (p *GeminiParser) ParseImage(ctx context.Context, fileName string, file io.Reader, opts models.ParseOptions) (string, error) {
+	return p.start(p, "Gemini", fileName, file, opts)
 }
 
-// parseImageProcess processes the image asynchronously.
-// It reads the file contents, validates the file type, performs parsing passes,
-// and updates the job status throughout the process.
-func (p *GeminiParser) parseImageProcess(ctx context.Context, jobID, fileName string, file io.Reader) {
-	fileExt := strings.ToLower(filepath.Ext(fileName))
-	var contentType string
-	switch fileExt {
-	case ".pdf":
-		contentType = "application/pdf"
-	default:
-		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusFailed, fmt.Errorf("unsupported file type. file must be PDF not %s", fileExt), nil)
-		return
-	}
-
-	fileBytes, err := io.ReadAll(file)
-	if err != nil {
-		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusFailed, fmt.Errorf("failed to read file contents: %w", err), nil)
-		return
-	}
-
-	// Update job status to processing
-	jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusProcessing, nil, nil)
-
-	// Initial parsing pass
-	rx, err := p.firstParsingPass(ctx, contentType, fileBytes)
-	if err != nil {
-		p.logger.Error("failed in first parsing pass", zap.String("job_id", jobID), zap.Error(err))
-		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusFailed, fmt.Errorf("failed in first parsing pass: %w", err), nil)
-		return
-	}
-
-	p.logger.Info("first parsing pass completed", zap.String("job_id", jobID), zap.String("file_name", fileName))
-
-	// Get embedding for the parsed prescription
-	embedding, err := p.GetEmbedding(ctx, rx)
-	if err != nil {
-		p.logger.Error("failed to get embedding", zap.String("job_id", jobID), zap.String("file_name", fileName), zap.Error(err))
-		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, rx)
-		return
-	}
-
-	// Get similar samples
-	samples, err := p.ds.GetSamples(ctx, embedding)
-	if err != nil {
-		p.logger.Error("failed to get samples", zap.String("job_id", jobID), zap.String("file_name", fileName), zap.Error(err))
-		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, rx)
-		return
-	}
-
-	p.logger.Info("sample images loaded", zap.String("job_id", jobID), zap.String("file_name", fileName), zap.Int("sample_count", len(samples)))
-
-	// Second parsing pass with examples
-	if len(samples) > 0 {
-		secondPassRx, err := p.secondParsingPass(ctx, contentType, fileBytes, samples, rx)
-		if err != nil {
-			p.logger.Error("failed in second parsing pass", zap.String("job_id", jobID), zap.Error(err))
-			jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, rx)
-			return
-		}
-		rx = secondPassRx
-	}
-
-	p.logger.Info("successfully processed image", zap.String("job_id", jobID), zap.String("file_name", fileName))
-	jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, rx)
+// prepare does nothing, since documents are sent to Gemini inline.
+func (p *GeminiParser) prepare(ctx context.Context, doc *document) (func(), error) {
+	return func() {}, nil
 }
 
-// firstParsingPass performs the initial parsing of the prescription.
+// parse performs the initial parsing of the prescription.
 // It sends the prescription image to Gemini API with system and user prompts
 // to extract structured data from the image.
-func (p *GeminiParser) firstParsingPass(ctx context.Context, contentType string, fileBytes []byte) (models.Prescription, error) {
-	cfg := &genai.GenerateContentConfig{
-		SystemInstruction: genai.NewContentFromText(systemPrompt, genai.RoleUser),
-		ResponseMIMEType:  "application/json",
-		ResponseSchema:    &geminiSchema,
-	}
-
-	userParts := []*genai.Part{
-		{
-			InlineData: &genai.Blob{
-				MIMEType: contentType,
-				Data:     fileBytes,
-			},
-		},
-		genai.NewPartFromText(parsePrompt),
-	}
-
+func (p *GeminiParser) parse(ctx context.Context, doc *document, promptSet prompts.Set) (models.Prescription, error) {
 	contents := []*genai.Content{
-		genai.NewContentFromParts(userParts, genai.RoleUser),
+		genai.NewContentFromParts(geminiDocumentParts(doc, promptSet.Parse), genai.RoleUser),
 	}
 
 	resp, err := p.client.Models.GenerateContent(
 		ctx,
 		"gemini-2.5-flash-preview-05-20",
 		contents,
-		cfg,
+		geminiConfig(promptSet),
 	)
 	if err != nil {
 		return models.Prescription{}, fmt.Errorf("failed to process image: %w", err)
 	}
 
-	var rx models.Prescription
-	err = json.Unmarshal([]byte(resp.Text()), &rx)
-	if err != nil {
-		return models.Prescription{}, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return rx, nil
+	return geminiPrescription(resp)
 }
 
-// secondParsingPass performs a review with example context.
+// reparse performs a review with example context.
 // It uses similar prescription samples to refine the initial parsing results,
 // potentially improving accuracy by learning from precedents.
-func (p *GeminiParser) secondParsingPass(ctx context.Context, contentType string, fileBytes []byte, samples []models.SamplePrescription, firstPassRx models.Prescription) (models.Prescription, error) {
+func (p *GeminiParser) reparse(ctx context.Context, doc *document, promptSet prompts.Set, samples []models.SamplePrescription) (models.Prescription, error) {
 	history := []*genai.Content{}
 
 	for _, sample := range samples {
 		sampleParts := []*genai.Part{
-			genai.NewPartFromURI(sample.FileID, sample.MIMEType),
-			genai.NewPartFromText(reviewPrompt),
+			geminiSamplePart(sample),
+			genai.NewPartFromText(promptSet.Review),
 		}
 
 		history = append(history, genai.NewContentFromParts(sampleParts, genai.RoleUser))
 		history = append(history, genai.NewContentFromText(sample.Content, genai.RoleModel))
 	}
 
-	cfg := &genai.GenerateContentConfig{
-		SystemInstruction: genai.NewContentFromText(systemPrompt, genai.RoleUser),
-		ResponseMIMEType:  "application/json",
-		ResponseSchema:    &geminiSchema,
-	}
-
 	chat, err := p.client.Chats.Create(
 		ctx,
 		"gemini-2.5-flash-preview-05-20",
-		cfg,
+		geminiConfig(promptSet),
 		history,
 	)
 	if err != nil {
-		return firstPassRx, fmt.Errorf("failed to initiate chat session: %w", err)
+		return models.Prescription{}, fmt.Errorf("failed to initiate chat session: %w", err)
+	}
+
+	parts := geminiDocumentParts(doc, promptSet.Parse)
+	resp, err := chat.SendMessage(ctx, *parts[0], *parts[1])
+	if err != nil {
+		return models.Prescription{}, fmt.Errorf("failed to run second pass: %w", err)
 	}
 
-	resp, err := chat.SendMessage(
+	return geminiPrescription(resp)
+}
+
+// check asks the model to correct a parse result against the document, following prompt.
+func (p *GeminiParser) check(ctx context.Context, doc *document, promptSet prompts.Set, prompt string, rx models.Prescription) (models.Prescription, error) {
+	rxJSON, err := json.Marshal(rx)
+	if err != nil {
+		return models.Prescription{}, fmt.Errorf("failed to marshal prescription: %w", err)
+	}
+
+	contents := []*genai.Content{
+		genai.NewContentFromParts(geminiDocumentParts(doc, prompt+string(rxJSON)), genai.RoleUser),
+	}
+
+	resp, err := p.client.Models.GenerateContent(
 		ctx,
-		genai.Part{
+		"gemini-2.5-flash-preview-05-20",
+		contents,
+		geminiConfig(promptSet),
+	)
+	if err != nil {
+		return models.Prescription{}, fmt.Errorf("failed to check prescription: %w", err)
+	}
+
+	return geminiPrescription(resp)
+}
+
+// geminiConfig returns the generation config requesting a prescription under the system prompt.
+func geminiConfig(promptSet prompts.Set) *genai.GenerateContentConfig {
+	return &genai.GenerateContentConfig{
+		SystemInstruction: genai.NewContentFromText(promptSet.System, genai.RoleUser),
+		ResponseMIMEType:  "application/json",
+		ResponseSchema:    &geminiSchema,
+	}
+}
+
+// geminiDocumentParts returns the parts showing the document with a prompt.
+func geminiDocumentParts(doc *document, prompt string) []*genai.Part {
+	return []*genai.Part{
+		{
 			InlineData: &genai.Blob{
-				MIMEType: contentType,
-				Data:     fileBytes,
+				MIMEType: doc.contentType,
+				Data:     doc.data,
 			},
 		},
-		genai.Part{
-			Text: parsePrompt,
-		},
-	)
-	if err != nil {
-		return firstPassRx, fmt.Errorf("failed to run second pass: %w", err)
+		genai.NewPartFromText(prompt),
 	}
+}
 
-	var secondPassRx models.Prescription
-	err = json.Unmarshal([]byte(resp.Text()), &secondPassRx)
+// geminiPrescription decodes the prescription of a Gemini response.
+func geminiPrescription(resp *genai.GenerateContentResponse) (models.Prescription, error) {
+	var rx models.Prescription
+	err := json.Unmarshal([]byte(resp.Text()), &rx)
 	if err != nil {
-		return firstPassRx, fmt.Errorf("failed to unmarshal second pass response: %w", err)
+		return models.Prescription{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return rx, nil
+}
+
+// geminiSamplePart returns the part showing a sample's document. A document kept in the blob store
+// is sent inline, since Gemini Files URIs expire after 48 hours and are unknown to other backends.
+func geminiSamplePart(sample models.SamplePrescription) *genai.Part {
+	if len(sample.Document) > 0 {
+		return &genai.Part{
+			InlineData: &genai.Blob{
+				MIMEType: sample.MIMEType,
+				Data:     sample.Document,
+			},
+		}
 	}
+	return genai.NewPartFromURI(sample.FileID, sample.MIMEType)
+}
+
+// geminiEmbeddingModel is the Gemini model used for prescription embeddings.
+var geminiEmbeddingModel = models.EmbeddingModel{
+	Name:       "gemini-embedding-exp-03-07",
+	Version:    EmbeddingVersion,
+	Dimensions: 1536,
+	Kind:       models.EmbeddingKindContent,
+}
+
+// EmbeddingModel returns the Gemini embedding model.
+func (p *GeminiParser) EmbeddingModel() models.EmbeddingModel {
+	return geminiEmbeddingModel
+}
 
-	return secondPassRx, nil
+// Templates returns the form templates documents are identified as, nil if none are configured.
+func (p *GeminiParser) Templates() *templates.Registry {
+	return p.templates
 }
 
 // GetEmbedding generates embeddings for a prescription using Gemini embeddings API.
 // It converts the prescription to JSON and sends it to the Gemini API to generate
 // a vector representation for similarity search.
-func (p *GeminiParser) GetEmbedding(ctx context.Context, prescription models.Prescription) ([]float32, error) {
+func (p *GeminiParser) GetEmbedding(ctx context.Context, prescription models.Prescription) (models.Embedding, error) {
 	jsonBytes, err := json.Marshal(prescription)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal prescription: %w", err)
+		return models.Embedding{}, fmt.Errorf("failed to marshal prescription: %w", err)
 	}
 
-	embeddingDimensionality := int32(1536)
+	embeddingDimensionality := int32(geminiEmbeddingModel.Dimensions)
 	resp, err := p.client.Models.EmbedContent(
 		ctx,
-		"gemini-embedding-exp-03-07",
+		geminiEmbeddingModel.Name,
 		[]*genai.Content{
 			genai.NewContentFromText(string(jsonBytes), genai.RoleUser),
 		},
@@ -249,10 +227,10 @@ func (p *GeminiParser) GetEmbedding(ctx context.Context, prescription models.Pre
 		},
 	)
 	if err != nil || len(resp.Embeddings) == 0 {
-		return nil, fmt.Errorf("failed to generate prescription embedding: %w", err)
+		return models.Embedding{}, fmt.Errorf("failed to generate prescription embedding: %w", err)
 	}
 
-	return resp.Embeddings[0].Values, nil
+	return models.Embedding{Model: geminiEmbeddingModel, Vector: resp.Embeddings[0].Values}, nil
 }
 
 // UploadImage uploads an image using the Gemini Files API.
@@ -17,7 +17,7 @@ func (p *GeminiParser) ScoreResult(ctx context.Context, expectedJSON, outputJSON
 	}
 
 	userParts := []*genai.Part{
-		genai.NewPartFromText(fmt.Sprintf("%sHere are the JSON objects to compare:\n\nValidated Expected JSON:\n%s\n\nParser Output JSON:\n%s", scoringPrompt, expectedJSON, outputJSON)),
+		genai.NewPartFromText(fmt.Sprintf("%sHere are the JSON objects to compare:\n\nValidated Expected JSON:\n%s\n\nParser Output JSON:\n%s", p.prompts.Default().Scoring, expectedJSON, outputJSON)),
 	}
 
 	contents := []*genai.Content{
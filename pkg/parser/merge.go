@@ -0,0 +1,226 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// passResult is the result of a model stage of the pipeline.
+type passResult struct {
+	stage string // Stage that produced the result
+	rx    models.Prescription
+}
+
+// candidate is the value one pass has for a field.
+type candidate struct {
+	pass  int // Index of the pass
+	value any
+}
+
+// arbiter merges the results of the model stages field by field.
+type arbiter struct {
+	passes  []passResult
+	flagged [][]string        // Fields validation raised warnings or errors on, by pass
+	origins map[string]string // Stage each non-empty field was taken from, by field path
+}
+
+// arbitrate merges the results of the model stages field by field and returns the merged result
+// and the stage each non-empty field was taken from, by field path. For each field, empty values
+// are passed over, values validation flagged in their pass are passed over unless every pass's
+// value was flagged, and of the remaining values the one the most passes agree on is taken,
+// the latest pass's on a tie. Objects are arbitrated field by field, as are lists of objects
+// whose passes have the same number of elements; other lists are taken whole, since elements of
+// lists of different lengths need not correspond.
+func arbitrate(ctx context.Context, validators []PostProcessor, passes []passResult) (models.Prescription, map[string]string, error) {
+	a := &arbiter{passes: passes, flagged: make([][]string, len(passes)), origins: map[string]string{}}
+
+	candidates := make([]candidate, len(passes))
+	for i, pass := range passes {
+		value, err := toJSONValue(pass.rx)
+		if err != nil {
+			return models.Prescription{}, nil, err
+		}
+		candidates[i] = candidate{pass: i, value: value}
+
+		// Validate a copy, since validators may mark up the prescription they check
+		validated, err := clonePrescription(pass.rx)
+		if err != nil {
+			return models.Prescription{}, nil, err
+		}
+		fields, _ := flaggedFields(process(ctx, validators, &validated))
+		a.flagged[i] = fields
+	}
+
+	merged := a.field("", candidates)
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return models.Prescription{}, nil, fmt.Errorf("failed to marshal merged prescription: %w", err)
+	}
+	var rx models.Prescription
+	if err := json.Unmarshal(data, &rx); err != nil {
+		return models.Prescription{}, nil, fmt.Errorf("failed to unmarshal merged prescription: %w", err)
+	}
+
+	return rx, a.origins, nil
+}
+
+// field returns the arbitrated value of the field at path from the passes' candidate values,
+// oldest pass first.
+func (a *arbiter) field(path string, candidates []candidate) any {
+	var present []candidate
+	for _, c := range candidates {
+		if !isEmpty(c.value) {
+			present = append(present, c)
+		}
+	}
+	if len(present) == 0 {
+		return candidates[len(candidates)-1].value
+	}
+
+	if objects, ok := allObjects(present); ok {
+		merged := map[string]any{}
+		for _, object := range objects {
+			for key := range object {
+				if _, done := merged[key]; done {
+					continue
+				}
+				children := make([]candidate, len(present))
+				for i, c := range present {
+					children[i] = candidate{pass: c.pass, value: objects[i][key]}
+				}
+				merged[key] = a.field(childPath(path, key), children)
+			}
+		}
+		return merged
+	}
+
+	if lists, ok := objectLists(present); ok {
+		merged := make([]any, len(lists[0]))
+		for i := range merged {
+			children := make([]candidate, len(present))
+			for j, c := range present {
+				children[j] = candidate{pass: c.pass, value: lists[j][i]}
+			}
+			merged[i] = a.field(fmt.Sprintf("%s[%d]", path, i), children)
+		}
+		return merged
+	}
+
+	pool := present
+	var valid []candidate
+	for _, c := range present {
+		if !a.isFlagged(c.pass, path) {
+			valid = append(valid, c)
+		}
+	}
+	if len(valid) > 0 {
+		pool = valid
+	}
+
+	best, bestVotes := pool[len(pool)-1], 0
+	for i := len(pool) - 1; i >= 0; i-- {
+		votes := 0
+		for _, other := range pool {
+			if reflect.DeepEqual(pool[i].value, other.value) {
+				votes++
+			}
+		}
+		if votes > bestVotes {
+			best, bestVotes = pool[i], votes
+		}
+	}
+
+	a.origins[path] = a.passes[best.pass].stage
+	return best.value
+}
+
+// isFlagged reports whether validation flagged the field at path, a field within it, or a
+// field containing it in a pass.
+func (a *arbiter) isFlagged(pass int, path string) bool {
+	for _, field := range a.flagged[pass] {
+		if field == path || withinField(field, path) || withinField(path, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// withinField reports whether the field at path lies within the field at parent.
+func withinField(path, parent string) bool {
+	rest, ok := strings.CutPrefix(path, parent)
+	return ok && (strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "["))
+}
+
+// childPath returns the path of a field of the object at path.
+func childPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// allObjects returns the candidates' values if they are all objects.
+func allObjects(candidates []candidate) ([]map[string]any, bool) {
+	objects := make([]map[string]any, len(candidates))
+	for i, c := range candidates {
+		object, ok := c.value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		objects[i] = object
+	}
+	return objects, true
+}
+
+// objectLists returns the candidates' values if they are all lists of objects of the same length.
+func objectLists(candidates []candidate) ([][]any, bool) {
+	lists := make([][]any, len(candidates))
+	for i, c := range candidates {
+		list, ok := c.value.([]any)
+		if !ok || i > 0 && len(list) != len(lists[0]) {
+			return nil, false
+		}
+		for _, element := range list {
+			if _, ok := element.(map[string]any); !ok {
+				return nil, false
+			}
+		}
+		lists[i] = list
+	}
+	return lists, true
+}
+
+// clonePrescription returns a deep copy of a prescription.
+func clonePrescription(rx models.Prescription) (models.Prescription, error) {
+	data, err := json.Marshal(rx)
+	if err != nil {
+		return models.Prescription{}, fmt.Errorf("failed to marshal prescription: %w", err)
+	}
+	var clone models.Prescription
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return models.Prescription{}, fmt.Errorf("failed to unmarshal prescription: %w", err)
+	}
+	return clone, nil
+}
+
+// isEmpty reports whether a decoded JSON value holds no data. False and zero are data.
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
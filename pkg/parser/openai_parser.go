@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,8 +12,9 @@ import (
 
 	"github.com/csotherden/prescription-parser/pkg/config"
 	"github.com/csotherden/prescription-parser/pkg/datastore"
-	"github.com/csotherden/prescription-parser/pkg/jobs"
 	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/prompts"
+	"github.com/csotherden/prescription-parser/pkg/templates"
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
 	"github.com/openai/openai-go/responses"
@@ -31,8 +34,7 @@ type openAIEmbedding struct {
 // It uses OpenAI's vision and embedding capabilities to process prescription images
 // and extract structured data from them.
 type OpenAIParser struct {
-	ds     datastore.Datastore
-	logger *zap.Logger
+	*pipeline
 	client openai.Client
 }
 
@@ -44,28 +46,22 @@ func NewOpenAIParser(cfg config.Config, ds datastore.Datastore, logger *zap.Logg
 		option.WithAPIKey(cfg.OpenAIAPIKey),
 	)
 
+	pipeline, err := newPipeline(cfg, ds, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	return &OpenAIParser{
-		ds:     ds,
-		logger: logger,
-		client: client,
+		pipeline: pipeline,
+		client:   client,
 	}, nil
 }
 
 // ParseImage handles parsing a prescription image using OpenAI vision API.
 // It creates an asynchronous job to process the image and returns the job ID.
-// The actual processing is done in a separate goroutine.
-func (p *OpenAIParser) ParseImage(ctx context.Context, fileName string, file io.Reader) (string, error) {
-	// Create a job for asynchronous processing
-	jobID := jobs.GlobalTracker.CreateJob(
-		JobTypeParsePrescription,
-		fmt.Sprintf("Processing image: %s", fileName),
-	)
-
-	p.logger.Info("starting image parsing", zap.String("job_id", jobID), zap.String("file_name", fileName))
-
-	go p.parseImageProcess(context.Background(), jobID, fileName, file)
-
-	return jobID, nil
+// The actual processing is done by the pipeline in a separate goroutine.
+func (p *OpenAIParser) ParseImage(ctx context.Context, fileName string, file io.Reader, opts models.ParseOptions) (string, error) {
+	return p.start(p, "OpenAI", fileName, file, opts)
 }
 
 // deleteImage removes an image from the OpenAI API.
@@ -78,156 +74,46 @@ func (p *OpenAIParser) deleteImage(ctx context.Context, imageID string) error {
 	return nil
 }
 
-// parseImageProcess processes the image asynchronously.
-// It validates the file type, uploads it to OpenAI, performs parsing passes,
-// and updates the job status throughout the process. It also cleans up
-// the uploaded files when done.
-func (p *OpenAIParser) parseImageProcess(ctx context.Context, jobID, fileName string, file io.Reader) {
-	fileExt := strings.ToLower(filepath.Ext(fileName))
-	var contentType string
-	switch fileExt {
-	case ".pdf":
-		contentType = "application/pdf"
-	default:
-		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusFailed, fmt.Errorf("unsupported file type. file must be PDF not %s", fileExt), nil)
-		return
-	}
-
-	inputFile := openai.File(file, fileName, contentType)
-
+// prepare uploads the document to OpenAI, since requests refer to it by file ID, and returns a
+// function deleting the upload.
+func (p *OpenAIParser) prepare(ctx context.Context, doc *document) (func(), error) {
 	storedFile, err := p.client.Files.New(ctx, openai.FileNewParams{
-		File:    inputFile,
+		File:    openai.File(bytes.NewReader(doc.data), doc.fileName, doc.contentType),
 		Purpose: openai.FilePurposeUserData,
 	})
 	if err != nil {
-		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusFailed, fmt.Errorf("failed to upload file: %w", err), nil)
-		p.logger.Error("failed to upload file", zap.String("job_id", jobID), zap.String("file_name", fileName), zap.Error(err))
-		return
+		return nil, fmt.Errorf("failed to upload file: %w", err)
 	}
 
-	// Update job status to processing
-	jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusProcessing, nil, nil)
-
-	defer func() {
-		err := p.deleteImage(ctx, storedFile.ID)
-		if err != nil {
+	doc.fileID = storedFile.ID
+	return func() {
+		if err := p.deleteImage(ctx, storedFile.ID); err != nil {
 			p.logger.Error("failed to delete image", zap.String("image_id", storedFile.ID), zap.Error(err))
 		}
-	}()
-
-	// Initial parsing pass
-	rx, err := p.firstParsingPass(ctx, storedFile.ID)
-	if err != nil {
-		p.logger.Error("failed in first parsing pass", zap.String("job_id", jobID), zap.Error(err))
-		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusFailed, fmt.Errorf("failed in first parsing pass: %w", err), nil)
-		return
-	}
-
-	p.logger.Info("first parsing pass completed", zap.String("job_id", jobID), zap.String("file_name", fileName))
-
-	// Get embedding for the parsed prescription
-	embedding, err := p.GetEmbedding(ctx, rx)
-	if err != nil {
-		p.logger.Error("failed to get embedding", zap.String("job_id", jobID), zap.String("file_name", fileName), zap.Error(err))
-		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, rx)
-		return
-	}
-
-	// Get similar samples
-	samples, err := p.ds.GetSamples(ctx, embedding)
-	if err != nil {
-		p.logger.Error("failed to get samples", zap.String("job_id", jobID), zap.String("file_name", fileName), zap.Error(err))
-		jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, rx)
-		return
-	}
-
-	p.logger.Info("sample images loaded", zap.String("job_id", jobID), zap.String("file_name", fileName), zap.Int("sample_count", len(samples)))
-
-	// Second parsing pass with examples
-	if len(samples) > 0 {
-		secondPassRx, err := p.secondParsingPass(ctx, storedFile.ID, samples, rx)
-		if err != nil {
-			p.logger.Error("failed in second parsing pass", zap.String("job_id", jobID), zap.Error(err))
-			jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, rx)
-			return
-		}
-		rx = secondPassRx
-	}
-
-	p.logger.Info("successfully processed image", zap.String("job_id", jobID), zap.String("file_name", fileName))
-	jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, rx)
+	}, nil
 }
 
-// firstParsingPass performs the initial parsing of the prescription.
+// parse performs the initial parsing of the prescription.
 // It sends the prescription image to OpenAI API with system and user prompts
 // to extract structured data from the image.
-func (p *OpenAIParser) firstParsingPass(ctx context.Context, fileID string) (models.Prescription, error) {
+func (p *OpenAIParser) parse(ctx context.Context, doc *document, promptSet prompts.Set) (models.Prescription, error) {
 	messages := []responses.ResponseInputItemUnionParam{
 		responses.ResponseInputItemParamOfMessage(
-			systemPrompt,
+			promptSet.System,
 			"system"),
+		openAIDocumentMessage(doc, promptSet.Parse),
 	}
 
-	imageMessage := responses.ResponseInputItemParamOfMessage(
-		responses.ResponseInputMessageContentListParam{
-			responses.ResponseInputContentUnionParam{
-				OfInputFile: &responses.ResponseInputFileParam{
-					FileID: openai.String(fileID),
-					Type:   "input_file",
-				},
-			},
-			responses.ResponseInputContentUnionParam{
-				OfInputText: &responses.ResponseInputTextParam{
-					Text: parsePrompt,
-					Type: "input_text",
-				},
-			},
-		},
-		"user",
-	)
-
-	messages = append(messages, imageMessage)
-
-	params := responses.ResponseNewParams{
-		Text: responses.ResponseTextConfigParam{
-			Format: responses.ResponseFormatTextConfigUnionParam{
-				OfJSONSchema: &responses.ResponseFormatTextJSONSchemaConfigParam{
-					Name:        "Prescription",
-					Schema:      PrescriptionResponseSchema,
-					Strict:      openai.Bool(true),
-					Description: openai.String("Prescription Image Parser Prescription JSON"),
-					Type:        "json_schema",
-				},
-			},
-		},
-		Model: "gpt-4.1-2025-04-14",
-		Input: responses.ResponseNewParamsInputUnion{
-			OfInputItemList: messages,
-		},
-		MaxOutputTokens: openai.Int(10240),
-	}
-
-	resp, err := p.client.Responses.New(ctx, params)
-	if err != nil {
-		return models.Prescription{}, fmt.Errorf("failed to process image: %w", err)
-	}
-
-	var rx models.Prescription
-	err = json.Unmarshal([]byte(resp.OutputText()), &rx)
-	if err != nil {
-		return models.Prescription{}, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return rx, nil
+	return p.respond(ctx, messages)
 }
 
-// secondParsingPass performs a fresh parsing pass with example context.
+// reparse performs a fresh parsing pass with example context.
 // It uses similar prescription samples to refine the initial parsing results,
 // potentially improving accuracy by learning from precedents.
-func (p *OpenAIParser) secondParsingPass(ctx context.Context, fileID string, samples []models.SamplePrescription, firstPassRx models.Prescription) (models.Prescription, error) {
+func (p *OpenAIParser) reparse(ctx context.Context, doc *document, promptSet prompts.Set, samples []models.SamplePrescription) (models.Prescription, error) {
 	messages := []responses.ResponseInputItemUnionParam{
 		responses.ResponseInputItemParamOfMessage(
-			systemPrompt,
+			promptSet.System,
 			"system"),
 	}
 
@@ -235,14 +121,11 @@ func (p *OpenAIParser) secondParsingPass(ctx context.Context, fileID string, sam
 		sampleMessage := responses.ResponseInputItemParamOfMessage(
 			responses.ResponseInputMessageContentListParam{
 				responses.ResponseInputContentUnionParam{
-					OfInputFile: &responses.ResponseInputFileParam{
-						FileID: openai.String(sample.FileID),
-						Type:   "input_file",
-					},
+					OfInputFile: openAISampleFile(sample),
 				},
 				responses.ResponseInputContentUnionParam{
 					OfInputText: &responses.ResponseInputTextParam{
-						Text: parsePrompt,
+						Text: promptSet.Parse,
 						Type: "input_text",
 					},
 				},
@@ -266,26 +149,51 @@ func (p *OpenAIParser) secondParsingPass(ctx context.Context, fileID string, sam
 		messages = append(messages, sampleResponse)
 	}
 
-	reviewMessage := responses.ResponseInputItemParamOfMessage(
+	messages = append(messages, openAIDocumentMessage(doc, promptSet.Review))
+
+	return p.respond(ctx, messages)
+}
+
+// check asks the model to correct a parse result against the document, following prompt.
+func (p *OpenAIParser) check(ctx context.Context, doc *document, promptSet prompts.Set, prompt string, rx models.Prescription) (models.Prescription, error) {
+	rxJSON, err := json.Marshal(rx)
+	if err != nil {
+		return models.Prescription{}, fmt.Errorf("failed to marshal prescription: %w", err)
+	}
+
+	messages := []responses.ResponseInputItemUnionParam{
+		responses.ResponseInputItemParamOfMessage(
+			promptSet.System,
+			"system"),
+		openAIDocumentMessage(doc, prompt+string(rxJSON)),
+	}
+
+	return p.respond(ctx, messages)
+}
+
+// openAIDocumentMessage returns a user message showing the document with a prompt.
+func openAIDocumentMessage(doc *document, prompt string) responses.ResponseInputItemUnionParam {
+	return responses.ResponseInputItemParamOfMessage(
 		responses.ResponseInputMessageContentListParam{
 			responses.ResponseInputContentUnionParam{
 				OfInputFile: &responses.ResponseInputFileParam{
-					FileID: openai.String(fileID),
+					FileID: openai.String(doc.fileID),
 					Type:   "input_file",
 				},
 			},
 			responses.ResponseInputContentUnionParam{
 				OfInputText: &responses.ResponseInputTextParam{
-					Text: reviewPrompt,
+					Text: prompt,
 					Type: "input_text",
 				},
 			},
 		},
 		"user",
 	)
+}
 
-	messages = append(messages, reviewMessage)
-
+// respond sends messages to the OpenAI API and decodes the prescription it responds with.
+func (p *OpenAIParser) respond(ctx context.Context, messages []responses.ResponseInputItemUnionParam) (models.Prescription, error) {
 	params := responses.ResponseNewParams{
 		Text: responses.ResponseTextConfigParam{
 			Format: responses.ResponseFormatTextConfigUnionParam{
@@ -307,47 +215,82 @@ func (p *OpenAIParser) secondParsingPass(ctx context.Context, fileID string, sam
 
 	resp, err := p.client.Responses.New(ctx, params)
 	if err != nil {
-		return firstPassRx, fmt.Errorf("failed to run second pass: %w", err)
+		return models.Prescription{}, fmt.Errorf("failed to process image: %w", err)
 	}
 
-	var secondPassRx models.Prescription
-	err = json.Unmarshal([]byte(resp.OutputText()), &secondPassRx)
+	var rx models.Prescription
+	err = json.Unmarshal([]byte(resp.OutputText()), &rx)
 	if err != nil {
-		return firstPassRx, fmt.Errorf("failed to unmarshal second pass response: %w", err)
+		return models.Prescription{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return rx, nil
+}
+
+// openAISampleFile returns the input file showing a sample's document. A document kept in the
+// blob store is sent inline, so the sample works even if it was uploaded to another backend or
+// the OpenAI file was deleted.
+func openAISampleFile(sample models.SamplePrescription) *responses.ResponseInputFileParam {
+	if len(sample.Document) > 0 {
+		return &responses.ResponseInputFileParam{
+			FileData: openai.String(fmt.Sprintf("data:%s;base64,%s", sample.MIMEType, base64.StdEncoding.EncodeToString(sample.Document))),
+			Filename: openai.String(sample.ID.String() + ".pdf"),
+			Type:     "input_file",
+		}
+	}
+	return &responses.ResponseInputFileParam{
+		FileID: openai.String(sample.FileID),
+		Type:   "input_file",
 	}
+}
+
+// openAIEmbeddingModel is the OpenAI model used for prescription embeddings.
+var openAIEmbeddingModel = models.EmbeddingModel{
+	Name:       openai.EmbeddingModelTextEmbedding3Small,
+	Version:    EmbeddingVersion,
+	Dimensions: 1536,
+	Kind:       models.EmbeddingKindContent,
+}
+
+// EmbeddingModel returns the OpenAI embedding model.
+func (p *OpenAIParser) EmbeddingModel() models.EmbeddingModel {
+	return openAIEmbeddingModel
+}
 
-	return secondPassRx, nil
+// Templates returns the form templates documents are identified as, nil if none are configured.
+func (p *OpenAIParser) Templates() *templates.Registry {
+	return p.templates
 }
 
 // GetEmbedding generates embeddings for a prescription using OpenAI.
 // It converts the prescription to JSON and sends it to the OpenAI API to generate
 // a vector representation for similarity search.
-func (p *OpenAIParser) GetEmbedding(ctx context.Context, prescription models.Prescription) ([]float32, error) {
+func (p *OpenAIParser) GetEmbedding(ctx context.Context, prescription models.Prescription) (models.Embedding, error) {
 	jsonBytes, err := json.Marshal(prescription)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal prescription: %w", err)
+		return models.Embedding{}, fmt.Errorf("failed to marshal prescription: %w", err)
 	}
 
 	resp, err := p.client.Embeddings.New(ctx, openai.EmbeddingNewParams{
 		Input: openai.EmbeddingNewParamsInputUnion{
 			OfString: openai.String(string(jsonBytes)),
 		},
-		Model:          openai.EmbeddingModelTextEmbedding3Small,
-		Dimensions:     openai.Int(1536),
+		Model:          openAIEmbeddingModel.Name,
+		Dimensions:     openai.Int(int64(openAIEmbeddingModel.Dimensions)),
 		EncodingFormat: "float",
 	})
 	if err != nil || len(resp.Data) == 0 {
-		return nil, fmt.Errorf("failed to generate prescription embedding: %w", err)
+		return models.Embedding{}, fmt.Errorf("failed to generate prescription embedding: %w", err)
 	}
 
 	var emb openAIEmbedding
 
 	err = json.Unmarshal([]byte(resp.Data[0].RawJSON()), &emb)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal prescription embedding: %w", err)
+		return models.Embedding{}, fmt.Errorf("failed to unmarshal prescription embedding: %w", err)
 	}
 
-	return emb.Embedding, nil
+	return models.Embedding{Model: openAIEmbeddingModel, Vector: emb.Embedding}, nil
 }
 
 // UploadImage uploads an image to the OpenAI API.
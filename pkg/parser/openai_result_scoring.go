@@ -17,7 +17,7 @@ func (p *OpenAIParser) ScoreResult(ctx context.Context, expectedJSON, outputJSON
 			responses.ResponseInputMessageContentListParam{
 				responses.ResponseInputContentUnionParam{
 					OfInputText: &responses.ResponseInputTextParam{
-						Text: fmt.Sprintf("%sHere are the JSON objects to compare:\n\nValidated Expected JSON:\n%s\n\nParser Output JSON:\n%s", scoringPrompt, expectedJSON, outputJSON),
+						Text: fmt.Sprintf("%sHere are the JSON objects to compare:\n\nValidated Expected JSON:\n%s\n\nParser Output JSON:\n%s", p.prompts.Default().Scoring, expectedJSON, outputJSON),
 						Type: "input_text",
 					},
 				},
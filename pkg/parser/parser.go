@@ -11,18 +11,33 @@ import (
 	"github.com/csotherden/prescription-parser/pkg/config"
 	"github.com/csotherden/prescription-parser/pkg/datastore"
 	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/templates"
 	"go.uber.org/zap"
 )
 
+// EmbeddingVersion is the version of the text embedded for a prescription. Bump it when that
+// text changes, so samples are re-embedded before they are compared with new embeddings.
+const EmbeddingVersion = "1"
+
 // Parser defines the interface for prescription parsing services
 type Parser interface {
 	// ParseImage processes a prescription image asynchronously and returns a job ID for tracking parsing progress.
-	// It takes a filename and file reader, initiates an asynchronous job, and returns the job ID.
-	ParseImage(ctx context.Context, fileName string, file io.Reader) (string, error)
+	// It takes a filename, file reader and per-request options, initiates an asynchronous job, and returns the job ID.
+	// It returns ErrUnknownRuleSet or ErrUnknownPromptVersion if the options select a rule set or
+	// prompt version that is not loaded.
+	ParseImage(ctx context.Context, fileName string, file io.Reader, opts models.ParseOptions) (string, error)
 
-	// GetEmbedding generates an embedding vector for a prescription.
+	// GetEmbedding generates an embedding vector for a prescription with the parser's embedding model.
 	// This vector representation can be used for similarity searches and document clustering.
-	GetEmbedding(ctx context.Context, prescription models.Prescription) ([]float32, error)
+	GetEmbedding(ctx context.Context, prescription models.Prescription) (models.Embedding, error)
+
+	// EmbeddingModel returns the model GetEmbedding uses. Only samples embedded with the same model
+	// are retrieved for parsing.
+	EmbeddingModel() models.EmbeddingModel
+
+	// Templates returns the form template registry documents are identified against, or nil when
+	// no template file is configured.
+	Templates() *templates.Registry
 
 	// UploadImage uploads an image to persistent storage and returns its ID.
 	// The image can then be referenced in subsequent API calls.
@@ -35,9 +50,21 @@ type Parser interface {
 // NewParser creates a new instance of a Parser implementation based on config.
 // It returns the appropriate parser implementation (OpenAI or Gemini) based on the configuration.
 // Returns an error if the parser backend specified in config is not supported.
+// When an experiment is enabled in the configured experiment file, parse jobs are split between
+// its variants.
 func NewParser(cfg config.Config, ds datastore.Datastore, logger *zap.Logger) (Parser, error) {
-	logger.Info("initializing parser", zap.String("parser_backend", cfg.ParserBackend))
+	logger.Info("initializing parser", zap.String("parser_backend", cfg.ParserBackend), zap.String("sample_retrieval", cfg.SampleRetrieval))
+
+	parser, err := newBackendParser(cfg, ds, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return withExperiment(cfg, ds, logger, parser)
+}
 
+// newBackendParser creates the parser of the configured backend.
+func newBackendParser(cfg config.Config, ds datastore.Datastore, logger *zap.Logger) (Parser, error) {
 	switch cfg.ParserBackend {
 	case "OpenAI":
 		return NewOpenAIParser(cfg, ds, logger)
@@ -1,10 +1,27 @@
 package parser
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/experiments"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/layout"
 	"github.com/csotherden/prescription-parser/pkg/mocks"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/prompts"
+	"github.com/csotherden/prescription-parser/pkg/segment"
+	"github.com/csotherden/prescription-parser/pkg/templates"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"go.uber.org/zap"
 )
 
@@ -39,6 +56,46 @@ func TestNewParser(t *testing.T) {
 			expectedType: "*parser.GeminiParser",
 			expectError:  false,
 		},
+		{
+			name: "Unknown sample retrieval",
+			config: config.Config{
+				ParserBackend:   "OpenAI",
+				OpenAIAPIKey:    "test-key",
+				SampleRetrieval: "random",
+			},
+			expectedType: "",
+			expectError:  true,
+		},
+		{
+			name: "Missing template file",
+			config: config.Config{
+				ParserBackend: "Gemini",
+				GeminiAPIKey:  "test-key",
+				TemplatesFile: filepath.Join(t.TempDir(), "templates.yaml"),
+			},
+			expectedType: "",
+			expectError:  true,
+		},
+		{
+			name: "Unknown default prompt version",
+			config: config.Config{
+				ParserBackend: "OpenAI",
+				OpenAIAPIKey:  "test-key",
+				PromptVersion: "9",
+			},
+			expectedType: "",
+			expectError:  true,
+		},
+		{
+			name: "Invalid pipeline",
+			config: config.Config{
+				ParserBackend: "Gemini",
+				GeminiAPIKey:  "test-key",
+				Pipeline:      "parse,reparse",
+			},
+			expectedType: "",
+			expectError:  true,
+		},
 		{
 			name: "Unknown parser",
 			config: config.Config{
@@ -89,3 +146,1104 @@ func getType(p Parser) string {
 		return "unknown"
 	}
 }
+
+func TestPostProcessingForRequest(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.yaml")
+	rules := "default_rule_set: standard\nrule_sets:\n  - name: standard\n    rules:\n      - {id: npi, field: $.prescriber.npi, required: true}\n  - name: strict\n    extends: standard\n"
+	if err := os.WriteFile(rulesFile, []byte(rules), 0o600); err != nil {
+		t.Fatalf("Failed to write rule file: %v", err)
+	}
+
+	pp, err := newPostProcessing(config.Config{RulesFile: rulesFile}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create post-processing: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		ruleSet     string
+		wantRuleSet string
+		wantErr     error
+	}{
+		{name: "default", ruleSet: "", wantRuleSet: "standard"},
+		{name: "selected", ruleSet: "strict", wantRuleSet: "strict"},
+		{name: "unknown", ruleSet: "missing", wantErr: ErrUnknownRuleSet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processing, ruleSet, err := pp.forRequest(models.ParseOptions{RuleSet: tt.ruleSet})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if ruleSet != tt.wantRuleSet {
+				t.Errorf("Expected rule set %q, got %q", tt.wantRuleSet, ruleSet)
+			}
+			if len(processing.validators) != len(pp.validators)+1 || len(processing.normalizers) != len(pp.normalizers) {
+				t.Errorf("Expected the rule set to be appended to %d validators, got %d", len(pp.validators), len(processing.validators))
+			}
+		})
+	}
+
+	noRules, err := newPostProcessing(config.Config{}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create post-processing: %v", err)
+	}
+	if _, _, err := noRules.forRequest(models.ParseOptions{RuleSet: "standard"}); !errors.Is(err, ErrUnknownRuleSet) {
+		t.Errorf("Expected ErrUnknownRuleSet without a rule file, got %v", err)
+	}
+}
+
+func TestCompleteJobPersistsResult(t *testing.T) {
+	ds := mocks.NewMockDatastore()
+
+	jobID := jobs.GlobalTracker.CreateJob(JobTypeParsePrescription, "Processing image: result.pdf")
+	jobs.GlobalTracker.SetAttribute(jobID, jobs.AttributeFileName, "result.pdf")
+
+	rx := models.Prescription{Patient: models.Patient{FirstName: "Ann"}}
+	completeJob(context.Background(), ds, zap.NewNop(), jobID, []models.DocumentPrescription{{Pages: models.PageRange{First: 1, Last: 1}, Prescription: rx}})
+
+	result, err := ds.GetParseResult(context.Background(), jobID)
+	if err != nil {
+		t.Fatalf("Expected the completed job to be persisted: %v", err)
+	}
+	if result.Prescription.Patient.FirstName != "Ann" || result.Attributes[jobs.AttributeFileName] != "result.pdf" || result.CompletedAt.IsZero() {
+		t.Errorf("Unexpected parse result %+v", result)
+	}
+	if result.Prescriptions != nil {
+		t.Errorf("Expected no prescriptions list for a single prescription, got %+v", result.Prescriptions)
+	}
+
+	// Saving the job again, as a re-parse does, keeps its review
+	if err := ds.SaveReview(context.Background(), jobID, models.Review{Reviewer: "pharmacist-1"}); err != nil {
+		t.Fatalf("Failed to save review: %v", err)
+	}
+	completeJob(context.Background(), ds, zap.NewNop(), jobID, []models.DocumentPrescription{{Pages: models.PageRange{First: 1, Last: 1}, Prescription: rx}})
+	if result, _ := ds.GetParseResult(context.Background(), jobID); result.Status != models.ParseResultReviewed || result.Review == nil {
+		t.Errorf("Expected the saved result to stay reviewed, got status %s with review %+v", result.Status, result.Review)
+	}
+
+	faxJobID := jobs.GlobalTracker.CreateJob(JobTypeParsePrescription, "Processing image: fax.pdf")
+	completeJob(context.Background(), ds, zap.NewNop(), faxJobID, []models.DocumentPrescription{
+		{Pages: models.PageRange{First: 1, Last: 2}, Prescription: rx},
+		{
+			Pages:        models.PageRange{First: 3, Last: 3},
+			Prescription: models.Prescription{Patient: models.Patient{FirstName: "Bob"}},
+			Validation:   []models.ValidationIssue{{Field: "prescriber.npi", Severity: models.SeverityError}},
+			Blocked:      true,
+		},
+	})
+	fax, err := ds.GetParseResult(context.Background(), faxJobID)
+	if err != nil {
+		t.Fatalf("Expected the completed job to be persisted: %v", err)
+	}
+	// The second prescription's block applies to the job, whose result is only part of the document
+	if fax.Prescription.Patient.FirstName != "Ann" || !fax.Blocked || len(fax.Validation) != 0 || len(fax.Prescriptions) != 2 || fax.Prescriptions[1].Pages.First != 3 {
+		t.Errorf("Expected the first prescription as the blocked result and both listed, got %+v", fax)
+	}
+	if job, _ := jobs.GlobalTracker.Snapshot(faxJobID); !job.Blocked || job.PrescriptionCount != 2 {
+		t.Errorf("Expected a blocked job with 2 prescriptions, got blocked: %v, count: %d", job.Blocked, job.PrescriptionCount)
+	}
+
+	skippedJobID := jobs.GlobalTracker.CreateJob(JobTypeParsePrescription, "Processing image: skipped.pdf")
+	completeJob(context.Background(), nil, zap.NewNop(), skippedJobID, []models.DocumentPrescription{{Prescription: rx}})
+	if _, err := ds.GetParseResult(context.Background(), skippedJobID); err == nil {
+		t.Errorf("Expected no parse result without a results store")
+	}
+	if job, _ := jobs.GlobalTracker.GetJob(skippedJobID); job.Status != jobs.JobStatusComplete {
+		t.Errorf("Expected the job to complete without a results store, got %s", job.Status)
+	}
+}
+
+func TestRetrieveSamples(t *testing.T) {
+	scan, err := os.ReadFile(filepath.Join("..", "..", "samples", "Humira1.pdf"))
+	if err != nil {
+		t.Fatalf("Failed to read sample document: %v", err)
+	}
+	fingerprint, err := layout.Fingerprint(scan)
+	if err != nil {
+		t.Fatalf("Failed to fingerprint sample document: %v", err)
+	}
+
+	content := models.Embedding{Model: mocks.MockEmbeddingModel, Vector: []float32{0.5, 0.5, 0.5}}
+	contentSamples := []models.SamplePrescription{{FileID: "content-sample"}}
+	layoutSamples := []models.SamplePrescription{{FileID: "layout-sample"}}
+
+	tests := []struct {
+		name          string
+		mode          string
+		document      []byte
+		layoutSamples []models.SamplePrescription
+		wantFileID    string
+		wantRetrieval string
+	}{
+		{"content", RetrievalContent, scan, layoutSamples, "content-sample", RetrievalContent},
+		{"layout", RetrievalLayout, scan, layoutSamples, "layout-sample", RetrievalLayout},
+		{"layout without matching samples", RetrievalLayout, scan, nil, "content-sample", RetrievalContent},
+		{"layout of document without a scan", RetrievalLayout, []byte("not a pdf"), layoutSamples, "content-sample", RetrievalContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := mocks.NewMockDatastore()
+			ds.SetSamplePrescriptions(content.Vector, contentSamples, nil)
+			if tt.layoutSamples != nil {
+				ds.SetSamplePrescriptions(fingerprint.Vector, tt.layoutSamples, nil)
+			}
+
+			retriever, err := newSampleRetriever(config.Config{SampleRetrieval: tt.mode}, ds, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Failed to create sample retriever: %v", err)
+			}
+
+			jobID := jobs.GlobalTracker.CreateJob(JobTypeParsePrescription, "Processing image: "+tt.name)
+			embedded := false
+			samples, err := retriever.retrieve(context.Background(), jobID, tt.document, models.SampleMetadata{}, "", func(ctx context.Context) (models.Embedding, error) {
+				embedded = true
+				return content, nil
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(samples) != 1 || samples[0].FileID != tt.wantFileID {
+				t.Errorf("Expected sample %s, got %+v", tt.wantFileID, samples)
+			}
+			if embedded != (tt.wantRetrieval == RetrievalContent) {
+				t.Errorf("Expected the content embedding to be computed only for content retrieval, computed: %v", embedded)
+			}
+			if job, _ := jobs.GlobalTracker.GetJob(jobID); job.Attributes[jobs.AttributeSampleRetrieval] != tt.wantRetrieval {
+				t.Errorf("Expected sample retrieval %s, got %q", tt.wantRetrieval, job.Attributes[jobs.AttributeSampleRetrieval])
+			}
+		})
+	}
+
+	t.Run("query settings", func(t *testing.T) {
+		ds := mocks.NewMockDatastore()
+		retriever, err := newSampleRetriever(config.Config{
+			SampleRetrieval:   RetrievalContent,
+			SampleCount:       5,
+			SampleDistance:    "cosine",
+			SampleMaxDistance: 0.4,
+			SampleMMRLambda:   0.7,
+		}, ds, zap.NewNop())
+		if err != nil {
+			t.Fatalf("Failed to create sample retriever: %v", err)
+		}
+
+		filter := models.SampleMetadata{Tenant: "acme", DrugClass: "biologic"}
+		_, err = retriever.retrieve(context.Background(), "", scan, filter, "", func(ctx context.Context) (models.Embedding, error) {
+			return content, nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		calls := ds.GetSamplesCalls()
+		if len(calls) != 1 {
+			t.Fatalf("Expected 1 GetSamples call, got %d", len(calls))
+		}
+		query := calls[0].Query
+		if query.K != 5 || query.Metric != models.DistanceCosine || query.MaxDistance != 0.4 || query.MMRLambda != 0.7 || query.Filter != filter {
+			t.Errorf("Unexpected sample query %+v", query)
+		}
+	})
+
+	t.Run("layout query metric", func(t *testing.T) {
+		ds := mocks.NewMockDatastore()
+		ds.SetSamplePrescriptions(fingerprint.Vector, layoutSamples, nil)
+		retriever, err := newSampleRetriever(config.Config{SampleRetrieval: RetrievalLayout, SampleDistance: "cosine"}, ds, zap.NewNop())
+		if err != nil {
+			t.Fatalf("Failed to create sample retriever: %v", err)
+		}
+
+		_, err = retriever.retrieve(context.Background(), "", scan, models.SampleMetadata{}, "", func(ctx context.Context) (models.Embedding, error) {
+			return content, nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// Fingerprints are compared with L2 whatever the configured metric, as a blank page's has no cosine distance
+		calls := ds.GetSamplesCalls()
+		if len(calls) != 1 || calls[0].Query.Metric != models.DistanceL2 {
+			t.Errorf("Expected 1 layout query with the l2 metric, got %+v", calls)
+		}
+	})
+
+	t.Run("form template", func(t *testing.T) {
+		for _, tt := range []struct {
+			name         string
+			filter       models.SampleMetadata
+			wantTemplate string
+		}{
+			{"preferred", models.SampleMetadata{Tenant: "acme"}, "humira-complete"},
+			{"requested", models.SampleMetadata{Template: "other"}, "other"},
+		} {
+			ds := mocks.NewMockDatastore()
+			ds.SetSamplePrescriptions(content.Vector, contentSamples, nil)
+			retriever, err := newSampleRetriever(config.Config{}, ds, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Failed to create sample retriever: %v", err)
+			}
+
+			_, err = retriever.retrieve(context.Background(), "", scan, tt.filter, "humira-complete", func(ctx context.Context) (models.Embedding, error) {
+				return content, nil
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			calls := ds.GetSamplesCalls()
+			if len(calls) != 1 || calls[0].Query.Filter.Template != tt.wantTemplate || calls[0].Query.Filter.Tenant != tt.filter.Tenant {
+				t.Errorf("%s: expected one query for template %s, got %+v", tt.name, tt.wantTemplate, calls)
+			}
+		}
+	})
+
+	t.Run("no samples of form template", func(t *testing.T) {
+		ds := mocks.NewMockDatastore()
+		retriever, err := newSampleRetriever(config.Config{}, ds, zap.NewNop())
+		if err != nil {
+			t.Fatalf("Failed to create sample retriever: %v", err)
+		}
+
+		embeddings := 0
+		_, err = retriever.retrieve(context.Background(), "", scan, models.SampleMetadata{}, "humira-complete", func(ctx context.Context) (models.Embedding, error) {
+			embeddings++
+			return content, nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		calls := ds.GetSamplesCalls()
+		if len(calls) != 2 || calls[0].Query.Filter.Template != "humira-complete" || calls[1].Query.Filter.Template != "" {
+			t.Errorf("Expected a template query followed by an unfiltered one, got %+v", calls)
+		}
+		if embeddings != 1 {
+			t.Errorf("Expected the content embedding to be computed once, got %d", embeddings)
+		}
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		for _, cfg := range []config.Config{
+			{SampleRetrieval: "random"},
+			{SampleDistance: "manhattan"},
+			{SampleMMRLambda: 1.5},
+		} {
+			if _, err := newSampleRetriever(cfg, mocks.NewMockDatastore(), zap.NewNop()); err == nil {
+				t.Errorf("Expected an error for %+v", cfg)
+			}
+		}
+	})
+
+	t.Run("content embedding error", func(t *testing.T) {
+		wantErr := errors.New("embedding unavailable")
+		retriever, err := newSampleRetriever(config.Config{}, mocks.NewMockDatastore(), zap.NewNop())
+		if err != nil {
+			t.Fatalf("Failed to create sample retriever: %v", err)
+		}
+		_, err = retriever.retrieve(context.Background(), "", scan, models.SampleMetadata{}, "", func(ctx context.Context) (models.Embedding, error) {
+			return models.Embedding{}, wantErr
+		})
+		if !errors.Is(err, wantErr) {
+			t.Errorf("Expected embedding error, got %v", err)
+		}
+	})
+}
+
+func TestSampleDocumentParts(t *testing.T) {
+	tests := []struct {
+		name       string
+		sample     models.SamplePrescription
+		wantInline bool
+	}{
+		{
+			name:   "provider file only",
+			sample: models.SamplePrescription{FileID: "file-123", MIMEType: "application/pdf"},
+		},
+		{
+			name:       "stored document",
+			sample:     models.SamplePrescription{FileID: "file-123", MIMEType: "application/pdf", Document: []byte("%PDF-1.7")},
+			wantInline: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part := geminiSamplePart(tt.sample)
+			if inline := part.InlineData != nil; inline != tt.wantInline {
+				t.Errorf("Gemini part inline = %v, want %v", inline, tt.wantInline)
+			}
+			if !tt.wantInline && (part.FileData == nil || part.FileData.FileURI != tt.sample.FileID) {
+				t.Errorf("Expected Gemini part to reference %s", tt.sample.FileID)
+			}
+
+			file := openAISampleFile(tt.sample)
+			if inline := file.FileData.IsPresent(); inline != tt.wantInline {
+				t.Errorf("OpenAI file inline = %v, want %v", inline, tt.wantInline)
+			}
+			if tt.wantInline && file.FileData.Value != "data:application/pdf;base64,JVBERi0xLjc=" {
+				t.Errorf("Unexpected OpenAI file data %q", file.FileData.Value)
+			}
+			if !tt.wantInline && file.FileID.Value != tt.sample.FileID {
+				t.Errorf("Expected OpenAI file to reference %s, got %q", tt.sample.FileID, file.FileID.Value)
+			}
+		})
+	}
+}
+
+func TestIdentifyTemplate(t *testing.T) {
+	samplesDir := filepath.Join("..", "..", "samples")
+	registry, err := templates.Parse([]byte(`
+templates:
+  - id: humira-complete
+    name: Humira Complete Enrollment
+    reference_pages:
+      - file: Humira1.pdf
+    hints:
+      - field: prescriber.npi
+        location: Prescriber box, right of the name
+  - id: gleevec
+    reference_pages:
+      - file: Gleevec.pdf
+`), samplesDir)
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	humira, err := os.ReadFile(filepath.Join(samplesDir, "Humira2.pdf"))
+	if err != nil {
+		t.Fatalf("Failed to read sample document: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		registry  *templates.Registry
+		document  []byte
+		requested string
+		want      string
+	}{
+		{"identified by layout", registry, humira, "", "humira-complete"},
+		{"requested template", registry, humira, "gleevec", "gleevec"},
+		{"unknown requested template", registry, humira, "other", "humira-complete"},
+		{"document without a scan", registry, []byte("not a pdf"), "", ""},
+		{"no templates", nil, humira, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := identifyTemplate(tt.registry, zap.NewNop(), "", tt.document, tt.requested)
+
+			var got string
+			if template != nil {
+				got = template.ID
+			}
+			if got != tt.want {
+				t.Errorf("identifyTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSystemInstructions(t *testing.T) {
+	const systemPrompt = "You read prescriptions.\n"
+	if got := systemInstructions(systemPrompt, nil, "Ignored without a template"); got != systemPrompt {
+		t.Errorf("Expected the system prompt without a template")
+	}
+
+	template := &templates.Template{
+		ID:    "humira-complete",
+		Name:  "Humira Complete Enrollment",
+		Hints: []templates.Hint{{Field: "prescriber.npi", Location: "Prescriber box, right of the name"}},
+	}
+
+	got := systemInstructions(systemPrompt, template, "")
+	for _, want := range []string{systemPrompt, "Humira Complete Enrollment form", "prescriber.npi: Prescriber box, right of the name"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected system instructions to contain %q", want)
+		}
+	}
+	if strings.Contains(got, "FORM-SPECIFIC INSTRUCTIONS") {
+		t.Errorf("Expected no form-specific instructions without an overlay")
+	}
+
+	got = systemInstructions(systemPrompt, template, "The M/H/W letters are phone labels.\n")
+	if !strings.HasSuffix(got, "FORM-SPECIFIC INSTRUCTIONS:\nThe M/H/W letters are phone labels.\n") {
+		t.Errorf("Expected the overlay to be appended, got %q", got[len(systemPrompt):])
+	}
+}
+
+func TestTemplateOverlay(t *testing.T) {
+	ds := mocks.NewMockDatastore()
+	ctx := context.Background()
+	for _, instructions := range []string{"The DAW box is in the lower right.", "The DAW box is in the lower left."} {
+		if _, err := ds.SavePromptOverlay(ctx, "humira-complete", instructions, "jdoe"); err != nil {
+			t.Fatalf("Failed to save prompt overlay: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		template    *templates.Template
+		want        string
+		wantVersion int
+	}{
+		{"latest version", &templates.Template{ID: "humira-complete"}, "The DAW box is in the lower left.", 2},
+		{"no overlay", &templates.Template{ID: "gleevec"}, "", 0},
+		{"no template", nil, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, version := templateOverlay(ctx, ds, zap.NewNop(), "", tt.template)
+			if got != tt.want || version != tt.wantVersion {
+				t.Errorf("templateOverlay() = %q, %d, want %q, %d", got, version, tt.want, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestPromptsForRequest(t *testing.T) {
+	registry, err := loadPrompts(config.Config{}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to load prompts: %v", err)
+	}
+
+	set, err := promptsForRequest(registry, models.ParseOptions{})
+	if err != nil || set.Version != prompts.BundledVersion {
+		t.Errorf("Expected the bundled prompt version by default, got %q, %v", set.Version, err)
+	}
+
+	if _, err := promptsForRequest(registry, models.ParseOptions{PromptVersion: "9"}); !errors.Is(err, ErrUnknownPromptVersion) {
+		t.Errorf("Expected ErrUnknownPromptVersion, got %v", err)
+	}
+}
+
+func TestExperimentParser(t *testing.T) {
+	openAI, gemini := mocks.NewMockParser(), mocks.NewMockParser()
+	experiment := experiments.Experiment{
+		ID: "review-prompt-2",
+		Variants: []experiments.Variant{
+			{Name: "control", Weight: 90},
+			{Name: "gemini-layout", Weight: 10, PromptVersion: "2", Backend: "Gemini", SampleRetrieval: RetrievalLayout},
+		},
+	}
+	roll := 0
+	p := &experimentParser{
+		Parser:     openAI,
+		experiment: experiment,
+		backends:   map[string]Parser{"OpenAI": openAI, "Gemini": gemini},
+		logger:     zap.NewNop(),
+		roll:       func() int { return roll },
+	}
+
+	filter := models.SampleMetadata{Tenant: "acme"}
+	if _, err := p.ParseImage(context.Background(), "a.pdf", strings.NewReader("%PDF"), models.ParseOptions{Samples: filter}); err != nil {
+		t.Fatalf("ParseImage() error = %v", err)
+	}
+	roll = 95
+	if _, err := p.ParseImage(context.Background(), "b.pdf", strings.NewReader("%PDF"), models.ParseOptions{Samples: filter}); err != nil {
+		t.Fatalf("ParseImage() error = %v", err)
+	}
+
+	control := openAI.GetParseImageOptions()
+	if len(control) != 1 || control[0].PromptVersion != "" || control[0].Samples != filter ||
+		control[0].Attributes[jobs.AttributeExperiment] != "review-prompt-2" || control[0].Attributes[jobs.AttributeVariant] != "control" {
+		t.Errorf("Expected the control variant on the configured backend, got %+v", control)
+	}
+
+	treatment := gemini.GetParseImageOptions()
+	if len(treatment) != 1 || treatment[0].PromptVersion != "2" || treatment[0].SampleRetrieval != RetrievalLayout ||
+		treatment[0].Attributes[jobs.AttributeVariant] != "gemini-layout" {
+		t.Errorf("Expected the treatment variant's settings on its backend, got %+v", treatment)
+	}
+
+	t.Run("requested prompt version", func(t *testing.T) {
+		if _, err := p.ParseImage(context.Background(), "c.pdf", strings.NewReader("%PDF"), models.ParseOptions{PromptVersion: "1"}); err != nil {
+			t.Fatalf("ParseImage() error = %v", err)
+		}
+		opts := openAI.GetParseImageOptions()
+		if last := opts[len(opts)-1]; last.PromptVersion != "1" || last.Attributes != nil {
+			t.Errorf("Expected the request to be left out of the experiment, got %+v", last)
+		}
+	})
+}
+
+func TestWithExperiment(t *testing.T) {
+	cfg := config.Config{ParserBackend: "OpenAI", OpenAIAPIKey: "test-key", GeminiAPIKey: "test-key"}
+	parser := mocks.NewMockParser()
+
+	writeExperiments := func(t *testing.T, data string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "experiments.yaml")
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name           string
+		data           string
+		wantExperiment bool
+		wantErr        string
+	}{
+		{"disabled", "experiments:\n  - id: a\n    variants:\n      - name: x\n        weight: 50\n      - name: y\n        weight: 50\n", false, ""},
+		{"enabled", "experiments:\n  - id: a\n    enabled: true\n    variants:\n      - name: x\n        weight: 50\n      - name: y\n        weight: 50\n        backend: Gemini\n", true, ""},
+		{"unknown prompt version", "experiments:\n  - id: a\n    enabled: true\n    variants:\n      - name: x\n        weight: 50\n      - name: y\n        weight: 50\n        prompt_version: \"9\"\n", false, "unknown prompt version"},
+		{"unknown sample retrieval", "experiments:\n  - id: a\n    enabled: true\n    variants:\n      - name: x\n        weight: 50\n      - name: y\n        weight: 50\n        sample_retrieval: random\n", false, "unknown sample retrieval"},
+		{"unknown backend", "experiments:\n  - id: a\n    enabled: true\n    variants:\n      - name: x\n        weight: 50\n      - name: y\n        weight: 50\n        backend: Claude\n", false, "unknown parser backend"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := cfg
+			cfg.ExperimentsFile = writeExperiments(t, tt.data)
+
+			got, err := withExperiment(cfg, mocks.NewMockDatastore(), zap.NewNop(), parser)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("withExperiment() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("withExperiment() error = %v", err)
+			}
+
+			experimentParser, ok := got.(*experimentParser)
+			if ok != tt.wantExperiment {
+				t.Fatalf("Expected an experiment parser: %v, got %T", tt.wantExperiment, got)
+			}
+			if ok {
+				if _, isGemini := experimentParser.backends["Gemini"].(*GeminiParser); !isGemini {
+					t.Errorf("Expected a Gemini parser for the Gemini variant, got %T", experimentParser.backends["Gemini"])
+				}
+			}
+		})
+	}
+
+	if got, err := withExperiment(cfg, mocks.NewMockDatastore(), zap.NewNop(), parser); err != nil || got != Parser(parser) {
+		t.Errorf("Expected the parser itself without an experiment file, got %T, %v", got, err)
+	}
+}
+
+func TestSampleRetrieverForRequest(t *testing.T) {
+	retriever, err := newSampleRetriever(config.Config{}, mocks.NewMockDatastore(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create sample retriever: %v", err)
+	}
+
+	if got, err := retriever.forRequest(models.ParseOptions{}); err != nil || got != retriever {
+		t.Errorf("Expected the configured retriever without an override, got %v", err)
+	}
+
+	got, err := retriever.forRequest(models.ParseOptions{SampleRetrieval: RetrievalLayout})
+	if err != nil || got.mode != RetrievalLayout || retriever.mode != "" {
+		t.Errorf("Expected a layout retriever leaving the configured one unchanged, got %+v, %v", got, err)
+	}
+
+	if _, err := retriever.forRequest(models.ParseOptions{SampleRetrieval: "random"}); err == nil {
+		t.Error("Expected an error for an unknown sample retrieval mode")
+	}
+}
+
+func TestParsePipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		pipeline string
+		want     []string
+		wantErr  bool
+	}{
+		{"default", "parse,retrieve,reparse,merge,normalize,validate", DefaultPipeline, false},
+		{"single pass", "parse, normalize, validate", []string{StageParse, StageNormalize, StageValidate}, false},
+		{"self-review and merge", "parse,self-review,merge", []string{StageParse, StageSelfReview, StageMerge}, false},
+		{"verify", "parse,normalize,validate,verify", []string{StageParse, StageNormalize, StageValidate, StageVerify}, false},
+		{"empty", "", nil, true},
+		{"no parse first", "retrieve,parse", nil, true},
+		{"second parse", "parse,parse", nil, true},
+		{"unknown stage", "parse,guess", nil, true},
+		{"reparse without retrieve", "parse,reparse", nil, true},
+		{"validate twice", "parse,validate,validate", nil, true},
+		{"normalize after validate", "parse,validate,normalize", nil, true},
+		{"merge after validate", "parse,self-review,validate,merge", nil, true},
+		{"verify without validate", "parse,normalize,verify", nil, true},
+		{"verify twice", "parse,validate,verify,verify", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePipeline(tt.pipeline)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPipeline) {
+					t.Errorf("Expected ErrInvalidPipeline, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParsePipeline(%q) = %v, want %v", tt.pipeline, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRequestPipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		pipeline string
+		wantErr  bool
+	}{
+		{"default", "parse,retrieve,reparse,merge,normalize,validate", false},
+		{"verify", "parse,normalize,validate,verify", false},
+		{"without validate", "parse", true},
+		{"single pass without validate", "parse,normalize", true},
+		{"invalid", "parse,guess,validate", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRequestPipeline(tt.pipeline)
+			if tt.wantErr != errors.Is(err, ErrInvalidPipeline) {
+				t.Errorf("ParseRequestPipeline(%q) error = %v, want error: %v", tt.pipeline, err, tt.wantErr)
+			}
+		})
+	}
+
+	// The deployment's pipeline may still leave out validation
+	if _, err := newPipeline(config.Config{Pipeline: "parse"}, mocks.NewMockDatastore(), zap.NewNop()); err != nil {
+		t.Errorf("Expected a configured pipeline without validate to be accepted, got %v", err)
+	}
+}
+
+// fakeBackend returns canned results and records the requests made to it.
+type fakeBackend struct {
+	parsed     models.Prescription
+	reparsed   models.Prescription
+	checked    models.Prescription
+	parseErr   error
+	parseErrOn int // Number of the parse request, from 1, failing with parseErr; zero for every request
+	checkErr   error
+	embedding  models.Embedding
+	calls      []string
+	prompts    []string // Prompts of the check requests
+}
+
+func (b *fakeBackend) prepare(ctx context.Context, doc *document) (func(), error) {
+	b.calls = append(b.calls, "prepare")
+	return func() { b.calls = append(b.calls, "release") }, nil
+}
+
+func (b *fakeBackend) parse(ctx context.Context, doc *document, promptSet prompts.Set) (models.Prescription, error) {
+	b.calls = append(b.calls, StageParse)
+	if b.parseErrOn != 0 && b.parseErrOn != countCalls(b.calls, StageParse) {
+		return b.parsed, nil
+	}
+	return b.parsed, b.parseErr
+}
+
+func (b *fakeBackend) reparse(ctx context.Context, doc *document, promptSet prompts.Set, samples []models.SamplePrescription) (models.Prescription, error) {
+	b.calls = append(b.calls, StageReparse)
+	return b.reparsed, nil
+}
+
+func (b *fakeBackend) check(ctx context.Context, doc *document, promptSet prompts.Set, prompt string, rx models.Prescription) (models.Prescription, error) {
+	b.calls = append(b.calls, "check")
+	b.prompts = append(b.prompts, prompt)
+	return b.checked, b.checkErr
+}
+
+func (b *fakeBackend) GetEmbedding(ctx context.Context, prescription models.Prescription) (models.Embedding, error) {
+	return b.embedding, nil
+}
+
+// countCalls returns the number of calls to a backend method.
+func countCalls(calls []string, method string) int {
+	count := 0
+	for _, call := range calls {
+		if call == method {
+			count++
+		}
+	}
+	return count
+}
+
+func TestPipelineRun(t *testing.T) {
+	embedding := models.Embedding{Model: mocks.MockEmbeddingModel, Vector: []float32{0.1, 0.2, 0.3}}
+	first := models.Prescription{Patient: models.Patient{FirstName: "Ann", LastName: "Lee"}}
+	second := models.Prescription{Patient: models.Patient{FirstName: "Anne"}}
+	invalidNPI := models.Prescription{Patient: models.Patient{FirstName: "Ann"}, Prescriber: models.Prescriber{Npi: "1234567890"}}
+
+	tests := []struct {
+		name             string
+		stages           []string
+		samples          []models.SamplePrescription
+		backend          *fakeBackend
+		wantStatus       jobs.JobStatus
+		wantCalls        []string
+		wantRx           models.Prescription
+		wantVerification []string
+	}{
+		{
+			name:       "reparse with samples",
+			stages:     DefaultPipeline,
+			samples:    []models.SamplePrescription{{MIMEType: "application/pdf", Content: "{}"}},
+			backend:    &fakeBackend{parsed: first, reparsed: second, embedding: embedding},
+			wantStatus: jobs.JobStatusComplete,
+			wantCalls:  []string{"prepare", StageParse, StageReparse, "release"},
+			wantRx:     models.Prescription{Patient: models.Patient{FirstName: "Anne", LastName: "Lee"}},
+		},
+		{
+			name:       "reparse skipped without samples",
+			stages:     DefaultPipeline,
+			backend:    &fakeBackend{parsed: first, reparsed: second, embedding: embedding},
+			wantStatus: jobs.JobStatusComplete,
+			wantCalls:  []string{"prepare", StageParse, "release"},
+			wantRx:     first,
+		},
+		{
+			name:       "merge fills empty fields",
+			stages:     []string{StageParse, StageSelfReview, StageMerge},
+			backend:    &fakeBackend{parsed: first, checked: second},
+			wantStatus: jobs.JobStatusComplete,
+			wantCalls:  []string{"prepare", StageParse, "check", "release"},
+			wantRx:     models.Prescription{Patient: models.Patient{FirstName: "Anne", LastName: "Lee"}},
+		},
+		{
+			name:       "failed self-review keeps result",
+			stages:     []string{StageParse, StageSelfReview},
+			backend:    &fakeBackend{parsed: first, checkErr: errors.New("timeout")},
+			wantStatus: jobs.JobStatusComplete,
+			wantCalls:  []string{"prepare", StageParse, "check", "release"},
+			wantRx:     first,
+		},
+		{
+			name:   "verify patches flagged fields",
+			stages: []string{StageParse, StageValidate, StageVerify},
+			backend: &fakeBackend{
+				parsed:  invalidNPI,
+				checked: models.Prescription{Patient: models.Patient{FirstName: "Bob"}, Prescriber: models.Prescriber{Npi: "1234567893"}},
+			},
+			wantStatus:       jobs.JobStatusComplete,
+			wantCalls:        []string{"prepare", StageParse, "check", "release"},
+			wantRx:           models.Prescription{Patient: models.Patient{FirstName: "Ann"}, Prescriber: models.Prescriber{Npi: "1234567893"}},
+			wantVerification: []string{"prescriber.npi"},
+		},
+		{
+			name:       "verify skipped without flagged fields",
+			stages:     []string{StageParse, StageValidate, StageVerify},
+			backend:    &fakeBackend{parsed: first, checked: second},
+			wantStatus: jobs.JobStatusComplete,
+			wantCalls:  []string{"prepare", StageParse, "release"},
+			wantRx:     first,
+		},
+		{
+			name:       "failed parse fails job",
+			stages:     DefaultPipeline,
+			backend:    &fakeBackend{parseErr: errors.New("timeout")},
+			wantStatus: jobs.JobStatusFailed,
+			wantCalls:  []string{"prepare", StageParse, "release"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := mocks.NewMockDatastore()
+			ds.SetSamplePrescriptions(embedding.Vector, tt.samples, nil)
+
+			pl, err := newPipeline(config.Config{}, ds, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Failed to create pipeline: %v", err)
+			}
+
+			jobID := jobs.GlobalTracker.CreateJob(JobTypeParsePrescription, "Processing image: rx.pdf")
+			processing, _, err := pl.postProcessing.forRequest(models.ParseOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get post-processing: %v", err)
+			}
+			r := &run{jobID: jobID, stages: tt.stages, retriever: pl.samples, processing: processing}
+			pl.run(context.Background(), tt.backend, r, "rx.pdf", strings.NewReader("%PDF-1.4"))
+
+			job, _ := jobs.GlobalTracker.Snapshot(jobID)
+			if job.Status != tt.wantStatus {
+				t.Fatalf("Expected status %s, got %s (%s)", tt.wantStatus, job.Status, job.Error)
+			}
+			if !slices.Equal(tt.backend.calls, tt.wantCalls) {
+				t.Errorf("Expected calls %v, got %v", tt.wantCalls, tt.backend.calls)
+			}
+			if tt.wantStatus == jobs.JobStatusComplete && !reflect.DeepEqual(job.Result, tt.wantRx) {
+				t.Errorf("Expected result %+v, got %+v", tt.wantRx, job.Result)
+			}
+
+			var verified []string
+			for _, change := range job.Verification {
+				verified = append(verified, change.Field)
+			}
+			if !slices.Equal(verified, tt.wantVerification) {
+				t.Errorf("Expected verification changes to %v, got %+v", tt.wantVerification, job.Verification)
+			}
+			if len(tt.wantVerification) > 0 && len(job.Validation) != 0 {
+				t.Errorf("Expected the patched result to be validated again, got %+v", job.Validation)
+			}
+		})
+	}
+}
+
+func TestPipelineRunSegments(t *testing.T) {
+	samplesDir := filepath.Join("..", "..", "samples")
+	registry, err := templates.Parse([]byte(`
+templates:
+  - id: humira-complete
+    reference_pages:
+      - file: Humira1.pdf
+  - id: gleevec
+    reference_pages:
+      - file: Gleevec.pdf
+`), samplesDir)
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	var documents []io.ReadSeeker
+	for _, name := range []string{"Humira1.pdf", "Humira2.pdf", "Gleevec.pdf"} {
+		document, err := os.ReadFile(filepath.Join(samplesDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		documents = append(documents, bytes.NewReader(document))
+	}
+	var fax bytes.Buffer
+	if err := api.MergeRaw(documents, &fax, false, nil); err != nil {
+		t.Fatalf("Failed to merge sample documents: %v", err)
+	}
+
+	pl, err := newPipeline(config.Config{SegmentDocuments: true}, mocks.NewMockDatastore(), zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create pipeline: %v", err)
+	}
+	pl.templates = registry
+	pl.segmenter = segment.NewSegmenter(registry)
+
+	jobID := jobs.GlobalTracker.CreateJob(JobTypeParsePrescription, "Processing image: fax.pdf")
+	processing, _, err := pl.postProcessing.forRequest(models.ParseOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get post-processing: %v", err)
+	}
+	rx := models.Prescription{Patient: models.Patient{FirstName: "Ann"}}
+	backend := &fakeBackend{parsed: rx}
+	r := &run{jobID: jobID, stages: []string{StageParse, StageValidate}, retriever: pl.samples, processing: processing}
+	pl.run(context.Background(), backend, r, "fax.pdf", bytes.NewReader(fax.Bytes()))
+
+	job, _ := jobs.GlobalTracker.Snapshot(jobID)
+	if job.Status != jobs.JobStatusComplete {
+		t.Fatalf("Expected status %s, got %s (%s)", jobs.JobStatusComplete, job.Status, job.Error)
+	}
+	if got := len(backend.calls); got != 9 {
+		t.Errorf("Expected each prescription to be prepared, parsed and released, got %v", backend.calls)
+	}
+	if !reflect.DeepEqual(job.Result, rx) || job.Attributes[jobs.AttributeTemplate] != "humira-complete" {
+		t.Errorf("Expected the first prescription as the result, got %+v with attributes %v", job.Result, job.Attributes)
+	}
+
+	want := []struct {
+		pages    models.PageRange
+		template string
+	}{
+		{models.PageRange{First: 1, Last: 1}, "humira-complete"},
+		{models.PageRange{First: 2, Last: 2}, "humira-complete"},
+		{models.PageRange{First: 3, Last: 3}, "gleevec"},
+	}
+	if len(job.Prescriptions) != len(want) {
+		t.Fatalf("Expected %d prescriptions, got %+v", len(want), job.Prescriptions)
+	}
+	for i, w := range want {
+		if got := job.Prescriptions[i]; got.Pages != w.pages || got.Template != w.template {
+			t.Errorf("Expected prescription %d on pages %+v from %s, got pages %+v from %s", i, w.pages, w.template, got.Pages, got.Template)
+		}
+	}
+
+	t.Run("failed prescription", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			parseErrOn int
+			wantStatus jobs.JobStatus
+			wantFailed []bool
+		}{
+			{"first", 1, jobs.JobStatusComplete, []bool{true, false, false}},
+			{"last", 3, jobs.JobStatusComplete, []bool{false, false, true}},
+			{"every", 0, jobs.JobStatusFailed, nil},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				jobID := jobs.GlobalTracker.CreateJob(JobTypeParsePrescription, "Processing image: fax.pdf")
+				backend := &fakeBackend{parsed: rx, parseErr: errors.New("model unavailable"), parseErrOn: tt.parseErrOn}
+				r := &run{jobID: jobID, stages: []string{StageParse, StageValidate}, retriever: pl.samples, processing: processing}
+				pl.run(context.Background(), backend, r, "fax.pdf", bytes.NewReader(fax.Bytes()))
+
+				job, _ := jobs.GlobalTracker.Snapshot(jobID)
+				if job.Status != tt.wantStatus {
+					t.Fatalf("Expected status %s, got %s (%s)", tt.wantStatus, job.Status, job.Error)
+				}
+				if tt.wantStatus == jobs.JobStatusFailed {
+					if !strings.Contains(job.Error, "model unavailable") {
+						t.Errorf("Expected the parse error on the job, got %q", job.Error)
+					}
+					return
+				}
+
+				// The job's result is the first prescription parsed
+				if !reflect.DeepEqual(job.Result, rx) || job.Attributes[jobs.AttributeTemplate] != "humira-complete" {
+					t.Errorf("Expected the first parsed prescription as the result, got %+v with attributes %v", job.Result, job.Attributes)
+				}
+				if len(job.Prescriptions) != len(tt.wantFailed) {
+					t.Fatalf("Expected %d prescriptions, got %+v", len(tt.wantFailed), job.Prescriptions)
+				}
+				for i, failed := range tt.wantFailed {
+					got := job.Prescriptions[i]
+					if got.Pages != want[i].pages {
+						t.Errorf("Expected prescription %d on pages %+v, got %+v", i, want[i].pages, got.Pages)
+					}
+					if failed != (got.Error != "") || failed && !got.Blocked {
+						t.Errorf("Expected prescription %d failed: %v, got error %q, blocked: %v", i, failed, got.Error, got.Blocked)
+					}
+				}
+			})
+		}
+	})
+}
+
+func TestArbitrate(t *testing.T) {
+	pp, err := newPostProcessing(config.Config{}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create post-processing: %v", err)
+	}
+
+	passes := []passResult{
+		{stage: StageParse, rx: models.Prescription{
+			DateWritten:  "2024-01-02",
+			Patient:      models.Patient{FirstName: "Ann", LastName: "Lee"},
+			Prescriber:   models.Prescriber{Npi: "1234567893"},
+			ClinicalInfo: []string{"BSA 1.8"},
+			Medications:  []models.Medication{{DrugName: "Humira", Strength: "40 mg"}},
+		}},
+		{stage: StageReparse, rx: models.Prescription{
+			DateWritten:  "2024-01-03",
+			Patient:      models.Patient{FirstName: "Anne"},
+			Prescriber:   models.Prescriber{Npi: "1234567890"},
+			ClinicalInfo: []string{},
+			Medications:  []models.Medication{{DrugName: "Humira", Strength: "80 mg"}},
+		}},
+		{stage: StageSelfReview, rx: models.Prescription{
+			DateWritten: "2024-01-02",
+			Patient:     models.Patient{FirstName: "Anne"},
+			Prescriber:  models.Prescriber{Npi: "1234567890"},
+			Medications: []models.Medication{{DrugName: "Humira", Strength: "80 mg"}},
+		}},
+	}
+
+	got, origins, err := arbitrate(context.Background(), pp.validators, passes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := models.Prescription{
+		DateWritten:  "2024-01-02",
+		Patient:      models.Patient{FirstName: "Anne", LastName: "Lee"},
+		Prescriber:   models.Prescriber{Npi: "1234567893"},
+		ClinicalInfo: []string{"BSA 1.8"},
+		Medications:  []models.Medication{{DrugName: "Humira", Strength: "80 mg"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("arbitrate() = %+v, want %+v", got, want)
+	}
+
+	wantOrigins := map[string]string{
+		"date_written":             StageSelfReview, // Agreement over the latest different value
+		"patient.first_name":       StageSelfReview, // Latest of the agreeing passes
+		"patient.last_name":        StageParse,      // Only non-empty value
+		"prescriber.npi":           StageParse,      // Only value passing validation
+		"clinical_info":            StageParse,      // Lists of strings are taken whole
+		"medications[0].drug_name": StageSelfReview, // Lists of objects of the same length are arbitrated by element
+		"medications[0].strength":  StageSelfReview,
+	}
+	for field, stage := range wantOrigins {
+		if origins[field] != stage {
+			t.Errorf("Expected %s to be taken from %s, got %q", field, stage, origins[field])
+		}
+	}
+	if _, ok := origins["patient.dob"]; ok {
+		t.Errorf("Expected no origin for a field empty in every pass")
+	}
+	if passes[0].rx.Medications[0].DeaSchedule != "" {
+		t.Errorf("Expected the passes not to be modified by validation")
+	}
+}
+
+func TestPatchFields(t *testing.T) {
+	rx := models.Prescription{
+		Patient:     models.Patient{FirstName: "Ann"},
+		Prescriber:  models.Prescriber{Npi: "1234567890"},
+		Medications: []models.Medication{{Strength: "40 mg"}},
+	}
+	checked := models.Prescription{
+		Patient:     models.Patient{FirstName: "Bob"},
+		Prescriber:  models.Prescriber{Npi: "1234567893"},
+		Medications: []models.Medication{{DrugName: "Humira", Strength: "80 mg"}, {DrugName: "Enbrel"}},
+	}
+
+	fields, questions := flaggedFields([]models.ValidationIssue{
+		{Field: "prescriber.npi", Severity: models.SeverityWarning, Message: "bad check digit"},
+		{Field: "medications[0].drug_name", Severity: models.SeverityWarning, Message: "missing"},
+		{Field: "medications[0].drug_name", Severity: models.SeverityError, Message: "required"},
+		{Field: "medications[0].strength", Severity: models.SeverityInfo, Message: "noted"},
+		{Field: "medications[1].drug_name", Severity: models.SeverityWarning, Message: "missing"},
+		{Field: "medications[*].ndc", Severity: models.SeverityWarning, Message: "required"},
+		{Code: "rules_unavailable", Severity: models.SeverityWarning},
+	})
+	if !strings.Contains(questions, "- medications[0].drug_name: missing; required\n") {
+		t.Errorf("Expected one question per field, got %q", questions)
+	}
+
+	got, changes, err := patchFields(rx, checked, fields)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := models.Prescription{
+		Patient:     models.Patient{FirstName: "Ann"},
+		Prescriber:  models.Prescriber{Npi: "1234567893"},
+		Medications: []models.Medication{{DrugName: "Humira", Strength: "40 mg"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("patchFields() = %+v, want %+v", got, want)
+	}
+
+	wantChanges := []models.FieldChange{
+		{Field: "prescriber.npi", Original: "1234567890", Corrected: "1234567893"},
+		{Field: "medications[0].drug_name", Original: "", Corrected: "Humira"},
+	}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("Expected changes %+v, got %+v", wantChanges, changes)
+	}
+
+	t.Run("checked has fewer medications", func(t *testing.T) {
+		rx := models.Prescription{Medications: []models.Medication{{DrugName: "Humira"}, {DrugName: "Enbrel", Strength: "50 mg"}}}
+		checked := models.Prescription{Medications: []models.Medication{{DrugName: "Humira"}}}
+
+		got, changes, err := patchFields(rx, checked, []string{"medications[1].drug_name", "medications[1].strength"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, rx) || len(changes) != 0 {
+			t.Errorf("Expected the second medication to be kept, got %+v with changes %+v", got, changes)
+		}
+	})
+}
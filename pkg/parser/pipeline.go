@@ -0,0 +1,565 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/prompts"
+	"github.com/csotherden/prescription-parser/pkg/segment"
+	"github.com/csotherden/prescription-parser/pkg/templates"
+	"go.uber.org/zap"
+)
+
+// Pipeline stages. A pipeline is the list of stages run for a document, in order, each working
+// on the result of the stages before it.
+const (
+	StageParse      = "parse"       // Parses the document
+	StageRetrieve   = "retrieve"    // Finds samples similar to the document for a reparse
+	StageReparse    = "reparse"     // Parses the document again after the retrieved samples as examples
+	StageSelfReview = "self-review" // Has the model check the result against the document and correct it
+	StageMerge      = "merge"       // Arbitrates between the passes field by field, recording each field's origin
+	StageNormalize  = "normalize"   // Standardizes addresses, phone numbers and drug names
+	StageValidate   = "validate"    // Checks controlled substances, consistency and the request's validation rule set
+	StageVerify     = "verify"      // Has the model re-check the fields validation flagged and patches only those
+)
+
+// DefaultPipeline is run when neither the configuration nor the request selects a pipeline.
+var DefaultPipeline = []string{StageParse, StageRetrieve, StageReparse, StageMerge, StageNormalize, StageValidate}
+
+// ErrInvalidPipeline is returned when a pipeline has unknown stages or stages in an invalid order.
+var ErrInvalidPipeline = errors.New("invalid pipeline")
+
+// stage is a step of the pipeline. A stage that fails is logged and the pipeline continues with
+// the result so far, except for StageParse, without whose result the job fails.
+type stage func(ctx context.Context, pl *pipeline, b backend, r *run) error
+
+// pipelineStages holds the pipeline stages by name.
+var pipelineStages = map[string]stage{
+	StageParse:      parseStage,
+	StageRetrieve:   retrieveStage,
+	StageReparse:    reparseStage,
+	StageSelfReview: selfReviewStage,
+	StageMerge:      mergeStage,
+	StageNormalize:  normalizeStage,
+	StageValidate:   validateStage,
+	StageVerify:     verifyStage,
+}
+
+// ParsePipeline parses a comma-separated list of stages. It returns an error wrapping
+// ErrInvalidPipeline if the pipeline does not start with a single parse stage, uses an unknown
+// stage, reparses before retrieving samples, normalizes, validates or verifies more than once,
+// validates before normalizing, merges after normalizing or validating, or verifies without
+// validating first.
+func ParsePipeline(s string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 || names[0] != StageParse {
+		return nil, fmt.Errorf("%w: must start with %s", ErrInvalidPipeline, StageParse)
+	}
+
+	seen := map[string]int{}
+	for _, name := range names {
+		if _, ok := pipelineStages[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown stage %q. Must be one of %s", ErrInvalidPipeline, name, strings.Join(stageNames(), ", "))
+		}
+		seen[name]++
+
+		switch {
+		case name == StageParse && seen[name] > 1:
+			return nil, fmt.Errorf("%w: %s can only be the first stage", ErrInvalidPipeline, StageParse)
+		case name == StageReparse && seen[StageRetrieve] == 0:
+			return nil, fmt.Errorf("%w: %s needs an earlier %s stage", ErrInvalidPipeline, StageReparse, StageRetrieve)
+		case (name == StageNormalize || name == StageValidate || name == StageVerify) && seen[name] > 1:
+			return nil, fmt.Errorf("%w: %s can only run once", ErrInvalidPipeline, name)
+		case name == StageNormalize && seen[StageValidate] > 0:
+			return nil, fmt.Errorf("%w: %s must come before %s", ErrInvalidPipeline, StageNormalize, StageValidate)
+		case name == StageMerge && seen[StageNormalize]+seen[StageValidate] > 0:
+			return nil, fmt.Errorf("%w: %s must come before %s and %s", ErrInvalidPipeline, StageMerge, StageNormalize, StageValidate)
+		case name == StageVerify && seen[StageValidate] == 0:
+			return nil, fmt.Errorf("%w: %s needs an earlier %s stage", ErrInvalidPipeline, StageVerify, StageValidate)
+		}
+	}
+
+	return names, nil
+}
+
+// ParseRequestPipeline parses the pipeline selected for a single request, or for an experiment
+// variant, like ParsePipeline. Only the deployment's pipeline may leave out validation, so it
+// also returns an error wrapping ErrInvalidPipeline if the pipeline has no validate stage.
+func ParseRequestPipeline(s string) ([]string, error) {
+	stages, err := ParsePipeline(s)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(stages, StageValidate) {
+		return nil, fmt.Errorf("%w: %s is required", ErrInvalidPipeline, StageValidate)
+	}
+	return stages, nil
+}
+
+// backend is the model-specific part of parsing, implemented by each parser. The pipeline
+// decides which requests are made, in which order.
+type backend interface {
+	// prepare makes a document available to the model and returns a function releasing it.
+	prepare(ctx context.Context, doc *document) (func(), error)
+
+	// parse parses a document.
+	parse(ctx context.Context, doc *document, promptSet prompts.Set) (models.Prescription, error)
+
+	// reparse parses a document after the samples' documents and prescriptions as examples.
+	reparse(ctx context.Context, doc *document, promptSet prompts.Set, samples []models.SamplePrescription) (models.Prescription, error)
+
+	// check has the model correct a parse result against its document, following prompt, which
+	// is followed by the result's JSON.
+	check(ctx context.Context, doc *document, promptSet prompts.Set, prompt string, rx models.Prescription) (models.Prescription, error)
+
+	// GetEmbedding generates the content embedding samples are retrieved by.
+	GetEmbedding(ctx context.Context, prescription models.Prescription) (models.Embedding, error)
+}
+
+// document is the document of a parse job.
+type document struct {
+	fileName    string
+	contentType string
+	data        []byte
+	fileID      string // ID of the uploaded file, for backends that upload documents
+}
+
+// run is the state of the pipeline of one job, and of each prescription found in its document.
+type run struct {
+	jobID        string
+	stages       []string
+	doc          *document
+	pages        models.PageRange // Pages of the job's document the prescription is parsed from
+	first        bool             // Whether the prescription is the first to be parsed, which is the job's result
+	promptSet    prompts.Set
+	template     *templates.Template
+	filter       models.SampleMetadata
+	retriever    *sampleRetriever
+	processing   requestProcessing
+	rx           models.Prescription         // Result so far
+	passes       []passResult                // Results of the model stages, oldest first
+	origins      map[string]string           // Stage each field was taken from, by field path, if the passes were merged
+	samples      []models.SamplePrescription // Samples found by the retrieve stage
+	issues       []models.ValidationIssue
+	verification []models.FieldChange // Fields changed by the verify stage, nil if it did not run
+}
+
+// pipeline runs parse jobs through the configured stages. It holds what the parsers share; the
+// model requests are made by the parser's backend.
+type pipeline struct {
+	ds             datastore.Datastore
+	results        datastore.Datastore // Store for completed job results, nil if results are not persisted
+	logger         *zap.Logger
+	postProcessing *postProcessing
+	samples        *sampleRetriever
+	templates      *templates.Registry // Form templates documents are identified as, nil if none are configured
+	segmenter      *segment.Segmenter  // Splits documents into their prescriptions, nil if documents are not segmented
+	prompts        *prompts.Registry
+	stages         []string // Stages run for requests that select none
+}
+
+// newPipeline creates the pipeline from the configuration.
+func newPipeline(cfg config.Config, ds datastore.Datastore, logger *zap.Logger) (*pipeline, error) {
+	stages := DefaultPipeline
+	if cfg.Pipeline != "" {
+		configured, err := ParsePipeline(cfg.Pipeline)
+		if err != nil {
+			return nil, err
+		}
+		stages = configured
+	}
+
+	postProcessing, err := newPostProcessing(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	samples, err := newSampleRetriever(cfg, ds, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	templateRegistry, err := loadTemplates(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	promptRegistry, err := loadPrompts(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var results datastore.Datastore
+	if cfg.PersistResults {
+		results = ds
+	}
+
+	var segmenter *segment.Segmenter
+	if cfg.SegmentDocuments {
+		segmenter = segment.NewSegmenter(templateRegistry)
+	}
+
+	return &pipeline{
+		ds:             ds,
+		results:        results,
+		logger:         logger,
+		postProcessing: postProcessing,
+		samples:        samples,
+		templates:      templateRegistry,
+		segmenter:      segmenter,
+		prompts:        promptRegistry,
+		stages:         stages,
+	}, nil
+}
+
+// start validates a parse request, creates its job and runs the pipeline for it in the
+// background with the backend of the named parser.
+func (pl *pipeline) start(b backend, backendName, fileName string, file io.Reader, opts models.ParseOptions) (string, error) {
+	processing, ruleSet, err := pl.postProcessing.forRequest(opts)
+	if err != nil {
+		return "", err
+	}
+
+	promptSet, err := promptsForRequest(pl.prompts, opts)
+	if err != nil {
+		return "", err
+	}
+
+	retriever, err := pl.samples.forRequest(opts)
+	if err != nil {
+		return "", err
+	}
+
+	stages := pl.stages
+	if opts.Pipeline != "" {
+		if stages, err = ParseRequestPipeline(opts.Pipeline); err != nil {
+			return "", err
+		}
+	}
+
+	// Create a job for asynchronous processing
+	jobID := jobs.GlobalTracker.CreateJob(
+		JobTypeParsePrescription,
+		fmt.Sprintf("Processing image: %s", fileName),
+	)
+
+	if ruleSet != "" {
+		jobs.GlobalTracker.SetAttribute(jobID, jobs.AttributeRuleSet, ruleSet)
+	}
+	jobs.GlobalTracker.SetAttribute(jobID, jobs.AttributeFileName, fileName)
+	jobs.GlobalTracker.SetAttribute(jobID, jobs.AttributePromptVersion, promptSet.Version)
+	jobs.GlobalTracker.SetAttribute(jobID, jobs.AttributePipeline, strings.Join(stages, ","))
+	jobs.GlobalTracker.SetAttribute(jobID, jobs.AttributeBackend, backendName)
+	for key, value := range opts.Attributes {
+		jobs.GlobalTracker.SetAttribute(jobID, key, value)
+	}
+
+	pl.logger.Info("starting image parsing", zap.String("job_id", jobID), zap.String("file_name", fileName), zap.String("rule_set", ruleSet), zap.String("prompt_version", promptSet.Version), zap.Strings("pipeline", stages))
+
+	r := &run{
+		jobID:      jobID,
+		stages:     stages,
+		promptSet:  promptSet,
+		filter:     opts.Samples,
+		retriever:  retriever,
+		processing: processing,
+	}
+	go pl.run(context.Background(), b, r, fileName, file)
+
+	return jobID, nil
+}
+
+// run reads the document, splits it into its prescriptions if documents are segmented, and
+// runs the stages for each, then completes the job with the results.
+func (pl *pipeline) run(ctx context.Context, b backend, r *run, fileName string, file io.Reader) {
+	fileExt := strings.ToLower(filepath.Ext(fileName))
+	var contentType string
+	switch fileExt {
+	case ".pdf":
+		contentType = "application/pdf"
+	default:
+		jobs.GlobalTracker.UpdateJob(r.jobID, jobs.JobStatusFailed, fmt.Errorf("unsupported file type. file must be PDF not %s", fileExt), nil)
+		return
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		jobs.GlobalTracker.UpdateJob(r.jobID, jobs.JobStatusFailed, fmt.Errorf("failed to read file contents: %w", err), nil)
+		return
+	}
+
+	// Update job status to processing
+	jobs.GlobalTracker.UpdateJob(r.jobID, jobs.JobStatusProcessing, nil, nil)
+
+	// A prescription that fails is recorded with its pages and error, and the job completes with
+	// the others. The job fails only when none of its prescriptions could be parsed.
+	segments := pl.segments(r.jobID, data)
+	prescriptions := make([]models.DocumentPrescription, 0, len(segments))
+	var firstErr error
+	for _, pages := range segments {
+		// Each prescription starts from the job's settings with a result of its own
+		segmentRun := *r
+		segmentRun.pages = pages
+		segmentRun.first = !slices.ContainsFunc(prescriptions, parsed)
+		segmentRun.doc = &document{fileName: fileName, contentType: contentType, data: data}
+
+		var prescription models.DocumentPrescription
+		var err error
+		if len(segments) > 1 {
+			segmentRun.doc.data, err = segment.Extract(data, pages)
+		}
+		if err == nil {
+			prescription, err = pl.runSegment(ctx, b, &segmentRun)
+		}
+		if err != nil {
+			pl.logger.Error("failed to parse prescription pages", zap.String("job_id", r.jobID), zap.String("file_name", fileName), zap.Int("first_page", pages.First), zap.Int("last_page", pages.Last), zap.Error(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			prescription = models.DocumentPrescription{Pages: pages, Blocked: true, Error: err.Error()}
+		}
+		prescriptions = append(prescriptions, prescription)
+	}
+
+	if !slices.ContainsFunc(prescriptions, parsed) {
+		jobs.GlobalTracker.UpdateJob(r.jobID, jobs.JobStatusFailed, firstErr, nil)
+		return
+	}
+
+	pl.logger.Info("successfully processed image", zap.String("job_id", r.jobID), zap.String("file_name", fileName), zap.Int("prescription_count", len(prescriptions)))
+	completeJob(ctx, pl.results, pl.logger, r.jobID, prescriptions)
+}
+
+// parsed reports whether a prescription of a document was parsed.
+func parsed(prescription models.DocumentPrescription) bool {
+	return prescription.Error == ""
+}
+
+// segments returns the pages of each prescription in a document. Unless documents are
+// segmented, or when a document cannot be segmented, the whole document is one prescription.
+func (pl *pipeline) segments(jobID string, data []byte) []models.PageRange {
+	if pl.segmenter != nil {
+		segments, err := pl.segmenter.Segments(data)
+		if err == nil {
+			pl.logger.Info("segmented document", zap.String("job_id", jobID), zap.Int("prescription_count", len(segments)))
+			return segments
+		}
+		pl.logger.Warn("failed to segment document, parsing it as one prescription", zap.String("job_id", jobID), zap.Error(err))
+	}
+
+	// A document whose pages cannot be counted is still parsed; its page range ends at 0
+	count, _ := segment.PageCount(data)
+	return []models.PageRange{{First: 1, Last: count}}
+}
+
+// runSegment prepares the document of one prescription for the backend, identifies its form
+// template and runs the stages. The job's template attributes are recorded from the first
+// prescription parsed, which is the job's result.
+func (pl *pipeline) runSegment(ctx context.Context, b backend, r *run) (models.DocumentPrescription, error) {
+	release, err := b.prepare(ctx, r.doc)
+	if err != nil {
+		pl.logger.Error("failed to prepare document", zap.String("job_id", r.jobID), zap.String("file_name", r.doc.fileName), zap.Error(err))
+		return models.DocumentPrescription{}, err
+	}
+	defer release()
+
+	r.template = identifyTemplate(pl.templates, pl.logger, r.jobID, r.doc.data, r.filter.Template)
+	overlay, overlayVersion := templateOverlay(ctx, pl.ds, pl.logger, r.jobID, r.template)
+	r.promptSet.System = systemInstructions(r.promptSet.System, r.template, overlay)
+
+	for _, name := range r.stages {
+		err := pipelineStages[name](ctx, pl, b, r)
+		if err == nil {
+			continue
+		}
+		if name == StageParse {
+			pl.logger.Error("failed in parse stage", zap.String("job_id", r.jobID), zap.Error(err))
+			return models.DocumentPrescription{}, fmt.Errorf("failed in parse stage: %w", err)
+		}
+		pl.logger.Error("failed in pipeline stage, continuing with the result so far", zap.String("job_id", r.jobID), zap.String("stage", name), zap.Error(err))
+	}
+
+	if r.first {
+		if r.template != nil {
+			jobs.GlobalTracker.SetAttribute(r.jobID, jobs.AttributeTemplate, r.template.ID)
+		}
+		if overlayVersion > 0 {
+			jobs.GlobalTracker.SetAttribute(r.jobID, jobs.AttributePromptOverlay, strconv.Itoa(overlayVersion))
+		}
+	}
+
+	prescription := models.DocumentPrescription{
+		Pages:        r.pages,
+		Prescription: r.rx,
+		Validation:   r.issues,
+		Blocked:      models.Blocking(r.issues),
+		Verification: r.verification,
+		Origins:      r.origins,
+	}
+	if r.template != nil {
+		prescription.Template = r.template.ID
+	}
+	return prescription, nil
+}
+
+// pass records the result of a model stage as the result so far.
+func (r *run) pass(stage string, rx models.Prescription) {
+	r.rx = rx
+	r.passes = append(r.passes, passResult{stage: stage, rx: rx})
+}
+
+// parseStage parses the document.
+func parseStage(ctx context.Context, pl *pipeline, b backend, r *run) error {
+	rx, err := b.parse(ctx, r.doc, r.promptSet)
+	if err != nil {
+		return err
+	}
+
+	r.pass(StageParse, rx)
+	pl.logger.Info("parse stage completed", zap.String("job_id", r.jobID), zap.String("file_name", r.doc.fileName))
+	return nil
+}
+
+// retrieveStage finds samples for a reparse, preferring samples of the document's form template.
+func retrieveStage(ctx context.Context, pl *pipeline, b backend, r *run) error {
+	var templateID string
+	if r.template != nil {
+		templateID = r.template.ID
+	}
+
+	samples, err := r.retriever.retrieve(ctx, r.jobID, r.doc.data, r.filter, templateID, func(ctx context.Context) (models.Embedding, error) {
+		return b.GetEmbedding(ctx, r.rx)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.samples = samples
+	pl.logger.Info("sample images loaded", zap.String("job_id", r.jobID), zap.String("file_name", r.doc.fileName), zap.Int("sample_count", len(samples)))
+	return nil
+}
+
+// reparseStage parses the document again after the retrieved samples as examples. It is skipped
+// when no samples were found.
+func reparseStage(ctx context.Context, pl *pipeline, b backend, r *run) error {
+	if len(r.samples) == 0 {
+		pl.logger.Info("no samples retrieved, skipping reparse stage", zap.String("job_id", r.jobID))
+		return nil
+	}
+
+	rx, err := b.reparse(ctx, r.doc, r.promptSet, r.samples)
+	if err != nil {
+		return err
+	}
+
+	r.pass(StageReparse, rx)
+	pl.logger.Info("reparse stage completed", zap.String("job_id", r.jobID), zap.String("file_name", r.doc.fileName))
+	return nil
+}
+
+// selfReviewStage has the model correct the result so far against the document.
+func selfReviewStage(ctx context.Context, pl *pipeline, b backend, r *run) error {
+	rx, err := b.check(ctx, r.doc, r.promptSet, r.promptSet.SelfReview, r.rx)
+	if err != nil {
+		return err
+	}
+
+	r.pass(StageSelfReview, rx)
+	pl.logger.Info("self-review stage completed", zap.String("job_id", r.jobID), zap.String("file_name", r.doc.fileName))
+	return nil
+}
+
+// mergeStage arbitrates between the results of the model stages field by field, so a later pass
+// cannot drop or spoil what an earlier pass read, and records the stage each field was taken
+// from. It is skipped when there was only one pass.
+func mergeStage(ctx context.Context, pl *pipeline, b backend, r *run) error {
+	if len(r.passes) < 2 {
+		return nil
+	}
+
+	rx, origins, err := arbitrate(ctx, r.processing.validators, r.passes)
+	if err != nil {
+		return err
+	}
+
+	r.rx = rx
+	r.origins = origins
+	pl.logger.Info("merge stage completed", zap.String("job_id", r.jobID), zap.Int("pass_count", len(r.passes)))
+	return nil
+}
+
+// normalizeStage standardizes the result with the normalizing post-processors.
+func normalizeStage(ctx context.Context, pl *pipeline, b backend, r *run) error {
+	r.issues = append(r.issues, process(ctx, r.processing.normalizers, &r.rx)...)
+	return nil
+}
+
+// validateStage checks the result with the validating post-processors and the request's rule set.
+func validateStage(ctx context.Context, pl *pipeline, b backend, r *run) error {
+	r.issues = append(r.issues, process(ctx, r.processing.validators, &r.rx)...)
+	return nil
+}
+
+// verifyStage has the model re-check the fields validation flagged against the document and
+// patches only those fields of the result, recording the fields it changed. The
+// result is then normalized, if the pipeline normalizes, and validated again, so its issues
+// reflect the corrections. It is skipped when no field was flagged.
+func verifyStage(ctx context.Context, pl *pipeline, b backend, r *run) error {
+	fields, questions := flaggedFields(r.issues)
+	if len(fields) == 0 {
+		pl.logger.Info("no fields flagged, skipping verify stage", zap.String("job_id", r.jobID))
+		return nil
+	}
+
+	checked, err := b.check(ctx, r.doc, r.promptSet, r.promptSet.Verify+questions+"\nPrevious parse:\n", r.rx)
+	if err != nil {
+		return err
+	}
+
+	rx, changes, err := patchFields(r.rx, checked, fields)
+	if err != nil {
+		return err
+	}
+
+	r.rx = rx
+	if r.origins != nil {
+		for _, change := range changes {
+			r.origins[change.Field] = StageVerify
+		}
+	}
+	r.issues = nil
+	if slices.Contains(r.stages, StageNormalize) {
+		r.issues = process(ctx, r.processing.normalizers, &r.rx)
+	}
+	r.issues = append(r.issues, process(ctx, r.processing.validators, &r.rx)...)
+
+	r.verification = changes
+	pl.logger.Info("verify stage completed", zap.String("job_id", r.jobID), zap.Strings("flagged_fields", fields), zap.Int("changed_count", len(changes)))
+	return nil
+}
+
+// stageNames returns the names of all stages in sorted order.
+func stageNames() []string {
+	names := make([]string, 0, len(pipelineStages))
+	for name := range pipelineStages {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/csotherden/prescription-parser/pkg/address"
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/consistency"
+	"github.com/csotherden/prescription-parser/pkg/controlled"
+	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/phone"
+	"github.com/csotherden/prescription-parser/pkg/rules"
+	"github.com/csotherden/prescription-parser/pkg/rxnorm"
+	"go.uber.org/zap"
+)
+
+// PostProcessor refines a parsed prescription before its job is marked complete.
+// Implementations may modify the prescription in place and return any validation issues found.
+type PostProcessor interface {
+	Process(ctx context.Context, rx *models.Prescription) []models.ValidationIssue
+}
+
+// ErrUnknownRuleSet is returned when a parse request selects a rule set that is not loaded.
+var ErrUnknownRuleSet = errors.New("unknown rule set")
+
+// postProcessing holds the post-processors run for every request and the validation
+// rule sets that requests may select. Normalizers standardize the prescription in the
+// normalize stage of the pipeline; validators check it in the validate stage.
+type postProcessing struct {
+	normalizers []PostProcessor
+	validators  []PostProcessor
+	ruleSets    *rules.Registry
+}
+
+// requestProcessing holds the post-processors run for one parse request.
+type requestProcessing struct {
+	normalizers []PostProcessor
+	validators  []PostProcessor
+}
+
+// newPostProcessing creates the post-processors enabled by the configuration.
+func newPostProcessing(cfg config.Config, logger *zap.Logger) (*postProcessing, error) {
+	var normalizers []PostProcessor
+
+	if cfg.StandardizeAddresses {
+		normalizers = append(normalizers, address.NewStandardizer())
+	}
+
+	if cfg.NormalizePhones {
+		normalizers = append(normalizers, phone.NewNormalizer())
+	}
+
+	if cfg.RxNormDir != "" {
+		index, err := rxnorm.Load(cfg.RxNormDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rxnorm data: %w", err)
+		}
+
+		logger.Info("loaded rxnorm data", zap.String("rxnorm_dir", cfg.RxNormDir), zap.Int("concept_count", index.Len()))
+		normalizers = append(normalizers, rxnorm.NewNormalizer(index))
+	}
+
+	scheduleTable := controlled.DefaultTable()
+	if cfg.ControlledTable != "" {
+		table, err := controlled.LoadTable(cfg.ControlledTable)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load controlled substance table: %w", err)
+		}
+		scheduleTable = table
+	}
+	validators := []PostProcessor{controlled.NewChecker(scheduleTable), consistency.NewChecker()}
+
+	var ruleSets *rules.Registry
+	if cfg.RulesFile != "" {
+		registry, err := rules.Load(cfg.RulesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rule file: %w", err)
+		}
+
+		logger.Info("loaded validation rules", zap.String("rules_file", cfg.RulesFile), zap.Strings("rule_sets", registry.Names()))
+		ruleSets = registry
+	}
+
+	return &postProcessing{normalizers: normalizers, validators: validators, ruleSets: ruleSets}, nil
+}
+
+// forRequest returns the post-processors to run for a parse request and the name of the
+// rule set applied, if any. The rule set is the last validator so its conditions can use
+// controlled substance flags. It returns ErrUnknownRuleSet if the requested rule set is
+// not loaded.
+func (pp *postProcessing) forRequest(opts models.ParseOptions) (requestProcessing, string, error) {
+	processing := requestProcessing{normalizers: pp.normalizers, validators: pp.validators}
+
+	ruleSet, ok := pp.ruleSets.RuleSet(opts.RuleSet)
+	if !ok {
+		if opts.RuleSet != "" {
+			return requestProcessing{}, "", fmt.Errorf("%w: %s", ErrUnknownRuleSet, opts.RuleSet)
+		}
+		return processing, "", nil
+	}
+
+	processing.validators = append(slices.Clone(pp.validators), ruleSet)
+	return processing, ruleSet.Name, nil
+}
+
+// process runs post-processors over a prescription and returns the validation issues they found.
+func process(ctx context.Context, processors []PostProcessor, rx *models.Prescription) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+	for _, processor := range processors {
+		issues = append(issues, processor.Process(ctx, rx)...)
+	}
+	return issues
+}
+
+// completeJob marks the job complete with the first prescription parsed from its document as the
+// result, recording that prescription's validation issues, verification changes and field
+// origins on the job along with every prescription found, including those that failed. At least
+// one prescription must have been parsed. The completed job is saved to results
+// unless results is nil; a failure to save is logged but does not fail the job.
+func completeJob(ctx context.Context, results datastore.Datastore, logger *zap.Logger, jobID string, prescriptions []models.DocumentPrescription) {
+	// The job's result is the first prescription that was parsed
+	first := prescriptions[slices.IndexFunc(prescriptions, parsed)]
+	jobs.GlobalTracker.SetValidation(jobID, first.Validation)
+	if first.Verification != nil {
+		jobs.GlobalTracker.SetVerification(jobID, first.Verification)
+	}
+	if first.Origins != nil {
+		jobs.GlobalTracker.SetOrigins(jobID, first.Origins)
+	}
+	jobs.GlobalTracker.SetPrescriptions(jobID, prescriptions)
+	jobs.GlobalTracker.UpdateJob(jobID, jobs.JobStatusComplete, nil, first.Prescription)
+
+	if results == nil {
+		return
+	}
+
+	job, ok := jobs.GlobalTracker.Snapshot(jobID)
+	if !ok || job.CompletedAt == nil {
+		return
+	}
+
+	result := models.ParseResult{
+		ID:           job.ID,
+		Prescription: first.Prescription,
+		Validation:   job.Validation,
+		Blocked:      job.Blocked,
+		Verification: job.Verification,
+		Origins:      job.Origins,
+		Attributes:   job.Attributes,
+		StartedAt:    job.StartedAt,
+		CompletedAt:  *job.CompletedAt,
+	}
+	// Documents holding a single prescription are saved as they were before segmentation
+	if len(job.Prescriptions) > 1 {
+		result.Prescriptions = job.Prescriptions
+	}
+
+	if err := results.SaveParseResult(ctx, result); err != nil {
+		logger.Error("failed to save parse result", zap.String("job_id", jobID), zap.Error(err))
+	}
+}
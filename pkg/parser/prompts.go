@@ -1,99 +1,62 @@
 package parser
 
-// systemPrompt is the detailed instruction set provided to the AI model to guide
-// its behavior when parsing prescription forms. It contains directions for data extraction,
-// formatting, and specific handling of various prescription fields.
-var systemPrompt = "You are an expert AI prescription parser trained to process scanned, faxed, or photographed prescription forms—some of which may be handwritten or low-quality.\n" +
-	"Your task is to extract structured prescription data and populate a JSON object based on a standardized schema.\n\n" +
-	"Use domain-specific knowledge of medical prescriptions to resolve ambiguities, infer values from context, and ensure accuracy even when characters or fields are unclear or handwritten.\n\n" +
-	"OBJECTIVE:\n" +
-	"\t- You should produce the most accurate and complete representation of the provided prescription image.\n" +
-	"\t- Given that this is a healthcare context, accuracy is important but your job is to reduce manual data entry time so completeness is also a high priority.\n" +
-	"\t- A licensed human pharmacist will review your output for accuracy, so strike the appropriate balance so that they do not need to manually input data you omitted but also spend minimal time correcting your mistakes.\n" +
-	"INPUT:\n" +
-	"A single-page or multi-page image or PDF file containing a prescription or specialty pharmacy order form.\n\n" +
-	"OUTPUT:\n" +
-	"A structured JSON object according to the schema provided. Include only fields with relevant or extractable data from the document.\n\n" +
-	"GENERAL INSTRUCTIONS:\n" +
-	"\t- If not otherwise detected, use the signature date as the date_written field value\n" +
-	"\t- Record weight and height using the **exact units indicated on the form**. Do not perform any unit conversions (e.g., from kg to lbs or cm to inches).\n" +
-	"\t- If a phone number is associated with the prescriber’s office or the insurer, do NOT assign it to the patient’s contact details or emergency contact fields.\n" +
-	"\t- Carefully associate all values (especially names, phone numbers, and addresses) with the correct entities: patient, prescriber, insurance provider, office staff, etc.\n" +
-	"\t- Normalize all phone numbers to a plain numeric string (e.g., 7038015897). Strip out all punctuation, spaces, parentheses, and plus signs.\n" +
-	"\t- If the NDC field is not clearly present or verifiable on the form, leave it blank. Do not fabricate or substitute a value like an NPI or a license number.\n" +
-	"\t- For insurance, ensure that the group number, ID number, and phone number match the actual labeled fields on the form. Do not mix them.\n" +
-	"\t- For the patient’s emergency contact, only populate this section if there is a **clearly designated** emergency contact listed. Do not assume this is the prescriber or office contact.\n" +
-	"\t- Avoid character misreadings (e.g., confusing 1 and 2). Use semantic context and consistent formatting to increase numerical accuracy.\n\n" +
-	"MEDICATION-SPECIFIC PARSING:\n" +
-	"\t- For sig (Instructions/Directions):\n" +
-	"\t\t- Populate the sig field with the exact text from the form.\n" +
-	"\t\t- Translate SIG abbreviations into plain English in the administration_notes field.\n" +
-	"\t\t- Example: \"25mg tab po qd\" → sig: \"25mg tab po qd\", administration_notes: \"Take one 25 mg tablet by mouth once daily\"\n\n" +
-	"\t- To determine the daw_code (Dispense As Written):\n" +
-	"\t\t- Examine the section of the form with two or more signature lines labeled with options like \"Substitution permitted\" and \"Dispense as written\".\n" +
-	"\t\t- Determine which signature line contains the prescriber's signature.\n" +
-	"\t\t- If the prescriber signed **next to or directly above a line labeled** \"Substitution permitted\" (or equivalent), set daw_code: 0\n" +
-	"\t\t- If the prescriber signed above or next to a line labeled \"Dispense as written\" or \"Do not substitute\", set daw_code: 1\n" +
-	"\t\t- Do not assume the DAW value based on default preferences—always use the **signature position relative to the line label**.\n" +
-	"\t\t- If the signature is not clearly aligned with any labeled option, default to daw_code: 0\n\n" +
-	"CHECKBOXES & MULTI-OPTION SECTIONS:\n" +
-	"\t- Prescription forms may list multiple medication or drug options with associated checkboxes.\n" +
-	"\t- Only include medications that are clearly prescribed: look for checkboxes that have a **checkmark or X, or that are filled or circled**.\n" +
-	"\t- DO NOT omit the drug_name field if a checkbox is marked—extract the drug name from the selected option.\n" +
-	"\t- If multiple strengths or forms are listed under a selected drug, only include the strength and form that is also written, marked, or circled.\n\n" +
-	"CLINICAL & DIAGNOSTIC INFO:\n" +
-	"\t- Add relevant values such as BSA, genetic markers, or lab checkboxes to clinical_info using the format: \"Label: result\" (e.g., \"BSA: 1.7 m²\")\n\n" +
-	"ATTACHMENTS:\n" +
-	"\t- Only mark attachment fields (e.g., lab_results, insurance_cards) as true if the form explicitly states the document is attached, usually via checkbox or written note.\n" +
-	"\t- Default to false for attachment fields unless there is explicit indication (like a check box) indicating the document type is attached.\n" +
-	"\t- Do NOT mark attachment fields true simply because related information is mentioned (e.g., insurance policy info in the form does not mean insurance card attached).\n"
+import (
+	"errors"
+	"fmt"
+	"strings"
 
-// parsePrompt is the basic instruction given to the AI model to parse a prescription image.
-// It's a concise command used in both initial parsing and example-based parsing.
-var parsePrompt = "Parse the provided prescription image into a JSON object according to the schema provided."
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/prompts"
+	"github.com/csotherden/prescription-parser/pkg/templates"
+	"go.uber.org/zap"
+)
 
-// reviewPrompt instructs the AI model to review and refine the parsed prescription data.
-// It's used in the second parsing pass to improve accuracy by double-checking specific fields.
-var reviewPrompt = "Your primary task is to parse the **CURRENT prescription image** provided in this turn into a JSON object according to the schema and all general system instructions.\n\n" +
-	"**Learning from Prior Examples (If Present in Message History):**\n" +
-	"The prior examples of prescriptions and their JSON outputs in the message history are provided to help you learn specific **terminology normalizations** and **formatting preferences**. Pay attention to patterns in the examples for things like:\n" +
-	"\t 1.  **Phone Number Labels:** Notice how checkboxes or indicators next to phone numbers in the examples (e.g., 'M', 'H', 'W') are translated into specific JSON labels (e.g., \"Mobile\", \"Home\", \"Work\"). Apply similar logic to the CURRENT image.\n" +
-	"\t 2.  **`administration_notes` Standardization:** Observe the style, phrasing, and level of detail used in the `administration_notes` field in the examples after SIG translation. Aim for similar consistency and clarity when generating this field for the CURRENT image.\n" +
-	"\t 3.  **Other Terminological Consistency:** Look for any other consistent terminology choices made in the example JSONs for fields that might have variable input on the form (e.g., units, medication forms, etc.) and apply similar standardization to the CURRENT image where appropriate.\n\n" +
-	"**Crucial Instruction:**\n" +
-	"While learning these *normalization patterns* from the examples, ALL specific data values (patient names, medication details, dates, addresses, NPIs, etc.) for the output JSON **MUST be extracted directly and exclusively from the CURRENT prescription image** you are parsing now. Do not copy data values from examples.\n\n" +
-	"**Parse the CURRENT prescription image now, applying any relevant normalization patterns learned from the examples.**\n"
+// ErrUnknownPromptVersion is returned when a parse request selects a prompt version that is not loaded.
+var ErrUnknownPromptVersion = errors.New("unknown prompt version")
 
-// scoringPrompt instructs the AI model to score the parsed prescription data for prompt regression testing and tuning.
-var scoringPrompt = "You are an expert JSON comparison and scoring AI. Your task is to compare a Parser Output JSON against a Validated Expected JSON for a medical prescription. You will score the Parser Output JSON field by field based on its accuracy and completeness relative to the Validated Expected JSON.\n\n" +
-	"**Inputs:**\n" +
-	"1.  **Validated Expected JSON:** This is the ground truth, manually reviewed and confirmed as correct for the prescription.\n" +
-	"2.  **Parser Output JSON:** This is the JSON generated by the parsing system that needs to be scored.\n\n" +
-	"**Scoring System (per field present in the Validated Expected JSON):**\n" +
-	"*   **1.0 point:** Exact match. The field path exists in both JSONs, and the values are identical (case-sensitive, type-sensitive unless semantically equivalent as per below).\n" +
-	"*   **0.75 points:** Semantically equivalent. The field path exists in both JSONs, values are different but convey the same meaning or are common acceptable abbreviations/variations.\n" +
-	"    *   Examples: \"Tab\" vs \"Tablet\", \"St.\" vs \"Street\", \"100 MG\" vs \"100mg\", \"Male\" vs \"M\" (if contextually clear for sex), date \"05/23/2025\" vs \"2025-05-23\" (if date format variations are acceptable and represent the same date).\n" +
-	"*   **0.25 points:** Value significantly different. The field path exists in both JSONs, both fields are populated, but the value in the Parser Output JSON is substantially incorrect or different from the Validated Expected JSON.\n" +
-	"    *   Example: Expected `quantity: \"30\"`, Output `quantity: \"3\"`. Expected `drug_name: \"Lipitor\"`, Output `drug_name: \"Lisinopril\"`.\n" +
-	"*   **0.0 points:**\n" +
-	"    *   **Missing Value:** The field exists in Validated Expected JSON and is populated, but it's missing in Parser Output JSON OR it exists but is empty/null in Parser Output JSON.\n" +
-	"    *   **Hallucinated Value:** The field is empty/null/absent in Validated Expected JSON, but it's populated in Parser Output JSON.\n" +
-	"    *   **Completely Unrelated Value:** The field exists in both, but the output value has no discernible relation to the expected value and is not just \"significantly different\" but wrong.\n\n" +
-	"**Instructions:**\n" +
-	"1.  **Iterate Field by Field:** Go through each field path present in the **Validated Expected JSON**.\n" +
-	"2.  **Compare and Score:** For each field from the Validated Expected JSON:\n" +
-	"    *   Check its presence and value in the Parser Output JSON at the same path.\n" +
-	"    *   Assign a score (1.0, 0.75, 0.25, or 0.0) based on the rules above.\n" +
-	"    *   Provide a brief `reasoning` for any score less than 1.0.\n" +
-	"3.  **Handle Nested Structures:** Apply the scoring rules recursively for nested objects and arrays.\n" +
-	"    *   If an entire nested object or array is expected but missing in the output, all fields within that expected structure effectively score 0.\n" +
-	"    *   If an array is expected, compare elements. If comparing arrays of objects\n" +
-	"4.  **Calculate Final Score:** `overall_score_percentage = (total_awarded_points / total_possible_points) * 100`. Round to two decimal places.\n" +
-	"5.  **Output Format:** Provide the results in the provided JSON structure.\n\n" +
-	"**Examples for Semantic Equivalence (0.75 points):**\n" +
-	"units: \"mg\" vs units: \"milligram\"\n" +
-	"frequency: \"QD\" vs frequency: \"once daily\"\n" +
-	"route: \"PO\" vs route: \"Per Oral / By Mouth\"\n" +
-	"Address components like \"Street\" vs \"St.\", \"Road\" vs \"Rd.\"\n" +
-	"Phone numbers: \"(123) 456-7890\" vs \"123-456-7890\" vs \"1234567890\" (if normalized forms are considered semantically same).\n" +
-	"Boolean representations: true vs \"true\" vs \"Yes\" (if defined as equivalent).\n\n"
+// loadPrompts loads the bundled prompts and the prompt versions of the configured directory.
+func loadPrompts(cfg config.Config, logger *zap.Logger) (*prompts.Registry, error) {
+	registry, err := prompts.Load(cfg.PromptsDir, cfg.PromptVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prompts: %w", err)
+	}
+
+	logger.Info("loaded prompts", zap.String("prompts_dir", cfg.PromptsDir), zap.Strings("prompt_versions", registry.Versions()), zap.String("default_prompt_version", registry.Default().Version))
+	return registry, nil
+}
+
+// promptsForRequest returns the prompt version selected by a parse request, or the default
+// version. It returns ErrUnknownPromptVersion if the version is not loaded.
+func promptsForRequest(registry *prompts.Registry, opts models.ParseOptions) (prompts.Set, error) {
+	set, ok := registry.Get(opts.PromptVersion)
+	if !ok {
+		return prompts.Set{}, fmt.Errorf("%w: %s", ErrUnknownPromptVersion, opts.PromptVersion)
+	}
+	return set, nil
+}
+
+// systemInstructions returns the system prompt for a document, extended with the name of its
+// form template, where fields are found on it and the template's prompt overlay when the
+// template is known.
+func systemInstructions(systemPrompt string, template *templates.Template, overlay string) string {
+	if template == nil {
+		return systemPrompt
+	}
+
+	var b strings.Builder
+	b.WriteString(systemPrompt)
+	fmt.Fprintf(&b, "\nFORM TEMPLATE:\n\t- This document was filled in on the %s form.\n", template.Name)
+	if len(template.Hints) > 0 {
+		b.WriteString("\t- On this form, fields are found at the following locations:\n")
+		for _, hint := range template.Hints {
+			fmt.Fprintf(&b, "\t\t- %s: %s\n", hint.Field, hint.Location)
+		}
+	}
+	if overlay = strings.TrimSpace(overlay); overlay != "" {
+		b.WriteString("\nFORM-SPECIFIC INSTRUCTIONS:\n")
+		b.WriteString(overlay)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,188 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/layout"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/retrieval"
+	"go.uber.org/zap"
+)
+
+// Sample retrieval modes, selected with config.Config.SampleRetrieval.
+const (
+	// RetrievalContent finds the samples whose prescriptions are most similar to the first pass's.
+	RetrievalContent = "content"
+	// RetrievalLayout finds the samples whose documents have the most similar page layout, so the
+	// examples are filled-in copies of the same form template.
+	RetrievalLayout = "layout"
+)
+
+// sampleRetriever finds the samples shown to the model in the second parsing pass.
+type sampleRetriever struct {
+	ds                datastore.Datastore
+	logger            *zap.Logger
+	mode              string                // RetrievalContent or RetrievalLayout
+	k                 int                   // Maximum number of samples
+	metric            models.DistanceMetric // Distance metric for content embeddings; layout fingerprints always use L2
+	maxDistance       float64               // Distance cutoff for content embeddings, zero for none
+	layoutMaxDistance float64               // Distance cutoff for layout fingerprints, zero for none
+	mmrLambda         float64               // MMR relevance weight, zero or one to not diversify
+}
+
+// newSampleRetriever creates a sample retriever from the sample retrieval settings of the config.
+func newSampleRetriever(cfg config.Config, ds datastore.Datastore, logger *zap.Logger) (*sampleRetriever, error) {
+	if err := checkRetrieval(cfg.SampleRetrieval); err != nil {
+		return nil, err
+	}
+
+	metric, err := retrieval.ParseMetric(cfg.SampleDistance)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.SampleMMRLambda < 0 || cfg.SampleMMRLambda > 1 {
+		return nil, fmt.Errorf("sample MMR lambda must be between 0 and 1, got %v", cfg.SampleMMRLambda)
+	}
+
+	k := cfg.SampleCount
+	if k <= 0 {
+		k = retrieval.DefaultK
+	}
+
+	return &sampleRetriever{
+		ds:                ds,
+		logger:            logger,
+		mode:              cfg.SampleRetrieval,
+		k:                 k,
+		metric:            metric,
+		maxDistance:       cfg.SampleMaxDistance,
+		layoutMaxDistance: cfg.LayoutMaxDistance,
+		mmrLambda:         cfg.SampleMMRLambda,
+	}, nil
+}
+
+// checkRetrieval validates a sample retrieval mode. An empty mode selects content retrieval.
+func checkRetrieval(mode string) error {
+	switch mode {
+	case "", RetrievalContent, RetrievalLayout:
+		return nil
+	default:
+		return fmt.Errorf("unknown sample retrieval: %s. Must be %s or %s", mode, RetrievalContent, RetrievalLayout)
+	}
+}
+
+// forRequest returns the retriever for a parse request, which uses the request's sample retrieval
+// mode when it sets one.
+func (r *sampleRetriever) forRequest(opts models.ParseOptions) (*sampleRetriever, error) {
+	if opts.SampleRetrieval == "" {
+		return r, nil
+	}
+	if err := checkRetrieval(opts.SampleRetrieval); err != nil {
+		return nil, err
+	}
+
+	retriever := *r
+	retriever.mode = opts.SampleRetrieval
+	return &retriever, nil
+}
+
+// retrieve finds the samples for a document and records how they were found on the job. Only
+// samples matching filter are considered. When the document's form template is known and the
+// filter does not select one, samples of that template are preferred, and the others are only
+// used when none of them is close. The content embedding of the first pass's prescription is
+// computed at most once, and only when needed.
+func (r *sampleRetriever) retrieve(ctx context.Context, jobID string, document []byte, filter models.SampleMetadata, template string,
+	contentEmbedding func(ctx context.Context) (models.Embedding, error)) ([]models.SamplePrescription, error) {
+
+	var embedding *models.Embedding
+	cachedEmbedding := func(ctx context.Context) (models.Embedding, error) {
+		if embedding == nil {
+			e, err := contentEmbedding(ctx)
+			if err != nil {
+				return models.Embedding{}, err
+			}
+			embedding = &e
+		}
+		return *embedding, nil
+	}
+
+	if template != "" && filter.Template == "" {
+		templateFilter := filter
+		templateFilter.Template = template
+		samples, err := r.find(ctx, jobID, document, templateFilter, cachedEmbedding)
+		if err != nil {
+			return nil, err
+		}
+		if len(samples) > 0 {
+			return samples, nil
+		}
+		r.logger.Info("no samples of the form template found, using all samples", zap.String("job_id", jobID), zap.String("template", template))
+	}
+
+	return r.find(ctx, jobID, document, filter, cachedEmbedding)
+}
+
+// find finds the samples matching filter. In layout mode the document's layout fingerprint is
+// used; a document that cannot be fingerprinted, or whose layout is close to no sample, falls
+// back to the content embedding of the first pass's prescription. No samples are returned when
+// no sample is within the distance cutoff.
+func (r *sampleRetriever) find(ctx context.Context, jobID string, document []byte, filter models.SampleMetadata,
+	contentEmbedding func(ctx context.Context) (models.Embedding, error)) ([]models.SamplePrescription, error) {
+
+	if r.mode == RetrievalLayout {
+		samples, err := r.layoutSamples(ctx, document, filter)
+		if err == nil && len(samples) > 0 {
+			jobs.GlobalTracker.SetAttribute(jobID, jobs.AttributeSampleRetrieval, RetrievalLayout)
+			return samples, nil
+		}
+		r.logger.Warn("no samples found by layout, falling back to content", zap.String("job_id", jobID), zap.Error(err))
+	}
+
+	embedding, err := contentEmbedding(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embedding: %w", err)
+	}
+
+	samples, err := r.ds.GetSamples(ctx, r.query(embedding, r.maxDistance, filter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get samples: %w", err)
+	}
+
+	jobs.GlobalTracker.SetAttribute(jobID, jobs.AttributeSampleRetrieval, RetrievalContent)
+	return samples, nil
+}
+
+// layoutSamples finds the samples whose documents are laid out most like document.
+func (r *sampleRetriever) layoutSamples(ctx context.Context, document []byte, filter models.SampleMetadata) ([]models.SamplePrescription, error) {
+	fingerprint, err := layout.Fingerprint(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fingerprint document layout: %w", err)
+	}
+
+	// Fingerprints are bit vectors, and a blank page's is all zeros, which has no cosine distance
+	query := r.query(fingerprint, r.layoutMaxDistance, filter)
+	query.Metric = models.DistanceL2
+
+	samples, err := r.ds.GetSamples(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get samples: %w", err)
+	}
+	return samples, nil
+}
+
+// query builds the sample query for an embedding.
+func (r *sampleRetriever) query(embedding models.Embedding, maxDistance float64, filter models.SampleMetadata) models.SampleQuery {
+	return models.SampleQuery{
+		Embedding:   embedding,
+		K:           r.k,
+		MaxDistance: maxDistance,
+		Metric:      r.metric,
+		MMRLambda:   r.mmrLambda,
+		Filter:      filter,
+	}
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/csotherden/prescription-parser/pkg/config"
+	"github.com/csotherden/prescription-parser/pkg/datastore"
+	"github.com/csotherden/prescription-parser/pkg/templates"
+	"go.uber.org/zap"
+)
+
+// loadTemplates loads the form template registry when a template file is configured.
+// Without one, documents are not identified and the registry is nil.
+func loadTemplates(cfg config.Config, logger *zap.Logger) (*templates.Registry, error) {
+	if cfg.TemplatesFile == "" {
+		return nil, nil
+	}
+
+	registry, err := templates.Load(cfg.TemplatesFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load template file: %w", err)
+	}
+
+	logger.Info("loaded form templates", zap.String("templates_file", cfg.TemplatesFile), zap.Int("template_count", registry.Len()))
+	return registry, nil
+}
+
+// identifyTemplate determines the form template of a document. A registered template given with the request is used as is; otherwise the document is
+// identified by its layout. It returns nil when the document matches no template.
+func identifyTemplate(registry *templates.Registry, logger *zap.Logger, jobID string, document []byte, requested string) *templates.Template {
+	if registry.Len() == 0 {
+		return nil
+	}
+
+	if template, ok := registry.Template(requested); ok {
+		return &template
+	}
+
+	match, err := registry.Identify(document)
+	if err != nil {
+		if !errors.Is(err, templates.ErrNoMatch) {
+			logger.Warn("failed to identify form template", zap.String("job_id", jobID), zap.Error(err))
+		}
+		return nil
+	}
+
+	logger.Info("identified form template", zap.String("job_id", jobID), zap.String("template", match.Template.ID), zap.Int("distance", match.Distance))
+	return &match.Template
+}
+
+// templateOverlay returns the instructions and version of the latest prompt overlay of a form
+// template. It returns an empty string and version 0 when the template is nil or has no overlay;
+// an overlay that cannot be read is logged and left out rather than failing the job.
+func templateOverlay(ctx context.Context, ds datastore.Datastore, logger *zap.Logger, jobID string, template *templates.Template) (string, int) {
+	if template == nil {
+		return "", 0
+	}
+
+	overlay, err := ds.GetPromptOverlay(ctx, template.ID)
+	if errors.Is(err, datastore.ErrNotFound) {
+		return "", 0
+	}
+	if err != nil {
+		logger.Error("failed to get prompt overlay", zap.String("job_id", jobID), zap.String("template", template.ID), zap.Error(err))
+		return "", 0
+	}
+
+	return overlay.Instructions, overlay.Version
+}
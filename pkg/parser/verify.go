@@ -0,0 +1,171 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// flaggedFields returns the fields validation raised warnings or errors on, in the order they
+// were first flagged, and the questions the verify stage asks about them: one line per field
+// with the issues found.
+func flaggedFields(issues []models.ValidationIssue) ([]string, string) {
+	var fields []string
+	messages := map[string][]string{}
+	for _, issue := range issues {
+		if issue.Field == "" || issue.Severity == models.SeverityInfo {
+			continue
+		}
+		if _, ok := messages[issue.Field]; !ok {
+			fields = append(fields, issue.Field)
+		}
+		messages[issue.Field] = append(messages[issue.Field], issue.Message)
+	}
+
+	var questions strings.Builder
+	for _, field := range fields {
+		fmt.Fprintf(&questions, "- %s: %s\n", field, strings.Join(messages[field], "; "))
+	}
+
+	return fields, questions.String()
+}
+
+// patchFields returns rx with the values of fields taken from checked, and the fields whose
+// value changed. Fields are paths in dot notation, e.g. medications[0].drug_name. A field that
+// cannot be addressed in rx, such as a wildcard path or a medication rx does not have, is left
+// as it is.
+func patchFields(rx, checked models.Prescription, fields []string) (models.Prescription, []models.FieldChange, error) {
+	doc, err := toJSONValue(rx)
+	if err != nil {
+		return models.Prescription{}, nil, err
+	}
+	checkedDoc, err := toJSONValue(checked)
+	if err != nil {
+		return models.Prescription{}, nil, err
+	}
+
+	changes := []models.FieldChange{}
+	for _, field := range fields {
+		path, ok := parseFieldPath(field)
+		if !ok {
+			continue
+		}
+		original, ok := lookupPath(doc, path)
+		if !ok {
+			continue
+		}
+		// A field the re-check left out, such as a medication past the end of its list, is kept
+		corrected, ok := lookupPath(checkedDoc, path)
+		if !ok || reflect.DeepEqual(original, corrected) || !setPath(doc, path, corrected) {
+			continue
+		}
+		changes = append(changes, models.FieldChange{Field: field, Original: original, Corrected: corrected})
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return models.Prescription{}, nil, fmt.Errorf("failed to marshal patched prescription: %w", err)
+	}
+	var patched models.Prescription
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return models.Prescription{}, nil, fmt.Errorf("failed to unmarshal patched prescription: %w", err)
+	}
+
+	return patched, changes, nil
+}
+
+// toJSONValue converts a prescription to its generic JSON representation.
+func toJSONValue(rx models.Prescription) (any, error) {
+	data, err := json.Marshal(rx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal prescription: %w", err)
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal prescription: %w", err)
+	}
+	return value, nil
+}
+
+// parseFieldPath splits a field path such as medications[0].drug_name into object keys and
+// array indexes. It returns false for paths with wildcards or malformed indexes.
+func parseFieldPath(field string) ([]any, bool) {
+	var path []any
+	for _, part := range strings.Split(strings.TrimPrefix(field, "$."), ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" {
+			return nil, false
+		}
+		path = append(path, key)
+
+		for rest != "" {
+			index, after, ok := strings.Cut(rest, "]")
+			if !ok {
+				return nil, false
+			}
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 {
+				return nil, false
+			}
+			path = append(path, i)
+
+			if after != "" && !strings.HasPrefix(after, "[") {
+				return nil, false
+			}
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return path, true
+}
+
+// lookupPath returns the value at path. A missing object key has a null value; an index
+// past the end of an array or a path through a value that is not a container is not found.
+func lookupPath(value any, path []any) (any, bool) {
+	for _, step := range path {
+		switch key := step.(type) {
+		case string:
+			object, ok := value.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			value = object[key]
+		case int:
+			array, ok := value.([]any)
+			if !ok || key >= len(array) {
+				return nil, false
+			}
+			value = array[key]
+		}
+	}
+	return value, true
+}
+
+// setPath sets the value at path in place. It returns false if the path's parent cannot be
+// found.
+func setPath(value any, path []any, newValue any) bool {
+	parent, ok := lookupPath(value, path[:len(path)-1])
+	if !ok {
+		return false
+	}
+
+	switch key := path[len(path)-1].(type) {
+	case string:
+		object, ok := parent.(map[string]any)
+		if !ok {
+			return false
+		}
+		object[key] = newValue
+	case int:
+		array, ok := parent.([]any)
+		if !ok || key >= len(array) {
+			return false
+		}
+		array[key] = newValue
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+// Package personname splits the free-text person names found on prescription forms into
+// their parts, for exports and checks that need a separate first and last name.
+package personname
+
+import "strings"
+
+// Name is a person's name split into parts.
+type Name struct {
+	Prefix string // Title such as "DR"
+	First  string // Given name
+	Middle string // Middle names or initials
+	Last   string // Family name
+	Suffix string // Credentials and generational suffixes such as "MD" or "JR", space separated
+}
+
+// prefixes lists titles that may precede a name, in upper case without periods.
+var prefixes = map[string]bool{
+	"DR": true, "MR": true, "MRS": true, "MS": true, "MISS": true, "PROF": true,
+}
+
+// suffixes lists credentials and generational suffixes that may follow a name, in upper case without periods.
+var suffixes = map[string]bool{
+	"MD": true, "DO": true, "NP": true, "PA": true, "C": true, "PA-C": true, "APRN": true, "FNP": true,
+	"FNP-C": true, "FNP-BC": true, "DNP": true, "DDS": true, "DMD": true, "DPM": true, "PHD": true,
+	"RN": true, "PHARMD": true, "OD": true, "MBBS": true, "FACP": true, "FAAD": true,
+	"JR": true, "SR": true, "II": true, "III": true, "IV": true,
+}
+
+// Split splits a full name such as "Dr. Jane A. Brown, MD" or "Brown, Jane A" into its parts.
+// A comma followed by anything other than credentials is read as "Last, First Middle".
+func Split(full string) Name {
+	var name Name
+
+	before, after, hasComma := strings.Cut(full, ",")
+	words := fields(before)
+	rest := fields(after)
+
+	if hasComma && len(rest) > 0 && !allSuffixes(rest) {
+		// "Last, First Middle [credentials]"
+		name.Last = strings.Join(words, " ")
+		words = rest
+		for len(words) > 0 && suffixes[clean(words[len(words)-1])] {
+			name.Suffix = strings.TrimSpace(clean(words[len(words)-1]) + " " + name.Suffix)
+			words = words[:len(words)-1]
+		}
+		for len(words) > 0 && prefixes[clean(words[0])] {
+			name.Prefix = strings.TrimSpace(name.Prefix + " " + clean(words[0]))
+			words = words[1:]
+		}
+		if len(words) > 0 {
+			name.First = words[0]
+			name.Middle = strings.Join(words[1:], " ")
+		}
+		return name
+	}
+
+	for _, word := range rest {
+		name.Suffix = strings.TrimSpace(name.Suffix + " " + clean(word))
+	}
+	for len(words) > 0 && prefixes[clean(words[0])] {
+		name.Prefix = strings.TrimSpace(name.Prefix + " " + clean(words[0]))
+		words = words[1:]
+	}
+	for len(words) > 1 && suffixes[clean(words[len(words)-1])] {
+		name.Suffix = strings.TrimSpace(clean(words[len(words)-1]) + " " + name.Suffix)
+		words = words[:len(words)-1]
+	}
+
+	switch len(words) {
+	case 0:
+	case 1:
+		name.Last = words[0]
+	default:
+		name.First = words[0]
+		name.Last = words[len(words)-1]
+		name.Middle = strings.Join(words[1:len(words)-1], " ")
+	}
+
+	return name
+}
+
+// fields splits s on spaces and commas.
+func fields(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' })
+}
+
+// clean upper-cases a word and removes periods so "M.D." compares equal to "MD".
+func clean(word string) string {
+	return strings.ToUpper(strings.ReplaceAll(word, ".", ""))
+}
+
+func allSuffixes(words []string) bool {
+	for _, word := range words {
+		if !suffixes[clean(word)] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+package personname
+
+import "testing"
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		full string
+		want Name
+	}{
+		{full: "Jane Brown", want: Name{First: "Jane", Last: "Brown"}},
+		{full: "Dr. Jane A. Brown, MD", want: Name{Prefix: "DR", First: "Jane", Middle: "A.", Last: "Brown", Suffix: "MD"}},
+		{full: "Jane Brown MD, FACP", want: Name{First: "Jane", Last: "Brown", Suffix: "MD FACP"}},
+		{full: "Brown, Jane A", want: Name{First: "Jane", Middle: "A", Last: "Brown"}},
+		{full: "Brown, Jane, M.D.", want: Name{First: "Jane", Last: "Brown", Suffix: "MD"}},
+		{full: "John Smith Jr.", want: Name{First: "John", Last: "Smith", Suffix: "JR"}},
+		{full: "Madonna", want: Name{Last: "Madonna"}},
+		{full: "", want: Name{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.full, func(t *testing.T) {
+			if got := Split(tt.full); got != tt.want {
+				t.Errorf("Split(%q) = %+v, want %+v", tt.full, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package phone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// labels maps the phone labels and checkbox abbreviations found on forms to standard labels.
+var labels = map[string]string{
+	"H": "Home", "HM": "Home", "HOME": "Home", "RES": "Home", "RESIDENCE": "Home",
+	"M": "Mobile", "MOB": "Mobile", "MOBILE": "Mobile", "C": "Mobile", "CELL": "Mobile", "CELLULAR": "Mobile",
+	"W": "Work", "WK": "Work", "WORK": "Work", "B": "Work", "BUS": "Work", "BUSINESS": "Work", "OFFICE": "Work",
+	"F": "Fax", "FAX": "Fax",
+}
+
+// Normalizer normalizes every phone-like field of a prescription.
+type Normalizer struct{}
+
+// NewNormalizer creates a phone number normalizer.
+func NewNormalizer() *Normalizer {
+	return &Normalizer{}
+}
+
+// phoneField is a phone-like string field and its path.
+type phoneField struct {
+	path  string
+	value *string
+	owner string // Party the number belongs to, used to describe misassignments
+}
+
+// Process normalizes the patient, emergency contact, prescriber office and insurance phone
+// numbers in place. Unreadable numbers and invalid NANP area or exchange codes are reported
+// as warnings, as are patient numbers that also belong to the prescriber's office or an insurer.
+func (n *Normalizer) Process(ctx context.Context, rx *models.Prescription) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+
+	var patientFields []phoneField
+	for i := range rx.Patient.PhoneNumbers {
+		phone := &rx.Patient.PhoneNumbers[i]
+		path := fmt.Sprintf("patient.phone_numbers[%d]", i)
+
+		if label, ok := labels[strings.ToUpper(strings.Trim(phone.Label, " .:"))]; ok {
+			phone.Label = label
+		}
+
+		number, issue, ok := normalize(path+".number", phone.Number)
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+		if ok {
+			phone.Number = number.Digits
+			if phone.Extension == "" {
+				phone.Extension = number.Extension
+			}
+		}
+		phone.Extension = strings.TrimSpace(strings.TrimLeft(phone.Extension, "xX#. "))
+
+		patientFields = append(patientFields, phoneField{path: path + ".number", value: &phone.Number, owner: "patient"})
+	}
+	contactField := phoneField{path: "patient.emergency_contact.phone", value: &rx.Patient.EmergencyContact.Phone, owner: "emergency contact"}
+	patientFields = append(patientFields, contactField)
+
+	otherFields := []phoneField{
+		{path: "prescriber.office.phone", value: &rx.Prescriber.Office.Phone, owner: "prescriber office phone"},
+		{path: "prescriber.office.fax", value: &rx.Prescriber.Office.Fax, owner: "prescriber office fax"},
+	}
+	for i := range rx.Patient.Insurance {
+		otherFields = append(otherFields, phoneField{
+			path:  fmt.Sprintf("patient.insurance[%d].phone_number", i),
+			value: &rx.Patient.Insurance[i].PhoneNumber,
+			owner: "insurance phone",
+		})
+	}
+
+	for _, field := range append([]phoneField{contactField}, otherFields...) {
+		number, issue, ok := normalize(field.path, *field.value)
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+		if ok {
+			*field.value = number.String()
+		}
+	}
+
+	issues = append(issues, checkMisassigned(patientFields, otherFields)...)
+
+	return issues
+}
+
+// normalize parses a phone number and validates NANP numbers. It returns the parsed number
+// and whether it should replace the field value, along with an issue for unusable values.
+// Empty values are ignored.
+func normalize(path, value string) (Number, *models.ValidationIssue, bool) {
+	if strings.TrimSpace(value) == "" {
+		return Number{}, nil, false
+	}
+
+	number, ok := Parse(value)
+	if !ok {
+		return Number{}, &models.ValidationIssue{
+			Field:    path,
+			Code:     "phone_invalid",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("%q is not a recognizable phone number", value),
+		}, false
+	}
+
+	if number.NANP && !ValidAreaCode(number.Digits[:3]) {
+		return number, &models.ValidationIssue{
+			Field:    path,
+			Code:     "phone_area_code_invalid",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("%s has invalid area code %s", number.Digits, number.Digits[:3]),
+		}, true
+	}
+
+	if number.NANP && !ValidExchange(number.Digits[3:6]) {
+		return number, &models.ValidationIssue{
+			Field:    path,
+			Code:     "phone_exchange_invalid",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("%s has invalid exchange code %s", number.Digits, number.Digits[3:6]),
+		}, true
+	}
+
+	return number, nil, true
+}
+
+// checkMisassigned flags patient and emergency contact numbers that are also recorded for the
+// prescriber's office or an insurer, which usually means the number was assigned to the wrong party.
+func checkMisassigned(patientFields, otherFields []phoneField) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+
+	for _, pf := range patientFields {
+		digits := numberDigits(*pf.value)
+		if digits == "" {
+			continue
+		}
+
+		for _, of := range otherFields {
+			if numberDigits(*of.value) != digits {
+				continue
+			}
+
+			issues = append(issues, models.ValidationIssue{
+				Field:    pf.path,
+				Code:     "phone_misassigned",
+				Severity: models.SeverityWarning,
+				Message:  fmt.Sprintf("%s number %s is the same as the %s (%s)", pf.owner, digits, of.owner, of.path),
+			})
+			break
+		}
+	}
+
+	return issues
+}
+
+// numberDigits returns a normalized field value without its extension.
+func numberDigits(value string) string {
+	digits, _, _ := strings.Cut(value, " x")
+	return strings.TrimSpace(digits)
+}
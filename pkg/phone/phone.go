@@ -0,0 +1,90 @@
+// Package phone normalizes the phone and fax numbers on parsed prescriptions. North American
+// numbers are formatted as ten digits and other numbers in E.164 form, extensions are split
+// from the number, and numbers that appear on both the patient and another party are flagged.
+package phone
+
+import (
+	"regexp"
+	"strings"
+)
+
+// extensionPattern matches a trailing extension such as "ext. 12", "x12" or "#12".
+var extensionPattern = regexp.MustCompile(`(?i)\s*(?:extension|ext\.?|x|#)\s*(\d{1,6})\s*$`)
+
+// Number is a parsed phone number.
+type Number struct {
+	Digits    string // Ten-digit NANP number, or an E.164 number with a leading "+" for other countries
+	Extension string // Extension digits, if any
+	NANP      bool   // Whether the number is in the North American Numbering Plan
+}
+
+// String formats the number with its extension, if any, as "7035551234 x12".
+func (n Number) String() string {
+	if n.Extension == "" {
+		return n.Digits
+	}
+	return n.Digits + " x" + n.Extension
+}
+
+// Parse reads a phone number written in any common format, such as "(703) 555-1234 ext. 12",
+// "+1 703.555.1234" or "+44 20 7946 0958". It reports false if the value is not a phone number.
+func Parse(s string) (Number, bool) {
+	var n Number
+
+	rest := strings.TrimSpace(s)
+	if m := extensionPattern.FindStringSubmatchIndex(rest); m != nil {
+		n.Extension = rest[m[2]:m[3]]
+		rest = rest[:m[0]]
+	}
+
+	international := strings.HasPrefix(rest, "+")
+
+	var b strings.Builder
+	for _, r := range rest {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' || r == '(' || r == ')' || r == '-' || r == '.' || r == '/' || r == ' ':
+		default:
+			return Number{}, false
+		}
+	}
+	digits := b.String()
+
+	if !international && strings.HasPrefix(digits, "011") {
+		international = true
+		digits = digits[3:]
+	}
+
+	switch {
+	case len(digits) == 11 && digits[0] == '1':
+		n.Digits, n.NANP = digits[1:], true
+	case len(digits) == 10 && !international:
+		n.Digits, n.NANP = digits, true
+	case international && len(digits) >= 8 && len(digits) <= 15 && digits[0] != '0':
+		n.Digits = "+" + digits
+	default:
+		return Number{}, false
+	}
+
+	return n, true
+}
+
+// ValidAreaCode reports whether a three-digit NANP area code can be assigned: it starts with
+// 2-9, its middle digit is not 9 (reserved for expansion) and it is not an N11 service code.
+func ValidAreaCode(code string) bool {
+	return validNXX(code) && code[1] != '9'
+}
+
+// ValidExchange reports whether a three-digit NANP central office code can be assigned:
+// it starts with 2-9 and is not an N11 service code.
+func ValidExchange(code string) bool {
+	return validNXX(code)
+}
+
+func validNXX(code string) bool {
+	if len(code) != 3 || code[0] < '2' || code[0] > '9' {
+		return false
+	}
+	return code[1:] != "11"
+}
@@ -0,0 +1,117 @@
+package phone
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   Number
+		wantOK bool
+	}{
+		{input: "(703) 801-5897", want: Number{Digits: "7038015897", NANP: true}, wantOK: true},
+		{input: "+1 703.801.5897", want: Number{Digits: "7038015897", NANP: true}, wantOK: true},
+		{input: "1-703-801-5897", want: Number{Digits: "7038015897", NANP: true}, wantOK: true},
+		{input: "703-801-5897 ext. 204", want: Number{Digits: "7038015897", Extension: "204", NANP: true}, wantOK: true},
+		{input: "7038015897x12", want: Number{Digits: "7038015897", Extension: "12", NANP: true}, wantOK: true},
+		{input: "703 801 5897 #3", want: Number{Digits: "7038015897", Extension: "3", NANP: true}, wantOK: true},
+		{input: "+44 20 7946 0958", want: Number{Digits: "+442079460958"}, wantOK: true},
+		{input: "011 44 20 7946 0958", want: Number{Digits: "+442079460958"}, wantOK: true},
+		{input: "801-5897", wantOK: false},
+		{input: "1-800-FLOWERS", wantOK: false},
+		{input: "see attached", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := Parse(tt.input)
+			if ok != tt.wantOK {
+				t.Fatalf("Parse(%q) ok = %v, want %v", tt.input, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidAreaCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: "703", want: true},
+		{code: "212", want: true},
+		{code: "800", want: true},
+		{code: "123", want: false},
+		{code: "911", want: false},
+		{code: "595", want: false},
+		{code: "70", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := ValidAreaCode(tt.code); got != tt.want {
+				t.Errorf("ValidAreaCode(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizerProcess(t *testing.T) {
+	rx := models.Prescription{
+		Patient: models.Patient{
+			PhoneNumbers: []models.PhoneNumber{
+				{Label: "C", Number: "(703) 801-5897"},
+				{Label: "w", Number: "202-555-0100 ext 44"},
+				{Label: "Home", Number: "(123) 555-0199"},
+			},
+			EmergencyContact: models.Contact{Phone: "+1 (571) 555-0123"},
+			Insurance:        []models.Insurance{{PhoneNumber: "1-800-555-0111"}},
+		},
+		Prescriber: models.Prescriber{
+			Office: models.PrescriberOffice{Phone: "202.555.0100 x12", Fax: "not listed"},
+		},
+	}
+
+	issues := NewNormalizer().Process(context.Background(), &rx)
+
+	wantPhones := []models.PhoneNumber{
+		{Label: "Mobile", Number: "7038015897"},
+		{Label: "Work", Number: "2025550100", Extension: "44"},
+		{Label: "Home", Number: "1235550199"},
+	}
+	for i, want := range wantPhones {
+		if rx.Patient.PhoneNumbers[i] != want {
+			t.Errorf("Expected phone %d to be %+v, got %+v", i, want, rx.Patient.PhoneNumbers[i])
+		}
+	}
+
+	if rx.Patient.EmergencyContact.Phone != "5715550123" {
+		t.Errorf("Unexpected emergency contact phone %q", rx.Patient.EmergencyContact.Phone)
+	}
+	if rx.Prescriber.Office.Phone != "2025550100 x12" {
+		t.Errorf("Unexpected office phone %q", rx.Prescriber.Office.Phone)
+	}
+	if rx.Patient.Insurance[0].PhoneNumber != "8005550111" {
+		t.Errorf("Unexpected insurance phone %q", rx.Patient.Insurance[0].PhoneNumber)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.Field+":"+issue.Code)
+	}
+	want := []string{
+		"patient.phone_numbers[2].number:phone_area_code_invalid",
+		"prescriber.office.fax:phone_invalid",
+		"patient.phone_numbers[1].number:phone_misassigned",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected issues %v, got %v", want, got)
+	}
+}
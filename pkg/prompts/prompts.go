@@ -0,0 +1,154 @@
+// Package prompts holds the versioned prompts given to the parsing models. Each version is a
+// complete set of the system, parse, review and scoring prompts, so the prompts that produced a
+// result can be identified by the version recorded on its job. Version 1 is bundled with the
+// service; further versions are loaded at startup from a directory with one subdirectory per
+// version, containing system.txt, parse.txt, review.txt and scoring.txt, and optionally
+// self_review.txt and verify.txt, which default to the bundled version's.
+package prompts
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// BundledVersion is the version of the prompts bundled with the service.
+const BundledVersion = "1"
+
+//go:embed bundled
+var bundled embed.FS
+
+// Set is one version of the prompts.
+type Set struct {
+	Version    string
+	System     string // Instructions on how to read prescription forms, given as the system prompt
+	Parse      string // Request to parse a document into the prescription schema
+	Review     string // Request to parse a document again, learning from the example samples before it
+	SelfReview string // Request to correct a parse result against the document, followed by the result's JSON
+	Verify     string // Request to re-check flagged fields, followed by the fields and the result's JSON
+	Scoring    string // Instructions for scoring a parse result against the expected result in evaluations
+}
+
+// Registry holds the prompt versions.
+type Registry struct {
+	defaultVersion string
+	sets           map[string]Set
+}
+
+// Load returns the bundled prompts and the versions in dir, if dir is not empty, with
+// defaultVersion used for requests that select no version. An empty defaultVersion selects the
+// bundled version.
+func Load(dir, defaultVersion string) (*Registry, error) {
+	bundledSets, err := fs.Sub(bundled, "bundled")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundled prompts: %w", err)
+	}
+
+	registry := &Registry{defaultVersion: defaultVersion, sets: make(map[string]Set)}
+	if registry.defaultVersion == "" {
+		registry.defaultVersion = BundledVersion
+	}
+
+	if err := registry.load(bundledSets); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := registry.load(os.DirFS(dir)); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := registry.sets[registry.defaultVersion]; !ok {
+		return nil, fmt.Errorf("default prompt version %q is not defined", registry.defaultVersion)
+	}
+
+	return registry, nil
+}
+
+// load reads each directory of fsys as a prompt version.
+func (r *Registry) load(fsys fs.FS) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return fmt.Errorf("failed to read prompt directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		version := entry.Name()
+		if _, exists := r.sets[version]; exists {
+			return fmt.Errorf("duplicate prompt version %q", version)
+		}
+
+		set := Set{Version: version}
+		for _, file := range []struct {
+			name   string
+			prompt *string
+		}{
+			{"system.txt", &set.System},
+			{"parse.txt", &set.Parse},
+			{"review.txt", &set.Review},
+			{"scoring.txt", &set.Scoring},
+		} {
+			data, err := fs.ReadFile(fsys, version+"/"+file.name)
+			if err != nil {
+				return fmt.Errorf("prompt version %q: failed to read %s: %w", version, file.name, err)
+			}
+			*file.prompt = string(data)
+		}
+
+		// Prompts added after the first versions fall back to the bundled version's
+		bundledSet := r.sets[BundledVersion]
+		for _, file := range []struct {
+			name     string
+			prompt   *string
+			fallback string
+		}{
+			{"self_review.txt", &set.SelfReview, bundledSet.SelfReview},
+			{"verify.txt", &set.Verify, bundledSet.Verify},
+		} {
+			data, err := fs.ReadFile(fsys, version+"/"+file.name)
+			switch {
+			case err == nil:
+				*file.prompt = string(data)
+			case errors.Is(err, fs.ErrNotExist) && version != BundledVersion:
+				*file.prompt = file.fallback
+			default:
+				return fmt.Errorf("prompt version %q: failed to read %s: %w", version, file.name, err)
+			}
+		}
+
+		r.sets[version] = set
+	}
+
+	return nil
+}
+
+// Get returns a prompt version, or the default version when version is empty. It returns false
+// if the version does not exist.
+func (r *Registry) Get(version string) (Set, bool) {
+	if version == "" {
+		version = r.defaultVersion
+	}
+	set, ok := r.sets[version]
+	return set, ok
+}
+
+// Default returns the version used for requests that select none.
+func (r *Registry) Default() Set {
+	return r.sets[r.defaultVersion]
+}
+
+// Versions returns the names of all prompt versions in sorted order.
+func (r *Registry) Versions() []string {
+	versions := make([]string, 0, len(r.sets))
+	for version := range r.sets {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	return versions
+}
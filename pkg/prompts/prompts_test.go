@@ -0,0 +1,120 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeVersion(t *testing.T, dir, version string, prompts map[string]string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Join(dir, version), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, prompt := range prompts {
+		if err := os.WriteFile(filepath.Join(dir, version, name), []byte(prompt), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+var completeVersion = map[string]string{
+	"system.txt":  "You read prescriptions.\n",
+	"parse.txt":   "Parse it.",
+	"review.txt":  "Parse it again.",
+	"scoring.txt": "Score it.\n\n",
+}
+
+func TestLoadBundled(t *testing.T) {
+	registry, err := Load("", "")
+	if err != nil {
+		t.Fatalf("Failed to load prompts: %v", err)
+	}
+
+	set, ok := registry.Get("")
+	if !ok || set.Version != BundledVersion {
+		t.Fatalf("Expected the bundled version by default, got %q", set.Version)
+	}
+	if !strings.HasPrefix(set.System, "You are an expert AI prescription parser") || set.Parse == "" || set.Review == "" || set.SelfReview == "" || set.Verify == "" || set.Scoring == "" {
+		t.Errorf("Expected every bundled prompt to be loaded, got %+v", set)
+	}
+	if registry.Default().Version != BundledVersion {
+		t.Errorf("Expected the bundled default, got %q", registry.Default().Version)
+	}
+}
+
+func TestLoadDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeVersion(t, dir, "2", completeVersion)
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("not a version"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry, err := Load(dir, "2")
+	if err != nil {
+		t.Fatalf("Failed to load prompts: %v", err)
+	}
+
+	if versions := registry.Versions(); !slices.Equal(versions, []string{"1", "2"}) {
+		t.Errorf("Expected versions 1 and 2, got %v", versions)
+	}
+
+	set, ok := registry.Get("")
+	if !ok || set.Version != "2" || set.System != "You read prescriptions.\n" || set.Scoring != "Score it.\n\n" {
+		t.Errorf("Expected the configured default to be read exactly, got %+v", set)
+	}
+	bundledSet, ok := registry.Get("1")
+	if !ok || bundledSet.Version != "1" {
+		t.Errorf("Expected the bundled version to be selectable, got %+v", bundledSet)
+	}
+	if set.SelfReview != bundledSet.SelfReview || set.Verify != bundledSet.Verify {
+		t.Errorf("Expected a version without self_review.txt and verify.txt to use the bundled prompts")
+	}
+
+	writeVersion(t, dir, "self-review", completeVersion)
+	writeVersion(t, dir, "self-review", map[string]string{"self_review.txt": "Check it."})
+	registry, err = Load(dir, "self-review")
+	if err != nil {
+		t.Fatalf("Failed to load prompts: %v", err)
+	}
+	if set := registry.Default(); set.SelfReview != "Check it." || set.Verify != bundledSet.Verify {
+		t.Errorf("Expected the version's own self-review prompt, got %q", set.SelfReview)
+	}
+	if _, ok := registry.Get("3"); ok {
+		t.Errorf("Expected an unknown version to be missing")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		versions       map[string]map[string]string
+		defaultVersion string
+		wantErr        string
+	}{
+		{"unknown default", nil, "2", "default prompt version"},
+		{"incomplete version", map[string]map[string]string{"2": {"system.txt": "x"}}, "", "failed to read parse.txt"},
+		{"bundled version redefined", map[string]map[string]string{"1": completeVersion}, "", "duplicate prompt version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for version, prompts := range tt.versions {
+				writeVersion(t, dir, version, prompts)
+			}
+
+			_, err := Load(dir, tt.defaultVersion)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing"), ""); err == nil {
+		t.Errorf("Expected an error for a missing prompt directory")
+	}
+}
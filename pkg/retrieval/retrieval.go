@@ -0,0 +1,96 @@
+// Package retrieval measures embedding distances and picks the samples shown to the model, trading
+// relevance against diversity with maximal marginal relevance (MMR).
+package retrieval
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// DefaultK is the number of samples retrieved when no k is configured.
+const DefaultK = 3
+
+// MMRCandidates is how many nearest samples per requested sample are considered when diversifying.
+const MMRCandidates = 5
+
+// ParseMetric validates a distance metric name. An empty name is L2.
+func ParseMetric(s string) (models.DistanceMetric, error) {
+	switch metric := models.DistanceMetric(s); metric {
+	case "":
+		return models.DistanceL2, nil
+	case models.DistanceL2, models.DistanceCosine:
+		return metric, nil
+	default:
+		return "", fmt.Errorf("unknown distance metric %q: must be l2 or cosine", s)
+	}
+}
+
+// Distance returns the distance between two vectors of the same length, as pgvector computes it:
+// the Euclidean distance for DistanceL2 and one minus the cosine similarity for DistanceCosine.
+// A zero vector has no direction, so its cosine distance is taken as 1, that of orthogonal vectors,
+// rather than the NaN pgvector returns.
+func Distance(metric models.DistanceMetric, a, b []float32) float64 {
+	if metric == models.DistanceCosine {
+		var dot, normA, normB float64
+		for i := range a {
+			dot += float64(a[i]) * float64(b[i])
+			normA += float64(a[i]) * float64(a[i])
+			normB += float64(b[i]) * float64(b[i])
+		}
+		if normA == 0 || normB == 0 {
+			return 1
+		}
+		return 1 - dot/math.Sqrt(normA*normB)
+	}
+
+	var sum float64
+	for i := range a {
+		d := float64(a[i]) - float64(b[i])
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// Diversify picks up to k of the candidates by maximal marginal relevance and returns their
+// indexes in the order picked. relevance[i] is the distance of candidate i from the query. Each
+// pick maximizes lambda times its closeness to the query plus (1 - lambda) times its distance from
+// the closest candidate already picked, so near-duplicates of a picked sample are passed over for
+// a slightly less relevant but different one. lambda 1 picks the nearest candidates.
+func Diversify(metric models.DistanceMetric, candidates [][]float32, relevance []float64, k int, lambda float64) []int {
+	picked := make([]int, 0, min(k, len(candidates)))
+	used := make([]bool, len(candidates))
+
+	// closest[i] is the distance of candidate i from the nearest picked candidate.
+	closest := make([]float64, len(candidates))
+	for i := range closest {
+		closest[i] = math.Inf(1)
+	}
+
+	for len(picked) < k && len(picked) < len(candidates) {
+		best, bestScore := -1, math.Inf(-1)
+		for i := range candidates {
+			if used[i] {
+				continue
+			}
+			score := -lambda * relevance[i]
+			if len(picked) > 0 {
+				score += (1 - lambda) * closest[i]
+			}
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		picked = append(picked, best)
+		used[best] = true
+		for i := range candidates {
+			if !used[i] {
+				closest[i] = min(closest[i], Distance(metric, candidates[i], candidates[best]))
+			}
+		}
+	}
+
+	return picked
+}
@@ -0,0 +1,89 @@
+package retrieval
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func TestParseMetric(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    models.DistanceMetric
+		wantErr bool
+	}{
+		{"", models.DistanceL2, false},
+		{"l2", models.DistanceL2, false},
+		{"cosine", models.DistanceCosine, false},
+		{"manhattan", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseMetric(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMetric(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMetric(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric models.DistanceMetric
+		a, b   []float32
+		want   float64
+	}{
+		{"l2", models.DistanceL2, []float32{0, 0}, []float32{3, 4}, 5},
+		{"l2 equal", models.DistanceL2, []float32{1, 2}, []float32{1, 2}, 0},
+		{"cosine same direction", models.DistanceCosine, []float32{1, 1}, []float32{2, 2}, 0},
+		{"cosine orthogonal", models.DistanceCosine, []float32{1, 0}, []float32{0, 1}, 1},
+		{"cosine opposite", models.DistanceCosine, []float32{1, 0}, []float32{-1, 0}, 2},
+		{"cosine zero vector", models.DistanceCosine, []float32{0, 0}, []float32{1, 0}, 1},
+		{"cosine both zero", models.DistanceCosine, []float32{0, 0}, []float32{0, 0}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distance(tt.metric, tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Distance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiversify(t *testing.T) {
+	query := []float32{0, 0}
+	// Two near-duplicates close to the query and a different sample slightly farther away.
+	candidates := [][]float32{{1, 0}, {1, 0.01}, {0, 1.1}}
+	relevance := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		relevance[i] = Distance(models.DistanceL2, query, candidate)
+	}
+
+	tests := []struct {
+		name   string
+		k      int
+		lambda float64
+		want   []int
+	}{
+		{"relevance only", 2, 1, []int{0, 1}},
+		{"diversified", 2, 0.5, []int{0, 2}},
+		{"k larger than candidates", 5, 0.5, []int{0, 2, 1}},
+		{"k zero", 0, 0.5, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diversify(models.DistanceL2, candidates, relevance, tt.k, tt.lambda); !slices.Equal(got, tt.want) {
+				t.Errorf("Diversify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
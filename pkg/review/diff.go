@@ -0,0 +1,98 @@
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// Diff compares a parsed prescription with its reviewed correction field by field and returns
+// the fields that differ, in path order. Fields are compared by their JSON values, so array
+// elements are matched by index and a field that is empty in one and missing in the other is
+// not a change. Values derived by post-processing, such as the RxNorm match, are not compared.
+func Diff(original, corrected models.Prescription) ([]models.FieldChange, error) {
+	before, err := flatten(original)
+	if err != nil {
+		return nil, err
+	}
+	after, err := flatten(corrected)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(before)+len(after))
+	for path := range before {
+		paths = append(paths, path)
+	}
+	for path := range after {
+		if _, ok := before[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	changes := []models.FieldChange{}
+	for _, path := range paths {
+		o, c := before[path], after[path]
+		if reflect.DeepEqual(o, c) {
+			continue
+		}
+		changes = append(changes, models.FieldChange{Field: path, Original: o, Corrected: c})
+	}
+
+	return changes, nil
+}
+
+// derivedFields are the JSON keys of values filled in by post-processing rather than parsed.
+var derivedFields = map[string]bool{
+	"normalized":   true,
+	"dea_schedule": true,
+}
+
+// flatten maps the path of every non-empty leaf value of the prescription's JSON to the value.
+func flatten(rx models.Prescription) (map[string]any, error) {
+	data, err := json.Marshal(rx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal prescription: %w", err)
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal prescription: %w", err)
+	}
+
+	leaves := map[string]any{}
+	var walk func(path string, value any)
+	walk = func(path string, value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for key, child := range v {
+				if derivedFields[key] {
+					continue
+				}
+				if path == "" {
+					walk(key, child)
+				} else {
+					walk(path+"."+key, child)
+				}
+			}
+		case []any:
+			for i, child := range v {
+				walk(fmt.Sprintf("%s[%d]", path, i), child)
+			}
+		case nil:
+		case string:
+			if v != "" {
+				leaves[path] = v
+			}
+		default:
+			leaves[path] = v
+		}
+	}
+	walk("", doc)
+
+	return leaves, nil
+}
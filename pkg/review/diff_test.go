@@ -0,0 +1,79 @@
+package review
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func TestDiff(t *testing.T) {
+	original := models.Prescription{
+		Patient: models.Patient{FirstName: "Ann", LastName: "Lee", Dob: "1980-02-01"},
+		Medications: []models.Medication{
+			{DrugName: "Humria", Refills: "5", Normalized: &models.DrugNormalization{RxCUI: "327361"}, DeaSchedule: "II"},
+			{DrugName: "Metformin"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(rx *models.Prescription)
+		changes []models.FieldChange
+	}{
+		{
+			name:    "unchanged",
+			edit:    func(rx *models.Prescription) {},
+			changes: []models.FieldChange{},
+		},
+		{
+			name: "corrected values",
+			edit: func(rx *models.Prescription) {
+				rx.Patient.Dob = "1980-01-02"
+				rx.Medications[0].DrugName = "Humira"
+			},
+			changes: []models.FieldChange{
+				{Field: "medications[0].drug_name", Original: "Humria", Corrected: "Humira"},
+				{Field: "patient.dob", Original: "1980-02-01", Corrected: "1980-01-02"},
+			},
+		},
+		{
+			name: "added and removed values",
+			edit: func(rx *models.Prescription) {
+				rx.Patient.LastName = ""
+				rx.Medications = rx.Medications[:1]
+				rx.Attachments.LabResults = true
+			},
+			changes: []models.FieldChange{
+				{Field: "attachments.lab_results", Original: false, Corrected: true},
+				{Field: "medications[1].drug_name", Original: "Metformin", Corrected: nil},
+				{Field: "patient.last_name", Original: "Lee", Corrected: nil},
+			},
+		},
+		{
+			name: "derived values ignored",
+			edit: func(rx *models.Prescription) {
+				rx.Medications[0].Normalized = nil
+				rx.Medications[0].DeaSchedule = ""
+			},
+			changes: []models.FieldChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			corrected := original
+			corrected.Medications = make([]models.Medication, len(original.Medications))
+			copy(corrected.Medications, original.Medications)
+			tt.edit(&corrected)
+
+			changes, err := Diff(original, corrected)
+			if err != nil {
+				t.Fatalf("Diff returned error: %v", err)
+			}
+			if !reflect.DeepEqual(changes, tt.changes) {
+				t.Errorf("Expected changes %+v, got %+v", tt.changes, changes)
+			}
+		})
+	}
+}
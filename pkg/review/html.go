@@ -0,0 +1,23 @@
+package review
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+)
+
+// htmlTemplate lays out a sheet as a page that prints with one table per section.
+//
+//go:embed review.html
+var htmlTemplate string
+
+var sheetTemplate = template.Must(template.New("review").Parse(htmlTemplate))
+
+// RenderHTML writes the sheet as a standalone, printable HTML page.
+func RenderHTML(w io.Writer, sheet *Sheet) error {
+	if err := sheetTemplate.Execute(w, sheet); err != nil {
+		return fmt.Errorf("failed to render review sheet: %w", err)
+	}
+	return nil
+}
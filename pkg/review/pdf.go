@@ -0,0 +1,292 @@
+package review
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/go-pdf/fpdf"
+)
+
+// Page layout of the PDF sheet in millimetres.
+const (
+	pdfMargin       = 12.0
+	labelWidth      = 44.0
+	valueWidth      = 86.0
+	checkWidth      = 16.0
+	correctionWidth = 45.0
+	valueLineHeight = 4.6
+	issueLineHeight = 4.0
+	cellPadding     = 1.2
+	checkboxSize    = 3.5
+	sectionSpacing  = 4.0
+)
+
+type rgb struct{ r, g, b int }
+
+var (
+	errorFill   = rgb{248, 215, 218}
+	warningFill = rgb{255, 243, 205}
+	headerFill  = rgb{240, 240, 240}
+	errorText   = rgb{132, 32, 41}
+	warningText = rgb{133, 100, 4}
+	mutedText   = rgb{110, 110, 110}
+)
+
+// RenderPDF writes the sheet as a US Letter PDF with the same sections and highlighting as the
+// HTML sheet. Only the standard PDF fonts are used, so characters outside Windows-1252 are
+// replaced.
+func RenderPDF(w io.Writer, sheet *Sheet) error {
+	pdf := fpdf.New("P", "mm", "Letter", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.SetTitle("Prescription Review "+sheet.JobID, true)
+	pdf.SetCreator("prescription-parser", true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin + 2)
+		pdf.SetFont("Helvetica", "", 8)
+		setTextColor(pdf, mutedText)
+		pdf.CellFormat(0, 4, fmt.Sprintf("Job %s - page %d of {nb}", sheet.JobID, pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	r := &pdfRenderer{pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+	pdf.AddPage()
+	r.header(sheet)
+
+	if len(sheet.Issues) > 0 {
+		r.sectionTitle("Validation Issues")
+		for _, issue := range sheet.Issues {
+			r.issueLine(issue)
+		}
+	}
+
+	for _, section := range sheet.Sections {
+		r.section(section)
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to render review sheet pdf: %w", err)
+	}
+	return nil
+}
+
+type pdfRenderer struct {
+	pdf *fpdf.Fpdf
+	tr  func(string) string
+}
+
+func (r *pdfRenderer) header(sheet *Sheet) {
+	pdf := r.pdf
+
+	pdf.SetFont("Helvetica", "B", 16)
+	setTextColor(pdf, rgb{34, 34, 34})
+	pdf.CellFormat(0, 8, "Prescription Review", "", 1, "L", false, 0, "")
+
+	meta := []string{"Job " + sheet.JobID}
+	if sheet.FileName != "" {
+		meta = append(meta, sheet.FileName)
+	}
+	if !sheet.CompletedAt.IsZero() {
+		meta = append(meta, "parsed "+sheet.CompletedAt.UTC().Format("2006-01-02 15:04 MST"))
+	}
+	pdf.SetFont("Helvetica", "", 9)
+	setTextColor(pdf, mutedText)
+	pdf.MultiCell(0, 4.5, r.tr(strings.Join(meta, " - ")), "", "L", false)
+
+	status, fill, text := "No blocking issues", rgb{209, 231, 221}, rgb{15, 81, 50}
+	if sheet.Blocked {
+		status, fill, text = "Blocked", errorFill, errorText
+	}
+	pdf.SetFont("Helvetica", "B", 9)
+	setFillColor(pdf, fill)
+	setTextColor(pdf, text)
+	pdf.CellFormat(pdf.GetStringWidth(status)+6, 6, status, "", 1, "C", true, 0, "")
+	pdf.Ln(2)
+}
+
+func (r *pdfRenderer) sectionTitle(title string) {
+	pdf := r.pdf
+	r.ensureSpace(sectionSpacing + 7 + 2*valueLineHeight)
+
+	pdf.Ln(sectionSpacing)
+	pdf.SetFont("Helvetica", "B", 12)
+	setTextColor(pdf, rgb{34, 34, 34})
+	pdf.CellFormat(0, 6, r.tr(title), "B", 1, "L", false, 0, "")
+	pdf.Ln(1)
+}
+
+func (r *pdfRenderer) issueLine(issue models.ValidationIssue) {
+	pdf := r.pdf
+	text := r.tr(fmt.Sprintf("%s %s: %s", strings.ToUpper(string(issue.Severity)), issue.Field, issue.Message))
+
+	pdf.SetFont("Helvetica", "", 9)
+	lines := pdf.SplitText(text, pageWidth(pdf))
+	r.ensureSpace(float64(len(lines)) * valueLineHeight)
+
+	setTextColor(pdf, severityText(issue.Severity))
+	pdf.MultiCell(0, valueLineHeight, text, "", "L", false)
+}
+
+func (r *pdfRenderer) section(section Section) {
+	r.sectionTitle(section.Title)
+	r.tableHeader()
+	for _, row := range section.Rows {
+		r.row(row)
+	}
+}
+
+func (r *pdfRenderer) tableHeader() {
+	pdf := r.pdf
+	pdf.SetFont("Helvetica", "B", 9)
+	setTextColor(pdf, rgb{34, 34, 34})
+	setFillColor(pdf, headerFill)
+	setDrawColor(pdf, rgb{204, 204, 204})
+	for _, col := range []struct {
+		title string
+		width float64
+	}{
+		{"Field", labelWidth},
+		{"Parsed value", valueWidth},
+		{"Checked", checkWidth},
+		{"Correction", correctionWidth},
+	} {
+		pdf.CellFormat(col.width, 6, col.title, "1", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+func (r *pdfRenderer) row(row Row) {
+	pdf := r.pdf
+
+	pdf.SetFont("Helvetica", "B", 9)
+	labelLines := pdf.SplitText(r.tr(row.Label), labelWidth-2*cellPadding)
+
+	value := row.Value
+	if value == "" {
+		value = "-"
+	}
+	pdf.SetFont("Helvetica", "", 9)
+	valueLines := pdf.SplitText(r.tr(value), valueWidth-2*cellPadding)
+
+	pdf.SetFont("Helvetica", "I", 8)
+	var issueLines [][]string
+	for _, issue := range row.Issues {
+		issueLines = append(issueLines, pdf.SplitText(r.tr(issue.Message), valueWidth-2*cellPadding))
+	}
+
+	labelHeight := float64(len(labelLines)) * valueLineHeight
+	if row.LowConfidence {
+		labelHeight += issueLineHeight
+	}
+	valueHeight := float64(len(valueLines)) * valueLineHeight
+	for _, lines := range issueLines {
+		valueHeight += float64(len(lines)) * issueLineHeight
+	}
+	height := max(labelHeight, valueHeight, checkboxSize+valueLineHeight) + 2*cellPadding
+
+	if r.ensureSpace(height) {
+		r.tableHeader()
+	}
+
+	x, y := pdf.GetXY()
+	fill := row.Highlighted()
+	if fill {
+		setFillColor(pdf, rowFill(row.Severity))
+	}
+	style := "D"
+	if fill {
+		style = "FD"
+	}
+	setDrawColor(pdf, rgb{204, 204, 204})
+	offset := x
+	for _, width := range []float64{labelWidth, valueWidth, checkWidth, correctionWidth} {
+		pdf.Rect(offset, y, width, height, style)
+		offset += width
+	}
+
+	// Label, with a verify marker under it for low confidence values.
+	lineY := y + cellPadding
+	pdf.SetFont("Helvetica", "B", 9)
+	setTextColor(pdf, rgb{34, 34, 34})
+	for _, line := range labelLines {
+		pdf.SetXY(x+cellPadding, lineY)
+		pdf.CellFormat(labelWidth-2*cellPadding, valueLineHeight, line, "", 0, "L", false, 0, "")
+		lineY += valueLineHeight
+	}
+	if row.LowConfidence {
+		pdf.SetFont("Helvetica", "B", 7.5)
+		setTextColor(pdf, warningText)
+		pdf.SetXY(x+cellPadding, lineY)
+		pdf.CellFormat(labelWidth-2*cellPadding, issueLineHeight, "VERIFY AGAINST DOCUMENT", "", 0, "L", false, 0, "")
+	}
+
+	// Value followed by the row's issues.
+	valueX := x + labelWidth + cellPadding
+	lineY = y + cellPadding
+	pdf.SetFont("Helvetica", "", 9)
+	setTextColor(pdf, rgb{34, 34, 34})
+	if row.Value == "" {
+		setTextColor(pdf, mutedText)
+	}
+	for _, line := range valueLines {
+		pdf.SetXY(valueX, lineY)
+		pdf.CellFormat(valueWidth-2*cellPadding, valueLineHeight, line, "", 0, "L", false, 0, "")
+		lineY += valueLineHeight
+	}
+	pdf.SetFont("Helvetica", "I", 8)
+	for i, lines := range issueLines {
+		setTextColor(pdf, severityText(row.Issues[i].Severity))
+		for _, line := range lines {
+			pdf.SetXY(valueX, lineY)
+			pdf.CellFormat(valueWidth-2*cellPadding, issueLineHeight, line, "", 0, "L", false, 0, "")
+			lineY += issueLineHeight
+		}
+	}
+
+	// An empty box to tick once the value has been checked against the document.
+	setDrawColor(pdf, rgb{34, 34, 34})
+	pdf.Rect(x+labelWidth+valueWidth+(checkWidth-checkboxSize)/2, y+cellPadding+0.5, checkboxSize, checkboxSize, "D")
+
+	pdf.SetXY(x, y+height)
+}
+
+// ensureSpace starts a new page if less than height is left on the current one. It reports
+// whether a page was added.
+func (r *pdfRenderer) ensureSpace(height float64) bool {
+	_, pageHeight := r.pdf.GetPageSize()
+	if r.pdf.GetY()+height <= pageHeight-pdfMargin-4 {
+		return false
+	}
+	r.pdf.AddPage()
+	return true
+}
+
+func pageWidth(pdf *fpdf.Fpdf) float64 {
+	width, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	return width - left - right
+}
+
+func rowFill(severity models.Severity) rgb {
+	if severity == models.SeverityError {
+		return errorFill
+	}
+	return warningFill
+}
+
+func severityText(severity models.Severity) rgb {
+	switch severity {
+	case models.SeverityError:
+		return errorText
+	case models.SeverityWarning:
+		return warningText
+	default:
+		return mutedText
+	}
+}
+
+func setFillColor(pdf *fpdf.Fpdf, c rgb) { pdf.SetFillColor(c.r, c.g, c.b) }
+func setTextColor(pdf *fpdf.Fpdf, c rgb) { pdf.SetTextColor(c.r, c.g, c.b) }
+func setDrawColor(pdf *fpdf.Fpdf, c rgb) { pdf.SetDrawColor(c.r, c.g, c.b) }
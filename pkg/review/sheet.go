@@ -0,0 +1,322 @@
+// Package review renders parsed prescriptions as printable verification sheets. A sheet lists
+// every parsed field by section next to space for the reviewer's check mark and correction,
+// highlights fields with validation issues and marks the ones to verify against the document.
+package review
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// Sheet is a review sheet for one parse result.
+type Sheet struct {
+	JobID       string
+	FileName    string
+	CompletedAt time.Time
+	Blocked     bool
+	Issues      []models.ValidationIssue // All validation issues, most severe first
+	Sections    []Section
+}
+
+// Section is a titled group of fields, such as the patient or one medication.
+type Section struct {
+	Title string
+	Rows  []Row
+}
+
+// Row is one field on the sheet.
+type Row struct {
+	Label string
+	Path  string // Field path the row covers, in the dot notation used by validation issues
+	Value string
+
+	// Severity is the most serious severity of the row's issues, or empty if it has none.
+	Severity models.Severity
+
+	// LowConfidence marks values the reviewer should verify against the document, because a
+	// check raised a warning or the drug name was only matched approximately.
+	LowConfidence bool
+
+	Issues []models.ValidationIssue
+}
+
+// Highlighted reports whether the row has a warning or error.
+func (r Row) Highlighted() bool {
+	return r.Severity == models.SeverityWarning || r.Severity == models.SeverityError
+}
+
+// NewSheet lays out a parse result as a review sheet and attaches each validation issue to the
+// rows whose field it refers to. Issues on a whole section, such as "medications", are only
+// listed in the sheet's issue summary.
+func NewSheet(result models.ParseResult) *Sheet {
+	rx := result.Prescription
+
+	sheet := &Sheet{
+		JobID:       result.ID,
+		FileName:    result.Attributes[jobs.AttributeFileName],
+		CompletedAt: result.CompletedAt,
+		Blocked:     result.Blocked,
+		Issues:      slices.Clone(result.Validation),
+	}
+	slices.SortStableFunc(sheet.Issues, func(a, b models.ValidationIssue) int {
+		return severityRank(b.Severity) - severityRank(a.Severity)
+	})
+
+	sheet.Sections = append(sheet.Sections, Section{Title: "Prescription", Rows: []Row{
+		{Label: "Date written", Path: "date_written", Value: rx.DateWritten},
+		{Label: "Date needed", Path: "date_needed", Value: rx.DateNeeded},
+		{Label: "Therapy status", Path: "therapy_status", Value: rx.TherapyStatus},
+	}})
+
+	sheet.Sections = append(sheet.Sections, patientSection(rx.Patient))
+	for i, policy := range rx.Patient.Insurance {
+		sheet.Sections = append(sheet.Sections, insuranceSection(i, policy))
+	}
+	sheet.Sections = append(sheet.Sections, prescriberSection(rx.Prescriber))
+	sheet.Sections = append(sheet.Sections, diagnosisSection(rx))
+	for i, med := range rx.Medications {
+		sheet.Sections = append(sheet.Sections, medicationSection(i, med))
+	}
+
+	if len(rx.FailedTherapies) > 0 {
+		section := Section{Title: "Failed Therapies"}
+		for i, therapy := range rx.FailedTherapies {
+			section.Rows = append(section.Rows, Row{
+				Label: therapy.Name,
+				Path:  fmt.Sprintf("failed_therapies[%d]", i),
+				Value: therapy.ReasonForDiscontinuation,
+			})
+		}
+		sheet.Sections = append(sheet.Sections, section)
+	}
+
+	sheet.Sections = append(sheet.Sections, Section{Title: "Delivery and Signature", Rows: []Row{
+		{Label: "Ship to", Path: "delivery.destination", Value: rx.Delivery.Destination},
+		{Label: "Delivery notes", Path: "delivery.notes", Value: rx.Delivery.Notes},
+		{Label: "Signature date", Path: "prescriber_signature.date", Value: rx.PrescriberSignature.Date},
+		{Label: "DAW code", Path: "prescriber_signature.daw_code", Value: rx.PrescriberSignature.DawCode},
+		{Label: "Attachments", Path: "attachments", Value: attachments(rx.Attachments)},
+	}})
+
+	for i := range sheet.Sections {
+		for j := range sheet.Sections[i].Rows {
+			sheet.Sections[i].Rows[j].attach(sheet.Issues)
+		}
+	}
+
+	// An approximate drug name match is worth a second look even when no check flagged it.
+	for i, med := range rx.Medications {
+		if med.Normalized != nil && med.Normalized.Confidence < 1 {
+			sheet.row(fmt.Sprintf("medications[%d].drug_name", i)).LowConfidence = true
+		}
+	}
+
+	return sheet
+}
+
+func patientSection(p models.Patient) Section {
+	section := Section{Title: "Patient", Rows: []Row{
+		{Label: "First name", Path: "patient.first_name", Value: p.FirstName},
+		{Label: "Middle name", Path: "patient.middle_name", Value: p.MiddleName},
+		{Label: "Last name", Path: "patient.last_name", Value: p.LastName},
+		{Label: "Date of birth", Path: "patient.dob", Value: p.Dob},
+		{Label: "Sex", Path: "patient.sex", Value: p.Sex},
+		{Label: "Weight", Path: "patient.weight", Value: measurement(p.Weight)},
+		{Label: "Height", Path: "patient.height", Value: measurement(p.Height)},
+		{Label: "Address", Path: "patient.address", Value: address(p.Address)},
+	}}
+
+	for i, phone := range p.PhoneNumbers {
+		label := "Phone"
+		if phone.Label != "" {
+			label = fmt.Sprintf("Phone (%s)", phone.Label)
+		}
+		value := phone.Number
+		if phone.Extension != "" {
+			value += " x" + phone.Extension
+		}
+		section.Rows = append(section.Rows, Row{Label: label, Path: fmt.Sprintf("patient.phone_numbers[%d]", i), Value: value})
+	}
+
+	contact := p.EmergencyContact
+	section.Rows = append(section.Rows,
+		Row{Label: "Allergies", Path: "patient.allergies", Value: strings.Join(p.Allergies, ", ")},
+		Row{Label: "Emergency contact", Path: "patient.emergency_contact", Value: join(", ", contact.Name, contact.Relationship, contact.Phone)},
+	)
+
+	return section
+}
+
+func insuranceSection(i int, policy models.Insurance) Section {
+	title := fmt.Sprintf("Insurance %d", i+1)
+	if policy.Type != "" {
+		title = fmt.Sprintf("%s (%s)", title, policy.Type)
+	}
+	path := fmt.Sprintf("patient.insurance[%d]", i)
+
+	return Section{Title: title, Rows: []Row{
+		{Label: "Provider", Path: path + ".provider", Value: policy.Provider},
+		{Label: "Member ID", Path: path + ".id_number", Value: policy.IdNumber},
+		{Label: "Group", Path: path + ".group_number", Value: policy.GroupNumber},
+		{Label: "RxBIN", Path: path + ".rx_bin", Value: policy.RxBin},
+		{Label: "PCN", Path: path + ".pcn", Value: policy.Pcn},
+		{Label: "Policyholder", Path: path + ".policyholder_name", Value: join(", DOB ", policy.PolicyholderName, policy.PolicyholderDob)},
+		{Label: "Phone", Path: path + ".phone_number", Value: policy.PhoneNumber},
+	}}
+}
+
+func prescriberSection(pr models.Prescriber) Section {
+	office := pr.Office
+	return Section{Title: "Prescriber", Rows: []Row{
+		{Label: "Name", Path: "prescriber.name", Value: pr.Name},
+		{Label: "Specialty", Path: "prescriber.specialty", Value: pr.Specialty},
+		{Label: "NPI", Path: "prescriber.npi", Value: pr.Npi},
+		{Label: "DEA", Path: "prescriber.dea", Value: pr.Dea},
+		{Label: "State license", Path: "prescriber.state_license", Value: pr.StateLicense},
+		{Label: "Office", Path: "prescriber.office.name", Value: office.Name},
+		{Label: "Office address", Path: "prescriber.office.address", Value: address(office.Address)},
+		{Label: "Office phone", Path: "prescriber.office.phone", Value: office.Phone},
+		{Label: "Office fax", Path: "prescriber.office.fax", Value: office.Fax},
+		{Label: "Office contact", Path: "prescriber.office.contact_name", Value: join(", ", office.ContactName, office.ContactEmail)},
+	}}
+}
+
+func diagnosisSection(rx models.Prescription) Section {
+	d := rx.Diagnosis
+	section := Section{Title: "Diagnosis", Rows: []Row{
+		{Label: "Primary diagnosis", Path: "diagnosis.primary_diagnosis", Value: diagnosis(d.PrimaryDiagnosis)},
+	}}
+
+	for i, additional := range d.AdditionalDiagnoses {
+		section.Rows = append(section.Rows, Row{
+			Label: fmt.Sprintf("Additional diagnosis %d", i+1),
+			Path:  fmt.Sprintf("diagnosis.additional_diagnoses[%d]", i),
+			Value: diagnosis(additional),
+		})
+	}
+
+	section.Rows = append(section.Rows,
+		Row{Label: "Date of diagnosis", Path: "diagnosis.date_of_diagnosis", Value: d.DateOfDiagnosis},
+		Row{Label: "Clinical information", Path: "clinical_info", Value: strings.Join(rx.ClinicalInfo, "; ")},
+	)
+
+	return section
+}
+
+func medicationSection(i int, med models.Medication) Section {
+	path := fmt.Sprintf("medications[%d]", i)
+
+	drug := med.DrugName
+	if med.Normalized != nil {
+		drug = fmt.Sprintf("%s (RxNorm %s: %s)", med.DrugName, med.Normalized.RxCUI, med.Normalized.Name)
+	}
+
+	section := Section{Title: fmt.Sprintf("Medication %d", i+1), Rows: []Row{
+		{Label: "Drug", Path: path + ".drug_name", Value: drug},
+		{Label: "NDC", Path: path + ".ndc", Value: med.Ndc},
+		{Label: "Strength", Path: path + ".strength", Value: med.Strength},
+		{Label: "Form", Path: path + ".form", Value: med.Form},
+		{Label: "SIG", Path: path + ".sig", Value: med.SIG},
+		{Label: "Directions", Path: path + ".administration_notes", Value: med.AdministrationNotes},
+		{Label: "Quantity", Path: path + ".quantity", Value: med.Quantity},
+		{Label: "Refills", Path: path + ".refills", Value: med.Refills},
+		{Label: "Start date", Path: path + ".start_date", Value: med.StartDate},
+		{Label: "Duration", Path: path + ".duration", Value: med.Duration},
+		{Label: "Indication", Path: path + ".indication", Value: med.Indication},
+	}}
+
+	if med.DeaSchedule != "" {
+		section.Rows = append(section.Rows, Row{Label: "DEA schedule", Path: path + ".dea_schedule", Value: "C-" + med.DeaSchedule})
+	}
+
+	return section
+}
+
+// attach adds the issues that refer to the row's field or to a field within it.
+func (r *Row) attach(issues []models.ValidationIssue) {
+	for _, issue := range issues {
+		if issue.Field != r.Path && !strings.HasPrefix(issue.Field, r.Path+".") && !strings.HasPrefix(issue.Field, r.Path+"[") {
+			continue
+		}
+
+		r.Issues = append(r.Issues, issue)
+		if severityRank(issue.Severity) > severityRank(r.Severity) {
+			r.Severity = issue.Severity
+		}
+		if issue.Severity == models.SeverityWarning {
+			r.LowConfidence = true
+		}
+	}
+}
+
+// row returns the row covering the given path, or a throwaway row if there is none.
+func (s *Sheet) row(path string) *Row {
+	for i := range s.Sections {
+		for j := range s.Sections[i].Rows {
+			if s.Sections[i].Rows[j].Path == path {
+				return &s.Sections[i].Rows[j]
+			}
+		}
+	}
+	return &Row{}
+}
+
+func severityRank(severity models.Severity) int {
+	switch severity {
+	case models.SeverityError:
+		return 3
+	case models.SeverityWarning:
+		return 2
+	case models.SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func address(a models.Address) string {
+	return join(", ", a.Street, a.City, join(" ", a.State, a.Zip))
+}
+
+func measurement(m models.Measurement) string {
+	return join(" ", m.Value, m.Unit)
+}
+
+func diagnosis(d models.Diagnosis) string {
+	return join(" - ", d.Icd10Code, d.Description)
+}
+
+func attachments(a models.AttachmentDetails) string {
+	var included []string
+	for _, attachment := range []struct {
+		name     string
+		included bool
+	}{
+		{"insurance cards", a.InsuranceCards},
+		{"lab results", a.LabResults},
+		{"pathology reports", a.PathologyReports},
+		{"clinical notes", a.ClinicalNotes},
+		{"other documents", a.OtherDocuments},
+	} {
+		if attachment.included {
+			included = append(included, attachment.name)
+		}
+	}
+	return strings.Join(included, ", ")
+}
+
+// join joins the non-empty values with the separator.
+func join(sep string, values ...string) string {
+	var parts []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,141 @@
+package review
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/csotherden/prescription-parser/pkg/jobs"
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func testResult() models.ParseResult {
+	return models.ParseResult{
+		ID: "job-1",
+		Prescription: models.Prescription{
+			DateWritten: "2025-03-14",
+			Patient: models.Patient{
+				FirstName:    "Ann",
+				LastName:     "Lee <O'Neil>",
+				Dob:          "1980-02-01",
+				Address:      models.Address{Street: "12 OAK ST", City: "RESTON", State: "VA", Zip: "99999"},
+				PhoneNumbers: []models.PhoneNumber{{Label: "Mobile", Number: "7035551234"}},
+				Insurance:    []models.Insurance{{Type: "Primary", Provider: "Aetna", IdNumber: "W123"}},
+			},
+			Prescriber: models.Prescriber{Name: "Dr. Jane Brown", Npi: "1234567893"},
+			Diagnosis: models.PatientDiagnosis{
+				PrimaryDiagnosis: models.Diagnosis{Description: "Psoriatic arthritis", Icd10Code: "L40.50"},
+			},
+			Medications: []models.Medication{
+				{DrugName: "Humria", Normalized: &models.DrugNormalization{RxCUI: "327361", Name: "adalimumab", Confidence: 0.83}},
+				{DrugName: "Oxycodone 5 mg", Refills: "2", DeaSchedule: "II"},
+			},
+		},
+		Validation: []models.ValidationIssue{
+			{Field: "patient.address.zip", Code: "address_zip_state_mismatch", Severity: models.SeverityWarning, Message: "ZIP 99999 is not in VA"},
+			{Field: "medications[1].drug_name", Code: "controlled_substance", Severity: models.SeverityInfo, Message: "Schedule II controlled substance"},
+			{Field: "medications[1].refills", Code: "schedule_ii_refills", Severity: models.SeverityError, Message: "Schedule II prescriptions cannot be refilled"},
+			{Field: "prescriber", Code: "incomplete", Severity: models.SeverityWarning, Message: "prescriber information is incomplete"},
+		},
+		Blocked:     true,
+		Attributes:  map[string]string{jobs.AttributeFileName: "humira.pdf"},
+		CompletedAt: time.Date(2025, 3, 14, 15, 4, 0, 0, time.UTC),
+	}
+}
+
+func TestNewSheet(t *testing.T) {
+	sheet := NewSheet(testResult())
+
+	if sheet.FileName != "humira.pdf" || !sheet.Blocked {
+		t.Errorf("Unexpected sheet header %+v", sheet)
+	}
+	if sheet.Issues[0].Severity != models.SeverityError || sheet.Issues[len(sheet.Issues)-1].Severity != models.SeverityInfo {
+		t.Errorf("Expected issues ordered by severity, got %v", sheet.Issues)
+	}
+
+	var titles []string
+	for _, section := range sheet.Sections {
+		titles = append(titles, section.Title)
+	}
+	wantTitles := "Prescription,Patient,Insurance 1 (Primary),Prescriber,Diagnosis,Medication 1,Medication 2,Delivery and Signature"
+	if got := strings.Join(titles, ","); got != wantTitles {
+		t.Errorf("Expected sections %s, got %s", wantTitles, got)
+	}
+
+	tests := []struct {
+		path          string
+		wantSeverity  models.Severity
+		wantLow       bool
+		wantIssues    int
+		wantHighlight bool
+	}{
+		{path: "patient.address", wantSeverity: models.SeverityWarning, wantLow: true, wantIssues: 1, wantHighlight: true},
+		{path: "medications[0].drug_name", wantLow: true},
+		{path: "medications[1].drug_name", wantSeverity: models.SeverityInfo, wantIssues: 1},
+		{path: "medications[1].refills", wantSeverity: models.SeverityError, wantIssues: 1, wantHighlight: true},
+		{path: "prescriber.name"},
+		{path: "patient.first_name"},
+	}
+
+	for _, tt := range tests {
+		row := sheet.row(tt.path)
+		if row.Path != tt.path {
+			t.Errorf("No row for %s", tt.path)
+			continue
+		}
+		if row.Severity != tt.wantSeverity || row.LowConfidence != tt.wantLow || len(row.Issues) != tt.wantIssues || row.Highlighted() != tt.wantHighlight {
+			t.Errorf("Unexpected row %+v", *row)
+		}
+	}
+
+	if row := sheet.row("medications[1].dea_schedule"); row.Value != "C-II" {
+		t.Errorf("Expected the DEA schedule row, got %+v", *row)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderHTML(&buf, NewSheet(testResult())); err != nil {
+		t.Fatalf("Failed to render html: %v", err)
+	}
+	html := buf.String()
+
+	for _, want := range []string{
+		"<title>Prescription Review job-1</title>",
+		`<span class="status blocked">Blocked</span>`,
+		`<tr class="error">`,
+		`<tr class="warning">`,
+		"&#9873; verify",
+		"Lee &lt;O&#39;Neil&gt;",
+		"Schedule II prescriptions cannot be refilled",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected html to contain %q", want)
+		}
+	}
+	if strings.Contains(html, "<O'Neil>") {
+		t.Errorf("Expected parsed values to be escaped")
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	result := testResult()
+	// Enough medications to run over several pages, with text outside Windows-1252.
+	for i := 0; i < 12; i++ {
+		result.Prescription.Medications = append(result.Prescription.Medications, models.Medication{
+			DrugName: "Metformin", SIG: strings.Repeat("Take one tablet by mouth twice daily with meals → ", 4),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := RenderPDF(&buf, NewSheet(result)); err != nil {
+		t.Fatalf("Failed to render pdf: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Fatalf("Expected a PDF document")
+	}
+	if pages := bytes.Count(buf.Bytes(), []byte("/Type /Page\n")); pages < 3 {
+		t.Errorf("Expected the sheet to span at least 3 pages, got %d", pages)
+	}
+}
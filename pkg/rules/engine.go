@@ -0,0 +1,290 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+// RuleSet is a compiled, named collection of rules.
+// It implements the parser's post-processor interface so it can run after parsing.
+type RuleSet struct {
+	Name        string
+	rules       []*Rule
+	drugClasses map[string][]string
+}
+
+// Process evaluates every applicable rule against the prescription and returns the violations.
+func (rs *RuleSet) Process(ctx context.Context, rx *models.Prescription) []models.ValidationIssue {
+	doc, err := toDocument(rx)
+	if err != nil {
+		return []models.ValidationIssue{{
+			Code:     "rules_unavailable",
+			Severity: models.SeverityWarning,
+			Message:  fmt.Sprintf("rule set %q could not be evaluated: %v", rs.Name, err),
+		}}
+	}
+
+	var issues []models.ValidationIssue
+	for _, rule := range rs.rules {
+		if !rs.applies(rule.When, rx) {
+			continue
+		}
+		issues = append(issues, rule.evaluate(doc)...)
+	}
+
+	return issues
+}
+
+// applies reports whether every condition of a rule holds for the prescription.
+func (rs *RuleSet) applies(cond Condition, rx *models.Prescription) bool {
+	if len(cond.Specialty) > 0 {
+		specialty := strings.ToLower(rx.Prescriber.Specialty)
+		if !anyOf(cond.Specialty, func(s string) bool {
+			return specialty != "" && strings.Contains(specialty, strings.ToLower(s))
+		}) {
+			return false
+		}
+	}
+
+	if len(cond.DiagnosisPrefix) > 0 {
+		codes := []string{rx.Diagnosis.PrimaryDiagnosis.Icd10Code}
+		for _, diagnosis := range rx.Diagnosis.AdditionalDiagnoses {
+			codes = append(codes, diagnosis.Icd10Code)
+		}
+		if !anyOf(cond.DiagnosisPrefix, func(prefix string) bool {
+			prefix = normalizeCode(prefix)
+			return anyOf(codes, func(code string) bool {
+				code = normalizeCode(code)
+				return code != "" && strings.HasPrefix(code, prefix)
+			})
+		}) {
+			return false
+		}
+	}
+
+	if len(cond.DrugClass) > 0 {
+		if !anyOf(cond.DrugClass, func(class string) bool {
+			return anyOf(rx.Medications, func(med models.Medication) bool {
+				return rs.inClass(strings.ToLower(class), med)
+			})
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// inClass reports whether a medication belongs to a drug class. Members match when every word
+// of the member name appears in the drug name or the normalized brand or generic name.
+func (rs *RuleSet) inClass(class string, med models.Medication) bool {
+	if class == DrugClassControlled {
+		return med.DeaSchedule != ""
+	}
+
+	names := []string{med.DrugName}
+	if med.Normalized != nil {
+		names = append(names, med.Normalized.Name, med.Normalized.BrandName, med.Normalized.GenericName)
+	}
+
+	for _, name := range names {
+		words := " " + strings.Join(tokenize(name), " ") + " "
+		for _, member := range rs.drugClasses[class] {
+			if member == "" {
+				continue
+			}
+			if containsWords(words, member) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// evaluate checks the rule's assertions against the selected values.
+func (r *Rule) evaluate(doc any) []models.ValidationIssue {
+	matches := r.selector.selectValues(doc)
+	field := r.selector.String()
+
+	var issues []models.ValidationIssue
+
+	if r.Required {
+		if len(matches) == 0 {
+			issues = append(issues, r.issue(field, fmt.Sprintf("%s is required", field)))
+		}
+		for _, m := range matches {
+			if isEmpty(m.value) {
+				issues = append(issues, r.issue(m.path, fmt.Sprintf("%s is required", m.path)))
+			}
+		}
+	}
+
+	if r.pattern != nil {
+		for _, m := range matches {
+			for _, s := range scalars(m.value) {
+				if s != "" && !r.pattern.MatchString(s) {
+					issues = append(issues, r.issue(m.path, fmt.Sprintf("%s value %q does not match %s", m.path, s, r.Pattern)))
+					break
+				}
+			}
+		}
+	}
+
+	if r.Contains != "" {
+		needle := strings.ToLower(r.Contains)
+		found := false
+		for _, m := range matches {
+			if anyOf(scalars(m.value), func(s string) bool { return strings.Contains(strings.ToLower(s), needle) }) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			issues = append(issues, r.issue(field, fmt.Sprintf("%s must contain %q", field, r.Contains)))
+		}
+	}
+
+	if r.MinItems > 0 {
+		if count := countItems(matches); count < r.MinItems {
+			issues = append(issues, r.issue(field, fmt.Sprintf("%s must have at least %d values but has %d", field, r.MinItems, count)))
+		}
+	}
+
+	return issues
+}
+
+// issue builds a violation of the rule, preferring the rule's configured message.
+func (r *Rule) issue(field, message string) models.ValidationIssue {
+	if r.Message != "" {
+		message = r.Message
+	}
+
+	return models.ValidationIssue{
+		Field:    field,
+		Code:     r.ID,
+		Severity: r.Severity,
+		Message:  message,
+	}
+}
+
+// toDocument converts a prescription to its generic JSON representation so selectors
+// address the same field names clients see.
+func toDocument(rx *models.Prescription) (any, error) {
+	data, err := json.Marshal(rx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal prescription: %w", err)
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal prescription: %w", err)
+	}
+
+	return doc, nil
+}
+
+// isEmpty reports whether a selected value is missing. Blank strings, false, empty arrays and
+// objects whose members are all empty count as missing.
+func isEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case bool:
+		return !v
+	case []any:
+		for _, item := range v {
+			if !isEmpty(item) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		for _, item := range v {
+			if !isEmpty(item) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// scalars returns the string form of a selected scalar value, or of each scalar element of an array.
+func scalars(v any) []string {
+	switch v := v.(type) {
+	case nil, map[string]any:
+		return nil
+	case string:
+		return []string{v}
+	case []any:
+		var out []string
+		for _, item := range v {
+			out = append(out, scalars(item)...)
+		}
+		return out
+	}
+
+	return []string{fmt.Sprint(v)}
+}
+
+// countItems counts the non-empty values selected. A single selected array counts its elements.
+func countItems(matches []match) int {
+	if len(matches) == 1 {
+		if arr, ok := matches[0].value.([]any); ok {
+			count := 0
+			for _, item := range arr {
+				if !isEmpty(item) {
+					count++
+				}
+			}
+			return count
+		}
+	}
+
+	count := 0
+	for _, m := range matches {
+		if !isEmpty(m.value) {
+			count++
+		}
+	}
+	return count
+}
+
+// normalizeCode upper-cases an ICD-10 code and removes its dot and surrounding space.
+func normalizeCode(code string) string {
+	return strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(code)), ".", "")
+}
+
+// tokenize upper-cases s and splits it into words of letters and digits.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	})
+}
+
+// containsWords reports whether every word of member appears in words, a space-delimited word list.
+func containsWords(words, member string) bool {
+	for _, word := range strings.Fields(member) {
+		if !strings.Contains(words, " "+word+" ") {
+			return false
+		}
+	}
+	return true
+}
+
+func anyOf[T any](items []T, pred func(T) bool) bool {
+	for _, item := range items {
+		if pred(item) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// selectorStep is a single step of a compiled field selector.
+type selectorStep struct {
+	key      string // Object member name, empty for array steps
+	index    int    // Array index when wildcard is false
+	wildcard bool   // Whether the step selects every array element
+	array    bool   // Whether the step indexes into an array
+}
+
+// Selector is a compiled JSONPath field selector.
+// It supports the subset of JSONPath needed to address prescription fields: a leading "$",
+// member access ("$.patient.first_name"), array indexes ("$.medications[0]") and array
+// wildcards ("$.medications[*].drug_name").
+type Selector struct {
+	expr  string
+	steps []selectorStep
+}
+
+// match is a value selected from a document along with its concrete path.
+type match struct {
+	path  string
+	value any
+}
+
+// CompileSelector parses a JSONPath expression into a selector.
+func CompileSelector(expr string) (Selector, error) {
+	rest := strings.TrimSpace(expr)
+	if !strings.HasPrefix(rest, "$") {
+		return Selector{}, fmt.Errorf("selector %q must start with $", expr)
+	}
+	rest = rest[1:]
+
+	var steps []selectorStep
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return Selector{}, fmt.Errorf("selector %q has an empty member name", expr)
+			}
+			steps = append(steps, selectorStep{key: key})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return Selector{}, fmt.Errorf("selector %q has an unterminated index", expr)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			if inner == "*" {
+				steps = append(steps, selectorStep{array: true, wildcard: true})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return Selector{}, fmt.Errorf("selector %q has an invalid index %q", expr, inner)
+				}
+				steps = append(steps, selectorStep{array: true, index: index})
+			}
+			rest = rest[end+1:]
+		default:
+			return Selector{}, fmt.Errorf("selector %q has unexpected character %q", expr, rest[0])
+		}
+	}
+
+	return Selector{expr: expr, steps: steps}, nil
+}
+
+// String returns the selector's field path without the leading "$.".
+func (s Selector) String() string {
+	return strings.TrimPrefix(strings.TrimPrefix(s.expr, "$"), ".")
+}
+
+// selectValues returns every value in doc addressed by the selector.
+// Members or indexes that do not exist produce no match.
+func (s Selector) selectValues(doc any) []match {
+	current := []match{{value: doc}}
+
+	for _, step := range s.steps {
+		var next []match
+		for _, m := range current {
+			if !step.array {
+				obj, ok := m.value.(map[string]any)
+				if !ok {
+					continue
+				}
+				v, ok := obj[step.key]
+				if !ok {
+					continue
+				}
+				path := step.key
+				if m.path != "" {
+					path = m.path + "." + step.key
+				}
+				next = append(next, match{path: path, value: v})
+				continue
+			}
+
+			arr, ok := m.value.([]any)
+			if !ok {
+				continue
+			}
+			for i, v := range arr {
+				if step.wildcard || i == step.index {
+					next = append(next, match{path: fmt.Sprintf("%s[%d]", m.path, i), value: v})
+				}
+			}
+		}
+		current = next
+	}
+
+	return current
+}
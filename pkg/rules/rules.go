@@ -0,0 +1,236 @@
+// Package rules evaluates customer-specific validation policies against parsed prescriptions.
+// Policies are declared in a YAML or JSON rule file containing named rule sets; each rule
+// selects prescription fields with a JSONPath expression, optionally applies only to certain
+// specialties, drug classes or diagnoses, and reports violations at a configured severity.
+package rules
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// DrugClassControlled is a built-in drug class matching medications flagged as controlled substances.
+const DrugClassControlled = "controlled"
+
+// File is the structure of a rule file.
+type File struct {
+	DefaultRuleSet string              `yaml:"default_rule_set"` // Rule set applied when a request does not select one
+	DrugClasses    map[string][]string `yaml:"drug_classes"`     // Drug class names mapped to member drug names
+	RuleSets       []RuleSetDefinition `yaml:"rule_sets"`        // Named rule sets
+}
+
+// RuleSetDefinition declares a named rule set in a rule file.
+type RuleSetDefinition struct {
+	Name    string `yaml:"name"`    // Name used to select the rule set
+	Extends string `yaml:"extends"` // Optional rule set whose rules are included before this set's rules
+	Rules   []Rule `yaml:"rules"`   // Rules in the set
+}
+
+// Rule is a single validation policy.
+// A rule must declare at least one assertion: required, pattern, contains or min_items.
+type Rule struct {
+	ID          string          `yaml:"id"`          // Identifier reported as the issue code
+	Description string          `yaml:"description"` // Human-readable description of the policy
+	Severity    models.Severity `yaml:"severity"`    // Severity of violations (defaults to warning)
+	When        Condition       `yaml:"when"`        // Conditions that must hold for the rule to apply
+	Field       string          `yaml:"field"`       // JSONPath selector of the checked field(s)
+	Required    bool            `yaml:"required"`    // Every selected value must be present and non-empty
+	Pattern     string          `yaml:"pattern"`     // Every non-empty selected value must match this regular expression
+	Contains    string          `yaml:"contains"`    // At least one selected value must contain this text (case-insensitive)
+	MinItems    int             `yaml:"min_items"`   // At least this many non-empty values must be selected
+	Message     string          `yaml:"message"`     // Optional message reported instead of the generated one
+
+	selector Selector
+	pattern  *regexp.Regexp
+}
+
+// Condition restricts a rule to matching prescriptions.
+// Each non-empty list must have at least one match for the rule to apply.
+type Condition struct {
+	Specialty       []string `yaml:"specialty"`        // Prescriber specialties (case-insensitive substring match)
+	DrugClass       []string `yaml:"drug_class"`       // Drug classes from the rule file, or "controlled"
+	DiagnosisPrefix []string `yaml:"diagnosis_prefix"` // ICD-10 code prefixes of any diagnosis (e.g. C, L40)
+}
+
+// Registry holds the rule sets loaded from a rule file.
+type Registry struct {
+	defaultRuleSet string
+	ruleSets       map[string]*RuleSet
+}
+
+// Load reads and compiles a YAML or JSON rule file.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule file: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse compiles rule file contents. Since JSON is valid YAML, either format is accepted.
+func Parse(data []byte) (*Registry, error) {
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rule file: %w", err)
+	}
+
+	definitions := make(map[string]RuleSetDefinition, len(file.RuleSets))
+	for _, def := range file.RuleSets {
+		if def.Name == "" {
+			return nil, fmt.Errorf("rule set is missing a name")
+		}
+		if _, exists := definitions[def.Name]; exists {
+			return nil, fmt.Errorf("duplicate rule set %q", def.Name)
+		}
+		definitions[def.Name] = def
+	}
+
+	drugClasses := make(map[string][]string, len(file.DrugClasses))
+	for class, members := range file.DrugClasses {
+		tokenized := make([]string, 0, len(members))
+		for _, member := range members {
+			tokenized = append(tokenized, strings.Join(tokenize(member), " "))
+		}
+		drugClasses[strings.ToLower(class)] = tokenized
+	}
+
+	registry := &Registry{
+		defaultRuleSet: file.DefaultRuleSet,
+		ruleSets:       make(map[string]*RuleSet, len(definitions)),
+	}
+
+	for name := range definitions {
+		rules, err := resolveRules(name, definitions, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		ruleSet := &RuleSet{Name: name, drugClasses: drugClasses}
+		seen := make(map[string]bool)
+		for _, rule := range rules {
+			if err := rule.compile(drugClasses); err != nil {
+				return nil, fmt.Errorf("rule set %q: %w", name, err)
+			}
+			if seen[rule.ID] {
+				return nil, fmt.Errorf("rule set %q: duplicate rule id %q", name, rule.ID)
+			}
+			seen[rule.ID] = true
+			ruleSet.rules = append(ruleSet.rules, rule)
+		}
+
+		registry.ruleSets[name] = ruleSet
+	}
+
+	if file.DefaultRuleSet != "" {
+		if _, ok := registry.ruleSets[file.DefaultRuleSet]; !ok {
+			return nil, fmt.Errorf("default rule set %q is not defined", file.DefaultRuleSet)
+		}
+	}
+
+	return registry, nil
+}
+
+// RuleSet returns the named rule set, or the default rule set when name is empty.
+// It returns false if the rule set does not exist or no default is configured.
+func (r *Registry) RuleSet(name string) (*RuleSet, bool) {
+	if r == nil {
+		return nil, false
+	}
+	if name == "" {
+		name = r.defaultRuleSet
+	}
+
+	ruleSet, ok := r.ruleSets[name]
+	return ruleSet, ok
+}
+
+// Names returns the names of all rule sets in sorted order.
+func (r *Registry) Names() []string {
+	if r == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(r.ruleSets))
+	for name := range r.ruleSets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// resolveRules returns the rules of a rule set preceded by the rules of the sets it extends.
+func resolveRules(name string, definitions map[string]RuleSetDefinition, visiting []string) ([]*Rule, error) {
+	if slices.Contains(visiting, name) {
+		return nil, fmt.Errorf("rule set %q extends itself through %s", name, strings.Join(visiting, " -> "))
+	}
+
+	def, ok := definitions[name]
+	if !ok {
+		return nil, fmt.Errorf("rule set %q extends undefined rule set %q", visiting[len(visiting)-1], name)
+	}
+
+	var rules []*Rule
+	if def.Extends != "" {
+		parent, err := resolveRules(def.Extends, definitions, append(visiting, name))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, parent...)
+	}
+
+	for _, rule := range def.Rules {
+		rules = append(rules, &rule)
+	}
+
+	return rules, nil
+}
+
+// compile validates the rule and prepares its selector and pattern.
+func (r *Rule) compile(drugClasses map[string][]string) error {
+	if r.ID == "" {
+		return fmt.Errorf("rule is missing an id")
+	}
+
+	switch r.Severity {
+	case "":
+		r.Severity = models.SeverityWarning
+	case models.SeverityInfo, models.SeverityWarning, models.SeverityError:
+	default:
+		return fmt.Errorf("rule %q has invalid severity %q", r.ID, r.Severity)
+	}
+
+	if !r.Required && r.Pattern == "" && r.Contains == "" && r.MinItems == 0 {
+		return fmt.Errorf("rule %q must declare required, pattern, contains or min_items", r.ID)
+	}
+
+	selector, err := CompileSelector(r.Field)
+	if err != nil {
+		return fmt.Errorf("rule %q: %w", r.ID, err)
+	}
+	r.selector = selector
+
+	if r.Pattern != "" {
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return fmt.Errorf("rule %q has invalid pattern: %w", r.ID, err)
+		}
+		r.pattern = pattern
+	}
+
+	for _, class := range r.When.DrugClass {
+		class = strings.ToLower(class)
+		if _, ok := drugClasses[class]; !ok && class != DrugClassControlled {
+			return fmt.Errorf("rule %q references undefined drug class %q", r.ID, class)
+		}
+	}
+
+	return nil
+}
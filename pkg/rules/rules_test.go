@@ -0,0 +1,271 @@
+package rules
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+const testRules = `
+default_rule_set: standard
+drug_classes:
+  biologic:
+    - adalimumab
+    - Humira
+    - ustekinumab
+rule_sets:
+  - name: standard
+    rules:
+      - id: npi_required
+        field: $.prescriber.npi
+        required: true
+        severity: error
+      - id: npi_format
+        field: $.prescriber.npi
+        pattern: '^\d{10}$'
+  - name: specialty-pharmacy
+    extends: standard
+    rules:
+      - id: oncology_pathology
+        when:
+          specialty: [oncology]
+          diagnosis_prefix: [C]
+        field: $.attachments.pathology_reports
+        required: true
+        message: oncology prescriptions require a pathology report
+      - id: biologic_tb_test
+        when:
+          drug_class: [biologic]
+        field: $.clinical_info
+        contains: TB
+      - id: controlled_quantity
+        when:
+          drug_class: [controlled]
+        field: $.medications[*].quantity
+        required: true
+        severity: error
+      - id: medications_present
+        field: $.medications
+        min_items: 1
+`
+
+func TestCompileSelector(t *testing.T) {
+	doc := map[string]any{
+		"patient": map[string]any{"first_name": "Ann"},
+		"medications": []any{
+			map[string]any{"drug_name": "Humira"},
+			map[string]any{"drug_name": "Metformin"},
+		},
+	}
+
+	tests := []struct {
+		expr      string
+		wantPaths []string
+		wantErr   bool
+	}{
+		{expr: "$.patient.first_name", wantPaths: []string{"patient.first_name"}},
+		{expr: "$.medications[1].drug_name", wantPaths: []string{"medications[1].drug_name"}},
+		{expr: "$.medications[*].drug_name", wantPaths: []string{"medications[0].drug_name", "medications[1].drug_name"}},
+		{expr: "$.medications[5].drug_name", wantPaths: nil},
+		{expr: "$.patient.missing", wantPaths: nil},
+		{expr: "patient.first_name", wantErr: true},
+		{expr: "$.medications[x]", wantErr: true},
+		{expr: "$.medications[0", wantErr: true},
+		{expr: "$..patient", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			selector, err := CompileSelector(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			var paths []string
+			for _, m := range selector.selectValues(doc) {
+				paths = append(paths, m.path)
+			}
+			if strings.Join(paths, ",") != strings.Join(tt.wantPaths, ",") {
+				t.Errorf("Expected paths %v, got %v", tt.wantPaths, paths)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+	}{
+		{name: "missing assertion", rules: "rule_sets: [{name: a, rules: [{id: r, field: $.patient}]}]"},
+		{name: "bad severity", rules: "rule_sets: [{name: a, rules: [{id: r, field: $.patient, required: true, severity: fatal}]}]"},
+		{name: "bad selector", rules: "rule_sets: [{name: a, rules: [{id: r, field: patient, required: true}]}]"},
+		{name: "bad pattern", rules: "rule_sets: [{name: a, rules: [{id: r, field: $.patient, pattern: '('}]}]"},
+		{name: "undefined drug class", rules: "rule_sets: [{name: a, rules: [{id: r, field: $.patient, required: true, when: {drug_class: [statin]}}]}]"},
+		{name: "undefined parent", rules: "rule_sets: [{name: a, extends: b}]"},
+		{name: "extends cycle", rules: "rule_sets: [{name: a, extends: b}, {name: b, extends: a}]"},
+		{name: "undefined default", rules: "default_rule_set: b\nrule_sets: [{name: a}]"},
+		{name: "duplicate rule id", rules: "rule_sets: [{name: a, rules: [{id: r, field: $.a, required: true}, {id: r, field: $.b, required: true}]}]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.rules)); err == nil {
+				t.Errorf("Expected error")
+			}
+		})
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	registry, err := Parse([]byte(`{"rule_sets": [{"name": "json", "rules": [{"id": "npi", "field": "$.prescriber.npi", "required": true}]}]}`))
+	if err != nil {
+		t.Fatalf("Failed to parse JSON rules: %v", err)
+	}
+
+	if _, ok := registry.RuleSet("json"); !ok {
+		t.Errorf("Expected rule set json")
+	}
+	if _, ok := registry.RuleSet(""); ok {
+		t.Errorf("Expected no default rule set")
+	}
+}
+
+func TestRuleSetProcess(t *testing.T) {
+	registry, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %v", err)
+	}
+
+	if got := strings.Join(registry.Names(), ","); got != "specialty-pharmacy,standard" {
+		t.Errorf("Unexpected rule set names %s", got)
+	}
+
+	tests := []struct {
+		name      string
+		ruleSet   string
+		rx        models.Prescription
+		wantCodes []string
+	}{
+		{
+			name:      "default rule set",
+			rx:        models.Prescription{Prescriber: models.Prescriber{Npi: "1234567890"}},
+			wantCodes: nil,
+		},
+		{
+			name:      "missing npi",
+			rx:        models.Prescription{},
+			wantCodes: []string{"npi_required"},
+		},
+		{
+			name:      "malformed npi",
+			rx:        models.Prescription{Prescriber: models.Prescriber{Npi: "12345"}},
+			wantCodes: []string{"npi_format"},
+		},
+		{
+			name:    "oncology without pathology",
+			ruleSet: "specialty-pharmacy",
+			rx: models.Prescription{
+				Prescriber:  models.Prescriber{Npi: "1234567890", Specialty: "Hematology/Oncology"},
+				Diagnosis:   models.PatientDiagnosis{PrimaryDiagnosis: models.Diagnosis{Icd10Code: "c92.10"}},
+				Medications: []models.Medication{{DrugName: "Gleevec"}},
+			},
+			wantCodes: []string{"oncology_pathology"},
+		},
+		{
+			name:    "oncology specialty without cancer diagnosis",
+			ruleSet: "specialty-pharmacy",
+			rx: models.Prescription{
+				Prescriber:  models.Prescriber{Npi: "1234567890", Specialty: "Oncology"},
+				Diagnosis:   models.PatientDiagnosis{PrimaryDiagnosis: models.Diagnosis{Icd10Code: "D50.9"}},
+				Medications: []models.Medication{{DrugName: "Ferrous sulfate"}},
+			},
+			wantCodes: nil,
+		},
+		{
+			name:    "biologic without TB test",
+			ruleSet: "specialty-pharmacy",
+			rx: models.Prescription{
+				Prescriber:   models.Prescriber{Npi: "1234567890"},
+				ClinicalInfo: []string{"BSA 12%"},
+				Medications:  []models.Medication{{DrugName: "Humira Pen 40 mg"}},
+			},
+			wantCodes: []string{"biologic_tb_test"},
+		},
+		{
+			name:    "biologic with TB test",
+			ruleSet: "specialty-pharmacy",
+			rx: models.Prescription{
+				Prescriber:   models.Prescriber{Npi: "1234567890"},
+				ClinicalInfo: []string{"Negative TB test 2024-01-02"},
+				Medications: []models.Medication{{
+					DrugName:   "Humira",
+					Normalized: &models.DrugNormalization{GenericName: "adalimumab"},
+				}},
+			},
+			wantCodes: nil,
+		},
+		{
+			name:    "controlled without quantity",
+			ruleSet: "specialty-pharmacy",
+			rx: models.Prescription{
+				Prescriber:  models.Prescriber{Npi: "1234567890"},
+				Medications: []models.Medication{{DrugName: "Oxycodone", DeaSchedule: "II"}, {DrugName: "Metformin", Quantity: "90"}},
+			},
+			wantCodes: []string{"controlled_quantity"},
+		},
+		{
+			name:      "no medications",
+			ruleSet:   "specialty-pharmacy",
+			rx:        models.Prescription{Prescriber: models.Prescriber{Npi: "1234567890"}},
+			wantCodes: []string{"medications_present"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleSet, ok := registry.RuleSet(tt.ruleSet)
+			if !ok {
+				t.Fatalf("Rule set %q not found", tt.ruleSet)
+			}
+
+			var codes []string
+			for _, issue := range ruleSet.Process(context.Background(), &tt.rx) {
+				codes = append(codes, issue.Code)
+			}
+
+			if strings.Join(codes, ",") != strings.Join(tt.wantCodes, ",") {
+				t.Errorf("Expected issues %v, got %v", tt.wantCodes, codes)
+			}
+		})
+	}
+}
+
+func TestRuleIssueDetails(t *testing.T) {
+	registry, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %v", err)
+	}
+	ruleSet, _ := registry.RuleSet("specialty-pharmacy")
+
+	rx := models.Prescription{
+		Prescriber: models.Prescriber{Npi: "1234567890"},
+		Medications: []models.Medication{
+			{DrugName: "Adderall", DeaSchedule: "II", Quantity: "60"},
+			{DrugName: "Oxycodone", DeaSchedule: "II"},
+		},
+	}
+
+	issues := ruleSet.Process(context.Background(), &rx)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %v", issues)
+	}
+	if issues[0].Field != "medications[1].quantity" || issues[0].Severity != models.SeverityError {
+		t.Errorf("Unexpected issue %+v", issues[0])
+	}
+}
@@ -0,0 +1,67 @@
+package rxnorm
+
+// confusablePairs lists characters that are commonly misread for one another in
+// handwritten or faxed documents. Substituting one for the other costs less than
+// an ordinary substitution.
+var confusablePairs = [][2]rune{
+	{'O', '0'}, {'I', '1'}, {'L', '1'}, {'I', 'L'}, {'S', '5'}, {'B', '8'},
+	{'Z', '2'}, {'G', '6'}, {'A', 'O'}, {'U', 'V'}, {'N', 'M'}, {'E', 'C'},
+	{'R', 'N'}, {'U', 'A'}, {'I', 'J'}, {'T', 'F'},
+}
+
+// confusableCost is the substitution cost for a pair of visually similar characters.
+const confusableCost = 0.5
+
+var confusable = func() map[[2]rune]bool {
+	m := make(map[[2]rune]bool, len(confusablePairs)*2)
+	for _, p := range confusablePairs {
+		m[p] = true
+		m[[2]rune{p[1], p[0]}] = true
+	}
+	return m
+}()
+
+// similarity returns a score between 0.0 and 1.0 describing how alike two normalized names are.
+// It is based on an optimal string alignment distance in which adjacent transpositions count as
+// a single edit and visually confusable characters are cheaper to substitute.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - editDistance(ra, rb)/float64(longest)
+}
+
+// editDistance computes the weighted optimal string alignment distance between a and b.
+func editDistance(a, b []rune) float64 {
+	d := make([][]float64, len(a)+1)
+	for i := range d {
+		d[i] = make([]float64, len(b)+1)
+		d[i][0] = float64(i)
+	}
+	for j := range d[0] {
+		d[0][j] = float64(j)
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1.0
+			switch {
+			case a[i-1] == b[j-1]:
+				cost = 0
+			case confusable[[2]rune{a[i-1], b[j-1]}]:
+				cost = confusableCost
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,174 @@
+package rxnorm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ConceptsFile is the name of the RxNorm concept names and sources file.
+	ConceptsFile = "RXNCONSO.RRF"
+
+	// RelationshipsFile is the name of the RxNorm relationships file.
+	RelationshipsFile = "RXNREL.RRF"
+)
+
+// Column positions in RXNCONSO.RRF.
+const (
+	consoRxCUI    = 0
+	consoLAT      = 1
+	consoSAB      = 11
+	consoTTY      = 12
+	consoSTR      = 14
+	consoSuppress = 16
+	consoColumns  = 18
+)
+
+// Column positions in RXNREL.RRF.
+const (
+	relRxCUI1    = 0
+	relRxCUI2    = 4
+	relRELA      = 7
+	relSAB       = 10
+	relSuppress  = 14
+	relColumns   = 16
+	maxLineBytes = 1 << 20
+)
+
+// retainedTermTypes lists the RXNCONSO term types kept in the index.
+var retainedTermTypes = map[string]bool{
+	TermTypeIngredient:         true,
+	TermTypePreciseIngredient:  true,
+	TermTypeMultipleIngredient: true,
+	TermTypeBrandName:          true,
+	TermTypeClinicalDrug:       true,
+	TermTypeBrandedDrug:        true,
+	TermTypeClinicalDrugForm:   true,
+	TermTypeBrandedDrugForm:    true,
+	TermTypeClinicalComponent:  true,
+	TermTypeDoseForm:           true,
+	TermTypeGenericPack:        true,
+	TermTypeBrandedPack:        true,
+	TermTypeSynonym:            true,
+	TermTypeTallmanSynonym:     true,
+	TermTypePrescribableName:   true,
+}
+
+// retainedRelationships lists the RXNREL relationship attributes used to link concepts.
+var retainedRelationships = map[string]bool{
+	"has_tradename":          true,
+	"tradename_of":           true,
+	"has_ingredient":         true,
+	"ingredient_of":          true,
+	"has_ingredients":        true,
+	"ingredients_of":         true,
+	"has_precise_ingredient": true,
+	"precise_ingredient_of":  true,
+	"consists_of":            true,
+	"constitutes":            true,
+	"has_dose_form":          true,
+	"dose_form_of":           true,
+	"isa":                    true,
+	"inverse_isa":            true,
+	"contains":               true,
+	"contained_in":           true,
+}
+
+// Load reads RXNCONSO.RRF and RXNREL.RRF from dir and returns the resulting index.
+// The files may be the full RxNorm release or any subset of it.
+func Load(dir string) (*Index, error) {
+	idx := NewIndex()
+
+	consoFile, err := os.Open(filepath.Join(dir, ConceptsFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", ConceptsFile, err)
+	}
+	defer consoFile.Close()
+
+	if err := idx.LoadConcepts(consoFile); err != nil {
+		return nil, err
+	}
+
+	relFile, err := os.Open(filepath.Join(dir, RelationshipsFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", RelationshipsFile, err)
+	}
+	defer relFile.Close()
+
+	if err := idx.LoadRelationships(relFile); err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// LoadConcepts reads English, non-suppressed RxNorm atoms from RXNCONSO.RRF formatted input.
+func (idx *Index) LoadConcepts(r io.Reader) error {
+	err := scanRRF(r, consoColumns, func(fields []string) {
+		if fields[consoLAT] != "ENG" || fields[consoSAB] != "RXNORM" || isSuppressed(fields[consoSuppress]) {
+			return
+		}
+		if !retainedTermTypes[fields[consoTTY]] {
+			return
+		}
+
+		idx.addConcept(fields[consoRxCUI], fields[consoTTY], fields[consoSTR])
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", ConceptsFile, err)
+	}
+
+	idx.buildNames()
+
+	return nil
+}
+
+// LoadRelationships reads RxNorm relationships from RXNREL.RRF formatted input.
+// Concepts must be loaded first; relationships referencing unknown concepts are skipped.
+func (idx *Index) LoadRelationships(r io.Reader) error {
+	err := scanRRF(r, relColumns, func(fields []string) {
+		if fields[relSAB] != "RXNORM" || isSuppressed(fields[relSuppress]) || !retainedRelationships[fields[relRELA]] {
+			return
+		}
+
+		a, b := fields[relRxCUI1], fields[relRxCUI2]
+		if _, ok := idx.concepts[a]; !ok {
+			return
+		}
+		if _, ok := idx.concepts[b]; !ok {
+			return
+		}
+
+		idx.addRelationship(a, b)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", RelationshipsFile, err)
+	}
+
+	return nil
+}
+
+// scanRRF calls fn with the fields of every pipe-delimited line that has at least minColumns fields.
+func scanRRF(r io.Reader, minColumns int, fn func(fields []string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineBytes)
+
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "|")
+		if len(fields) < minColumns {
+			continue
+		}
+		fn(fields)
+	}
+
+	return scanner.Err()
+}
+
+// isSuppressed reports whether an RRF SUPPRESS flag marks the row as obsolete or suppressed.
+func isSuppressed(flag string) bool {
+	return flag == "O" || flag == "Y" || flag == "E"
+}
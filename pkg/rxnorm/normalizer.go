@@ -0,0 +1,324 @@
+package rxnorm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+const (
+	// DefaultMinSimilarity is the lowest similarity accepted for an approximate drug name match.
+	DefaultMinSimilarity = 0.8
+
+	// MatchTypeExact indicates the drug name matched an RxNorm name exactly after normalization.
+	MatchTypeExact = "exact"
+
+	// MatchTypeFuzzy indicates the drug name matched an RxNorm name by approximate spelling.
+	MatchTypeFuzzy = "fuzzy"
+
+	// minFuzzyLength is the shortest name considered for approximate matching.
+	minFuzzyLength = 4
+)
+
+// numberPattern matches integer and decimal quantities in strength and product names.
+var numberPattern = regexp.MustCompile(`\d+(?:\.\d+)?|\.\d+`)
+
+// formSynonyms maps abbreviations and variants found on prescriptions to the words
+// RxNorm uses in dose form and product names.
+var formSynonyms = map[string][]string{
+	"TAB":          {"TABLET"},
+	"TABS":         {"TABLET"},
+	"TABLETS":      {"TABLET"},
+	"CAP":          {"CAPSULE"},
+	"CAPS":         {"CAPSULE"},
+	"CAPSULES":     {"CAPSULE"},
+	"INJ":          {"INJECTION"},
+	"INJECTABLE":   {"INJECTION"},
+	"PEN":          {"PEN", "INJECTOR"},
+	"PENS":         {"PEN", "INJECTOR"},
+	"AUTOINJECTOR": {"AUTO", "INJECTOR"},
+	"SYR":          {"SYRINGE"},
+	"SYRINGES":     {"SYRINGE"},
+	"PFS":          {"PREFILLED", "SYRINGE"},
+	"SOLN":         {"SOLUTION"},
+	"SOL":          {"SOLUTION"},
+	"SUSP":         {"SUSPENSION"},
+	"OINT":         {"OINTMENT"},
+	"ER":           {"EXTENDED", "RELEASE"},
+	"XR":           {"EXTENDED", "RELEASE"},
+	"DR":           {"DELAYED", "RELEASE"},
+}
+
+// Normalizer maps parsed medications to RxNorm concepts.
+// It implements the parser's post-processing interface so it can run on every parse result.
+type Normalizer struct {
+	index         *Index
+	minSimilarity float64
+}
+
+// NewNormalizer creates a normalizer backed by the given index.
+func NewNormalizer(index *Index) *Normalizer {
+	return &Normalizer{
+		index:         index,
+		minSimilarity: DefaultMinSimilarity,
+	}
+}
+
+// nameMatch is the RxNorm ingredient or brand concept a drug name resolved to.
+type nameMatch struct {
+	concept    *Concept
+	confidence float64
+	exact      bool
+}
+
+// Process normalizes every medication in the prescription and returns warnings for drug names
+// that could only be matched approximately or could not be matched at all.
+func (n *Normalizer) Process(ctx context.Context, rx *models.Prescription) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+
+	for i := range rx.Medications {
+		med := &rx.Medications[i]
+		if strings.TrimSpace(med.DrugName) == "" {
+			continue
+		}
+
+		field := fmt.Sprintf("medications[%d].drug_name", i)
+
+		norm, ok := n.Normalize(*med)
+		if !ok {
+			issues = append(issues, models.ValidationIssue{
+				Field:    field,
+				Code:     "rxnorm_unmatched",
+				Severity: models.SeverityWarning,
+				Message:  fmt.Sprintf("drug name %q could not be matched to an RxNorm concept", med.DrugName),
+			})
+			continue
+		}
+
+		med.Normalized = norm
+
+		if norm.MatchType == MatchTypeFuzzy {
+			issues = append(issues, models.ValidationIssue{
+				Field:    field,
+				Code:     "rxnorm_fuzzy_match",
+				Severity: models.SeverityWarning,
+				Message:  fmt.Sprintf("drug name %q was matched to %q by approximate spelling (confidence %.2f)", med.DrugName, norm.Name, norm.Confidence),
+			})
+		}
+	}
+
+	return issues
+}
+
+// Normalize resolves a medication's drug name, strength and form to an RxNorm concept.
+// When the strength identifies a single product the clinical or branded drug is returned,
+// otherwise the matched ingredient or brand name concept is returned.
+func (n *Normalizer) Normalize(med models.Medication) (*models.DrugNormalization, bool) {
+	match, ok := n.matchName(med.DrugName)
+	if !ok {
+		return nil, false
+	}
+
+	c := match.concept
+	norm := &models.DrugNormalization{
+		RxCUI:      c.RxCUI,
+		Name:       c.Name,
+		TermType:   c.TermType,
+		MatchType:  MatchTypeExact,
+		Confidence: math.Round(match.confidence*100) / 100,
+	}
+	if !match.exact {
+		norm.MatchType = MatchTypeFuzzy
+	}
+
+	var ingredients, products []*Concept
+	if c.TermType == TermTypeBrandName {
+		norm.BrandName = c.Name
+		ingredients = n.index.Related(c.RxCUI, 1, TermTypeIngredient, TermTypeMultipleIngredient)
+		products = n.index.Related(c.RxCUI, 1, TermTypeBrandedDrug, TermTypeBrandedPack)
+	} else {
+		ingredients = []*Concept{c}
+		products = n.index.Related(c.RxCUI, 2, TermTypeClinicalDrug, TermTypeGenericPack)
+	}
+
+	if product := n.selectProduct(products, med); product != nil {
+		norm.RxCUI = product.RxCUI
+		norm.Name = product.Name
+		norm.TermType = product.TermType
+
+		if forms := n.index.Related(product.RxCUI, 1, TermTypeDoseForm); len(forms) > 0 {
+			norm.DoseForm = forms[0].Name
+		}
+		if len(ingredients) == 0 {
+			ingredients = n.index.Related(product.RxCUI, 3, TermTypeIngredient, TermTypeMultipleIngredient)
+		}
+	}
+
+	names := make([]string, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		names = append(names, ingredient.Name)
+	}
+	norm.GenericName = strings.Join(names, " / ")
+
+	return norm, true
+}
+
+// matchName finds the ingredient or brand concept named by a free-text drug name.
+// Exact matches on any run of words in the name win over approximate matches, and
+// longer runs win over shorter ones so "Humira Pen" resolves through "HUMIRA".
+func (n *Normalizer) matchName(drugName string) (nameMatch, bool) {
+	tokens := strings.Fields(normalizeName(drugName))
+
+	for size := len(tokens); size > 0; size-- {
+		for start := 0; start+size <= len(tokens); start++ {
+			key := strings.Join(tokens[start:start+size], " ")
+			if ids, ok := n.index.names[key]; ok {
+				return nameMatch{concept: n.preferredConcept(ids), confidence: 1, exact: true}, true
+			}
+		}
+	}
+
+	var best nameMatch
+	var bestKey string
+	for size := len(tokens); size > 0; size-- {
+		for start := 0; start+size <= len(tokens); start++ {
+			phrase := strings.Join(tokens[start:start+size], " ")
+			if len(phrase) < minFuzzyLength {
+				continue
+			}
+
+			for _, key := range n.index.nameKeys {
+				if abs(len(key)-len(phrase))*3 > len(key) {
+					continue
+				}
+
+				score := similarity(phrase, key)
+				if score >= n.minSimilarity && score > best.confidence {
+					best = nameMatch{confidence: score}
+					bestKey = key
+				}
+			}
+		}
+	}
+
+	if bestKey == "" {
+		return nameMatch{}, false
+	}
+
+	best.concept = n.preferredConcept(n.index.names[bestKey])
+	return best, true
+}
+
+// preferredConcept picks a single concept when several share a name, favouring ingredients over brands.
+func (n *Normalizer) preferredConcept(ids []string) *Concept {
+	order := []string{TermTypeIngredient, TermTypeMultipleIngredient, TermTypePreciseIngredient, TermTypeBrandName}
+
+	var best *Concept
+	for _, id := range ids {
+		c := n.index.concepts[id]
+		if best == nil || slices.Index(order, c.TermType) < slices.Index(order, best.TermType) {
+			best = c
+		}
+	}
+
+	return best
+}
+
+// selectProduct chooses the product whose name contains the medication's strength, using
+// dose form words to break ties. It returns nil when the strength is missing or matches no product.
+func (n *Normalizer) selectProduct(products []*Concept, med models.Medication) *Concept {
+	strengths := quantities(med.Strength)
+	if len(strengths) == 0 {
+		return nil
+	}
+
+	wantForm := formTokens(med.Form + " " + med.DrugName)
+
+	var best *Concept
+	bestScore := 0
+	for _, product := range products {
+		available := quantities(product.Name)
+
+		matched := 0
+		for _, q := range strengths {
+			if slices.Contains(available, q) {
+				matched++
+			}
+		}
+		if matched == 0 {
+			continue
+		}
+
+		productForm := formTokens(product.Name)
+		for _, form := range n.index.Related(product.RxCUI, 1, TermTypeDoseForm) {
+			for token := range formTokens(form.Name) {
+				productForm[token] = true
+			}
+		}
+
+		formOverlap := 0
+		for token := range wantForm {
+			if productForm[token] {
+				formOverlap++
+			}
+		}
+
+		score := matched*10 + formOverlap
+		if score > bestScore {
+			best = product
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+// quantities extracts the distinct numeric values in s in canonical form (e.g. "0.40" becomes "0.4").
+func quantities(s string) []string {
+	var out []string
+	for _, m := range numberPattern.FindAllString(s, -1) {
+		f, err := strconv.ParseFloat(m, 64)
+		if err != nil {
+			continue
+		}
+		q := strconv.FormatFloat(f, 'f', -1, 64)
+		if !slices.Contains(out, q) {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+// formTokens returns the upper-cased words of s with dose form abbreviations expanded.
+// Bracketed text is kept since RxNorm product names carry the brand in square brackets.
+func formTokens(s string) map[string]bool {
+	tokens := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z')
+	})
+
+	for _, word := range words {
+		if expanded, ok := formSynonyms[word]; ok {
+			for _, token := range expanded {
+				tokens[token] = true
+			}
+			continue
+		}
+		tokens[word] = true
+	}
+
+	return tokens
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,221 @@
+package rxnorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+)
+
+func TestLoad(t *testing.T) {
+	idx, err := Load("testdata")
+	if err != nil {
+		t.Fatalf("Failed to load RxNorm subset: %v", err)
+	}
+
+	if _, ok := idx.Concept("999001"); ok {
+		t.Errorf("Expected obsolete concept to be skipped")
+	}
+	if _, ok := idx.Concept("999002"); ok {
+		t.Errorf("Expected non-RXNORM source concept to be skipped")
+	}
+
+	metformin, ok := idx.Concept("6809")
+	if !ok {
+		t.Fatalf("Expected metformin concept to be loaded")
+	}
+	if metformin.TermType != TermTypeIngredient || metformin.Name != "metformin" {
+		t.Errorf("Expected IN metformin, got %s %s", metformin.TermType, metformin.Name)
+	}
+	if len(metformin.Synonyms) != 1 || metformin.Synonyms[0] != "metFORMIN" {
+		t.Errorf("Expected tall man synonym to be recorded, got %v", metformin.Synonyms)
+	}
+
+	products := idx.Related("352056", 1, TermTypeBrandedDrug)
+	if len(products) != 3 {
+		t.Errorf("Expected 3 Humira branded products, got %d", len(products))
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	idx, err := Load("testdata")
+	if err != nil {
+		t.Fatalf("Failed to load RxNorm subset: %v", err)
+	}
+	normalizer := NewNormalizer(idx)
+
+	tests := []struct {
+		name          string
+		medication    models.Medication
+		wantOK        bool
+		wantRxCUI     string
+		wantTermType  string
+		wantBrand     string
+		wantGeneric   string
+		wantDoseForm  string
+		wantMatchType string
+	}{
+		{
+			name:          "brand name with strength and form",
+			medication:    models.Medication{DrugName: "HUMIRA", Form: "Syringe", Strength: "40 mg/0.4 mL"},
+			wantOK:        true,
+			wantRxCUI:     "1650002",
+			wantTermType:  TermTypeBrandedDrug,
+			wantBrand:     "Humira",
+			wantGeneric:   "adalimumab",
+			wantDoseForm:  "Prefilled Syringe",
+			wantMatchType: MatchTypeExact,
+		},
+		{
+			name:          "brand name with qualifier and device in name",
+			medication:    models.Medication{DrugName: "HUMIRA(CF) PEN", Strength: "40 mg/0.4 mL"},
+			wantOK:        true,
+			wantRxCUI:     "1650003",
+			wantTermType:  TermTypeBrandedDrug,
+			wantBrand:     "Humira",
+			wantGeneric:   "adalimumab",
+			wantDoseForm:  "Auto-Injector",
+			wantMatchType: MatchTypeExact,
+		},
+		{
+			name:          "brand name without strength",
+			medication:    models.Medication{DrugName: "Humira Pen"},
+			wantOK:        true,
+			wantRxCUI:     "352056",
+			wantTermType:  TermTypeBrandName,
+			wantBrand:     "Humira",
+			wantGeneric:   "adalimumab",
+			wantMatchType: MatchTypeExact,
+		},
+		{
+			name:          "generic name",
+			medication:    models.Medication{DrugName: "adalimumab", Strength: "40 mg/0.4 mL", Form: "injection"},
+			wantOK:        true,
+			wantRxCUI:     "1650001",
+			wantTermType:  TermTypeClinicalDrug,
+			wantGeneric:   "adalimumab",
+			wantDoseForm:  "Prefilled Syringe",
+			wantMatchType: MatchTypeExact,
+		},
+		{
+			name:          "generic salt name",
+			medication:    models.Medication{DrugName: "Metformin HCl", Strength: "500mg", Form: "tab"},
+			wantOK:        true,
+			wantRxCUI:     "861007",
+			wantTermType:  TermTypeClinicalDrug,
+			wantGeneric:   "metformin",
+			wantDoseForm:  "Oral Tablet",
+			wantMatchType: MatchTypeExact,
+		},
+		{
+			name:          "strength not available",
+			medication:    models.Medication{DrugName: "Gleevec", Strength: "25 mg", Form: "tablet"},
+			wantOK:        true,
+			wantRxCUI:     "203150",
+			wantTermType:  TermTypeBrandName,
+			wantBrand:     "Gleevec",
+			wantGeneric:   "imatinib",
+			wantMatchType: MatchTypeExact,
+		},
+		{
+			name:          "handwriting transposition",
+			medication:    models.Medication{DrugName: "Humria", Strength: "40 mg/0.4 mL", Form: "syringe"},
+			wantOK:        true,
+			wantRxCUI:     "1650002",
+			wantTermType:  TermTypeBrandedDrug,
+			wantBrand:     "Humira",
+			wantGeneric:   "adalimumab",
+			wantDoseForm:  "Prefilled Syringe",
+			wantMatchType: MatchTypeFuzzy,
+		},
+		{
+			name:          "confusable characters",
+			medication:    models.Medication{DrugName: "Gleevac 100mg", Strength: "100 mg"},
+			wantOK:        true,
+			wantRxCUI:     "403792",
+			wantTermType:  TermTypeBrandedDrug,
+			wantBrand:     "Gleevec",
+			wantGeneric:   "imatinib",
+			wantDoseForm:  "Oral Tablet",
+			wantMatchType: MatchTypeFuzzy,
+		},
+		{
+			name:       "unknown drug",
+			medication: models.Medication{DrugName: "Zzyzxoril"},
+			wantOK:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			norm, ok := normalizer.Normalize(tt.medication)
+			if ok != tt.wantOK {
+				t.Fatalf("Expected ok %v, got %v", tt.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+
+			if norm.RxCUI != tt.wantRxCUI {
+				t.Errorf("Expected RxCUI %s, got %s (%s)", tt.wantRxCUI, norm.RxCUI, norm.Name)
+			}
+			if norm.TermType != tt.wantTermType {
+				t.Errorf("Expected term type %s, got %s", tt.wantTermType, norm.TermType)
+			}
+			if norm.BrandName != tt.wantBrand {
+				t.Errorf("Expected brand name %q, got %q", tt.wantBrand, norm.BrandName)
+			}
+			if norm.GenericName != tt.wantGeneric {
+				t.Errorf("Expected generic name %q, got %q", tt.wantGeneric, norm.GenericName)
+			}
+			if norm.DoseForm != tt.wantDoseForm {
+				t.Errorf("Expected dose form %q, got %q", tt.wantDoseForm, norm.DoseForm)
+			}
+			if norm.MatchType != tt.wantMatchType {
+				t.Errorf("Expected match type %s, got %s", tt.wantMatchType, norm.MatchType)
+			}
+		})
+	}
+}
+
+func TestNormalizerProcess(t *testing.T) {
+	idx, err := Load("testdata")
+	if err != nil {
+		t.Fatalf("Failed to load RxNorm subset: %v", err)
+	}
+	normalizer := NewNormalizer(idx)
+
+	rx := models.Prescription{
+		Medications: []models.Medication{
+			{DrugName: "Humira", Strength: "40 mg/0.4 mL", Form: "Syringe"},
+			{DrugName: "Humria"},
+			{DrugName: "Zzyzxoril"},
+			{DrugName: ""},
+		},
+	}
+
+	issues := normalizer.Process(context.Background(), &rx)
+
+	if rx.Medications[0].Normalized == nil || rx.Medications[0].Normalized.RxCUI != "1650002" {
+		t.Errorf("Expected first medication to be normalized to 1650002, got %+v", rx.Medications[0].Normalized)
+	}
+	if rx.Medications[2].Normalized != nil {
+		t.Errorf("Expected unknown medication to have no normalization")
+	}
+
+	wantCodes := map[string]string{
+		"medications[1].drug_name": "rxnorm_fuzzy_match",
+		"medications[2].drug_name": "rxnorm_unmatched",
+	}
+	if len(issues) != len(wantCodes) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(wantCodes), len(issues), issues)
+	}
+	for _, issue := range issues {
+		if wantCodes[issue.Field] != issue.Code {
+			t.Errorf("Unexpected issue %s on %s", issue.Code, issue.Field)
+		}
+		if issue.Severity != models.SeverityWarning {
+			t.Errorf("Expected warning severity, got %s", issue.Severity)
+		}
+	}
+}
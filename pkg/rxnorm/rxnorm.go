@@ -0,0 +1,197 @@
+// Package rxnorm provides drug name normalization against a local subset of the RxNorm
+// release files. It loads concepts from RXNCONSO.RRF and relationships from RXNREL.RRF
+// and resolves free-text drug names to RxNorm concepts.
+package rxnorm
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// RxNorm term types retained by the loader.
+const (
+	TermTypeIngredient         = "IN"
+	TermTypePreciseIngredient  = "PIN"
+	TermTypeMultipleIngredient = "MIN"
+	TermTypeBrandName          = "BN"
+	TermTypeClinicalDrug       = "SCD"
+	TermTypeBrandedDrug        = "SBD"
+	TermTypeClinicalDrugForm   = "SCDF"
+	TermTypeBrandedDrugForm    = "SBDF"
+	TermTypeClinicalComponent  = "SCDC"
+	TermTypeDoseForm           = "DF"
+	TermTypeGenericPack        = "GPCK"
+	TermTypeBrandedPack        = "BPCK"
+	TermTypeSynonym            = "SY"
+	TermTypeTallmanSynonym     = "TMSY"
+	TermTypePrescribableName   = "PSN"
+)
+
+// Concept is a single RxNorm concept identified by its RxCUI.
+type Concept struct {
+	RxCUI    string   // RxNorm concept unique identifier
+	Name     string   // Preferred name of the concept
+	TermType string   // Term type of the preferred name (e.g. IN, BN, SCD)
+	Synonyms []string // Alternate names (SY, TMSY and PSN atoms)
+}
+
+// Index holds the loaded RxNorm concepts and the relationships between them.
+type Index struct {
+	concepts map[string]*Concept            // Concepts keyed by RxCUI
+	related  map[string]map[string]struct{} // Undirected relationships between RxCUIs
+	names    map[string][]string            // Normalized ingredient and brand names to RxCUIs
+	nameKeys []string                       // Sorted keys of names, scanned for approximate matches
+}
+
+// NewIndex creates an empty index.
+func NewIndex() *Index {
+	return &Index{
+		concepts: make(map[string]*Concept),
+		related:  make(map[string]map[string]struct{}),
+		names:    make(map[string][]string),
+	}
+}
+
+// Concept returns the concept with the given RxCUI.
+func (idx *Index) Concept(rxcui string) (*Concept, bool) {
+	c, ok := idx.concepts[rxcui]
+	return c, ok
+}
+
+// Len returns the number of concepts in the index.
+func (idx *Index) Len() int {
+	return len(idx.concepts)
+}
+
+// Related returns the concepts of the given term types reachable from rxcui within maxHops relationships.
+// Results are ordered by distance and then by RxCUI so lookups are deterministic.
+func (idx *Index) Related(rxcui string, maxHops int, termTypes ...string) []*Concept {
+	wanted := make(map[string]bool, len(termTypes))
+	for _, tty := range termTypes {
+		wanted[tty] = true
+	}
+
+	visited := map[string]bool{rxcui: true}
+	frontier := []string{rxcui}
+	var results []*Concept
+
+	for hop := 0; hop < maxHops && len(frontier) > 0; hop++ {
+		var next []string
+		for _, id := range frontier {
+			for _, neighbour := range slices.Sorted(maps.Keys(idx.related[id])) {
+				if visited[neighbour] {
+					continue
+				}
+				visited[neighbour] = true
+				next = append(next, neighbour)
+
+				if c, ok := idx.concepts[neighbour]; ok && wanted[c.TermType] {
+					results = append(results, c)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return results
+}
+
+// addConcept adds or updates a concept from a single RXNCONSO atom.
+func (idx *Index) addConcept(rxcui, tty, str string) {
+	c, ok := idx.concepts[rxcui]
+	if !ok {
+		c = &Concept{RxCUI: rxcui}
+		idx.concepts[rxcui] = c
+	}
+
+	switch tty {
+	case TermTypeSynonym, TermTypeTallmanSynonym, TermTypePrescribableName:
+		c.Synonyms = append(c.Synonyms, str)
+	default:
+		c.Name = str
+		c.TermType = tty
+	}
+}
+
+// addRelationship links two concepts in both directions.
+func (idx *Index) addRelationship(a, b string) {
+	if a == b {
+		return
+	}
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		if idx.related[pair[0]] == nil {
+			idx.related[pair[0]] = make(map[string]struct{})
+		}
+		idx.related[pair[0]][pair[1]] = struct{}{}
+	}
+}
+
+// buildNames indexes the names of ingredient and brand concepts for lookup by drug name.
+// Concepts with only synonym atoms are discarded since they have no preferred name.
+func (idx *Index) buildNames() {
+	idx.names = make(map[string][]string)
+
+	for id, c := range idx.concepts {
+		if c.TermType == "" {
+			delete(idx.concepts, id)
+			continue
+		}
+		if !isNameTermType(c.TermType) {
+			continue
+		}
+
+		seen := map[string]bool{}
+		for _, name := range append([]string{c.Name}, c.Synonyms...) {
+			key := normalizeName(name)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			idx.names[key] = append(idx.names[key], id)
+		}
+	}
+
+	for _, ids := range idx.names {
+		slices.Sort(ids)
+	}
+	idx.nameKeys = slices.Sorted(maps.Keys(idx.names))
+}
+
+// isNameTermType reports whether concepts of the term type can be matched directly by drug name.
+func isNameTermType(tty string) bool {
+	switch tty {
+	case TermTypeIngredient, TermTypePreciseIngredient, TermTypeMultipleIngredient, TermTypeBrandName:
+		return true
+	default:
+		return false
+	}
+}
+
+// normalizeName upper-cases a drug name, drops parenthesized qualifiers such as "(CF)"
+// and collapses all punctuation to single spaces.
+func normalizeName(s string) string {
+	var b strings.Builder
+	depth := 0
+
+	for _, r := range strings.ToUpper(s) {
+		switch {
+		case r == '(' || r == '[':
+			depth++
+			b.WriteRune(' ')
+		case r == ')' || r == ']':
+			if depth > 0 {
+				depth--
+			}
+			b.WriteRune(' ')
+		case depth > 0:
+			continue
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
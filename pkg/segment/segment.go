@@ -0,0 +1,109 @@
+// Package segment splits documents holding several prescriptions, such as a fax of the forms of
+// different patients, into the pages of each prescription. Boundaries are found locally from the
+// layout fingerprints of the pages, without calling a model provider: a page starts a new
+// prescription when it is laid out like the first page of the prescription before it, i.e. it is
+// another copy of the same form, or like the first page of a known form template, or when it is
+// laid out unlike both that first page and the page before it, i.e. it is another form. As later
+// pages of a form are laid out unlike its first, only those of known templates are kept with it.
+package segment
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/csotherden/prescription-parser/pkg/layout"
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/templates"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// Segmenter finds the prescriptions of documents.
+type Segmenter struct {
+	templates   *templates.Registry // Form templates whose first pages start prescriptions, nil if none are configured
+	maxDistance int                 // Bits a page may differ from another to be laid out like it
+}
+
+// NewSegmenter creates a segmenter recognizing the first pages of the templates of a registry,
+// which may be nil. Repeated copies of a form are recognized within templates.DefaultMaxDistance.
+func NewSegmenter(registry *templates.Registry) *Segmenter {
+	return &Segmenter{templates: registry, maxDistance: templates.DefaultMaxDistance}
+}
+
+// Segments returns the page ranges of the prescriptions in a PDF, in page order. Pages without a
+// scanned image, such as cover sheets generated by a fax server, never start a prescription, and
+// pages laid out like a later page of a template continue the prescription before them unless
+// they are also laid out like the first page of a form. A document whose pages cannot be counted is returned as an error.
+func (s *Segmenter) Segments(document []byte) ([]models.PageRange, error) {
+	count, err := PageCount(document)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := []models.PageRange{{First: 1, Last: 1}}
+	var formStart []float32 // Fingerprint of the first page of the current prescription
+	var previous []float32  // Fingerprint of the last scanned page
+	for page := 1; page <= count; page++ {
+		fingerprint, err := layout.FingerprintPage(document, page)
+		if errors.Is(err, layout.ErrNoImage) {
+			segments[len(segments)-1].Last = page
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fingerprint page %d: %w", page, err)
+		}
+
+		// The first scanned page belongs to the prescription of any cover pages before it
+		if formStart == nil {
+			segments[len(segments)-1].Last = page
+			formStart = fingerprint.Vector
+			previous = fingerprint.Vector
+			continue
+		}
+
+		sameForm := layout.Distance(fingerprint.Vector, formStart) <= s.maxDistance
+		otherForm := !sameForm && layout.Distance(fingerprint.Vector, previous) > s.maxDistance &&
+			!s.templates.ContinuesForm(fingerprint.Vector)
+		previous = fingerprint.Vector
+		if sameForm || otherForm || s.templates.StartsForm(fingerprint.Vector) {
+			segments = append(segments, models.PageRange{First: page, Last: page})
+			formStart = fingerprint.Vector
+			continue
+		}
+		segments[len(segments)-1].Last = page
+	}
+
+	return segments, nil
+}
+
+// PageCount returns the number of pages of a PDF.
+func PageCount(document []byte) (int, error) {
+	count, err := api.PageCount(bytes.NewReader(document), relaxedConfig())
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pages: %w", err)
+	}
+	return count, nil
+}
+
+// Extract returns a PDF of a range of pages of a PDF, and an error if the range is past the end
+// of the document.
+func Extract(document []byte, pages models.PageRange) ([]byte, error) {
+	var out bytes.Buffer
+	selected := []string{fmt.Sprintf("%d-%d", pages.First, pages.Last)}
+	if err := api.Trim(bytes.NewReader(document), &out, selected, relaxedConfig()); err != nil {
+		return nil, fmt.Errorf("failed to extract pages %d-%d: %w", pages.First, pages.Last, err)
+	}
+	// pdfcpu writes nothing for pages past the end of the document
+	if out.Len() == 0 {
+		return nil, fmt.Errorf("failed to extract pages %d-%d: no such pages", pages.First, pages.Last)
+	}
+	return out.Bytes(), nil
+}
+
+// relaxedConfig returns a pdfcpu configuration tolerating the minor defects of scanned documents.
+func relaxedConfig() *model.Configuration {
+	conf := model.NewDefaultConfiguration()
+	conf.ValidationMode = model.ValidationRelaxed
+	return conf
+}
@@ -0,0 +1,124 @@
+package segment
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/models"
+	"github.com/csotherden/prescription-parser/pkg/templates"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+// mergeSamples returns a PDF of the pages of sample documents, in order.
+func mergeSamples(t *testing.T, names ...string) []byte {
+	t.Helper()
+
+	var documents []io.ReadSeeker
+	for _, name := range names {
+		document, err := os.ReadFile(filepath.Join("..", "..", "samples", name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		documents = append(documents, bytes.NewReader(document))
+	}
+
+	var out bytes.Buffer
+	if err := api.MergeRaw(documents, &out, false, relaxedConfig()); err != nil {
+		t.Fatalf("Failed to merge samples: %v", err)
+	}
+	return out.Bytes()
+}
+
+func TestSegments(t *testing.T) {
+	registry, err := templates.Parse([]byte("templates:\n  - id: gleevec\n    reference_pages:\n      - file: Gleevec.pdf\n"), filepath.Join("..", "..", "samples"))
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	// A two-page form whose second page is laid out like Gleevec.pdf
+	merged := filepath.Join(t.TempDir(), "merged.pdf")
+	if err := os.WriteFile(merged, mergeSamples(t, "Humira1.pdf", "Gleevec.pdf"), 0o600); err != nil {
+		t.Fatalf("Failed to write merged samples: %v", err)
+	}
+	twoPages, err := templates.Parse([]byte("templates:\n  - id: two-pages\n    reference_pages:\n      - file: "+merged+"\n      - file: "+merged+"\n        page: 2\n"), "")
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		documents []string
+		registry  *templates.Registry
+		want      []models.PageRange
+	}{
+		{
+			name:      "single page",
+			documents: []string{"Humira1.pdf"},
+			want:      []models.PageRange{{First: 1, Last: 1}},
+		},
+		{
+			name:      "repeated form",
+			documents: []string{"Humira1.pdf", "Humira2.pdf", "Humira3.pdf"},
+			want:      []models.PageRange{{First: 1, Last: 1}, {First: 2, Last: 2}, {First: 3, Last: 3}},
+		},
+		{
+			name:      "different unregistered forms",
+			documents: []string{"Humira1.pdf", "Gleevec.pdf"},
+			want:      []models.PageRange{{First: 1, Last: 1}, {First: 2, Last: 2}},
+		},
+		{
+			name:      "later page of a template",
+			documents: []string{"Humira1.pdf", "Gleevec.pdf", "Humira2.pdf"},
+			registry:  twoPages,
+			want:      []models.PageRange{{First: 1, Last: 2}, {First: 3, Last: 3}},
+		},
+		{
+			name:      "template first page",
+			documents: []string{"Humira1.pdf", "Humira2.pdf", "Gleevec.pdf"},
+			registry:  registry,
+			want:      []models.PageRange{{First: 1, Last: 1}, {First: 2, Last: 2}, {First: 3, Last: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSegmenter(tt.registry).Segments(mergeSamples(t, tt.documents...))
+			if err != nil {
+				t.Fatalf("Segments() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Segments() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("invalid document", func(t *testing.T) {
+		if _, err := NewSegmenter(nil).Segments([]byte("not a pdf")); err == nil {
+			t.Error("Expected an error for an invalid document")
+		}
+	})
+}
+
+func TestExtract(t *testing.T) {
+	document := mergeSamples(t, "Humira1.pdf", "Humira2.pdf", "Gleevec.pdf")
+
+	extracted, err := Extract(document, models.PageRange{First: 2, Last: 3})
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	count, err := PageCount(extracted)
+	if err != nil {
+		t.Fatalf("PageCount() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 pages, got %d", count)
+	}
+
+	if _, err := Extract(document, models.PageRange{First: 4, Last: 5}); err == nil {
+		t.Error("Expected an error for pages past the end of the document")
+	}
+}
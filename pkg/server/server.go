@@ -55,7 +55,7 @@ func (s *Server) setupMiddleware() {
 
 func (s *Server) setupRoutes() error {
 	// Create handlers
-	parserHandler := parser.NewHandler(s.parser, s.ds, s.logger)
+	parserHandler := parser.NewHandler(s.config, s.parser, s.ds, s.logger)
 
 	// Setup API routes
 	apiRouter := s.router.PathPrefix("/api").Subrouter()
@@ -0,0 +1,257 @@
+// Package templates identifies the form template a prescription document was filled in on, such
+// as a manufacturer's enrollment form. Templates are declared in a YAML or JSON template file; each
+// has reference pages, whose layout fingerprints incoming documents are matched against, and
+// optional hints telling the model where fields are found on the form.
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/csotherden/prescription-parser/pkg/layout"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultMaxDistance is the number of bits a document's layout fingerprint may differ from a
+// reference page's when the template file does not set a limit. Filled-in copies of one form are
+// typically within 60 bits of each other and different forms about 200 bits apart.
+const DefaultMaxDistance = 100
+
+// ErrNoMatch is returned when no template's reference pages are laid out like a document.
+var ErrNoMatch = errors.New("no matching template")
+
+// File is the structure of a template file.
+type File struct {
+	MaxDistance int          `yaml:"max_distance"` // Bits a document may differ from a reference page (defaults to DefaultMaxDistance)
+	Templates   []Definition `yaml:"templates"`    // Form templates
+}
+
+// Definition declares a form template in a template file.
+type Definition struct {
+	ID             string          `yaml:"id"`              // Identifier recorded on jobs and samples
+	Name           string          `yaml:"name"`            // Human-readable name of the form
+	ReferencePages []ReferencePage `yaml:"reference_pages"` // Pages documents are compared with
+	Hints          []Hint          `yaml:"hints"`           // Optional field locations given to the model
+}
+
+// ReferencePage is a page of a document filled in on the template.
+type ReferencePage struct {
+	File string `yaml:"file"` // PDF file, relative to the template file
+	Page int    `yaml:"page"` // Page number from 1 (defaults to 1)
+}
+
+// Hint tells the model where a field is found on the form.
+type Hint struct {
+	Field    string `yaml:"field" json:"field"`       // Prescription field, such as prescriber.npi
+	Location string `yaml:"location" json:"location"` // Where the field is on the form
+}
+
+// Template is a form template of the registry.
+type Template struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	ReferencePages int    `json:"reference_pages"` // Number of reference pages
+	Hints          []Hint `json:"hints,omitempty"`
+}
+
+// Match is the template a document was identified as.
+type Match struct {
+	Template Template
+	Distance int // Bits the document's fingerprint differs from the closest reference page
+}
+
+// reference is the layout fingerprint of a template's reference page.
+type reference struct {
+	template    string
+	fingerprint []float32
+	firstPage   bool // Whether the page is the first page of its file, and so of a filled-in form
+}
+
+// Registry holds the templates loaded from a template file.
+type Registry struct {
+	maxDistance int
+	templates   map[string]Template
+	references  []reference
+}
+
+// Load reads a YAML or JSON template file and fingerprints its reference pages.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	return Parse(data, filepath.Dir(path))
+}
+
+// Parse compiles template file contents, reading reference pages relative to dir. Since JSON is
+// valid YAML, either format is accepted.
+func Parse(data []byte, dir string) (*Registry, error) {
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse template file: %w", err)
+	}
+
+	registry := &Registry{
+		maxDistance: file.MaxDistance,
+		templates:   make(map[string]Template, len(file.Templates)),
+	}
+	if registry.maxDistance <= 0 {
+		registry.maxDistance = DefaultMaxDistance
+	}
+
+	for _, def := range file.Templates {
+		if def.ID == "" {
+			return nil, fmt.Errorf("template is missing an id")
+		}
+		if _, exists := registry.templates[def.ID]; exists {
+			return nil, fmt.Errorf("duplicate template %q", def.ID)
+		}
+		if len(def.ReferencePages) == 0 {
+			return nil, fmt.Errorf("template %q has no reference pages", def.ID)
+		}
+
+		for _, hint := range def.Hints {
+			if hint.Field == "" || hint.Location == "" {
+				return nil, fmt.Errorf("template %q: hints need a field and a location", def.ID)
+			}
+		}
+
+		for _, page := range def.ReferencePages {
+			fingerprint, err := fingerprintPage(dir, page)
+			if err != nil {
+				return nil, fmt.Errorf("template %q: %w", def.ID, err)
+			}
+			registry.references = append(registry.references, reference{template: def.ID, fingerprint: fingerprint, firstPage: page.Page <= 1})
+		}
+
+		name := def.Name
+		if name == "" {
+			name = def.ID
+		}
+		registry.templates[def.ID] = Template{
+			ID:             def.ID,
+			Name:           name,
+			ReferencePages: len(def.ReferencePages),
+			Hints:          def.Hints,
+		}
+	}
+
+	return registry, nil
+}
+
+// fingerprintPage reads a reference page and computes its layout fingerprint.
+func fingerprintPage(dir string, page ReferencePage) ([]float32, error) {
+	path := page.File
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	document, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reference page: %w", err)
+	}
+
+	pageNr := page.Page
+	if pageNr <= 0 {
+		pageNr = 1
+	}
+	fingerprint, err := layout.FingerprintPage(document, pageNr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fingerprint page %d of %s: %w", pageNr, page.File, err)
+	}
+	return fingerprint.Vector, nil
+}
+
+// Identify finds the template whose reference pages are laid out most like the first page of a
+// document. It returns ErrNoMatch if no reference page is within the maximum distance, and an
+// error wrapping layout.ErrNoImage if the document has no scanned first page.
+func (r *Registry) Identify(document []byte) (Match, error) {
+	if r == nil || len(r.references) == 0 {
+		return Match{}, ErrNoMatch
+	}
+
+	fingerprint, err := layout.Fingerprint(document)
+	if err != nil {
+		return Match{}, fmt.Errorf("failed to fingerprint document layout: %w", err)
+	}
+
+	best := -1
+	bestDistance := r.maxDistance + 1
+	for i, ref := range r.references {
+		if d := layout.Distance(fingerprint.Vector, ref.fingerprint); d < bestDistance {
+			best, bestDistance = i, d
+		}
+	}
+	if best < 0 {
+		return Match{}, ErrNoMatch
+	}
+
+	return Match{Template: r.templates[r.references[best].template], Distance: bestDistance}, nil
+}
+
+// StartsForm reports whether a page, given by its layout fingerprint, is laid out like the first
+// page of a template's filled-in form, i.e. within the maximum distance of a reference page that
+// is the first page of its file.
+func (r *Registry) StartsForm(fingerprint []float32) bool {
+	if r == nil {
+		return false
+	}
+
+	for _, ref := range r.references {
+		if ref.firstPage && layout.Distance(fingerprint, ref.fingerprint) <= r.maxDistance {
+			return true
+		}
+	}
+	return false
+}
+
+// ContinuesForm reports whether a page, given by its layout fingerprint, is laid out like a later
+// page of a template's filled-in form, i.e. within the maximum distance of a reference page that
+// is not the first page of its file.
+func (r *Registry) ContinuesForm(fingerprint []float32) bool {
+	if r == nil {
+		return false
+	}
+
+	for _, ref := range r.references {
+		if !ref.firstPage && layout.Distance(fingerprint, ref.fingerprint) <= r.maxDistance {
+			return true
+		}
+	}
+	return false
+}
+
+// Template returns the template with an ID, and false if there is none.
+func (r *Registry) Template(id string) (Template, bool) {
+	if r == nil {
+		return Template{}, false
+	}
+	template, ok := r.templates[id]
+	return template, ok
+}
+
+// Templates returns the templates ordered by ID.
+func (r *Registry) Templates() []Template {
+	if r == nil {
+		return []Template{}
+	}
+
+	templates := make([]Template, 0, len(r.templates))
+	for _, template := range r.templates {
+		templates = append(templates, template)
+	}
+	slices.SortFunc(templates, func(a, b Template) int { return strings.Compare(a.ID, b.ID) })
+	return templates
+}
+
+// Len returns the number of templates.
+func (r *Registry) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.templates)
+}
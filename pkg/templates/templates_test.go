@@ -0,0 +1,246 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/csotherden/prescription-parser/pkg/layout"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+const testTemplates = `
+templates:
+  - id: humira-complete
+    name: Humira Complete Enrollment
+    reference_pages:
+      - file: Humira1.pdf
+    hints:
+      - field: prescriber.npi
+        location: Prescriber information box, right of the prescriber name
+  - id: gleevec
+    reference_pages:
+      - file: Gleevec.pdf
+        page: 1
+`
+
+func readSample(t *testing.T, name string) []byte {
+	t.Helper()
+
+	document, err := os.ReadFile(filepath.Join("..", "..", "samples", name))
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	return document
+}
+
+// writeMergedSamples writes a PDF of the pages of sample documents, in order, to a temporary
+// directory and returns its path.
+func writeMergedSamples(t *testing.T, names ...string) string {
+	t.Helper()
+
+	var documents []io.ReadSeeker
+	for _, name := range names {
+		documents = append(documents, bytes.NewReader(readSample(t, name)))
+	}
+	var out bytes.Buffer
+	if err := api.MergeRaw(documents, &out, false, nil); err != nil {
+		t.Fatalf("Failed to merge samples: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "merged.pdf")
+	if err := os.WriteFile(path, out.Bytes(), 0o600); err != nil {
+		t.Fatalf("Failed to write merged samples: %v", err)
+	}
+	return path
+}
+
+func TestIdentify(t *testing.T) {
+	registry, err := Parse([]byte(testTemplates), filepath.Join("..", "..", "samples"))
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	tests := []struct {
+		document string
+		want     string
+	}{
+		{"Humira2.pdf", "humira-complete"},
+		{"Humira3.pdf", "humira-complete"},
+		{"Humira4.pdf", "humira-complete"},
+		{"Gleevec.pdf", "gleevec"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.document, func(t *testing.T) {
+			match, err := registry.Identify(readSample(t, tt.document))
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if match.Template.ID != tt.want {
+				t.Errorf("Identify() = %s, want %s", match.Template.ID, tt.want)
+			}
+			if match.Distance > DefaultMaxDistance {
+				t.Errorf("Identify() distance = %d, beyond the maximum of %d", match.Distance, DefaultMaxDistance)
+			}
+		})
+	}
+
+	t.Run("no match", func(t *testing.T) {
+		humiraOnly, err := Parse([]byte("templates:\n  - id: humira-complete\n    reference_pages:\n      - file: Humira1.pdf\n"), filepath.Join("..", "..", "samples"))
+		if err != nil {
+			t.Fatalf("Failed to parse templates: %v", err)
+		}
+		if _, err := humiraOnly.Identify(readSample(t, "Gleevec.pdf")); !errors.Is(err, ErrNoMatch) {
+			t.Errorf("Expected ErrNoMatch, got %v", err)
+		}
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		if _, err := registry.Identify([]byte("not a pdf")); err == nil || errors.Is(err, ErrNoMatch) {
+			t.Errorf("Expected a fingerprint error, got %v", err)
+		}
+	})
+
+	t.Run("nil registry", func(t *testing.T) {
+		var empty *Registry
+		if _, err := empty.Identify(readSample(t, "Humira1.pdf")); !errors.Is(err, ErrNoMatch) {
+			t.Errorf("Expected ErrNoMatch, got %v", err)
+		}
+	})
+}
+
+func TestTemplates(t *testing.T) {
+	registry, err := Parse([]byte(testTemplates), filepath.Join("..", "..", "samples"))
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	templates := registry.Templates()
+	if len(templates) != 2 || templates[0].ID != "gleevec" || templates[1].ID != "humira-complete" {
+		t.Fatalf("Expected templates ordered by ID, got %+v", templates)
+	}
+	if templates[0].Name != "gleevec" {
+		t.Errorf("Expected the name to default to the ID, got %q", templates[0].Name)
+	}
+
+	humira, ok := registry.Template("humira-complete")
+	if !ok {
+		t.Fatal("Expected humira-complete to be registered")
+	}
+	if humira.ReferencePages != 1 || len(humira.Hints) != 1 || humira.Hints[0].Field != "prescriber.npi" {
+		t.Errorf("Unexpected template: %+v", humira)
+	}
+	if _, ok := registry.Template("unknown"); ok {
+		t.Error("Expected an unknown template to be missing")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"missing id", "templates:\n  - name: Form\n    reference_pages:\n      - file: Humira1.pdf\n", "missing an id"},
+		{"duplicate id", "templates:\n  - id: a\n    reference_pages:\n      - file: Humira1.pdf\n  - id: a\n    reference_pages:\n      - file: Humira2.pdf\n", "duplicate template"},
+		{"no reference pages", "templates:\n  - id: a\n", "no reference pages"},
+		{"missing file", "templates:\n  - id: a\n    reference_pages:\n      - file: missing.pdf\n", "failed to read reference page"},
+		{"incomplete hint", "templates:\n  - id: a\n    reference_pages:\n      - file: Humira1.pdf\n    hints:\n      - field: prescriber.npi\n", "field and a location"},
+		{"invalid yaml", "templates: [", "failed to parse template file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data), filepath.Join("..", "..", "samples"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("invalid reference page", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "invalid.pdf"), []byte("not a pdf"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := Parse([]byte("templates:\n  - id: a\n    reference_pages:\n      - file: invalid.pdf\n"), dir)
+		if err == nil || !strings.Contains(err.Error(), "failed to fingerprint page 1 of invalid.pdf") {
+			t.Errorf("Expected a fingerprint error, got %v", err)
+		}
+	})
+}
+
+func TestStartsForm(t *testing.T) {
+	registry, err := Parse([]byte(testTemplates), filepath.Join("..", "..", "samples"))
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+	humiraOnly, err := Parse([]byte("templates:\n  - id: humira-complete\n    reference_pages:\n      - file: Humira1.pdf\n"), filepath.Join("..", "..", "samples"))
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	fingerprint, err := layout.Fingerprint(readSample(t, "Gleevec.pdf"))
+	if err != nil {
+		t.Fatalf("Failed to fingerprint Gleevec.pdf: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		registry *Registry
+		want     bool
+	}{
+		{"template", registry, true},
+		{"no template", humiraOnly, false},
+		{"no registry", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.registry.StartsForm(fingerprint.Vector); got != tt.want {
+				t.Errorf("StartsForm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContinuesForm(t *testing.T) {
+	// A two-page form whose second page is laid out like Gleevec.pdf
+	merged := writeMergedSamples(t, "Humira1.pdf", "Gleevec.pdf")
+	twoPages, err := Parse([]byte("templates:\n  - id: two-pages\n    reference_pages:\n      - file: "+merged+"\n      - file: "+merged+"\n        page: 2\n"), "")
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+	firstPageOnly, err := Parse([]byte(testTemplates), filepath.Join("..", "..", "samples"))
+	if err != nil {
+		t.Fatalf("Failed to parse templates: %v", err)
+	}
+
+	fingerprint, err := layout.Fingerprint(readSample(t, "Gleevec.pdf"))
+	if err != nil {
+		t.Fatalf("Failed to fingerprint Gleevec.pdf: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		registry *Registry
+		want     bool
+	}{
+		{"later page", twoPages, true},
+		{"first page", firstPageOnly, false},
+		{"no registry", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.registry.ContinuesForm(fingerprint.Vector); got != tt.want {
+				t.Errorf("ContinuesForm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}